@@ -1,14 +1,28 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/sukryu/IV-auth-services/internal/adapters/db/postgres"
+	"github.com/sukryu/IV-auth-services/internal/adapters/geoip"
+	httpapi "github.com/sukryu/IV-auth-services/internal/adapters/http"
 	events "github.com/sukryu/IV-auth-services/internal/adapters/kafka"
+	"github.com/sukryu/IV-auth-services/internal/adapters/mail"
+	"github.com/sukryu/IV-auth-services/internal/adapters/platforms"
+	"github.com/sukryu/IV-auth-services/internal/adapters/secrets"
+	"github.com/sukryu/IV-auth-services/internal/adapters/tokens"
+	"github.com/sukryu/IV-auth-services/internal/adapters/webauthn"
 	"github.com/sukryu/IV-auth-services/internal/config"
+	"github.com/sukryu/IV-auth-services/internal/core/domain"
+	"github.com/sukryu/IV-auth-services/internal/jobs"
 	"github.com/sukryu/IV-auth-services/pkg/logger"
 	"go.uber.org/zap"
 )
@@ -43,21 +57,374 @@ func main() {
 	eventPub := events.NewKafkaEventPublisher(cfg, log)
 	defer eventPub.Close()
 
-	// 저장소와 서비스를 초기화 해도 주입할 gRPC 서비스가 아직 미구현이므로 주석.
-	// TokenGenerator 초기화
-	// tokenGen, err := tokens.NewJWTTokenGenerator(cfg, log)
-	// if err != nil {
-	//     log.Fatal("Failed to initialize token generator", logger.Field("error", err))
-	// }
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// 만료된 블랙리스트 항목 정리 작업
+	tokenRepo := postgres.NewTokenRepository(db.Pool, log.Zap())
+	blacklistPurge, err := jobs.NewPurgeJob("token_blacklist", tokenRepo,
+		cfg.Blacklist.PurgeInterval, cfg.Blacklist.PurgeBatchSize, cfg.Blacklist.PurgeMaxBatches, log)
+	if err != nil {
+		log.Fatal("Failed to initialize blacklist purge job", zap.Error(err))
+	}
+	go blacklistPurge.Run(ctx)
+
+	// DPoP 증명 재사용 방지 기록 정리 작업
+	dpopReplayRepo := postgres.NewDPoPReplayRepository(db.Pool, log.Zap())
+	if cfg.DPoP.Enabled {
+		dpopPurge, err := jobs.NewPurgeJob("dpop_proof_replays", dpopReplayRepo,
+			cfg.Blacklist.PurgeInterval, cfg.Blacklist.PurgeBatchSize, cfg.Blacklist.PurgeMaxBatches, log)
+		if err != nil {
+			log.Fatal("Failed to initialize dpop replay purge job", zap.Error(err))
+		}
+		go dpopPurge.Run(ctx)
+	}
+
+	// Prometheus 메트릭 엔드포인트
+	metricsMux := http.NewServeMux()
+	metricsMux.Handle("/metrics", promhttp.Handler())
+	metricsServer := &http.Server{
+		Addr:              fmt.Sprintf(":%d", cfg.Metrics.Port),
+		Handler:           metricsMux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	go func() {
+		if err := metricsServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Error("Metrics server failed", zap.Error(err))
+		}
+	}()
+
+	// TokenGenerator 초기화 (ID 토큰은 항상 JWT, 액세스/리프레시 토큰은 token.format에 따름)
+	signer, err := tokens.NewSigner(cfg, log)
+	if err != nil {
+		log.Fatal("Failed to initialize token signer", zap.Error(err))
+	}
+	jwtGen, err := tokens.NewJWTTokenGenerator(cfg, log, signer)
+	if err != nil {
+		log.Fatal("Failed to initialize token generator", zap.Error(err))
+	}
+	tokenGen, err := tokens.NewTokenGenerator(cfg, log, jwtGen)
+	if err != nil {
+		log.Fatal("Failed to initialize token generator", zap.Error(err))
+	}
+
 	// 저장소 초기화
-	// userRepo := postgres.NewUserRepository(db, log)
-	// tokenRepo := postgres.NewTokenRepository(db, log)
-	// platformRepo := postgres.NewPlatformAccountRepository(db, log)
+	userRepo := postgres.NewUserRepository(db.Pool, log.Zap())
+	auditRepo := postgres.NewAuditLogRepository(db.Pool, log.Zap())
+	clientRepo := postgres.NewOAuthClientRepository(db.Pool, log.Zap())
+	codeRepo := postgres.NewAuthorizationCodeRepository(db.Pool, log.Zap())
+	consentRepo := postgres.NewConsentRepository(db.Pool, log.Zap())
+	sessionRepo := postgres.NewSessionRepository(db.Pool, log.Zap())
 
-	// // 서비스 초기화 (TokenGenerator는 미구현 상태로 임시 주석)
-	// authSvc := domain.NewAuthService(userRepo, tokenRepo, nil, eventPub)
-	// userMgmtSvc := domain.NewUserManagementService(userRepo, eventPub)
-	// platformSvc := domain.NewPlatformService(platformRepo, eventPub)
+	// 서비스 초기화
+	authOpts := []domain.AuthServiceOption{domain.WithSessions(sessionRepo)}
+	if cfg.DPoP.Enabled {
+		dpopVerifier, err := tokens.NewDPoPProofVerifier(cfg, log)
+		if err != nil {
+			log.Fatal("Failed to initialize dpop verifier", zap.Error(err))
+		}
+		authOpts = append(authOpts, domain.WithDPoP(dpopVerifier, dpopReplayRepo))
+	}
+	var passkeySvc domain.PasskeyService
+	if cfg.WebAuthn.Enabled {
+		webAuthnVerifier, err := webauthn.NewVerifier(cfg, log)
+		if err != nil {
+			log.Fatal("Failed to initialize webauthn verifier", zap.Error(err))
+		}
+		credentialRepo := postgres.NewWebAuthnCredentialRepository(db.Pool, log.Zap())
+		ceremonyRepo := postgres.NewWebAuthnCeremonyRepository(db.Pool, log.Zap())
+		passkeySvc = domain.NewPasskeyService(userRepo, credentialRepo, ceremonyRepo, webAuthnVerifier, auditRepo,
+			cfg.WebAuthn.RPID, cfg.WebAuthn.RPName, cfg.WebAuthn.Timeout)
+		authOpts = append(authOpts, domain.WithPasskeys(passkeySvc))
+
+		// 만료된 WebAuthn 세리머니 정리 작업
+		ceremonyPurge, err := jobs.NewPurgeJob("webauthn_ceremonies", ceremonyRepo,
+			cfg.Blacklist.PurgeInterval, cfg.Blacklist.PurgeBatchSize, cfg.Blacklist.PurgeMaxBatches, log)
+		if err != nil {
+			log.Fatal("Failed to initialize webauthn ceremony purge job", zap.Error(err))
+		}
+		go ceremonyPurge.Run(ctx)
+	}
+	// 이메일 발송 기능(로그인 링크, 이메일 확인, 비밀번호 재설정)이 공유하는 메일러와
+	// 재인증, 게스트 생성, 기기 코드 입력을 포함한 요청 횟수 제한
+	var (
+		mailer        domain.Mailer
+		rateLimitRepo domain.RateLimitRepository
+	)
+	emailEnabled := cfg.MagicLink.Enabled || cfg.EmailVerification.Enabled || cfg.PasswordReset.Enabled
+	if emailEnabled {
+		mailer, err = mail.NewMailer(cfg, log)
+		if err != nil {
+			log.Fatal("Failed to initialize mailer", zap.Error(err))
+		}
+	}
+	if emailEnabled || cfg.StepUp.Enabled || cfg.Guests.Enabled || cfg.DeviceAuthorization.Enabled {
+		rateLimitRepo = postgres.NewRateLimitRepository(db.Pool, log.Zap())
+
+		// 만료된 요청 횟수 기록 정리 작업
+		rateLimitPurge, err := jobs.NewPurgeJob("rate_limits", rateLimitRepo,
+			cfg.Blacklist.PurgeInterval, cfg.Blacklist.PurgeBatchSize, cfg.Blacklist.PurgeMaxBatches, log)
+		if err != nil {
+			log.Fatal("Failed to initialize rate limit purge job", zap.Error(err))
+		}
+		go rateLimitPurge.Run(ctx)
+	}
+	var magicLinkSvc domain.MagicLinkService
+	if cfg.MagicLink.Enabled {
+		magicLinkRepo := postgres.NewMagicLinkTokenRepository(db.Pool, log.Zap())
+		magicLinkSvc = domain.NewMagicLinkService(userRepo, magicLinkRepo, rateLimitRepo, mailer,
+			cfg.MagicLink.URL, cfg.MagicLink.TTL, cfg.MagicLink.RateLimit, cfg.MagicLink.RateWindow)
+		authOpts = append(authOpts, domain.WithMagicLinks(magicLinkSvc))
+
+		// 만료된 로그인 링크 정리 작업
+		magicLinkPurge, err := jobs.NewPurgeJob("magic_link_tokens", magicLinkRepo,
+			cfg.Blacklist.PurgeInterval, cfg.Blacklist.PurgeBatchSize, cfg.Blacklist.PurgeMaxBatches, log)
+		if err != nil {
+			log.Fatal("Failed to initialize magic link purge job", zap.Error(err))
+		}
+		go magicLinkPurge.Run(ctx)
+	}
+	var emailVerificationSvc domain.EmailVerificationService
+	if cfg.EmailVerification.Enabled {
+		verificationRepo := postgres.NewEmailVerificationTokenRepository(db.Pool, log.Zap())
+		emailVerificationSvc = domain.NewEmailVerificationService(userRepo, verificationRepo, rateLimitRepo, mailer, auditRepo, eventPub,
+			cfg.EmailVerification.URL, cfg.EmailVerification.TTL, cfg.EmailVerification.ResendLimit, cfg.EmailVerification.ResendWindow)
+
+		// 만료된 이메일 확인 링크 정리 작업
+		verificationPurge, err := jobs.NewPurgeJob("email_verification_tokens", verificationRepo,
+			cfg.Blacklist.PurgeInterval, cfg.Blacklist.PurgeBatchSize, cfg.Blacklist.PurgeMaxBatches, log)
+		if err != nil {
+			log.Fatal("Failed to initialize email verification purge job", zap.Error(err))
+		}
+		go verificationPurge.Run(ctx)
+	}
+	var passwordResetSvc domain.PasswordResetService
+	if cfg.PasswordReset.Enabled {
+		resetRepo := postgres.NewPasswordResetTokenRepository(db.Pool, log.Zap())
+		passwordResetSvc = domain.NewPasswordResetService(userRepo, resetRepo, sessionRepo, rateLimitRepo, mailer, auditRepo, eventPub,
+			cfg.PasswordReset.URL, cfg.PasswordReset.TTL, cfg.PasswordReset.RateLimit, cfg.PasswordReset.RateWindow)
+
+		// 만료된 비밀번호 재설정 링크 정리 작업
+		resetPurge, err := jobs.NewPurgeJob("password_reset_tokens", resetRepo,
+			cfg.Blacklist.PurgeInterval, cfg.Blacklist.PurgeBatchSize, cfg.Blacklist.PurgeMaxBatches, log)
+		if err != nil {
+			log.Fatal("Failed to initialize password reset purge job", zap.Error(err))
+		}
+		go resetPurge.Run(ctx)
+	}
+	platformRepo := postgres.NewPlatformAccountRepository(db.Pool, log.Zap())
+	var platformLoginSvc domain.PlatformLoginService
+	if cfg.PlatformLogin.Enabled {
+		platformClients, err := platforms.NewOAuthClients(cfg, log)
+		if err != nil {
+			log.Fatal("Failed to initialize platform oauth clients", zap.Error(err))
+		}
+		var platformLoginOpts []domain.PlatformLoginServiceOption
+		if cfg.EmailVerification.RequireForPlatformLinking {
+			platformLoginOpts = append(platformLoginOpts, domain.WithVerifiedPlatformEmailRequired())
+		}
+		platformLoginSvc = domain.NewPlatformLoginService(userRepo, platformRepo, eventPub, platformClients, platformLoginOpts...)
+		authOpts = append(authOpts, domain.WithPlatformLogin(platformLoginSvc))
+	}
+	var mfaSvc domain.MFAService
+	if cfg.MFA.Enabled {
+		secretCipher, err := secrets.NewAESGCMCipher(cfg.MFA.EncryptionKey)
+		if err != nil {
+			log.Fatal("Failed to initialize mfa secret cipher", zap.Error(err))
+		}
+		totpRepo := postgres.NewTOTPCredentialRepository(db.Pool, log.Zap())
+		mfaChallengeRepo := postgres.NewMFAChallengeRepository(db.Pool, log.Zap())
+		recoveryCodeRepo := postgres.NewRecoveryCodeRepository(db.Pool, log.Zap())
+		var mfaOpts []domain.MFAServiceOption
+		if passkeySvc != nil {
+			mfaOpts = append(mfaOpts, domain.WithPasskeyFactor(passkeySvc))
+		}
+		mfaSvc = domain.NewMFAService(userRepo, totpRepo, recoveryCodeRepo, secretCipher, auditRepo, cfg.MFA.Issuer, cfg.MFA.TOTPDrift, mfaOpts...)
+		authOpts = append(authOpts, domain.WithMFA(mfaSvc, mfaChallengeRepo, cfg.MFA.ChallengeTTL, cfg.MFA.MaxAttempts))
+
+		// 만료된 MFA 챌린지 정리 작업
+		mfaPurge, err := jobs.NewPurgeJob("mfa_challenges", mfaChallengeRepo,
+			cfg.Blacklist.PurgeInterval, cfg.Blacklist.PurgeBatchSize, cfg.Blacklist.PurgeMaxBatches, log)
+		if err != nil {
+			log.Fatal("Failed to initialize mfa challenge purge job", zap.Error(err))
+		}
+		go mfaPurge.Run(ctx)
+	}
+	if cfg.StepUp.Enabled {
+		authOpts = append(authOpts, domain.WithReAuthentication(cfg.StepUp.TokenTTL, rateLimitRepo, cfg.StepUp.MaxAttempts, cfg.StepUp.AttemptWindow))
+	}
+	if cfg.Guests.Enabled {
+		authOpts = append(authOpts, domain.WithGuests(rateLimitRepo, cfg.Guests.Scopes, cfg.Guests.RateLimit, cfg.Guests.RateWindow))
+		if emailVerificationSvc != nil {
+			authOpts = append(authOpts, domain.WithGuestVerification(emailVerificationSvc))
+		}
+
+		// 활성 세션 없이 방치된 게스트 정리 작업
+		guestPurge, err := jobs.NewPurgeJob("guest_users", postgres.NewGuestUserRepository(db.Pool, log.Zap(), cfg.Guests.IdleTTL),
+			cfg.Blacklist.PurgeInterval, cfg.Blacklist.PurgeBatchSize, cfg.Blacklist.PurgeMaxBatches, log)
+		if err != nil {
+			log.Fatal("Failed to initialize guest user purge job", zap.Error(err))
+		}
+		go guestPurge.Run(ctx)
+	}
+	if cfg.LoginRisk.Enabled {
+		var locator domain.IPLocator
+		if cfg.LoginRisk.GeoIP.URL != "" {
+			httpLocator, err := geoip.NewHTTPLocator(cfg, log)
+			if err != nil {
+				log.Fatal("Failed to initialize geoip locator", zap.Error(err))
+			}
+			locator = httpLocator
+		}
+		loginAttemptRepo := postgres.NewLoginAttemptRepository(db.Pool, log.Zap())
+		loginRiskSvc := domain.NewLoginRiskService(loginAttemptRepo, locator, auditRepo, eventPub, domain.RiskPolicy{
+			NewDeviceScore:        cfg.LoginRisk.NewDeviceScore,
+			NewIPScore:            cfg.LoginRisk.NewIPScore,
+			NewASNScore:           cfg.LoginRisk.NewASNScore,
+			ImpossibleTravelScore: cfg.LoginRisk.ImpossibleTravelScore,
+			FailureScore:          cfg.LoginRisk.FailureScore,
+			MaxFailureScore:       cfg.LoginRisk.MaxFailureScore,
+			MFAThreshold:          cfg.LoginRisk.MFAThreshold,
+			BlockThreshold:        cfg.LoginRisk.BlockThreshold,
+			MaxTravelSpeed:        cfg.LoginRisk.MaxTravelSpeed,
+			FailureWindow:         cfg.LoginRisk.FailureWindow,
+			HistorySize:           cfg.LoginRisk.HistorySize,
+		}, cfg.LoginRisk.Retention)
+		authOpts = append(authOpts, domain.WithLoginRisk(loginRiskSvc, mailer))
+
+		// 보관 기간이 지난 로그인 기록 정리 작업
+		loginAttemptPurge, err := jobs.NewPurgeJob("login_attempts", loginAttemptRepo,
+			cfg.Blacklist.PurgeInterval, cfg.Blacklist.PurgeBatchSize, cfg.Blacklist.PurgeMaxBatches, log)
+		if err != nil {
+			log.Fatal("Failed to initialize login attempt purge job", zap.Error(err))
+		}
+		go loginAttemptPurge.Run(ctx)
+	}
+	var apiKeySvc domain.APIKeyService
+	if cfg.APIKeys.Enabled {
+		apiKeyRepo := postgres.NewAPIKeyRepository(db.Pool, log.Zap())
+		apiKeySvc = domain.NewAPIKeyService(apiKeyRepo, userRepo, auditRepo, cfg.APIKeys.AllowedScopes, cfg.APIKeys.MaxPerUser)
+		authOpts = append(authOpts, domain.WithAPIKeys(apiKeySvc))
+	}
+	authSvc := domain.NewAuthService(userRepo, tokenRepo, tokenGen, eventPub, authOpts...)
+	authzSvc := domain.NewAuthorizationService(authSvc, tokenGen, jwtGen, clientRepo, codeRepo, consentRepo, auditRepo, cfg.OAuth.AuthorizationCodeTTL)
+	clientCredentialsSvc := domain.NewClientCredentialsService(clientRepo, tokenGen, auditRepo)
+	userInfoSvc := domain.NewUserInfoService(userRepo)
+	var deviceSvc domain.DeviceAuthorizationService
+	if cfg.DeviceAuthorization.Enabled {
+		deviceRepo := postgres.NewDeviceAuthorizationRepository(db.Pool, log.Zap())
+		deviceSvc = domain.NewDeviceAuthorizationService(authSvc, authzSvc, clientRepo, deviceRepo, rateLimitRepo, auditRepo,
+			cfg.DeviceAuthorization.VerificationURL, cfg.DeviceAuthorization.TTL, cfg.DeviceAuthorization.Interval,
+			cfg.DeviceAuthorization.UserCodeLimit, cfg.DeviceAuthorization.UserCodeWindow)
+
+		// 만료된 기기 인가 요청 정리 작업
+		devicePurge, err := jobs.NewPurgeJob("oauth_device_authorizations", deviceRepo,
+			cfg.Blacklist.PurgeInterval, cfg.Blacklist.PurgeBatchSize, cfg.Blacklist.PurgeMaxBatches, log)
+		if err != nil {
+			log.Fatal("Failed to initialize device authorization purge job", zap.Error(err))
+		}
+		go devicePurge.Run(ctx)
+	}
+	var tokenExchangeSvc domain.TokenExchangeService
+	if cfg.TokenExchange.Enabled {
+		policies := make([]*domain.TokenExchangePolicy, 0, len(cfg.TokenExchange.Actors))
+		for _, actor := range cfg.TokenExchange.Actors {
+			policy, err := domain.NewTokenExchangePolicy(actor.ClientID, actor.Audiences, actor.Scopes)
+			if err != nil {
+				log.Fatal("Invalid token exchange policy", zap.Error(err), zap.String("client_id", actor.ClientID))
+			}
+			policies = append(policies, policy)
+		}
+		tokenExchangeSvc = domain.NewTokenExchangeService(authSvc, tokenGen, eventPub, policies, cfg.TokenExchange.TTL)
+	}
+	var impersonationSvc domain.ImpersonationService
+	if cfg.Impersonation.Enabled {
+		roleRepo := postgres.NewRoleRepository(db.Pool, log.Zap())
+		impersonationSvc = domain.NewImpersonationService(userRepo, roleRepo, tokenRepo, tokenGen, auditRepo, cfg.Impersonation.TokenTTL)
+	}
+	sessionSvc := domain.NewSessionService(sessionRepo, eventPub)
+	var userMgmtOpts []domain.UserManagementServiceOption
+	if emailVerificationSvc != nil {
+		userMgmtOpts = append(userMgmtOpts, domain.WithSignupVerification(emailVerificationSvc))
+	}
+	userMgmtSvc := domain.NewUserManagementService(userRepo, sessionRepo, auditRepo, eventPub, userMgmtOpts...)
+	var platformOpts []domain.PlatformServiceOption
+	if cfg.EmailVerification.RequireForPlatformLinking {
+		platformOpts = append(platformOpts, domain.WithVerifiedEmailRequired(userRepo))
+	}
+	platformSvc := domain.NewPlatformService(platformRepo, eventPub, platformOpts...)
+
+	// 만료된 인가 코드 정리 작업
+	codePurge, err := jobs.NewPurgeJob("oauth_authorization_codes", codeRepo,
+		cfg.Blacklist.PurgeInterval, cfg.Blacklist.PurgeBatchSize, cfg.Blacklist.PurgeMaxBatches, log)
+	if err != nil {
+		log.Fatal("Failed to initialize authorization code purge job", zap.Error(err))
+	}
+	go codePurge.Run(ctx)
+
+	// 만료되거나 폐기된 세션 정리 작업
+	sessionPurge, err := jobs.NewPurgeJob("user_sessions", sessionRepo,
+		cfg.Blacklist.PurgeInterval, cfg.Blacklist.PurgeBatchSize, cfg.Blacklist.PurgeMaxBatches, log)
+	if err != nil {
+		log.Fatal("Failed to initialize session purge job", zap.Error(err))
+	}
+	go sessionPurge.Run(ctx)
+
+	// OAuth 2.0 HTTP 엔드포인트
+	handlerOpts := []httpapi.HandlerOption{
+		httpapi.WithClientCredentialsGrant(clientCredentialsSvc),
+		httpapi.WithOpenIDConnect(userInfoSvc, jwtGen),
+		httpapi.WithSessionManagement(sessionSvc),
+		httpapi.WithUserManagement(userMgmtSvc),
+		httpapi.WithPlatformAccounts(platformSvc),
+	}
+	if mfaSvc != nil {
+		handlerOpts = append(handlerOpts, httpapi.WithMFA(mfaSvc))
+	}
+	if magicLinkSvc != nil {
+		handlerOpts = append(handlerOpts, httpapi.WithMagicLinks(magicLinkSvc))
+	}
+	if emailVerificationSvc != nil {
+		handlerOpts = append(handlerOpts, httpapi.WithEmailVerification(emailVerificationSvc))
+	}
+	if passwordResetSvc != nil {
+		handlerOpts = append(handlerOpts, httpapi.WithPasswordReset(passwordResetSvc))
+	}
+	if passkeySvc != nil {
+		handlerOpts = append(handlerOpts, httpapi.WithPasskeys(passkeySvc))
+	}
+	if platformLoginSvc != nil {
+		handlerOpts = append(handlerOpts, httpapi.WithPlatformLogin(platformLoginSvc))
+	}
+	if deviceSvc != nil {
+		handlerOpts = append(handlerOpts, httpapi.WithDeviceAuthorization(deviceSvc))
+	}
+	if apiKeySvc != nil {
+		handlerOpts = append(handlerOpts, httpapi.WithAPIKeys(apiKeySvc))
+	}
+	if tokenExchangeSvc != nil {
+		handlerOpts = append(handlerOpts, httpapi.WithTokenExchange(tokenExchangeSvc))
+	}
+	if cfg.Guests.Enabled {
+		handlerOpts = append(handlerOpts, httpapi.WithGuests())
+	}
+	if impersonationSvc != nil {
+		handlerOpts = append(handlerOpts, httpapi.WithImpersonation(impersonationSvc))
+	}
+	if cfg.StepUp.Enabled {
+		handlerOpts = append(handlerOpts, httpapi.WithStepUp(domain.StepUpRequirement{MaxAge: cfg.StepUp.MaxAge}))
+	}
+	handler := httpapi.NewHandler(cfg, log, authSvc, authzSvc, handlerOpts...)
+	httpServer := &http.Server{
+		Addr:              fmt.Sprintf(":%d", cfg.HTTP.Port),
+		Handler:           handler.Routes(),
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	go func() {
+		if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Error("HTTP server failed", zap.Error(err))
+		}
+	}()
 
 	// 서비스 시작 로그
 	log.Info("IV-auth-service started successfully",
@@ -70,4 +437,13 @@ func main() {
 	<-sigChan
 
 	log.Info("Shutting down service")
+	cancel()
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer shutdownCancel()
+	if err := httpServer.Shutdown(shutdownCtx); err != nil {
+		log.Warn("Failed to shut down http server", zap.Error(err))
+	}
+	if err := metricsServer.Shutdown(shutdownCtx); err != nil {
+		log.Warn("Failed to shut down metrics server", zap.Error(err))
+	}
 }
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_token_blacklist_expires_at;
\ No newline at end of file
@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS idx_token_blacklist_expires_at ON token_blacklist(expires_at);
\ No newline at end of file
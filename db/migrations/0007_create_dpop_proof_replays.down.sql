@@ -0,0 +1 @@
+DROP TABLE IF EXISTS dpop_proof_replays;
\ No newline at end of file
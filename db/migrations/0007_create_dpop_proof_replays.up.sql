@@ -0,0 +1,7 @@
+CREATE TABLE dpop_proof_replays (
+    jti VARCHAR(255) PRIMARY KEY,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_dpop_proof_replays_expires_at ON dpop_proof_replays(expires_at);
\ No newline at end of file
@@ -0,0 +1,10 @@
+CREATE TABLE oauth_clients (
+    id VARCHAR(64) PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    secret_hash BYTEA NOT NULL,
+    secret_salt BYTEA NOT NULL,
+    allowed_scopes TEXT[] NOT NULL DEFAULT '{}',
+    token_ttl_seconds INTEGER NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
\ No newline at end of file
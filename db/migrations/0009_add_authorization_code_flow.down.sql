@@ -0,0 +1,11 @@
+DROP TABLE IF EXISTS oauth_consents;
+DROP TABLE IF EXISTS oauth_authorization_codes;
+
+DELETE FROM oauth_clients WHERE client_type = 'PUBLIC';
+ALTER TABLE oauth_clients
+    DROP CONSTRAINT IF EXISTS chk_oauth_clients_client_type,
+    DROP COLUMN IF EXISTS first_party,
+    DROP COLUMN IF EXISTS redirect_uris,
+    DROP COLUMN IF EXISTS client_type,
+    ALTER COLUMN secret_hash SET NOT NULL,
+    ALTER COLUMN secret_salt SET NOT NULL;
@@ -0,0 +1,29 @@
+ALTER TABLE oauth_clients
+    ADD COLUMN client_type VARCHAR(20) NOT NULL DEFAULT 'CONFIDENTIAL',
+    ADD COLUMN redirect_uris TEXT[] NOT NULL DEFAULT '{}',
+    ADD COLUMN first_party BOOLEAN NOT NULL DEFAULT FALSE,
+    ALTER COLUMN secret_hash DROP NOT NULL,
+    ALTER COLUMN secret_salt DROP NOT NULL,
+    ADD CONSTRAINT chk_oauth_clients_client_type CHECK (client_type IN ('CONFIDENTIAL', 'PUBLIC'));
+
+CREATE TABLE oauth_authorization_codes (
+    code_hash VARCHAR(64) PRIMARY KEY,
+    client_id VARCHAR(64) NOT NULL REFERENCES oauth_clients(id) ON DELETE CASCADE,
+    user_id VARCHAR(36) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    redirect_uri TEXT NOT NULL,
+    scopes TEXT[] NOT NULL DEFAULT '{}',
+    code_challenge VARCHAR(128) NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_oauth_authorization_codes_expires_at ON oauth_authorization_codes(expires_at);
+
+CREATE TABLE oauth_consents (
+    user_id VARCHAR(36) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    client_id VARCHAR(64) NOT NULL REFERENCES oauth_clients(id) ON DELETE CASCADE,
+    scopes TEXT[] NOT NULL DEFAULT '{}',
+    granted_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, client_id)
+);
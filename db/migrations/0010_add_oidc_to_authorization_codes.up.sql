@@ -0,0 +1,4 @@
+ALTER TABLE oauth_authorization_codes
+    ADD COLUMN nonce VARCHAR(255) NOT NULL DEFAULT '',
+    ADD COLUMN auth_time TIMESTAMP,
+    ADD COLUMN amr TEXT[] NOT NULL DEFAULT '{}';
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS mfa_challenges;
+DROP TABLE IF EXISTS totp_credentials;
@@ -0,0 +1,19 @@
+CREATE TABLE magic_link_tokens (
+    token_hash VARCHAR(64) PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    email VARCHAR(255) NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_magic_link_tokens_expires_at ON magic_link_tokens(expires_at);
+
+CREATE TABLE rate_limits (
+    key VARCHAR(320) NOT NULL,
+    window_start TIMESTAMP NOT NULL,
+    count INTEGER NOT NULL DEFAULT 0,
+    expires_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (key, window_start)
+);
+
+CREATE INDEX idx_rate_limits_expires_at ON rate_limits(expires_at);
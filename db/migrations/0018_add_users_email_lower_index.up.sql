@@ -0,0 +1,9 @@
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM users GROUP BY lower(email) HAVING count(*) > 1) THEN
+        RAISE EXCEPTION 'users.email has addresses that differ only in case; merge those accounts before applying this migration';
+    END IF;
+END
+$$;
+
+CREATE UNIQUE INDEX idx_users_email_lower ON users (lower(email));
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_platform_accounts_platform_user;
@@ -0,0 +1 @@
+CREATE UNIQUE INDEX idx_platform_accounts_platform_user ON platform_accounts(platform, platform_user_id);
@@ -0,0 +1,17 @@
+CREATE TABLE oauth_device_authorizations (
+    device_code_hash VARCHAR(64) PRIMARY KEY,
+    user_code_hash VARCHAR(64) NOT NULL UNIQUE,
+    client_id VARCHAR(64) NOT NULL REFERENCES oauth_clients(id) ON DELETE CASCADE,
+    scopes TEXT[] NOT NULL DEFAULT '{}',
+    status VARCHAR(20) NOT NULL DEFAULT 'PENDING',
+    interval_seconds INTEGER NOT NULL,
+    last_polled_at TIMESTAMP,
+    user_id VARCHAR(36) REFERENCES users(id) ON DELETE CASCADE,
+    auth_time TIMESTAMP,
+    amr TEXT[] NOT NULL DEFAULT '{}',
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_oauth_device_authorizations_status CHECK (status IN ('PENDING', 'APPROVED', 'DENIED'))
+);
+
+CREATE INDEX idx_oauth_device_authorizations_expires_at ON oauth_device_authorizations(expires_at);
@@ -0,0 +1,4 @@
+DELETE FROM users WHERE status = 'GUEST';
+ALTER TABLE users DROP CONSTRAINT IF EXISTS chk_users_guest_credentials;
+ALTER TABLE users ALTER COLUMN password_hash SET NOT NULL;
+ALTER TABLE users ALTER COLUMN email SET NOT NULL;
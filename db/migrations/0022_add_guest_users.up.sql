@@ -0,0 +1,4 @@
+ALTER TABLE users ALTER COLUMN email DROP NOT NULL;
+ALTER TABLE users ALTER COLUMN password_hash DROP NOT NULL;
+ALTER TABLE users ADD CONSTRAINT chk_users_guest_credentials
+    CHECK (status = 'GUEST' OR (email IS NOT NULL AND password_hash IS NOT NULL));
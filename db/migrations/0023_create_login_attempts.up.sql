@@ -0,0 +1,16 @@
+CREATE TABLE login_attempts (
+    id VARCHAR(36) PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    ip_address VARCHAR(45) NOT NULL DEFAULT '',
+    device_fingerprint VARCHAR(64) NOT NULL DEFAULT '',
+    asn VARCHAR(32),
+    country_code VARCHAR(2),
+    latitude DOUBLE PRECISION,
+    longitude DOUBLE PRECISION,
+    succeeded BOOLEAN NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_login_attempts_user_id_created_at ON login_attempts(user_id, created_at DESC);
+CREATE INDEX idx_login_attempts_expires_at ON login_attempts(expires_at);
@@ -0,0 +1 @@
+ALTER TABLE mfa_challenges ADD COLUMN email_code_hash VARCHAR(64);
@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS idx_users_guest_created_at ON users(created_at) WHERE status = 'GUEST';
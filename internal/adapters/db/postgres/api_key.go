@@ -0,0 +1,177 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sukryu/IV-auth-services/internal/core/domain"
+	"go.uber.org/zap"
+)
+
+// apiKeyColumns lists the columns read by scanAPIKey, in order.
+const apiKeyColumns = `id, user_id, name, prefix, key_hash, scopes, expires_at, last_used_at, last_used_ip, created_at, revoked_at`
+
+// apiKeyRepository implements domain.APIKeyRepository for PostgreSQL.
+type apiKeyRepository struct {
+	db     *pgxpool.Pool
+	logger *zap.Logger
+}
+
+// NewAPIKeyRepository creates a new apiKeyRepository instance.
+func NewAPIKeyRepository(db *pgxpool.Pool, logger *zap.Logger) domain.APIKeyRepository {
+	return &apiKeyRepository{
+		db:     db,
+		logger: logger.With(zap.String("component", "api_key_repository")),
+	}
+}
+
+// Save inserts a key or updates its revocation.
+func (r *apiKeyRepository) Save(ctx context.Context, key *domain.APIKey) error {
+	if key == nil {
+		return errors.New("api key must not be nil")
+	}
+
+	query := `
+        INSERT INTO api_keys (id, user_id, name, prefix, key_hash, scopes, expires_at, last_used_at, last_used_ip, created_at, revoked_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+        ON CONFLICT (id) DO UPDATE SET
+            revoked_at = EXCLUDED.revoked_at
+    `
+	_, err := r.db.Exec(ctx, query,
+		key.ID(),
+		key.UserID(),
+		key.Name(),
+		key.Prefix(),
+		key.KeyHash(),
+		key.Scopes(),
+		key.ExpiresAt(),
+		key.LastUsedAt(),
+		key.LastUsedIP(),
+		key.CreatedAt(),
+		key.RevokedAt(),
+	)
+	if err != nil {
+		r.logger.Error("Failed to save api key", zap.Error(err), zap.String("api_key_id", key.ID()))
+		return errors.New("failed to save api key: " + err.Error())
+	}
+	return nil
+}
+
+// FindByID retrieves an API key by its ID from the database.
+func (r *apiKeyRepository) FindByID(ctx context.Context, id string) (*domain.APIKey, error) {
+	if id == "" {
+		return nil, errors.New("api key id must not be empty")
+	}
+
+	query := `
+        SELECT ` + apiKeyColumns + `
+        FROM api_keys
+        WHERE id = $1
+    `
+	key, err := scanAPIKey(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil // 키 없음
+		}
+		r.logger.Error("Failed to find api key by id", zap.Error(err), zap.String("api_key_id", id))
+		return nil, errors.New("failed to find api key: " + err.Error())
+	}
+	return key, nil
+}
+
+// FindByHash retrieves the API key with the given hash from the database.
+func (r *apiKeyRepository) FindByHash(ctx context.Context, keyHash string) (*domain.APIKey, error) {
+	if keyHash == "" {
+		return nil, errors.New("api key hash must not be empty")
+	}
+
+	query := `
+        SELECT ` + apiKeyColumns + `
+        FROM api_keys
+        WHERE key_hash = $1
+    `
+	key, err := scanAPIKey(r.db.QueryRow(ctx, query, keyHash))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil // 키 없음
+		}
+		r.logger.Error("Failed to find api key by hash", zap.Error(err))
+		return nil, errors.New("failed to find api key: " + err.Error())
+	}
+	return key, nil
+}
+
+// FindByUserID retrieves the unrevoked API keys of a user, newest first.
+func (r *apiKeyRepository) FindByUserID(ctx context.Context, userID string) ([]*domain.APIKey, error) {
+	if userID == "" {
+		return nil, errors.New("user id must not be empty")
+	}
+
+	query := `
+        SELECT ` + apiKeyColumns + `
+        FROM api_keys
+        WHERE user_id = $1 AND revoked_at IS NULL
+        ORDER BY created_at DESC
+    `
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		r.logger.Error("Failed to find api keys by user id", zap.Error(err), zap.String("user_id", userID))
+		return nil, errors.New("failed to find api keys: " + err.Error())
+	}
+	defer rows.Close()
+
+	var keys []*domain.APIKey
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			r.logger.Error("Failed to scan api key row", zap.Error(err))
+			return nil, errors.New("failed to scan api key: " + err.Error())
+		}
+		keys = append(keys, key)
+	}
+	if err := rows.Err(); err != nil {
+		r.logger.Error("Error iterating api key rows", zap.Error(err))
+		return nil, errors.New("failed to iterate api keys: " + err.Error())
+	}
+	return keys, nil
+}
+
+// RecordUsage stores the last use of an API key.
+func (r *apiKeyRepository) RecordUsage(ctx context.Context, id string, usedAt time.Time, ip string) error {
+	if id == "" {
+		return errors.New("api key id must not be empty")
+	}
+
+	query := `
+        UPDATE api_keys
+        SET last_used_at = $2, last_used_ip = $3
+        WHERE id = $1
+    `
+	if _, err := r.db.Exec(ctx, query, id, usedAt, ip); err != nil {
+		r.logger.Error("Failed to record api key usage", zap.Error(err), zap.String("api_key_id", id))
+		return errors.New("failed to record api key usage: " + err.Error())
+	}
+	return nil
+}
+
+// scanAPIKey reads one row selected with apiKeyColumns into a domain.APIKey.
+func scanAPIKey(row pgx.Row) (*domain.APIKey, error) {
+	var (
+		id, userID, name string
+		prefix, keyHash  string
+		scopes           []string
+		expiresAt        *time.Time
+		lastUsedAt       *time.Time
+		lastUsedIP       string
+		createdAt        time.Time
+		revokedAt        *time.Time
+	)
+	if err := row.Scan(&id, &userID, &name, &prefix, &keyHash, &scopes, &expiresAt, &lastUsedAt, &lastUsedIP,
+		&createdAt, &revokedAt); err != nil {
+		return nil, err
+	}
+	return domain.NewAPIKeyFromStorage(id, userID, name, prefix, keyHash, scopes, expiresAt, lastUsedAt, lastUsedIP, createdAt, revokedAt)
+}
@@ -0,0 +1,124 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sukryu/IV-auth-services/internal/core/domain"
+	"go.uber.org/zap"
+)
+
+// authorizationCodeRepository implements domain.AuthorizationCodeRepository for PostgreSQL.
+type authorizationCodeRepository struct {
+	db     *pgxpool.Pool
+	logger *zap.Logger
+}
+
+// NewAuthorizationCodeRepository creates a new authorizationCodeRepository instance.
+func NewAuthorizationCodeRepository(db *pgxpool.Pool, logger *zap.Logger) domain.AuthorizationCodeRepository {
+	return &authorizationCodeRepository{
+		db:     db,
+		logger: logger.With(zap.String("component", "authorization_code_repository")),
+	}
+}
+
+// Save stores a newly issued authorization code.
+func (r *authorizationCodeRepository) Save(ctx context.Context, code *domain.AuthorizationCode) error {
+	if code == nil {
+		return errors.New("authorization code must not be nil")
+	}
+
+	query := `
+        INSERT INTO oauth_authorization_codes (code_hash, client_id, user_id, redirect_uri, scopes, code_challenge, nonce, auth_time, amr, expires_at, created_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+    `
+	var authTime *time.Time
+	if !code.AuthTime().IsZero() {
+		t := code.AuthTime()
+		authTime = &t
+	}
+	_, err := r.db.Exec(ctx, query,
+		code.CodeHash(),
+		code.ClientID(),
+		code.UserID(),
+		code.RedirectURI(),
+		code.Scopes(),
+		code.CodeChallenge(),
+		code.Nonce(),
+		authTime,
+		code.AMR(),
+		code.ExpiresAt(),
+		code.CreatedAt(),
+	)
+	if err != nil {
+		r.logger.Error("Failed to save authorization code", zap.Error(err), zap.String("client_id", code.ClientID()))
+		return errors.New("failed to save authorization code: " + err.Error())
+	}
+	return nil
+}
+
+// Consume deletes the code and returns it, so concurrent redemptions cannot both succeed.
+func (r *authorizationCodeRepository) Consume(ctx context.Context, codeHash string) (*domain.AuthorizationCode, error) {
+	if codeHash == "" {
+		return nil, errors.New("code hash must not be empty")
+	}
+
+	query := `
+        DELETE FROM oauth_authorization_codes
+        WHERE code_hash = $1
+        RETURNING client_id, user_id, redirect_uri, scopes, code_challenge, nonce, auth_time, amr, expires_at
+    `
+	var (
+		clientID      string
+		userID        string
+		redirectURI   string
+		scopes        []string
+		codeChallenge string
+		nonce         string
+		authTime      sql.NullTime
+		amr           []string
+		expiresAt     time.Time
+	)
+	err := r.db.QueryRow(ctx, query, codeHash).Scan(&clientID, &userID, &redirectURI, &scopes, &codeChallenge, &nonce, &authTime, &amr, &expiresAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil // 코드 없음 또는 이미 사용됨
+		}
+		r.logger.Error("Failed to consume authorization code", zap.Error(err))
+		return nil, errors.New("failed to consume authorization code: " + err.Error())
+	}
+	code, err := domain.NewAuthorizationCode(codeHash, clientID, userID, redirectURI, scopes, codeChallenge, expiresAt)
+	if err != nil {
+		return nil, err
+	}
+	code.SetAuthenticationContext(nonce, authTime.Time, amr)
+	return code, nil
+}
+
+// PurgeExpired deletes a bounded batch of expired, unredeemed codes.
+func (r *authorizationCodeRepository) PurgeExpired(ctx context.Context, before time.Time, limit int) (int64, error) {
+	if limit <= 0 {
+		return 0, errors.New("limit must be positive")
+	}
+
+	query := `
+        DELETE FROM oauth_authorization_codes
+        WHERE code_hash IN (
+            SELECT code_hash FROM oauth_authorization_codes
+            WHERE expires_at <= $1
+            ORDER BY expires_at
+            LIMIT $2
+            FOR UPDATE SKIP LOCKED
+        )
+    `
+	result, err := r.db.Exec(ctx, query, before, limit)
+	if err != nil {
+		r.logger.Error("Failed to purge expired authorization codes", zap.Error(err))
+		return 0, errors.New("failed to purge expired authorization codes: " + err.Error())
+	}
+	return result.RowsAffected(), nil
+}
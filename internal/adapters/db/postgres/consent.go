@@ -0,0 +1,132 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sukryu/IV-auth-services/internal/core/domain"
+	"go.uber.org/zap"
+)
+
+// consentRepository implements domain.ConsentRepository for PostgreSQL.
+type consentRepository struct {
+	db     *pgxpool.Pool
+	logger *zap.Logger
+}
+
+// NewConsentRepository creates a new consentRepository instance.
+func NewConsentRepository(db *pgxpool.Pool, logger *zap.Logger) domain.ConsentRepository {
+	return &consentRepository{
+		db:     db,
+		logger: logger.With(zap.String("component", "consent_repository")),
+	}
+}
+
+// Save saves a consent to the database.
+func (r *consentRepository) Save(ctx context.Context, consent *domain.Consent) error {
+	if consent == nil {
+		return errors.New("consent must not be nil")
+	}
+
+	query := `
+        INSERT INTO oauth_consents (user_id, client_id, scopes, granted_at, updated_at)
+        VALUES ($1, $2, $3, $4, $5)
+        ON CONFLICT (user_id, client_id) DO UPDATE SET
+            scopes = EXCLUDED.scopes,
+            updated_at = EXCLUDED.updated_at
+    `
+	_, err := r.db.Exec(ctx, query, consent.UserID(), consent.ClientID(), consent.Scopes(), consent.GrantedAt(), consent.UpdatedAt())
+	if err != nil {
+		r.logger.Error("Failed to save consent", zap.Error(err), zap.String("user_id", consent.UserID()), zap.String("client_id", consent.ClientID()))
+		return errors.New("failed to save consent: " + err.Error())
+	}
+	return nil
+}
+
+// Find retrieves the consent a user granted to a client from the database.
+func (r *consentRepository) Find(ctx context.Context, userID, clientID string) (*domain.Consent, error) {
+	if userID == "" || clientID == "" {
+		return nil, errors.New("user id and client id must not be empty")
+	}
+
+	query := `
+        SELECT scopes, granted_at, updated_at
+        FROM oauth_consents
+        WHERE user_id = $1 AND client_id = $2
+    `
+	var (
+		scopes    []string
+		grantedAt time.Time
+		updatedAt time.Time
+	)
+	err := r.db.QueryRow(ctx, query, userID, clientID).Scan(&scopes, &grantedAt, &updatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil // 동의 없음
+		}
+		r.logger.Error("Failed to find consent", zap.Error(err), zap.String("user_id", userID), zap.String("client_id", clientID))
+		return nil, errors.New("failed to find consent: " + err.Error())
+	}
+	return domain.NewConsentFromStorage(userID, clientID, scopes, grantedAt, updatedAt)
+}
+
+// FindByUserID retrieves all consents granted by a user from the database.
+func (r *consentRepository) FindByUserID(ctx context.Context, userID string) ([]*domain.Consent, error) {
+	if userID == "" {
+		return nil, errors.New("user id must not be empty")
+	}
+
+	query := `
+        SELECT client_id, scopes, granted_at, updated_at
+        FROM oauth_consents
+        WHERE user_id = $1
+        ORDER BY granted_at
+    `
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		r.logger.Error("Failed to find consents by user id", zap.Error(err), zap.String("user_id", userID))
+		return nil, errors.New("failed to find consents: " + err.Error())
+	}
+	defer rows.Close()
+
+	var consents []*domain.Consent
+	for rows.Next() {
+		var (
+			clientID  string
+			scopes    []string
+			grantedAt time.Time
+			updatedAt time.Time
+		)
+		if err := rows.Scan(&clientID, &scopes, &grantedAt, &updatedAt); err != nil {
+			r.logger.Error("Failed to scan consent row", zap.Error(err))
+			return nil, errors.New("failed to scan consent: " + err.Error())
+		}
+		consent, err := domain.NewConsentFromStorage(userID, clientID, scopes, grantedAt, updatedAt)
+		if err != nil {
+			return nil, err
+		}
+		consents = append(consents, consent)
+	}
+	if err := rows.Err(); err != nil {
+		r.logger.Error("Error iterating consent rows", zap.Error(err))
+		return nil, errors.New("failed to iterate consents: " + err.Error())
+	}
+	return consents, nil
+}
+
+// Delete removes the consent a user granted to a client from the database.
+func (r *consentRepository) Delete(ctx context.Context, userID, clientID string) error {
+	if userID == "" || clientID == "" {
+		return errors.New("user id and client id must not be empty")
+	}
+
+	query := `DELETE FROM oauth_consents WHERE user_id = $1 AND client_id = $2`
+	if _, err := r.db.Exec(ctx, query, userID, clientID); err != nil {
+		r.logger.Error("Failed to delete consent", zap.Error(err), zap.String("user_id", userID), zap.String("client_id", clientID))
+		return errors.New("failed to delete consent: " + err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,224 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sukryu/IV-auth-services/internal/core/domain"
+	"go.uber.org/zap"
+)
+
+// deviceAuthorizationColumns lists the columns read by scanDeviceAuthorization, in order.
+const deviceAuthorizationColumns = `device_code_hash, user_code_hash, client_id, scopes, status, interval_seconds,
+        last_polled_at, user_id, auth_time, amr, expires_at, created_at`
+
+// deviceAuthorizationRepository implements domain.DeviceAuthorizationRepository for PostgreSQL.
+type deviceAuthorizationRepository struct {
+	db     *pgxpool.Pool
+	logger *zap.Logger
+}
+
+// NewDeviceAuthorizationRepository creates a new deviceAuthorizationRepository instance.
+func NewDeviceAuthorizationRepository(db *pgxpool.Pool, logger *zap.Logger) domain.DeviceAuthorizationRepository {
+	return &deviceAuthorizationRepository{
+		db:     db,
+		logger: logger.With(zap.String("component", "device_authorization_repository")),
+	}
+}
+
+// Save inserts a newly issued request.
+func (r *deviceAuthorizationRepository) Save(ctx context.Context, authorization *domain.DeviceAuthorization) error {
+	if authorization == nil {
+		return errors.New("device authorization must not be nil")
+	}
+
+	query := `
+        INSERT INTO oauth_device_authorizations (device_code_hash, user_code_hash, client_id, scopes, status, interval_seconds,
+            last_polled_at, user_id, auth_time, amr, expires_at, created_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+    `
+	userID, authTime, amr := decisionColumns(authorization)
+	_, err := r.db.Exec(ctx, query,
+		authorization.DeviceCodeHash(),
+		authorization.UserCodeHash(),
+		authorization.ClientID(),
+		authorization.Scopes(),
+		string(authorization.Status()),
+		int(authorization.Interval().Seconds()),
+		authorization.LastPolledAt(),
+		userID,
+		authTime,
+		amr,
+		authorization.ExpiresAt(),
+		authorization.CreatedAt(),
+	)
+	if err != nil {
+		r.logger.Error("Failed to save device authorization", zap.Error(err), zap.String("client_id", authorization.ClientID()))
+		return errors.New("failed to save device authorization: " + err.Error())
+	}
+	return nil
+}
+
+// RecordPoll updates only the polling state, so a poll that read the row before the user
+// decided cannot overwrite the decision.
+func (r *deviceAuthorizationRepository) RecordPoll(ctx context.Context, authorization *domain.DeviceAuthorization) error {
+	if authorization == nil {
+		return errors.New("device authorization must not be nil")
+	}
+
+	query := `
+        UPDATE oauth_device_authorizations
+        SET last_polled_at = $2, interval_seconds = $3
+        WHERE device_code_hash = $1
+    `
+	_, err := r.db.Exec(ctx, query, authorization.DeviceCodeHash(), authorization.LastPolledAt(), int(authorization.Interval().Seconds()))
+	if err != nil {
+		r.logger.Error("Failed to record device poll", zap.Error(err), zap.String("client_id", authorization.ClientID()))
+		return errors.New("failed to record device poll: " + err.Error())
+	}
+	return nil
+}
+
+// Decide stores the approval or denial only while the request is pending, so concurrent
+// decisions cannot overwrite each other.
+func (r *deviceAuthorizationRepository) Decide(ctx context.Context, authorization *domain.DeviceAuthorization) (bool, error) {
+	if authorization == nil {
+		return false, errors.New("device authorization must not be nil")
+	}
+
+	query := `
+        UPDATE oauth_device_authorizations
+        SET status = $2, user_id = $3, auth_time = $4, amr = $5
+        WHERE device_code_hash = $1 AND status = $6
+    `
+	userID, authTime, amr := decisionColumns(authorization)
+	result, err := r.db.Exec(ctx, query, authorization.DeviceCodeHash(), string(authorization.Status()),
+		userID, authTime, amr, string(domain.DeviceAuthorizationPending))
+	if err != nil {
+		r.logger.Error("Failed to decide device authorization", zap.Error(err), zap.String("client_id", authorization.ClientID()))
+		return false, errors.New("failed to decide device authorization: " + err.Error())
+	}
+	return result.RowsAffected() > 0, nil
+}
+
+// decisionColumns returns the approving user, authentication time and methods of a request
+// as column values; the user and time are NULL until the request is approved.
+func decisionColumns(authorization *domain.DeviceAuthorization) (*string, *time.Time, []string) {
+	var (
+		userID   *string
+		authTime *time.Time
+	)
+	if authorization.UserID() != "" {
+		id := authorization.UserID()
+		userID = &id
+	}
+	if !authorization.AuthTime().IsZero() {
+		t := authorization.AuthTime()
+		authTime = &t
+	}
+	amr := authorization.AMR()
+	if amr == nil {
+		amr = []string{}
+	}
+	return userID, authTime, amr
+}
+
+// FindByDeviceCodeHash retrieves a request by the hash of its device code.
+func (r *deviceAuthorizationRepository) FindByDeviceCodeHash(ctx context.Context, deviceCodeHash string) (*domain.DeviceAuthorization, error) {
+	if deviceCodeHash == "" {
+		return nil, errors.New("device code hash must not be empty")
+	}
+
+	query := `
+        SELECT ` + deviceAuthorizationColumns + `
+        FROM oauth_device_authorizations
+        WHERE device_code_hash = $1
+    `
+	return r.scanDeviceAuthorization(r.db.QueryRow(ctx, query, deviceCodeHash))
+}
+
+// FindByUserCodeHash retrieves a request by the hash of its user code.
+func (r *deviceAuthorizationRepository) FindByUserCodeHash(ctx context.Context, userCodeHash string) (*domain.DeviceAuthorization, error) {
+	if userCodeHash == "" {
+		return nil, errors.New("user code hash must not be empty")
+	}
+
+	query := `
+        SELECT ` + deviceAuthorizationColumns + `
+        FROM oauth_device_authorizations
+        WHERE user_code_hash = $1
+    `
+	return r.scanDeviceAuthorization(r.db.QueryRow(ctx, query, userCodeHash))
+}
+
+// Consume deletes the request and returns it, so concurrent polls cannot both redeem it.
+func (r *deviceAuthorizationRepository) Consume(ctx context.Context, deviceCodeHash string) (*domain.DeviceAuthorization, error) {
+	if deviceCodeHash == "" {
+		return nil, errors.New("device code hash must not be empty")
+	}
+
+	query := `
+        DELETE FROM oauth_device_authorizations
+        WHERE device_code_hash = $1
+        RETURNING ` + deviceAuthorizationColumns + `
+    `
+	return r.scanDeviceAuthorization(r.db.QueryRow(ctx, query, deviceCodeHash))
+}
+
+// PurgeExpired deletes a bounded batch of expired requests.
+func (r *deviceAuthorizationRepository) PurgeExpired(ctx context.Context, before time.Time, limit int) (int64, error) {
+	if limit <= 0 {
+		return 0, errors.New("limit must be positive")
+	}
+
+	query := `
+        DELETE FROM oauth_device_authorizations
+        WHERE device_code_hash IN (
+            SELECT device_code_hash FROM oauth_device_authorizations
+            WHERE expires_at <= $1
+            ORDER BY expires_at
+            LIMIT $2
+            FOR UPDATE SKIP LOCKED
+        )
+    `
+	result, err := r.db.Exec(ctx, query, before, limit)
+	if err != nil {
+		r.logger.Error("Failed to purge expired device authorizations", zap.Error(err))
+		return 0, errors.New("failed to purge expired device authorizations: " + err.Error())
+	}
+	return result.RowsAffected(), nil
+}
+
+// scanDeviceAuthorization reads one row selected with deviceAuthorizationColumns.
+func (r *deviceAuthorizationRepository) scanDeviceAuthorization(row pgx.Row) (*domain.DeviceAuthorization, error) {
+	var (
+		deviceCodeHash  string
+		userCodeHash    string
+		clientID        string
+		scopes          []string
+		status          string
+		intervalSeconds int
+		lastPolledAt    *time.Time
+		userID          sql.NullString
+		authTime        sql.NullTime
+		amr             []string
+		expiresAt       time.Time
+		createdAt       time.Time
+	)
+	err := row.Scan(&deviceCodeHash, &userCodeHash, &clientID, &scopes, &status, &intervalSeconds,
+		&lastPolledAt, &userID, &authTime, &amr, &expiresAt, &createdAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil // 요청 없음 또는 이미 사용됨
+		}
+		r.logger.Error("Failed to scan device authorization", zap.Error(err))
+		return nil, errors.New("failed to find device authorization: " + err.Error())
+	}
+	return domain.NewDeviceAuthorizationFromStorage(deviceCodeHash, userCodeHash, clientID, scopes,
+		domain.DeviceAuthorizationStatus(status), time.Duration(intervalSeconds)*time.Second, lastPolledAt,
+		userID.String, authTime.Time, amr, expiresAt, createdAt)
+}
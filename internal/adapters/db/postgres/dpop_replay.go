@@ -0,0 +1,69 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sukryu/IV-auth-services/internal/core/domain"
+	"go.uber.org/zap"
+)
+
+// dpopReplayRepository implements domain.DPoPReplayRepository for PostgreSQL.
+type dpopReplayRepository struct {
+	db     *pgxpool.Pool
+	logger *zap.Logger
+}
+
+// NewDPoPReplayRepository creates a new dpopReplayRepository instance.
+func NewDPoPReplayRepository(db *pgxpool.Pool, logger *zap.Logger) domain.DPoPReplayRepository {
+	return &dpopReplayRepository{
+		db:     db,
+		logger: logger.With(zap.String("component", "dpop_replay_repository")),
+	}
+}
+
+// MarkUsed records a proof jti and reports false if it was already recorded.
+func (r *dpopReplayRepository) MarkUsed(ctx context.Context, jti string, expiresAt time.Time) (bool, error) {
+	if jti == "" {
+		return false, errors.New("proof jti must not be empty")
+	}
+
+	// 이미 존재하는 jti면 삽입되지 않으므로 영향받은 행 수로 재사용 여부 판단
+	query := `
+        INSERT INTO dpop_proof_replays (jti, expires_at, created_at)
+        VALUES ($1, $2, $3)
+        ON CONFLICT (jti) DO NOTHING
+    `
+	result, err := r.db.Exec(ctx, query, jti, expiresAt, time.Now())
+	if err != nil {
+		r.logger.Error("Failed to record dpop proof", zap.Error(err), zap.String("jti", jti))
+		return false, errors.New("failed to record dpop proof: " + err.Error())
+	}
+	return result.RowsAffected() == 1, nil
+}
+
+// PurgeExpired deletes a bounded batch of expired proof records.
+func (r *dpopReplayRepository) PurgeExpired(ctx context.Context, before time.Time, limit int) (int64, error) {
+	if limit <= 0 {
+		return 0, errors.New("limit must be positive")
+	}
+
+	query := `
+        DELETE FROM dpop_proof_replays
+        WHERE jti IN (
+            SELECT jti FROM dpop_proof_replays
+            WHERE expires_at <= $1
+            ORDER BY expires_at
+            LIMIT $2
+            FOR UPDATE SKIP LOCKED
+        )
+    `
+	result, err := r.db.Exec(ctx, query, before, limit)
+	if err != nil {
+		r.logger.Error("Failed to purge expired dpop proofs", zap.Error(err))
+		return 0, errors.New("failed to purge expired dpop proofs: " + err.Error())
+	}
+	return result.RowsAffected(), nil
+}
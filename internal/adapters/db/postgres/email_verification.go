@@ -0,0 +1,105 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sukryu/IV-auth-services/internal/core/domain"
+	"go.uber.org/zap"
+)
+
+// emailVerificationTokenRepository implements domain.EmailVerificationTokenRepository for PostgreSQL.
+type emailVerificationTokenRepository struct {
+	db     *pgxpool.Pool
+	logger *zap.Logger
+}
+
+// NewEmailVerificationTokenRepository creates a new emailVerificationTokenRepository instance.
+func NewEmailVerificationTokenRepository(db *pgxpool.Pool, logger *zap.Logger) domain.EmailVerificationTokenRepository {
+	return &emailVerificationTokenRepository{
+		db:     db,
+		logger: logger.With(zap.String("component", "email_verification_token_repository")),
+	}
+}
+
+// Save stores a newly issued verification token.
+func (r *emailVerificationTokenRepository) Save(ctx context.Context, token *domain.EmailVerificationToken) error {
+	if token == nil {
+		return errors.New("email verification token must not be nil")
+	}
+
+	query := `
+        INSERT INTO email_verification_tokens (token_hash, user_id, email, expires_at, created_at)
+        VALUES ($1, $2, $3, $4, $5)
+    `
+	_, err := r.db.Exec(ctx, query,
+		token.TokenHash(),
+		token.UserID(),
+		token.Email().String(),
+		token.ExpiresAt(),
+		token.CreatedAt(),
+	)
+	if err != nil {
+		r.logger.Error("Failed to save email verification token", zap.Error(err), zap.String("user_id", token.UserID()))
+		return errors.New("failed to save email verification token: " + err.Error())
+	}
+	return nil
+}
+
+// Consume deletes the token and returns it, so a link can be used only once.
+func (r *emailVerificationTokenRepository) Consume(ctx context.Context, tokenHash string) (*domain.EmailVerificationToken, error) {
+	if tokenHash == "" {
+		return nil, errors.New("token hash must not be empty")
+	}
+
+	query := `
+        DELETE FROM email_verification_tokens
+        WHERE token_hash = $1
+        RETURNING token_hash, user_id, email, expires_at, created_at
+    `
+	var (
+		hash, userID, emailStr string
+		expiresAt, createdAt   time.Time
+	)
+	err := r.db.QueryRow(ctx, query, tokenHash).Scan(&hash, &userID, &emailStr, &expiresAt, &createdAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil // 이미 사용됨
+		}
+		r.logger.Error("Failed to consume email verification token", zap.Error(err))
+		return nil, errors.New("failed to consume email verification token: " + err.Error())
+	}
+
+	email, err := domain.NewEmail(emailStr)
+	if err != nil {
+		return nil, err
+	}
+	return domain.NewEmailVerificationTokenFromStorage(hash, userID, email, expiresAt, createdAt)
+}
+
+// PurgeExpired deletes a bounded batch of expired verification tokens.
+func (r *emailVerificationTokenRepository) PurgeExpired(ctx context.Context, before time.Time, limit int) (int64, error) {
+	if limit <= 0 {
+		return 0, errors.New("limit must be positive")
+	}
+
+	query := `
+        DELETE FROM email_verification_tokens
+        WHERE token_hash IN (
+            SELECT token_hash FROM email_verification_tokens
+            WHERE expires_at <= $1
+            ORDER BY expires_at
+            LIMIT $2
+            FOR UPDATE SKIP LOCKED
+        )
+    `
+	result, err := r.db.Exec(ctx, query, before, limit)
+	if err != nil {
+		r.logger.Error("Failed to purge expired email verification tokens", zap.Error(err))
+		return 0, errors.New("failed to purge expired email verification tokens: " + err.Error())
+	}
+	return result.RowsAffected(), nil
+}
@@ -0,0 +1,59 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sukryu/IV-auth-services/internal/core/domain"
+	"go.uber.org/zap"
+)
+
+// guestUserRepository implements domain.GuestUserRepository for PostgreSQL.
+type guestUserRepository struct {
+	db      *pgxpool.Pool
+	logger  *zap.Logger
+	idleTTL time.Duration // 마지막 로그인 후 이 기간이 지난 게스트만 삭제
+}
+
+// NewGuestUserRepository creates a new guestUserRepository instance. Guests are purged once
+// idleTTL has passed since they last signed in and none of their sessions is still active.
+func NewGuestUserRepository(db *pgxpool.Pool, logger *zap.Logger, idleTTL time.Duration) domain.GuestUserRepository {
+	return &guestUserRepository{
+		db:      db,
+		logger:  logger.With(zap.String("component", "guest_user_repository")),
+		idleTTL: idleTTL,
+	}
+}
+
+// PurgeExpired deletes a bounded batch of abandoned guest users. Rows that reference them,
+// such as sessions, are removed by ON DELETE CASCADE.
+func (r *guestUserRepository) PurgeExpired(ctx context.Context, before time.Time, limit int) (int64, error) {
+	if limit <= 0 {
+		return 0, errors.New("limit must be positive")
+	}
+
+	// 토큰이 유일한 자격 증명이므로 활성 세션이 없는 게스트는 다시 로그인할 수 없음
+	query := `
+        DELETE FROM users
+        WHERE id IN (
+            SELECT u.id FROM users u
+            WHERE u.status = $1
+              AND COALESCE(u.last_login_at, u.created_at) <= $2
+              AND NOT EXISTS (
+                  SELECT 1 FROM user_sessions s
+                  WHERE s.user_id = u.id AND s.revoked_at IS NULL AND s.expires_at > $3
+              )
+            LIMIT $4
+            FOR UPDATE SKIP LOCKED
+        )
+        AND status = $1
+    `
+	result, err := r.db.Exec(ctx, query, domain.UserStatusGuest, before.Add(-r.idleTTL), before, limit)
+	if err != nil {
+		r.logger.Error("Failed to purge guest users", zap.Error(err))
+		return 0, errors.New("failed to purge guest users: " + err.Error())
+	}
+	return result.RowsAffected(), nil
+}
@@ -0,0 +1,177 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sukryu/IV-auth-services/internal/core/domain"
+	"go.uber.org/zap"
+)
+
+// loginAttemptColumns lists the columns read by scanLoginAttempt, in order.
+const loginAttemptColumns = `id, user_id, ip_address, device_fingerprint, asn, country_code, latitude, longitude, succeeded, created_at, expires_at`
+
+// loginAttemptRepository implements domain.LoginAttemptRepository for PostgreSQL.
+type loginAttemptRepository struct {
+	db     *pgxpool.Pool
+	logger *zap.Logger
+}
+
+// NewLoginAttemptRepository creates a new loginAttemptRepository instance.
+func NewLoginAttemptRepository(db *pgxpool.Pool, logger *zap.Logger) domain.LoginAttemptRepository {
+	return &loginAttemptRepository{
+		db:     db,
+		logger: logger.With(zap.String("component", "login_attempt_repository")),
+	}
+}
+
+// Save inserts a login attempt into the database.
+func (r *loginAttemptRepository) Save(ctx context.Context, attempt *domain.LoginAttempt) error {
+	if attempt == nil {
+		return errors.New("login attempt must not be nil")
+	}
+
+	var (
+		asn, countryCode    *string
+		latitude, longitude *float64
+	)
+	if location := attempt.Location(); location != nil {
+		asn, countryCode = &location.ASN, &location.CountryCode
+		latitude, longitude = &location.Latitude, &location.Longitude
+	}
+
+	query := `
+        INSERT INTO login_attempts (id, user_id, ip_address, device_fingerprint, asn, country_code, latitude, longitude, succeeded, created_at, expires_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+    `
+	_, err := r.db.Exec(ctx, query,
+		attempt.ID(),
+		attempt.UserID(),
+		attempt.IPAddress(),
+		attempt.DeviceFingerprint(),
+		asn,
+		countryCode,
+		latitude,
+		longitude,
+		attempt.Succeeded(),
+		attempt.CreatedAt(),
+		attempt.ExpiresAt(),
+	)
+	if err != nil {
+		r.logger.Error("Failed to save login attempt", zap.Error(err), zap.String("user_id", attempt.UserID()))
+		return errors.New("failed to save login attempt: " + err.Error())
+	}
+	return nil
+}
+
+// FindRecentSuccessful retrieves the latest successful logins of a user, newest first.
+func (r *loginAttemptRepository) FindRecentSuccessful(ctx context.Context, userID string, limit int) ([]*domain.LoginAttempt, error) {
+	if userID == "" {
+		return nil, errors.New("user id must not be empty")
+	}
+	if limit <= 0 {
+		return nil, errors.New("limit must be positive")
+	}
+
+	query := `
+        SELECT ` + loginAttemptColumns + `
+        FROM login_attempts
+        WHERE user_id = $1 AND succeeded
+        ORDER BY created_at DESC
+        LIMIT $2
+    `
+	rows, err := r.db.Query(ctx, query, userID, limit)
+	if err != nil {
+		r.logger.Error("Failed to find login attempts", zap.Error(err), zap.String("user_id", userID))
+		return nil, errors.New("failed to find login attempts: " + err.Error())
+	}
+	defer rows.Close()
+
+	var attempts []*domain.LoginAttempt
+	for rows.Next() {
+		attempt, err := scanLoginAttempt(rows)
+		if err != nil {
+			r.logger.Error("Failed to scan login attempt row", zap.Error(err))
+			return nil, errors.New("failed to scan login attempt: " + err.Error())
+		}
+		attempts = append(attempts, attempt)
+	}
+	if err := rows.Err(); err != nil {
+		r.logger.Error("Error iterating login attempt rows", zap.Error(err))
+		return nil, errors.New("failed to iterate login attempts: " + err.Error())
+	}
+	return attempts, nil
+}
+
+// CountFailedSince counts the failed logins of a user since the given time.
+func (r *loginAttemptRepository) CountFailedSince(ctx context.Context, userID string, since time.Time) (int, error) {
+	if userID == "" {
+		return 0, errors.New("user id must not be empty")
+	}
+
+	query := `
+        SELECT COUNT(*)
+        FROM login_attempts
+        WHERE user_id = $1 AND NOT succeeded AND created_at >= $2
+    `
+	var count int
+	if err := r.db.QueryRow(ctx, query, userID, since).Scan(&count); err != nil {
+		r.logger.Error("Failed to count failed logins", zap.Error(err), zap.String("user_id", userID))
+		return 0, errors.New("failed to count failed logins: " + err.Error())
+	}
+	return count, nil
+}
+
+// PurgeExpired deletes a bounded batch of login attempts past their retention.
+func (r *loginAttemptRepository) PurgeExpired(ctx context.Context, before time.Time, limit int) (int64, error) {
+	if limit <= 0 {
+		return 0, errors.New("limit must be positive")
+	}
+
+	query := `
+        DELETE FROM login_attempts
+        WHERE id IN (
+            SELECT id FROM login_attempts
+            WHERE expires_at <= $1
+            LIMIT $2
+            FOR UPDATE SKIP LOCKED
+        )
+    `
+	result, err := r.db.Exec(ctx, query, before, limit)
+	if err != nil {
+		r.logger.Error("Failed to purge expired login attempts", zap.Error(err))
+		return 0, errors.New("failed to purge expired login attempts: " + err.Error())
+	}
+	return result.RowsAffected(), nil
+}
+
+// scanLoginAttempt reads one row selected with loginAttemptColumns into a domain.LoginAttempt.
+func scanLoginAttempt(row pgx.Row) (*domain.LoginAttempt, error) {
+	var (
+		id, userID          string
+		ipAddress           string
+		deviceFingerprint   string
+		asn, countryCode    *string
+		latitude, longitude *float64
+		succeeded           bool
+		createdAt           time.Time
+		expiresAt           time.Time
+	)
+	if err := row.Scan(&id, &userID, &ipAddress, &deviceFingerprint, &asn, &countryCode, &latitude, &longitude,
+		&succeeded, &createdAt, &expiresAt); err != nil {
+		return nil, err
+	}
+
+	// 위치 조회에 실패한 시도는 위치 없이 저장됨
+	var location *domain.IPLocation
+	if asn != nil && latitude != nil && longitude != nil {
+		location = &domain.IPLocation{ASN: *asn, Latitude: *latitude, Longitude: *longitude}
+		if countryCode != nil {
+			location.CountryCode = *countryCode
+		}
+	}
+	return domain.NewLoginAttemptFromStorage(id, userID, ipAddress, deviceFingerprint, location, succeeded, createdAt, expiresAt)
+}
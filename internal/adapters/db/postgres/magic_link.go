@@ -0,0 +1,105 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sukryu/IV-auth-services/internal/core/domain"
+	"go.uber.org/zap"
+)
+
+// magicLinkTokenRepository implements domain.MagicLinkTokenRepository for PostgreSQL.
+type magicLinkTokenRepository struct {
+	db     *pgxpool.Pool
+	logger *zap.Logger
+}
+
+// NewMagicLinkTokenRepository creates a new magicLinkTokenRepository instance.
+func NewMagicLinkTokenRepository(db *pgxpool.Pool, logger *zap.Logger) domain.MagicLinkTokenRepository {
+	return &magicLinkTokenRepository{
+		db:     db,
+		logger: logger.With(zap.String("component", "magic_link_token_repository")),
+	}
+}
+
+// Save stores a newly issued link token.
+func (r *magicLinkTokenRepository) Save(ctx context.Context, token *domain.MagicLinkToken) error {
+	if token == nil {
+		return errors.New("magic link token must not be nil")
+	}
+
+	query := `
+        INSERT INTO magic_link_tokens (token_hash, user_id, email, expires_at, created_at)
+        VALUES ($1, $2, $3, $4, $5)
+    `
+	_, err := r.db.Exec(ctx, query,
+		token.TokenHash(),
+		token.UserID(),
+		token.Email().String(),
+		token.ExpiresAt(),
+		token.CreatedAt(),
+	)
+	if err != nil {
+		r.logger.Error("Failed to save magic link token", zap.Error(err), zap.String("user_id", token.UserID()))
+		return errors.New("failed to save magic link token: " + err.Error())
+	}
+	return nil
+}
+
+// Consume deletes the token and returns it, so a link can be redeemed only once.
+func (r *magicLinkTokenRepository) Consume(ctx context.Context, tokenHash string) (*domain.MagicLinkToken, error) {
+	if tokenHash == "" {
+		return nil, errors.New("token hash must not be empty")
+	}
+
+	query := `
+        DELETE FROM magic_link_tokens
+        WHERE token_hash = $1
+        RETURNING token_hash, user_id, email, expires_at, created_at
+    `
+	var (
+		hash, userID, emailStr string
+		expiresAt, createdAt   time.Time
+	)
+	err := r.db.QueryRow(ctx, query, tokenHash).Scan(&hash, &userID, &emailStr, &expiresAt, &createdAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil // 이미 사용됨
+		}
+		r.logger.Error("Failed to consume magic link token", zap.Error(err))
+		return nil, errors.New("failed to consume magic link token: " + err.Error())
+	}
+
+	email, err := domain.NewEmail(emailStr)
+	if err != nil {
+		return nil, err
+	}
+	return domain.NewMagicLinkTokenFromStorage(hash, userID, email, expiresAt, createdAt)
+}
+
+// PurgeExpired deletes a bounded batch of expired link tokens.
+func (r *magicLinkTokenRepository) PurgeExpired(ctx context.Context, before time.Time, limit int) (int64, error) {
+	if limit <= 0 {
+		return 0, errors.New("limit must be positive")
+	}
+
+	query := `
+        DELETE FROM magic_link_tokens
+        WHERE token_hash IN (
+            SELECT token_hash FROM magic_link_tokens
+            WHERE expires_at <= $1
+            ORDER BY expires_at
+            LIMIT $2
+            FOR UPDATE SKIP LOCKED
+        )
+    `
+	result, err := r.db.Exec(ctx, query, before, limit)
+	if err != nil {
+		r.logger.Error("Failed to purge expired magic link tokens", zap.Error(err))
+		return 0, errors.New("failed to purge expired magic link tokens: " + err.Error())
+	}
+	return result.RowsAffected(), nil
+}
@@ -0,0 +1,161 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sukryu/IV-auth-services/internal/core/domain"
+	"go.uber.org/zap"
+)
+
+// mfaChallengeRepository implements domain.MFAChallengeRepository for PostgreSQL.
+type mfaChallengeRepository struct {
+	db     *pgxpool.Pool
+	logger *zap.Logger
+}
+
+// NewMFAChallengeRepository creates a new mfaChallengeRepository instance.
+func NewMFAChallengeRepository(db *pgxpool.Pool, logger *zap.Logger) domain.MFAChallengeRepository {
+	return &mfaChallengeRepository{
+		db:     db,
+		logger: logger.With(zap.String("component", "mfa_challenge_repository")),
+	}
+}
+
+// Save stores a newly issued challenge.
+func (r *mfaChallengeRepository) Save(ctx context.Context, challenge *domain.MFAChallenge) error {
+	if challenge == nil {
+		return errors.New("mfa challenge must not be nil")
+	}
+
+	query := `
+        INSERT INTO mfa_challenges (token_hash, user_id, auth_time, amr, attempts, email_code_hash, expires_at, created_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+    `
+	_, err := r.db.Exec(ctx, query,
+		challenge.TokenHash(),
+		challenge.UserID(),
+		challenge.AuthTime(),
+		challenge.AMR(),
+		challenge.Attempts(),
+		nullableString(challenge.EmailCodeHash()),
+		challenge.ExpiresAt(),
+		challenge.CreatedAt(),
+	)
+	if err != nil {
+		r.logger.Error("Failed to save mfa challenge", zap.Error(err), zap.String("user_id", challenge.UserID()))
+		return errors.New("failed to save mfa challenge: " + err.Error())
+	}
+	return nil
+}
+
+// FindByTokenHash retrieves a challenge without counting an attempt.
+func (r *mfaChallengeRepository) FindByTokenHash(ctx context.Context, tokenHash string) (*domain.MFAChallenge, error) {
+	if tokenHash == "" {
+		return nil, errors.New("token hash must not be empty")
+	}
+
+	query := `
+        SELECT token_hash, user_id, auth_time, amr, attempts, email_code_hash, expires_at, created_at
+        FROM mfa_challenges
+        WHERE token_hash = $1
+    `
+	challenge, err := scanMFAChallenge(r.db.QueryRow(ctx, query, tokenHash))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil // 챌린지 없음
+		}
+		r.logger.Error("Failed to find mfa challenge", zap.Error(err))
+		return nil, errors.New("failed to find mfa challenge: " + err.Error())
+	}
+	return challenge, nil
+}
+
+// IncrementAttempts counts an attempt and returns the challenge with the new count.
+func (r *mfaChallengeRepository) IncrementAttempts(ctx context.Context, tokenHash string) (*domain.MFAChallenge, error) {
+	if tokenHash == "" {
+		return nil, errors.New("token hash must not be empty")
+	}
+
+	query := `
+        UPDATE mfa_challenges SET attempts = attempts + 1
+        WHERE token_hash = $1
+        RETURNING token_hash, user_id, auth_time, amr, attempts, email_code_hash, expires_at, created_at
+    `
+	challenge, err := scanMFAChallenge(r.db.QueryRow(ctx, query, tokenHash))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil // 챌린지 없음
+		}
+		r.logger.Error("Failed to increment mfa challenge attempts", zap.Error(err))
+		return nil, errors.New("failed to increment mfa challenge attempts: " + err.Error())
+	}
+	return challenge, nil
+}
+
+// Consume deletes the challenge and returns it, so only one request can complete it.
+func (r *mfaChallengeRepository) Consume(ctx context.Context, tokenHash string) (*domain.MFAChallenge, error) {
+	if tokenHash == "" {
+		return nil, errors.New("token hash must not be empty")
+	}
+
+	query := `
+        DELETE FROM mfa_challenges
+        WHERE token_hash = $1
+        RETURNING token_hash, user_id, auth_time, amr, attempts, email_code_hash, expires_at, created_at
+    `
+	challenge, err := scanMFAChallenge(r.db.QueryRow(ctx, query, tokenHash))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil // 이미 사용됨
+		}
+		r.logger.Error("Failed to consume mfa challenge", zap.Error(err))
+		return nil, errors.New("failed to consume mfa challenge: " + err.Error())
+	}
+	return challenge, nil
+}
+
+// PurgeExpired deletes a bounded batch of expired challenges.
+func (r *mfaChallengeRepository) PurgeExpired(ctx context.Context, before time.Time, limit int) (int64, error) {
+	if limit <= 0 {
+		return 0, errors.New("limit must be positive")
+	}
+
+	query := `
+        DELETE FROM mfa_challenges
+        WHERE token_hash IN (
+            SELECT token_hash FROM mfa_challenges
+            WHERE expires_at <= $1
+            ORDER BY expires_at
+            LIMIT $2
+            FOR UPDATE SKIP LOCKED
+        )
+    `
+	result, err := r.db.Exec(ctx, query, before, limit)
+	if err != nil {
+		r.logger.Error("Failed to purge expired mfa challenges", zap.Error(err))
+		return 0, errors.New("failed to purge expired mfa challenges: " + err.Error())
+	}
+	return result.RowsAffected(), nil
+}
+
+// scanMFAChallenge reads an mfa_challenges row into a domain.MFAChallenge.
+func scanMFAChallenge(row pgx.Row) (*domain.MFAChallenge, error) {
+	var (
+		tokenHash, userID string
+		authTime          time.Time
+		amr               []string
+		attempts          int
+		emailCodeHash     sql.NullString
+		expiresAt         time.Time
+		createdAt         time.Time
+	)
+	if err := row.Scan(&tokenHash, &userID, &authTime, &amr, &attempts, &emailCodeHash, &expiresAt, &createdAt); err != nil {
+		return nil, err
+	}
+	return domain.NewMFAChallengeFromStorage(tokenHash, userID, authTime, amr, attempts, emailCodeHash.String, expiresAt, createdAt)
+}
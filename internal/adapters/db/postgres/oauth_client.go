@@ -0,0 +1,125 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sukryu/IV-auth-services/internal/core/domain"
+	"go.uber.org/zap"
+)
+
+// oauthClientRepository implements domain.OAuthClientRepository for PostgreSQL.
+type oauthClientRepository struct {
+	db     *pgxpool.Pool
+	logger *zap.Logger
+}
+
+// NewOAuthClientRepository creates a new oauthClientRepository instance.
+func NewOAuthClientRepository(db *pgxpool.Pool, logger *zap.Logger) domain.OAuthClientRepository {
+	return &oauthClientRepository{
+		db:     db,
+		logger: logger.With(zap.String("component", "oauth_client_repository")),
+	}
+}
+
+// Save saves an OAuth client to the database.
+func (r *oauthClientRepository) Save(ctx context.Context, client *domain.OAuthClient) error {
+	if client == nil {
+		return errors.New("client must not be nil")
+	}
+
+	// 공개 클라이언트는 시크릿이 없으므로 NULL로 저장
+	var secretHash, secretSalt []byte
+	if !client.IsPublic() {
+		secretHash = client.SecretHash().Hash()
+		secretSalt = client.SecretHash().Salt()
+	}
+
+	query := `
+        INSERT INTO oauth_clients (id, name, client_type, secret_hash, secret_salt, allowed_scopes, redirect_uris, first_party, token_ttl_seconds, created_at, updated_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+        ON CONFLICT (id) DO UPDATE SET
+            name = EXCLUDED.name,
+            client_type = EXCLUDED.client_type,
+            secret_hash = EXCLUDED.secret_hash,
+            secret_salt = EXCLUDED.secret_salt,
+            allowed_scopes = EXCLUDED.allowed_scopes,
+            redirect_uris = EXCLUDED.redirect_uris,
+            first_party = EXCLUDED.first_party,
+            token_ttl_seconds = EXCLUDED.token_ttl_seconds,
+            updated_at = EXCLUDED.updated_at
+    `
+	_, err := r.db.Exec(ctx, query,
+		client.ID(),
+		client.Name(),
+		string(client.Type()),
+		secretHash,
+		secretSalt,
+		client.AllowedScopes(),
+		client.RedirectURIs(),
+		client.IsFirstParty(),
+		int(client.TokenTTL().Seconds()),
+		client.CreatedAt(),
+		client.UpdatedAt(),
+	)
+	if err != nil {
+		r.logger.Error("Failed to save oauth client", zap.Error(err), zap.String("client_id", client.ID()))
+		return errors.New("failed to save oauth client: " + err.Error())
+	}
+	r.logger.Debug("OAuth client saved successfully", zap.String("client_id", client.ID()))
+	return nil
+}
+
+// FindByID retrieves an OAuth client by ID from the database.
+func (r *oauthClientRepository) FindByID(ctx context.Context, id string) (*domain.OAuthClient, error) {
+	if id == "" {
+		return nil, errors.New("client id must not be empty")
+	}
+
+	query := `
+        SELECT id, name, client_type, secret_hash, secret_salt, allowed_scopes, redirect_uris, first_party, token_ttl_seconds
+        FROM oauth_clients
+        WHERE id = $1
+    `
+	var (
+		name          string
+		clientType    string
+		secretHash    []byte
+		secretSalt    []byte
+		allowedScopes []string
+		redirectURIs  []string
+		firstParty    bool
+		ttlSeconds    int
+	)
+	err := r.db.QueryRow(ctx, query, id).Scan(&id, &name, &clientType, &secretHash, &secretSalt, &allowedScopes, &redirectURIs, &firstParty, &ttlSeconds)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil // 클라이언트 없음
+		}
+		r.logger.Error("Failed to find oauth client by id", zap.Error(err), zap.String("client_id", id))
+		return nil, errors.New("failed to find oauth client: " + err.Error())
+	}
+
+	ttl := time.Duration(ttlSeconds) * time.Second
+	var client *domain.OAuthClient
+	if domain.OAuthClientType(clientType) == domain.OAuthClientPublic {
+		client, err = domain.NewPublicOAuthClient(id, name, allowedScopes, ttl)
+	} else {
+		secret, hashErr := domain.NewPasswordFromHash(secretHash, secretSalt)
+		if hashErr != nil {
+			return nil, hashErr
+		}
+		client, err = domain.NewOAuthClient(id, name, secret, allowedScopes, ttl)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := client.SetRedirectURIs(redirectURIs); err != nil {
+		return nil, err
+	}
+	client.SetFirstParty(firstParty)
+	return client, nil
+}
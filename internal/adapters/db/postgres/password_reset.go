@@ -0,0 +1,122 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sukryu/IV-auth-services/internal/core/domain"
+	"go.uber.org/zap"
+)
+
+// passwordResetTokenRepository implements domain.PasswordResetTokenRepository for PostgreSQL.
+type passwordResetTokenRepository struct {
+	db     *pgxpool.Pool
+	logger *zap.Logger
+}
+
+// NewPasswordResetTokenRepository creates a new passwordResetTokenRepository instance.
+func NewPasswordResetTokenRepository(db *pgxpool.Pool, logger *zap.Logger) domain.PasswordResetTokenRepository {
+	return &passwordResetTokenRepository{
+		db:     db,
+		logger: logger.With(zap.String("component", "password_reset_token_repository")),
+	}
+}
+
+// Save stores a newly issued reset token.
+func (r *passwordResetTokenRepository) Save(ctx context.Context, token *domain.PasswordResetToken) error {
+	if token == nil {
+		return errors.New("password reset token must not be nil")
+	}
+
+	query := `
+        INSERT INTO password_reset_tokens (token_hash, user_id, email, expires_at, created_at)
+        VALUES ($1, $2, $3, $4, $5)
+    `
+	_, err := r.db.Exec(ctx, query,
+		token.TokenHash(),
+		token.UserID(),
+		token.Email().String(),
+		token.ExpiresAt(),
+		token.CreatedAt(),
+	)
+	if err != nil {
+		r.logger.Error("Failed to save password reset token", zap.Error(err), zap.String("user_id", token.UserID()))
+		return errors.New("failed to save password reset token: " + err.Error())
+	}
+	return nil
+}
+
+// Consume deletes the token and returns it, so a link can be used only once.
+func (r *passwordResetTokenRepository) Consume(ctx context.Context, tokenHash string) (*domain.PasswordResetToken, error) {
+	if tokenHash == "" {
+		return nil, errors.New("token hash must not be empty")
+	}
+
+	query := `
+        DELETE FROM password_reset_tokens
+        WHERE token_hash = $1
+        RETURNING token_hash, user_id, email, expires_at, created_at
+    `
+	var (
+		hash, userID, emailStr string
+		expiresAt, createdAt   time.Time
+	)
+	err := r.db.QueryRow(ctx, query, tokenHash).Scan(&hash, &userID, &emailStr, &expiresAt, &createdAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil // 이미 사용됨
+		}
+		r.logger.Error("Failed to consume password reset token", zap.Error(err))
+		return nil, errors.New("failed to consume password reset token: " + err.Error())
+	}
+
+	email, err := domain.NewEmail(emailStr)
+	if err != nil {
+		return nil, err
+	}
+	return domain.NewPasswordResetTokenFromStorage(hash, userID, email, expiresAt, createdAt)
+}
+
+// DeleteByUserID deletes every outstanding reset token of a user.
+func (r *passwordResetTokenRepository) DeleteByUserID(ctx context.Context, userID string) error {
+	if userID == "" {
+		return errors.New("user id must not be empty")
+	}
+
+	query := `
+        DELETE FROM password_reset_tokens
+        WHERE user_id = $1
+    `
+	if _, err := r.db.Exec(ctx, query, userID); err != nil {
+		r.logger.Error("Failed to delete password reset tokens", zap.Error(err), zap.String("user_id", userID))
+		return errors.New("failed to delete password reset tokens: " + err.Error())
+	}
+	return nil
+}
+
+// PurgeExpired deletes a bounded batch of expired reset tokens.
+func (r *passwordResetTokenRepository) PurgeExpired(ctx context.Context, before time.Time, limit int) (int64, error) {
+	if limit <= 0 {
+		return 0, errors.New("limit must be positive")
+	}
+
+	query := `
+        DELETE FROM password_reset_tokens
+        WHERE token_hash IN (
+            SELECT token_hash FROM password_reset_tokens
+            WHERE expires_at <= $1
+            ORDER BY expires_at
+            LIMIT $2
+            FOR UPDATE SKIP LOCKED
+        )
+    `
+	result, err := r.db.Exec(ctx, query, before, limit)
+	if err != nil {
+		r.logger.Error("Failed to purge expired password reset tokens", zap.Error(err))
+		return 0, errors.New("failed to purge expired password reset tokens: " + err.Error())
+	}
+	return result.RowsAffected(), nil
+}
@@ -157,6 +157,45 @@ func (r *platformAccountRepository) FindByUserID(ctx context.Context, userID str
 	return accounts, nil
 }
 
+// FindByPlatformUserID retrieves the platform account of an external identity from the database.
+func (r *platformAccountRepository) FindByPlatformUserID(ctx context.Context, platform domain.PlatformType, platformUserID string) (*domain.PlatformAccount, error) {
+	if platform == "" || platformUserID == "" {
+		return nil, errors.New("platform and platform user id must not be empty")
+	}
+
+	query := `
+        SELECT id, user_id, platform, platform_user_id, platform_username, access_token, refresh_token, token_expires_at, created_at, updated_at
+        FROM platform_accounts
+        WHERE platform = $1 AND platform_user_id = $2
+    `
+	row := r.db.QueryRow(ctx, query, platform, platformUserID)
+
+	var (
+		id               string
+		userID           string
+		platformUsername string
+		accessToken      string
+		refreshToken     string
+		tokenExpiresAt   sql.NullTime
+		createdAt        time.Time
+		updatedAt        time.Time
+	)
+	err := row.Scan(&id, &userID, &platform, &platformUserID, &platformUsername, &accessToken, &refreshToken, &tokenExpiresAt, &createdAt, &updatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil // 연결된 계정 없음
+		}
+		r.logger.Error("Failed to find platform account by platform user id", zap.Error(err), zap.String("platform", string(platform)))
+		return nil, errors.New("failed to find platform account: " + err.Error())
+	}
+
+	var expiresAt *time.Time
+	if tokenExpiresAt.Valid {
+		expiresAt = &tokenExpiresAt.Time
+	}
+	return domain.NewPlatformAccount(id, userID, platform, platformUserID, platformUsername, accessToken, refreshToken, expiresAt)
+}
+
 // Delete removes a platform account from the database.
 func (r *platformAccountRepository) Delete(ctx context.Context, id string) error {
 	if id == "" {
@@ -0,0 +1,74 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sukryu/IV-auth-services/internal/core/domain"
+	"go.uber.org/zap"
+)
+
+// rateLimitRepository implements domain.RateLimitRepository for PostgreSQL.
+type rateLimitRepository struct {
+	db     *pgxpool.Pool
+	logger *zap.Logger
+}
+
+// NewRateLimitRepository creates a new rateLimitRepository instance.
+func NewRateLimitRepository(db *pgxpool.Pool, logger *zap.Logger) domain.RateLimitRepository {
+	return &rateLimitRepository{
+		db:     db,
+		logger: logger.With(zap.String("component", "rate_limit_repository")),
+	}
+}
+
+// Increment counts a request in the fixed window that contains the current time.
+// Concurrent requests are serialized by the upsert on (key, window_start).
+func (r *rateLimitRepository) Increment(ctx context.Context, key string, window time.Duration) (int, error) {
+	if key == "" {
+		return 0, errors.New("rate limit key must not be empty")
+	}
+	if window <= 0 {
+		return 0, errors.New("rate limit window must be positive")
+	}
+
+	windowStart := time.Now().Truncate(window)
+	query := `
+        INSERT INTO rate_limits (key, window_start, count, expires_at)
+        VALUES ($1, $2, 1, $3)
+        ON CONFLICT (key, window_start) DO UPDATE SET count = rate_limits.count + 1
+        RETURNING count
+    `
+	var count int
+	if err := r.db.QueryRow(ctx, query, key, windowStart, windowStart.Add(window)).Scan(&count); err != nil {
+		r.logger.Error("Failed to increment rate limit", zap.Error(err), zap.String("key", key))
+		return 0, errors.New("failed to increment rate limit: " + err.Error())
+	}
+	return count, nil
+}
+
+// PurgeExpired deletes a bounded batch of counters whose window has ended.
+func (r *rateLimitRepository) PurgeExpired(ctx context.Context, before time.Time, limit int) (int64, error) {
+	if limit <= 0 {
+		return 0, errors.New("limit must be positive")
+	}
+
+	query := `
+        DELETE FROM rate_limits
+        WHERE (key, window_start) IN (
+            SELECT key, window_start FROM rate_limits
+            WHERE expires_at <= $1
+            ORDER BY expires_at
+            LIMIT $2
+            FOR UPDATE SKIP LOCKED
+        )
+    `
+	result, err := r.db.Exec(ctx, query, before, limit)
+	if err != nil {
+		r.logger.Error("Failed to purge expired rate limits", zap.Error(err))
+		return 0, errors.New("failed to purge expired rate limits: " + err.Error())
+	}
+	return result.RowsAffected(), nil
+}
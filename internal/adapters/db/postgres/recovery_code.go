@@ -0,0 +1,133 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sukryu/IV-auth-services/internal/core/domain"
+	"go.uber.org/zap"
+)
+
+// recoveryCodeRepository implements domain.RecoveryCodeRepository for PostgreSQL.
+type recoveryCodeRepository struct {
+	db     *pgxpool.Pool
+	logger *zap.Logger
+}
+
+// NewRecoveryCodeRepository creates a new recoveryCodeRepository instance.
+func NewRecoveryCodeRepository(db *pgxpool.Pool, logger *zap.Logger) domain.RecoveryCodeRepository {
+	return &recoveryCodeRepository{
+		db:     db,
+		logger: logger.With(zap.String("component", "recovery_code_repository")),
+	}
+}
+
+// ReplaceForUser deletes the user's codes and inserts the new set in one transaction.
+func (r *recoveryCodeRepository) ReplaceForUser(ctx context.Context, userID string, codes []*domain.RecoveryCode) error {
+	if userID == "" {
+		return errors.New("user id must not be empty")
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		r.logger.Error("Failed to begin transaction", zap.Error(err))
+		return errors.New("failed to begin transaction: " + err.Error())
+	}
+	defer func() { _ = tx.Rollback(ctx) }() // 커밋 후 호출되면 무시됨
+
+	if _, err := tx.Exec(ctx, `DELETE FROM mfa_recovery_codes WHERE user_id = $1`, userID); err != nil {
+		r.logger.Error("Failed to delete recovery codes", zap.Error(err), zap.String("user_id", userID))
+		return errors.New("failed to delete recovery codes: " + err.Error())
+	}
+
+	query := `
+        INSERT INTO mfa_recovery_codes (id, user_id, code_hash, salt, used_at, created_at)
+        VALUES ($1, $2, $3, $4, $5, $6)
+    `
+	for _, code := range codes {
+		if code.UserID() != userID {
+			return errors.New("recovery code belongs to another user")
+		}
+		_, err := tx.Exec(ctx, query,
+			code.ID(),
+			code.UserID(),
+			code.Hash().Hash(),
+			code.Hash().Salt(),
+			code.UsedAt(),
+			code.CreatedAt(),
+		)
+		if err != nil {
+			r.logger.Error("Failed to insert recovery code", zap.Error(err), zap.String("user_id", userID))
+			return errors.New("failed to insert recovery code: " + err.Error())
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		r.logger.Error("Failed to commit recovery codes", zap.Error(err), zap.String("user_id", userID))
+		return errors.New("failed to commit recovery codes: " + err.Error())
+	}
+	return nil
+}
+
+// FindUnusedByUserID retrieves the unused codes of a user from the database.
+func (r *recoveryCodeRepository) FindUnusedByUserID(ctx context.Context, userID string) ([]*domain.RecoveryCode, error) {
+	if userID == "" {
+		return nil, errors.New("user id must not be empty")
+	}
+
+	query := `
+        SELECT id, code_hash, salt, created_at
+        FROM mfa_recovery_codes
+        WHERE user_id = $1 AND used_at IS NULL
+    `
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		r.logger.Error("Failed to find recovery codes", zap.Error(err), zap.String("user_id", userID))
+		return nil, errors.New("failed to find recovery codes: " + err.Error())
+	}
+	defer rows.Close()
+
+	var codes []*domain.RecoveryCode
+	for rows.Next() {
+		var (
+			id         string
+			hash, salt []byte
+			createdAt  time.Time
+		)
+		if err := rows.Scan(&id, &hash, &salt, &createdAt); err != nil {
+			r.logger.Error("Failed to scan recovery code row", zap.Error(err))
+			return nil, errors.New("failed to scan recovery code: " + err.Error())
+		}
+		password, err := domain.NewPasswordFromHash(hash, salt)
+		if err != nil {
+			return nil, err
+		}
+		code, err := domain.NewRecoveryCodeFromStorage(id, userID, password, nil, createdAt)
+		if err != nil {
+			return nil, err
+		}
+		codes = append(codes, code)
+	}
+	if err := rows.Err(); err != nil {
+		r.logger.Error("Error iterating recovery code rows", zap.Error(err))
+		return nil, errors.New("failed to iterate recovery codes: " + err.Error())
+	}
+	return codes, nil
+}
+
+// MarkUsed sets used_at only if the code is still unused, in a single conditional update.
+func (r *recoveryCodeRepository) MarkUsed(ctx context.Context, id string, usedAt time.Time) (bool, error) {
+	if id == "" {
+		return false, errors.New("recovery code id must not be empty")
+	}
+
+	query := `UPDATE mfa_recovery_codes SET used_at = $2 WHERE id = $1 AND used_at IS NULL`
+	result, err := r.db.Exec(ctx, query, id, usedAt)
+	if err != nil {
+		r.logger.Error("Failed to mark recovery code used", zap.Error(err))
+		return false, errors.New("failed to mark recovery code used: " + err.Error())
+	}
+	return result.RowsAffected() == 1, nil
+}
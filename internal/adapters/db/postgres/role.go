@@ -0,0 +1,59 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sukryu/IV-auth-services/internal/core/domain"
+	"go.uber.org/zap"
+)
+
+// roleRepository implements domain.RoleRepository for PostgreSQL.
+type roleRepository struct {
+	db     *pgxpool.Pool
+	logger *zap.Logger
+}
+
+// NewRoleRepository creates a new roleRepository instance.
+func NewRoleRepository(db *pgxpool.Pool, logger *zap.Logger) domain.RoleRepository {
+	return &roleRepository{
+		db:     db,
+		logger: logger.With(zap.String("component", "role_repository")),
+	}
+}
+
+// FindNamesByUserID returns the names of the roles assigned to a user.
+func (r *roleRepository) FindNamesByUserID(ctx context.Context, userID string) ([]string, error) {
+	if userID == "" {
+		return nil, errors.New("user id must not be empty")
+	}
+
+	query := `
+        SELECT r.name
+        FROM user_roles ur
+        JOIN roles r ON r.id = ur.role_id
+        WHERE ur.user_id = $1
+    `
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		r.logger.Error("Failed to find roles by user id", zap.Error(err), zap.String("user_id", userID))
+		return nil, errors.New("failed to find roles: " + err.Error())
+	}
+	defer rows.Close()
+
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			r.logger.Error("Failed to scan role row", zap.Error(err))
+			return nil, errors.New("failed to scan role: " + err.Error())
+		}
+		names = append(names, name)
+	}
+	if err := rows.Err(); err != nil {
+		r.logger.Error("Error iterating role rows", zap.Error(err))
+		return nil, errors.New("failed to iterate roles: " + err.Error())
+	}
+	return names, nil
+}
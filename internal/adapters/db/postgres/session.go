@@ -0,0 +1,197 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sukryu/IV-auth-services/internal/core/domain"
+	"go.uber.org/zap"
+)
+
+// sessionRepository implements domain.SessionRepository for PostgreSQL.
+type sessionRepository struct {
+	db     *pgxpool.Pool
+	logger *zap.Logger
+}
+
+// NewSessionRepository creates a new sessionRepository instance.
+func NewSessionRepository(db *pgxpool.Pool, logger *zap.Logger) domain.SessionRepository {
+	return &sessionRepository{
+		db:     db,
+		logger: logger.With(zap.String("component", "session_repository")),
+	}
+}
+
+// Save inserts a session or updates its refresh time, IP address and revocation. A stored
+// revocation is kept even if the given session was loaded before it happened.
+func (r *sessionRepository) Save(ctx context.Context, session *domain.Session) error {
+	if session == nil {
+		return errors.New("session must not be nil")
+	}
+
+	query := `
+        INSERT INTO user_sessions (id, user_id, device_name, user_agent, ip_address, created_at, last_refreshed_at, expires_at, revoked_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+        ON CONFLICT (id) DO UPDATE SET
+            ip_address = EXCLUDED.ip_address,
+            last_refreshed_at = EXCLUDED.last_refreshed_at,
+            expires_at = EXCLUDED.expires_at,
+            revoked_at = COALESCE(user_sessions.revoked_at, EXCLUDED.revoked_at)
+    `
+	_, err := r.db.Exec(ctx, query,
+		session.ID(),
+		session.UserID(),
+		session.DeviceName(),
+		session.UserAgent(),
+		session.IPAddress(),
+		session.CreatedAt(),
+		session.LastRefreshedAt(),
+		session.ExpiresAt(),
+		session.RevokedAt(),
+	)
+	if err != nil {
+		r.logger.Error("Failed to save session", zap.Error(err), zap.String("session_id", session.ID()))
+		return errors.New("failed to save session: " + err.Error())
+	}
+	return nil
+}
+
+// Touch stores the session's refresh time, expiry and IP address only while the session is
+// not revoked, so a refresh racing a revocation cannot bring the session back.
+func (r *sessionRepository) Touch(ctx context.Context, session *domain.Session) (bool, error) {
+	if session == nil {
+		return false, errors.New("session must not be nil")
+	}
+
+	query := `
+        UPDATE user_sessions
+        SET last_refreshed_at = $2, expires_at = $3, ip_address = $4
+        WHERE id = $1 AND revoked_at IS NULL
+    `
+	result, err := r.db.Exec(ctx, query, session.ID(), session.LastRefreshedAt(), session.ExpiresAt(), session.IPAddress())
+	if err != nil {
+		r.logger.Error("Failed to touch session", zap.Error(err), zap.String("session_id", session.ID()))
+		return false, errors.New("failed to touch session: " + err.Error())
+	}
+	return result.RowsAffected() > 0, nil
+}
+
+// FindByID retrieves a session by its ID from the database.
+func (r *sessionRepository) FindByID(ctx context.Context, id string) (*domain.Session, error) {
+	if id == "" {
+		return nil, errors.New("session id must not be empty")
+	}
+
+	query := `
+        SELECT id, user_id, device_name, user_agent, ip_address, created_at, last_refreshed_at, expires_at, revoked_at
+        FROM user_sessions
+        WHERE id = $1
+    `
+	session, err := scanSession(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil // 세션 없음
+		}
+		r.logger.Error("Failed to find session by id", zap.Error(err), zap.String("session_id", id))
+		return nil, errors.New("failed to find session: " + err.Error())
+	}
+	return session, nil
+}
+
+// FindActiveByUserID retrieves the unrevoked, unexpired sessions of a user, most recently used first.
+func (r *sessionRepository) FindActiveByUserID(ctx context.Context, userID string) ([]*domain.Session, error) {
+	if userID == "" {
+		return nil, errors.New("user id must not be empty")
+	}
+
+	query := `
+        SELECT id, user_id, device_name, user_agent, ip_address, created_at, last_refreshed_at, expires_at, revoked_at
+        FROM user_sessions
+        WHERE user_id = $1 AND revoked_at IS NULL AND expires_at > $2
+        ORDER BY last_refreshed_at DESC
+    `
+	rows, err := r.db.Query(ctx, query, userID, time.Now())
+	if err != nil {
+		r.logger.Error("Failed to find sessions by user id", zap.Error(err), zap.String("user_id", userID))
+		return nil, errors.New("failed to find sessions: " + err.Error())
+	}
+	defer rows.Close()
+
+	var sessions []*domain.Session
+	for rows.Next() {
+		session, err := scanSession(rows)
+		if err != nil {
+			r.logger.Error("Failed to scan session row", zap.Error(err))
+			return nil, errors.New("failed to scan session: " + err.Error())
+		}
+		sessions = append(sessions, session)
+	}
+	if err := rows.Err(); err != nil {
+		r.logger.Error("Error iterating session rows", zap.Error(err))
+		return nil, errors.New("failed to iterate sessions: " + err.Error())
+	}
+	return sessions, nil
+}
+
+// RevokeAllByUserID revokes the user's active sessions, optionally keeping one.
+func (r *sessionRepository) RevokeAllByUserID(ctx context.Context, userID, exceptID string, revokedAt time.Time) (int64, error) {
+	if userID == "" {
+		return 0, errors.New("user id must not be empty")
+	}
+
+	query := `
+        UPDATE user_sessions
+        SET revoked_at = $3
+        WHERE user_id = $1 AND revoked_at IS NULL AND id <> $2
+    `
+	result, err := r.db.Exec(ctx, query, userID, exceptID, revokedAt)
+	if err != nil {
+		r.logger.Error("Failed to revoke sessions by user id", zap.Error(err), zap.String("user_id", userID))
+		return 0, errors.New("failed to revoke sessions: " + err.Error())
+	}
+	return result.RowsAffected(), nil
+}
+
+// PurgeExpired deletes a bounded batch of sessions that expired or were revoked before the given time.
+// Tokens referencing a purged session are rejected like those of a revoked one.
+func (r *sessionRepository) PurgeExpired(ctx context.Context, before time.Time, limit int) (int64, error) {
+	if limit <= 0 {
+		return 0, errors.New("limit must be positive")
+	}
+
+	query := `
+        DELETE FROM user_sessions
+        WHERE id IN (
+            SELECT id FROM user_sessions
+            WHERE expires_at <= $1 OR revoked_at <= $1
+            LIMIT $2
+            FOR UPDATE SKIP LOCKED
+        )
+    `
+	result, err := r.db.Exec(ctx, query, before, limit)
+	if err != nil {
+		r.logger.Error("Failed to purge expired sessions", zap.Error(err))
+		return 0, errors.New("failed to purge expired sessions: " + err.Error())
+	}
+	return result.RowsAffected(), nil
+}
+
+// scanSession reads a user_sessions row into a domain.Session.
+func scanSession(row pgx.Row) (*domain.Session, error) {
+	var (
+		id, userID      string
+		metadata        domain.ClientMetadata
+		createdAt       time.Time
+		lastRefreshedAt time.Time
+		expiresAt       time.Time
+		revokedAt       *time.Time
+	)
+	if err := row.Scan(&id, &userID, &metadata.DeviceName, &metadata.UserAgent, &metadata.IPAddress,
+		&createdAt, &lastRefreshedAt, &expiresAt, &revokedAt); err != nil {
+		return nil, err
+	}
+	return domain.NewSessionFromStorage(id, userID, metadata, createdAt, lastRefreshedAt, expiresAt, revokedAt)
+}
@@ -65,3 +65,28 @@ func (r *tokenRepository) IsBlacklisted(ctx context.Context, tokenID string) (bo
 	}
 	return exists, nil
 }
+
+// PurgeExpired deletes a bounded batch of expired blacklist entries.
+// FOR UPDATE SKIP LOCKED를 사용하므로 여러 레플리카가 동시에 실행해도 같은 행을 두고 경합하지 않는다.
+func (r *tokenRepository) PurgeExpired(ctx context.Context, before time.Time, limit int) (int64, error) {
+	if limit <= 0 {
+		return 0, errors.New("limit must be positive")
+	}
+
+	query := `
+        DELETE FROM token_blacklist
+        WHERE token_id IN (
+            SELECT token_id FROM token_blacklist
+            WHERE expires_at <= $1
+            ORDER BY expires_at
+            LIMIT $2
+            FOR UPDATE SKIP LOCKED
+        )
+    `
+	result, err := r.db.Exec(ctx, query, before, limit)
+	if err != nil {
+		r.logger.Error("Failed to purge expired blacklist entries", zap.Error(err))
+		return 0, errors.New("failed to purge expired blacklist entries: " + err.Error())
+	}
+	return result.RowsAffected(), nil
+}
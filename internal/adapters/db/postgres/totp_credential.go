@@ -0,0 +1,112 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sukryu/IV-auth-services/internal/core/domain"
+	"go.uber.org/zap"
+)
+
+// totpCredentialRepository implements domain.TOTPCredentialRepository for PostgreSQL.
+type totpCredentialRepository struct {
+	db     *pgxpool.Pool
+	logger *zap.Logger
+}
+
+// NewTOTPCredentialRepository creates a new totpCredentialRepository instance.
+func NewTOTPCredentialRepository(db *pgxpool.Pool, logger *zap.Logger) domain.TOTPCredentialRepository {
+	return &totpCredentialRepository{
+		db:     db,
+		logger: logger.With(zap.String("component", "totp_credential_repository")),
+	}
+}
+
+// Save inserts or replaces the TOTP credential of a user.
+func (r *totpCredentialRepository) Save(ctx context.Context, credential *domain.TOTPCredential) error {
+	if credential == nil {
+		return errors.New("totp credential must not be nil")
+	}
+
+	query := `
+        INSERT INTO totp_credentials (user_id, encrypted_secret, confirmed_at, last_used_step, created_at)
+        VALUES ($1, $2, $3, $4, $5)
+        ON CONFLICT (user_id) DO UPDATE SET
+            encrypted_secret = EXCLUDED.encrypted_secret,
+            confirmed_at = EXCLUDED.confirmed_at,
+            last_used_step = EXCLUDED.last_used_step,
+            created_at = EXCLUDED.created_at
+    `
+	_, err := r.db.Exec(ctx, query,
+		credential.UserID(),
+		credential.EncryptedSecret(),
+		credential.ConfirmedAt(),
+		credential.LastUsedStep(),
+		credential.CreatedAt(),
+	)
+	if err != nil {
+		r.logger.Error("Failed to save totp credential", zap.Error(err), zap.String("user_id", credential.UserID()))
+		return errors.New("failed to save totp credential: " + err.Error())
+	}
+	return nil
+}
+
+// FindByUserID retrieves the TOTP credential of a user from the database.
+func (r *totpCredentialRepository) FindByUserID(ctx context.Context, userID string) (*domain.TOTPCredential, error) {
+	if userID == "" {
+		return nil, errors.New("user id must not be empty")
+	}
+
+	query := `
+        SELECT encrypted_secret, confirmed_at, last_used_step, created_at
+        FROM totp_credentials
+        WHERE user_id = $1
+    `
+	var (
+		encryptedSecret string
+		confirmedAt     *time.Time
+		lastUsedStep    int64
+		createdAt       time.Time
+	)
+	err := r.db.QueryRow(ctx, query, userID).Scan(&encryptedSecret, &confirmedAt, &lastUsedStep, &createdAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil // 등록된 TOTP 없음
+		}
+		r.logger.Error("Failed to find totp credential", zap.Error(err), zap.String("user_id", userID))
+		return nil, errors.New("failed to find totp credential: " + err.Error())
+	}
+	return domain.NewTOTPCredentialFromStorage(userID, encryptedSecret, confirmedAt, lastUsedStep, createdAt)
+}
+
+// MarkStepUsed advances last_used_step only if step is newer, in a single conditional update.
+func (r *totpCredentialRepository) MarkStepUsed(ctx context.Context, userID string, step int64) (bool, error) {
+	if userID == "" {
+		return false, errors.New("user id must not be empty")
+	}
+
+	query := `UPDATE totp_credentials SET last_used_step = $2 WHERE user_id = $1 AND last_used_step < $2`
+	result, err := r.db.Exec(ctx, query, userID, step)
+	if err != nil {
+		r.logger.Error("Failed to mark totp step used", zap.Error(err), zap.String("user_id", userID))
+		return false, errors.New("failed to mark totp step used: " + err.Error())
+	}
+	return result.RowsAffected() == 1, nil
+}
+
+// Delete removes the TOTP credential of a user from the database.
+func (r *totpCredentialRepository) Delete(ctx context.Context, userID string) error {
+	if userID == "" {
+		return errors.New("user id must not be empty")
+	}
+
+	query := `DELETE FROM totp_credentials WHERE user_id = $1`
+	if _, err := r.db.Exec(ctx, query, userID); err != nil {
+		r.logger.Error("Failed to delete totp credential", zap.Error(err), zap.String("user_id", userID))
+		return errors.New("failed to delete totp credential: " + err.Error())
+	}
+	return nil
+}
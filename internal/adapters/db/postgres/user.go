@@ -6,6 +6,7 @@ import (
 	"errors"
 	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/sukryu/IV-auth-services/internal/core/domain"
 	"go.uber.org/zap"
@@ -32,21 +33,29 @@ func (r *userRepository) SaveUser(ctx context.Context, user *domain.User) error
 	}
 
 	query := `
-        INSERT INTO users (id, username, email, password_hash, status, subscription_tier, created_at, updated_at, last_login_at)
-        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+        INSERT INTO users (id, username, email, email_verified, password_hash, status, subscription_tier, created_at, updated_at, last_login_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
         ON CONFLICT (id) DO UPDATE SET
             username = EXCLUDED.username,
             email = EXCLUDED.email,
+            email_verified = EXCLUDED.email_verified,
             password_hash = EXCLUDED.password_hash,
             status = EXCLUDED.status,
             subscription_tier = EXCLUDED.subscription_tier,
             updated_at = EXCLUDED.updated_at,
             last_login_at = EXCLUDED.last_login_at
     `
+	// 게스트는 이메일과 비밀번호 없이 저장 (NULL)
+	var email *string
+	if user.Email() != "" {
+		e := user.Email().String()
+		email = &e
+	}
 	_, err := r.db.Exec(ctx, query,
 		user.ID(),
 		user.Username(),
-		user.Email().String(),
+		email,
+		user.EmailVerified(),
 		user.PasswordHash().Hash(),
 		user.Status(),
 		user.SubscriptionTier(),
@@ -69,15 +78,64 @@ func (r *userRepository) FindByUsername(ctx context.Context, username string) (*
 	}
 
 	query := `
-        SELECT id, username, email, password_hash, status, subscription_tier, created_at, updated_at, last_login_at
+        SELECT id, username, email, email_verified, password_hash, status, subscription_tier, created_at, updated_at, last_login_at
         FROM users
         WHERE username = $1
     `
-	row := r.db.QueryRow(ctx, query, username)
+	user, err := scanUser(r.db.QueryRow(ctx, query, username))
+	if err != nil {
+		r.logger.Error("Failed to find user by username", zap.Error(err), zap.String("username", username))
+		return nil, errors.New("failed to find user: " + err.Error())
+	}
+	return user, nil
+}
+
+// FindByID retrieves a user by ID from the database.
+func (r *userRepository) FindByID(ctx context.Context, id string) (*domain.User, error) {
+	if id == "" {
+		return nil, errors.New("user id must not be empty")
+	}
+
+	query := `
+        SELECT id, username, email, email_verified, password_hash, status, subscription_tier, created_at, updated_at, last_login_at
+        FROM users
+        WHERE id = $1
+    `
+	user, err := scanUser(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		r.logger.Error("Failed to find user by id", zap.Error(err), zap.String("user_id", id))
+		return nil, errors.New("failed to find user: " + err.Error())
+	}
+	return user, nil
+}
+
+// FindByEmail retrieves a user by email address from the database.
+func (r *userRepository) FindByEmail(ctx context.Context, email domain.Email) (*domain.User, error) {
+	if !email.IsValid() {
+		return nil, errors.New("invalid email")
+	}
+
+	query := `
+        SELECT id, username, email, email_verified, password_hash, status, subscription_tier, created_at, updated_at, last_login_at
+        FROM users
+        WHERE lower(email) = $1
+    `
+	// 소문자 정규화 이전에 저장된 주소도 찾도록 lower(email)로 비교, 고유 인덱스라 한 행만 일치
+	user, err := scanUser(r.db.QueryRow(ctx, query, email.Normalize().String()))
+	if err != nil {
+		r.logger.Error("Failed to find user by email", zap.Error(err))
+		return nil, errors.New("failed to find user: " + err.Error())
+	}
+	return user, nil
+}
 
+// scanUser restores a user from a row, returning nil if the row does not exist.
+func scanUser(row pgx.Row) (*domain.User, error) {
 	var (
 		id               string
-		emailStr         string
+		username         string
+		emailStr         sql.NullString
+		emailVerified    bool
 		passwordHash     []byte
 		status           domain.UserStatus
 		subscriptionTier string
@@ -85,16 +143,26 @@ func (r *userRepository) FindByUsername(ctx context.Context, username string) (*
 		updatedAt        time.Time
 		lastLoginAt      sql.NullTime
 	)
-	err := row.Scan(&id, &username, &emailStr, &passwordHash, &status, &subscriptionTier, &createdAt, &updatedAt, &lastLoginAt)
+	err := row.Scan(&id, &username, &emailStr, &emailVerified, &passwordHash, &status, &subscriptionTier, &createdAt, &updatedAt, &lastLoginAt)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, nil // 사용자 없음
 		}
-		r.logger.Error("Failed to find user by username", zap.Error(err), zap.String("username", username))
-		return nil, errors.New("failed to find user: " + err.Error())
+		return nil, err
+	}
+
+	if status == domain.UserStatusGuest {
+		user, err := domain.NewGuestUser(id, username)
+		if err != nil {
+			return nil, err
+		}
+		if lastLoginAt.Valid {
+			user.SetLastLoginAt(lastLoginAt.Time)
+		}
+		return user, nil
 	}
 
-	email, err := domain.NewEmail(emailStr)
+	email, err := domain.NewEmail(emailStr.String)
 	if err != nil {
 		return nil, err
 	}
@@ -107,6 +175,7 @@ func (r *userRepository) FindByUsername(ctx context.Context, username string) (*
 		return nil, err
 	}
 	user.SetStatus(status) // 상태 복원
+	user.SetEmailVerified(emailVerified)
 	if lastLoginAt.Valid {
 		user.SetLastLoginAt(lastLoginAt.Time)
 	}
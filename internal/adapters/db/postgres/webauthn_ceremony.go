@@ -0,0 +1,110 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sukryu/IV-auth-services/internal/core/domain"
+	"go.uber.org/zap"
+)
+
+// webAuthnCeremonyRepository implements domain.WebAuthnCeremonyRepository for PostgreSQL.
+type webAuthnCeremonyRepository struct {
+	db     *pgxpool.Pool
+	logger *zap.Logger
+}
+
+// NewWebAuthnCeremonyRepository creates a new webAuthnCeremonyRepository instance.
+func NewWebAuthnCeremonyRepository(db *pgxpool.Pool, logger *zap.Logger) domain.WebAuthnCeremonyRepository {
+	return &webAuthnCeremonyRepository{
+		db:     db,
+		logger: logger.With(zap.String("component", "webauthn_ceremony_repository")),
+	}
+}
+
+// Save stores a newly started ceremony.
+func (r *webAuthnCeremonyRepository) Save(ctx context.Context, ceremony *domain.WebAuthnCeremony) error {
+	if ceremony == nil {
+		return errors.New("webauthn ceremony must not be nil")
+	}
+
+	var userID *string // 패스워드 없는 로그인은 NULL
+	if id := ceremony.UserID(); id != "" {
+		userID = &id
+	}
+	query := `
+        INSERT INTO webauthn_ceremonies (challenge_hash, user_id, purpose, expires_at, created_at)
+        VALUES ($1, $2, $3, $4, $5)
+    `
+	_, err := r.db.Exec(ctx, query,
+		ceremony.ChallengeHash(),
+		userID,
+		ceremony.Purpose(),
+		ceremony.ExpiresAt(),
+		ceremony.CreatedAt(),
+	)
+	if err != nil {
+		r.logger.Error("Failed to save webauthn ceremony", zap.Error(err), zap.String("purpose", ceremony.Purpose()))
+		return errors.New("failed to save webauthn ceremony: " + err.Error())
+	}
+	return nil
+}
+
+// Consume deletes the ceremony and returns it, so only one response can complete it.
+func (r *webAuthnCeremonyRepository) Consume(ctx context.Context, challengeHash string) (*domain.WebAuthnCeremony, error) {
+	if challengeHash == "" {
+		return nil, errors.New("challenge hash must not be empty")
+	}
+
+	query := `
+        DELETE FROM webauthn_ceremonies
+        WHERE challenge_hash = $1
+        RETURNING challenge_hash, user_id, purpose, expires_at, created_at
+    `
+	var (
+		hash, purpose        string
+		userID               *string
+		expiresAt, createdAt time.Time
+	)
+	err := r.db.QueryRow(ctx, query, challengeHash).Scan(&hash, &userID, &purpose, &expiresAt, &createdAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil // 이미 사용됨
+		}
+		r.logger.Error("Failed to consume webauthn ceremony", zap.Error(err))
+		return nil, errors.New("failed to consume webauthn ceremony: " + err.Error())
+	}
+
+	var user string
+	if userID != nil {
+		user = *userID
+	}
+	return domain.NewWebAuthnCeremonyFromStorage(hash, user, purpose, expiresAt, createdAt)
+}
+
+// PurgeExpired deletes a bounded batch of expired ceremonies.
+func (r *webAuthnCeremonyRepository) PurgeExpired(ctx context.Context, before time.Time, limit int) (int64, error) {
+	if limit <= 0 {
+		return 0, errors.New("limit must be positive")
+	}
+
+	query := `
+        DELETE FROM webauthn_ceremonies
+        WHERE challenge_hash IN (
+            SELECT challenge_hash FROM webauthn_ceremonies
+            WHERE expires_at <= $1
+            ORDER BY expires_at
+            LIMIT $2
+            FOR UPDATE SKIP LOCKED
+        )
+    `
+	result, err := r.db.Exec(ctx, query, before, limit)
+	if err != nil {
+		r.logger.Error("Failed to purge expired webauthn ceremonies", zap.Error(err))
+		return 0, errors.New("failed to purge expired webauthn ceremonies: " + err.Error())
+	}
+	return result.RowsAffected(), nil
+}
@@ -0,0 +1,164 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sukryu/IV-auth-services/internal/core/domain"
+	"go.uber.org/zap"
+)
+
+// webAuthnCredentialRepository implements domain.WebAuthnCredentialRepository for PostgreSQL.
+type webAuthnCredentialRepository struct {
+	db     *pgxpool.Pool
+	logger *zap.Logger
+}
+
+// NewWebAuthnCredentialRepository creates a new webAuthnCredentialRepository instance.
+func NewWebAuthnCredentialRepository(db *pgxpool.Pool, logger *zap.Logger) domain.WebAuthnCredentialRepository {
+	return &webAuthnCredentialRepository{
+		db:     db,
+		logger: logger.With(zap.String("component", "webauthn_credential_repository")),
+	}
+}
+
+// Save stores a newly registered credential.
+func (r *webAuthnCredentialRepository) Save(ctx context.Context, credential *domain.WebAuthnCredential) error {
+	if credential == nil {
+		return errors.New("webauthn credential must not be nil")
+	}
+
+	query := `
+        INSERT INTO webauthn_credentials (id, user_id, name, public_key, sign_count, transports, created_at, last_used_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+    `
+	transports := credential.Transports()
+	if transports == nil {
+		transports = []string{}
+	}
+	_, err := r.db.Exec(ctx, query,
+		credential.ID(),
+		credential.UserID(),
+		credential.Name(),
+		credential.PublicKey(),
+		int64(credential.SignCount()),
+		transports,
+		credential.CreatedAt(),
+		credential.LastUsedAt(),
+	)
+	if err != nil {
+		r.logger.Error("Failed to save webauthn credential", zap.Error(err), zap.String("user_id", credential.UserID()))
+		return errors.New("failed to save webauthn credential: " + err.Error())
+	}
+	return nil
+}
+
+// FindByID retrieves a credential by its credential ID from the database.
+func (r *webAuthnCredentialRepository) FindByID(ctx context.Context, id []byte) (*domain.WebAuthnCredential, error) {
+	if len(id) == 0 {
+		return nil, errors.New("credential id must not be empty")
+	}
+
+	query := `
+        SELECT id, user_id, name, public_key, sign_count, transports, created_at, last_used_at
+        FROM webauthn_credentials
+        WHERE id = $1
+    `
+	credential, err := scanWebAuthnCredential(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil // 자격 증명 없음
+		}
+		r.logger.Error("Failed to find webauthn credential", zap.Error(err))
+		return nil, errors.New("failed to find webauthn credential: " + err.Error())
+	}
+	return credential, nil
+}
+
+// FindByUserID retrieves the credentials of a user, oldest first.
+func (r *webAuthnCredentialRepository) FindByUserID(ctx context.Context, userID string) ([]*domain.WebAuthnCredential, error) {
+	if userID == "" {
+		return nil, errors.New("user id must not be empty")
+	}
+
+	query := `
+        SELECT id, user_id, name, public_key, sign_count, transports, created_at, last_used_at
+        FROM webauthn_credentials
+        WHERE user_id = $1
+        ORDER BY created_at
+    `
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		r.logger.Error("Failed to find webauthn credentials by user id", zap.Error(err), zap.String("user_id", userID))
+		return nil, errors.New("failed to find webauthn credentials: " + err.Error())
+	}
+	defer rows.Close()
+
+	var credentials []*domain.WebAuthnCredential
+	for rows.Next() {
+		credential, err := scanWebAuthnCredential(rows)
+		if err != nil {
+			r.logger.Error("Failed to scan webauthn credential row", zap.Error(err))
+			return nil, errors.New("failed to scan webauthn credential: " + err.Error())
+		}
+		credentials = append(credentials, credential)
+	}
+	if err := rows.Err(); err != nil {
+		r.logger.Error("Error iterating webauthn credential rows", zap.Error(err))
+		return nil, errors.New("failed to iterate webauthn credentials: " + err.Error())
+	}
+	return credentials, nil
+}
+
+// MarkUsed advances sign_count in a single conditional update. Authenticators without a
+// counter always report zero, which is accepted only while the stored counter is zero too.
+func (r *webAuthnCredentialRepository) MarkUsed(ctx context.Context, id []byte, signCount uint32, usedAt time.Time) (bool, error) {
+	if len(id) == 0 {
+		return false, errors.New("credential id must not be empty")
+	}
+
+	query := `
+        UPDATE webauthn_credentials SET sign_count = $2, last_used_at = $3
+        WHERE id = $1 AND (sign_count < $2 OR (sign_count = 0 AND $2 = 0))
+    `
+	result, err := r.db.Exec(ctx, query, id, int64(signCount), usedAt)
+	if err != nil {
+		r.logger.Error("Failed to mark webauthn credential used", zap.Error(err))
+		return false, errors.New("failed to mark webauthn credential used: " + err.Error())
+	}
+	return result.RowsAffected() == 1, nil
+}
+
+// Delete removes a credential of the user from the database.
+func (r *webAuthnCredentialRepository) Delete(ctx context.Context, userID string, id []byte) (bool, error) {
+	if userID == "" {
+		return false, errors.New("user id must not be empty")
+	}
+
+	query := `DELETE FROM webauthn_credentials WHERE id = $1 AND user_id = $2`
+	result, err := r.db.Exec(ctx, query, id, userID)
+	if err != nil {
+		r.logger.Error("Failed to delete webauthn credential", zap.Error(err), zap.String("user_id", userID))
+		return false, errors.New("failed to delete webauthn credential: " + err.Error())
+	}
+	return result.RowsAffected() == 1, nil
+}
+
+// scanWebAuthnCredential reads a webauthn_credentials row into a domain.WebAuthnCredential.
+func scanWebAuthnCredential(row pgx.Row) (*domain.WebAuthnCredential, error) {
+	var (
+		id, publicKey []byte
+		userID, name  string
+		signCount     int64
+		transports    []string
+		createdAt     time.Time
+		lastUsedAt    *time.Time
+	)
+	if err := row.Scan(&id, &userID, &name, &publicKey, &signCount, &transports, &createdAt, &lastUsedAt); err != nil {
+		return nil, err
+	}
+	return domain.NewWebAuthnCredentialFromStorage(id, userID, name, publicKey, uint32(signCount), transports, createdAt, lastUsedAt)
+}
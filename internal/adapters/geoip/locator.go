@@ -0,0 +1,102 @@
+package geoip
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/sukryu/IV-auth-services/internal/config"
+	"github.com/sukryu/IV-auth-services/internal/core/domain"
+	"github.com/sukryu/IV-auth-services/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// ipPlaceholder is replaced with the looked-up address in the configured URL.
+const ipPlaceholder = "{ip}"
+
+// maxResponseBody bounds the size of a lookup response.
+const maxResponseBody = 64 << 10
+
+// HTTPLocator resolves IP addresses with an ipapi.co compatible JSON API, e.g.
+// "https://ipapi.co/{ip}/json/".
+type HTTPLocator struct {
+	url    string
+	client *http.Client
+	logger *logger.Logger
+}
+
+// NewHTTPLocator creates a new HTTPLocator instance.
+func NewHTTPLocator(cfg *config.Config, log *logger.Logger) (*HTTPLocator, error) {
+	if !strings.Contains(cfg.LoginRisk.GeoIP.URL, ipPlaceholder) {
+		return nil, errors.New("geoip url must contain " + ipPlaceholder)
+	}
+	return &HTTPLocator{
+		url:    cfg.LoginRisk.GeoIP.URL,
+		client: &http.Client{Timeout: cfg.LoginRisk.GeoIP.Timeout},
+		logger: log.With(zap.String("component", "geoip_locator")),
+	}, nil
+}
+
+// locationResponse is the subset of the lookup response used by HTTPLocator.
+type locationResponse struct {
+	Error       bool     `json:"error"` // 사설 주소 등 조회할 수 없는 주소
+	ASN         string   `json:"asn"`
+	CountryCode string   `json:"country_code"`
+	Latitude    *float64 `json:"latitude"`
+	Longitude   *float64 `json:"longitude"`
+}
+
+// Locate looks up the address. Private, loopback and unparsable addresses are not sent and
+// resolve to nil, as do addresses the API cannot locate.
+func (l *HTTPLocator) Locate(ctx context.Context, ip string) (*domain.IPLocation, error) {
+	addr := net.ParseIP(ip)
+	if addr == nil || addr.IsPrivate() || addr.IsLoopback() || addr.IsLinkLocalUnicast() || addr.IsUnspecified() {
+		return nil, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.Replace(l.url, ipPlaceholder, url.PathEscape(addr.String()), 1), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := l.client.Do(req)
+	if err != nil {
+		l.logger.Warn("GeoIP lookup failed", zap.Error(err))
+		return nil, errors.New("geoip lookup failed: " + err.Error())
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
+	if err != nil {
+		return nil, errors.New("failed to read geoip response: " + err.Error())
+	}
+	if resp.StatusCode != http.StatusOK {
+		l.logger.Warn("GeoIP lookup failed", zap.Int("status", resp.StatusCode))
+		return nil, errors.New("geoip lookup failed: unexpected status " + strconv.Itoa(resp.StatusCode))
+	}
+
+	var location locationResponse
+	if err := json.Unmarshal(body, &location); err != nil {
+		return nil, errors.New("failed to decode geoip response: " + err.Error())
+	}
+	if location.Error || location.ASN == "" || location.Latitude == nil || location.Longitude == nil {
+		return nil, nil
+	}
+	countryCode := strings.ToUpper(location.CountryCode)
+	if len(countryCode) != 2 {
+		countryCode = "" // ISO 3166-1 alpha-2 코드만 저장
+	}
+	return &domain.IPLocation{
+		ASN:         location.ASN,
+		CountryCode: countryCode,
+		Latitude:    *location.Latitude,
+		Longitude:   *location.Longitude,
+	}, nil
+}
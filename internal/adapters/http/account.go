@@ -0,0 +1,65 @@
+package httpapi
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/sukryu/IV-auth-services/internal/core/domain"
+)
+
+// changePassword handles POST /v1/password. The form carries current_password and
+// new_password; the user's other devices are signed out. keep_current_session=false signs
+// out the calling session as well.
+func (h *Handler) changePassword(w http.ResponseWriter, r *http.Request) {
+	claims, err := h.authenticateUser(r)
+	if err != nil {
+		h.writeUnauthorized(w, err)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		h.writeError(w, domain.NewOAuthError(domain.OAuthErrInvalidRequest, "malformed form body"))
+		return
+	}
+
+	keepSessionID := claims.SessionID
+	if v := r.PostForm.Get("keep_current_session"); v != "" {
+		keep, err := strconv.ParseBool(v)
+		if err != nil {
+			h.writeError(w, domain.NewOAuthError(domain.OAuthErrInvalidRequest, "keep_current_session must be a boolean"))
+			return
+		}
+		if !keep {
+			keepSessionID = ""
+		}
+	}
+
+	err = h.users.ChangePassword(r.Context(), claims.Subject,
+		r.PostForm.Get("current_password"), r.PostForm.Get("new_password"), keepSessionID)
+	switch {
+	case err == nil:
+		w.WriteHeader(http.StatusNoContent)
+	case errors.Is(err, domain.ErrInvalidCurrentPassword):
+		h.writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid_current_password"})
+	case isPasswordPolicyError(err):
+		h.writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid_password", Description: err.Error()})
+	default:
+		h.writeError(w, err)
+	}
+}
+
+// deleteAccount handles DELETE /v1/account. The account is deleted and every session of the
+// user is signed out, including the calling one.
+func (h *Handler) deleteAccount(w http.ResponseWriter, r *http.Request) {
+	claims, err := h.authenticateUser(r)
+	if err != nil {
+		h.writeUnauthorized(w, err)
+		return
+	}
+
+	if err := h.users.DeleteAccount(r.Context(), claims.Subject); err != nil {
+		h.writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
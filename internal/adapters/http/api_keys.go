@@ -0,0 +1,116 @@
+package httpapi
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sukryu/IV-auth-services/internal/core/domain"
+)
+
+// apiKeyResponse describes a personal API key. Key is only set in the creation response.
+type apiKeyResponse struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	Key        string     `json:"key,omitempty"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	LastUsedIP string     `json:"last_used_ip,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// toAPIKeyResponse converts an API key to its JSON representation without the raw key.
+func toAPIKeyResponse(key *domain.APIKey) apiKeyResponse {
+	return apiKeyResponse{
+		ID:         key.ID(),
+		Name:       key.Name(),
+		Prefix:     key.Prefix(),
+		Scopes:     key.Scopes(),
+		ExpiresAt:  key.ExpiresAt(),
+		LastUsedAt: key.LastUsedAt(),
+		LastUsedIP: key.LastUsedIP(),
+		CreatedAt:  key.CreatedAt(),
+	}
+}
+
+// createAPIKey handles POST /v1/api-keys. The form carries name, scope (space-separated)
+// and an optional expires_in in seconds. The raw key is returned once and never again.
+func (h *Handler) createAPIKey(w http.ResponseWriter, r *http.Request) {
+	claims, err := h.authenticateUser(r)
+	if err != nil {
+		h.writeUnauthorized(w, err)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		h.writeError(w, domain.NewOAuthError(domain.OAuthErrInvalidRequest, "malformed form body"))
+		return
+	}
+
+	var expiresAt *time.Time
+	if v := r.PostForm.Get("expires_in"); v != "" {
+		seconds, err := strconv.Atoi(v)
+		if err != nil || seconds <= 0 {
+			h.writeError(w, domain.NewOAuthError(domain.OAuthErrInvalidRequest, "expires_in must be a positive number of seconds"))
+			return
+		}
+		t := time.Now().Add(time.Duration(seconds) * time.Second)
+		expiresAt = &t
+	}
+
+	key, raw, err := h.apiKeys.CreateAPIKey(r.Context(), claims.Subject, r.PostForm.Get("name"), strings.Fields(r.PostForm.Get("scope")), expiresAt)
+	if err != nil {
+		if errors.Is(err, domain.ErrAPIKeyLimitReached) {
+			h.writeJSON(w, http.StatusConflict, errorResponse{Error: "limit_reached", Description: err.Error()})
+			return
+		}
+		h.writeError(w, err)
+		return
+	}
+
+	resp := toAPIKeyResponse(key)
+	resp.Key = raw
+	h.writeJSON(w, http.StatusCreated, resp)
+}
+
+// listAPIKeys handles GET /v1/api-keys and returns the signed-in user's unrevoked keys.
+func (h *Handler) listAPIKeys(w http.ResponseWriter, r *http.Request) {
+	claims, err := h.authenticateUser(r)
+	if err != nil {
+		h.writeUnauthorized(w, err)
+		return
+	}
+
+	keys, err := h.apiKeys.ListAPIKeys(r.Context(), claims.Subject)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	resp := make([]apiKeyResponse, 0, len(keys))
+	for _, key := range keys {
+		resp = append(resp, toAPIKeyResponse(key))
+	}
+	h.writeJSON(w, http.StatusOK, resp)
+}
+
+// revokeAPIKey handles DELETE /v1/api-keys/{key_id}; requests using the key fail afterwards.
+func (h *Handler) revokeAPIKey(w http.ResponseWriter, r *http.Request) {
+	claims, err := h.authenticateUser(r)
+	if err != nil {
+		h.writeUnauthorized(w, err)
+		return
+	}
+
+	if err := h.apiKeys.RevokeAPIKey(r.Context(), claims.Subject, r.PathValue("key_id")); err != nil {
+		if errors.Is(err, domain.ErrAPIKeyNotFound) {
+			h.writeJSON(w, http.StatusNotFound, errorResponse{Error: "not_found"})
+			return
+		}
+		h.writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,105 @@
+package httpapi
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sukryu/IV-auth-services/internal/core/domain"
+)
+
+// deviceAuthorizationResponse is the device authorization response (RFC 8628 3.2).
+type deviceAuthorizationResponse struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete"`
+	ExpiresIn               int    `json:"expires_in"`
+	Interval                int    `json:"interval"`
+}
+
+// deviceVerificationResponse describes the request the user is asked to approve.
+type deviceVerificationResponse struct {
+	ClientID   string   `json:"client_id"`
+	ClientName string   `json:"client_name"`
+	Scopes     []string `json:"scopes"`
+}
+
+// deviceAuthorization handles POST /oauth2/device_authorization (RFC 8628 3.1).
+func (h *Handler) deviceAuthorization(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		h.writeError(w, domain.NewOAuthError(domain.OAuthErrInvalidRequest, "malformed form body"))
+		return
+	}
+	clientID, clientSecret := clientCredentials(r)
+
+	grant, err := h.devices.StartDeviceAuthorization(r.Context(), clientID, clientSecret, strings.Fields(r.PostForm.Get("scope")))
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	h.writeJSON(w, http.StatusOK, deviceAuthorizationResponse{
+		DeviceCode:              grant.DeviceCode,
+		UserCode:                grant.UserCode,
+		VerificationURI:         grant.VerificationURI,
+		VerificationURIComplete: grant.VerificationURIComplete,
+		ExpiresIn:               int(time.Until(grant.ExpiresAt).Seconds()),
+		Interval:                int(grant.Interval.Seconds()),
+	})
+}
+
+// lookupDevice handles GET /v1/device?user_code= and shows the signed-in user what a
+// device is asking for before they approve it.
+func (h *Handler) lookupDevice(w http.ResponseWriter, r *http.Request) {
+	claims, err := h.authenticateUser(r)
+	if err != nil {
+		h.writeUnauthorized(w, err)
+		return
+	}
+
+	verification, err := h.devices.LookupUserCode(r.Context(), claims, r.URL.Query().Get("user_code"))
+	if err != nil {
+		h.writeDeviceError(w, err)
+		return
+	}
+	h.writeJSON(w, http.StatusOK, deviceVerificationResponse{
+		ClientID:   verification.ClientID,
+		ClientName: verification.ClientName,
+		Scopes:     verification.Scopes,
+	})
+}
+
+// decideDevice handles POST /v1/device. The form carries user_code and approve ("true" to
+// approve, anything else denies).
+func (h *Handler) decideDevice(w http.ResponseWriter, r *http.Request) {
+	claims, err := h.authenticateUser(r)
+	if err != nil {
+		h.writeUnauthorized(w, err)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		h.writeError(w, domain.NewOAuthError(domain.OAuthErrInvalidRequest, "malformed form body"))
+		return
+	}
+
+	approve, _ := strconv.ParseBool(r.PostForm.Get("approve"))
+	if err := h.devices.DecideUserCode(r.Context(), claims, r.PostForm.Get("user_code"), approve); err != nil {
+		h.writeDeviceError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeDeviceError maps device verification errors to responses; other errors are handled by writeError.
+func (h *Handler) writeDeviceError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrUserCodeInvalid):
+		h.writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid_user_code"})
+	case errors.Is(err, domain.ErrRateLimited):
+		h.writeJSON(w, http.StatusTooManyRequests, errorResponse{Error: "rate_limited"})
+	default:
+		h.writeError(w, err)
+	}
+}
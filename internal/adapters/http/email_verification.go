@@ -0,0 +1,50 @@
+package httpapi
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/sukryu/IV-auth-services/internal/core/domain"
+)
+
+// resendEmailVerification handles POST /v1/email/verification and sends the signed-in user
+// a new verification link.
+func (h *Handler) resendEmailVerification(w http.ResponseWriter, r *http.Request) {
+	claims, err := h.authenticateUser(r)
+	if err != nil {
+		h.writeUnauthorized(w, err)
+		return
+	}
+
+	if err := h.emailVerification.RequestVerification(r.Context(), claims.Subject); err != nil {
+		switch {
+		case errors.Is(err, domain.ErrEmailAlreadyVerified):
+			h.writeJSON(w, http.StatusConflict, errorResponse{Error: "email_already_verified"})
+		case errors.Is(err, domain.ErrRateLimited):
+			h.writeJSON(w, http.StatusTooManyRequests, errorResponse{Error: "rate_limited"})
+		default:
+			h.writeError(w, err)
+		}
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// verifyEmail handles POST /v1/email/verify. The form carries the token from the emailed
+// link; it needs no access token, so the link also works on a device that is not signed in.
+func (h *Handler) verifyEmail(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		h.writeError(w, domain.NewOAuthError(domain.OAuthErrInvalidRequest, "malformed form body"))
+		return
+	}
+
+	if err := h.emailVerification.VerifyEmail(r.Context(), r.PostForm.Get("token")); err != nil {
+		if errors.Is(err, domain.ErrEmailVerificationInvalid) {
+			h.writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid_token"})
+			return
+		}
+		h.writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,86 @@
+package httpapi
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/sukryu/IV-auth-services/internal/core/domain"
+)
+
+// guestLogin handles POST /v1/sessions/guest. Viewers who have not signed up receive a token
+// pair for a new guest user, limited to the guest scopes. A DPoP proof header binds the pair
+// to the client's key.
+func (h *Handler) guestLogin(w http.ResponseWriter, r *http.Request) {
+	opts, tokenType, err := h.bindDPoP(r)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	token, err := h.authService.AuthenticateGuest(r.Context(), opts...)
+	if err != nil {
+		if errors.Is(err, domain.ErrRateLimited) {
+			h.writeJSON(w, http.StatusTooManyRequests, errorResponse{Error: "rate_limited"})
+			return
+		}
+		h.writeError(w, err)
+		return
+	}
+	h.writeLoginTokens(w, token, tokenType)
+}
+
+// upgradeGuest handles POST /v1/guest/upgrade with a guest's token. The form carries either
+// username, email and password, or platform (e.g. "twitch") and the platform's authorization
+// code. The response is an unrestricted token pair for the same user.
+func (h *Handler) upgradeGuest(w http.ResponseWriter, r *http.Request) {
+	claims, err := h.authenticateUser(r)
+	if err != nil {
+		h.writeUnauthorized(w, err)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		h.writeError(w, domain.NewOAuthError(domain.OAuthErrInvalidRequest, "malformed form body"))
+		return
+	}
+
+	opts, tokenType, err := h.bindDPoP(r)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	upgrade := domain.GuestUpgrade{
+		Username: r.PostForm.Get("username"),
+		Email:    r.PostForm.Get("email"),
+		Password: r.PostForm.Get("password"),
+	}
+	if platform := r.PostForm.Get("platform"); platform != "" {
+		upgrade = domain.GuestUpgrade{Platform: domain.PlatformType(strings.ToUpper(platform)), Code: r.PostForm.Get("code")}
+	}
+
+	token, err := h.authService.UpgradeGuest(r.Context(), claims, upgrade, opts...)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrNotGuest):
+			h.writeJSON(w, http.StatusBadRequest, errorResponse{Error: "not_guest"})
+		case errors.Is(err, domain.ErrUsernameTaken):
+			h.writeJSON(w, http.StatusConflict, errorResponse{Error: "username_taken"})
+		case errors.Is(err, domain.ErrEmailInUse):
+			h.writeJSON(w, http.StatusConflict, errorResponse{Error: "account_exists"})
+		case errors.Is(err, domain.ErrPlatformAccountLinked):
+			h.writeJSON(w, http.StatusConflict, errorResponse{
+				Error:       "platform_account_linked",
+				Description: "the platform account already has an account; sign in with it instead",
+			})
+		case isPasswordPolicyError(err):
+			h.writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid_password", Description: err.Error()})
+		case upgrade.Platform != "":
+			h.writePlatformLoginError(w, err)
+		default:
+			h.writeError(w, err)
+		}
+		return
+	}
+	h.writeLoginTokens(w, token, tokenType)
+}
@@ -0,0 +1,73 @@
+package httpapi
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/sukryu/IV-auth-services/internal/core/domain"
+)
+
+// impersonate handles POST /v1/admin/impersonations. An admin posts user_id and reason and
+// receives a short-lived access token for that user without a refresh token. A DPoP proof
+// header binds the token to the admin's key.
+func (h *Handler) impersonate(w http.ResponseWriter, r *http.Request) {
+	claims, err := h.authenticateUser(r)
+	if err != nil {
+		h.writeUnauthorized(w, err)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		h.writeError(w, domain.NewOAuthError(domain.OAuthErrInvalidRequest, "malformed form body"))
+		return
+	}
+
+	opts, tokenType, err := h.bindDPoP(r)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	token, err := h.impersonation.Impersonate(r.Context(), claims, r.PostForm.Get("user_id"), r.PostForm.Get("reason"), opts...)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrImpersonationForbidden):
+			h.writeJSON(w, http.StatusForbidden, errorResponse{Error: "forbidden"})
+		case errors.Is(err, domain.ErrImpersonationTargetNotFound):
+			h.writeJSON(w, http.StatusNotFound, errorResponse{Error: "not_found"})
+		case errors.Is(err, domain.ErrImpersonationReasonRequired):
+			h.writeJSON(w, http.StatusBadRequest, errorResponse{Error: "reason_required", Description: err.Error()})
+		default:
+			h.writeError(w, err)
+		}
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, tokenResponse{
+		AccessToken: token.AccessToken(),
+		TokenType:   tokenType,
+		ExpiresIn:   int(time.Until(token.Expiry()).Seconds()),
+	})
+}
+
+// endImpersonation handles DELETE /v1/admin/impersonations/current, called with the
+// impersonation token itself; the token is rejected afterwards.
+func (h *Handler) endImpersonation(w http.ResponseWriter, r *http.Request) {
+	claims, err := h.authenticateUser(r)
+	if err != nil {
+		h.writeUnauthorized(w, err)
+		return
+	}
+
+	_, accessToken, _ := strings.Cut(r.Header.Get("Authorization"), " ")
+	if err := h.impersonation.EndImpersonation(r.Context(), claims, accessToken); err != nil {
+		if errors.Is(err, domain.ErrNotImpersonating) {
+			h.writeJSON(w, http.StatusBadRequest, errorResponse{Error: "not_impersonating"})
+			return
+		}
+		h.writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,54 @@
+package httpapi
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/sukryu/IV-auth-services/internal/core/domain"
+	"go.uber.org/zap"
+)
+
+// requestMagicLink handles POST /v1/magic-links. The form carries the email address to send
+// a login link to. The response is the same whether or not the address is registered.
+func (h *Handler) requestMagicLink(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		h.writeError(w, domain.NewOAuthError(domain.OAuthErrInvalidRequest, "malformed form body"))
+		return
+	}
+
+	if err := h.magicLinks.RequestLink(r.Context(), r.PostForm.Get("email")); err != nil {
+		if errors.Is(err, domain.ErrRateLimited) {
+			h.writeJSON(w, http.StatusTooManyRequests, errorResponse{Error: "rate_limited"})
+			return
+		}
+		// 발송 실패도 같은 응답을 보내 주소 등록 여부가 드러나지 않도록 함
+		h.logger.Error("Failed to send magic link", zap.Error(err))
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// magicLinkLogin handles POST /v1/sessions/magic-link. The form carries the token from the
+// emailed link; the response is a token pair or an MFA prompt, as with password login.
+func (h *Handler) magicLinkLogin(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		h.writeError(w, domain.NewOAuthError(domain.OAuthErrInvalidRequest, "malformed form body"))
+		return
+	}
+
+	opts, tokenType, err := h.bindDPoP(r)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	token, err := h.authService.AuthenticateMagicLink(r.Context(), r.PostForm.Get("token"), opts...)
+	if err != nil {
+		if h.writeMFAPrompt(w, err) {
+			return
+		}
+		h.logger.Info("Magic link login failed", zap.Error(err))
+		h.writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "invalid_magic_link"})
+		return
+	}
+	h.writeLoginTokens(w, token, tokenType)
+}
@@ -0,0 +1,174 @@
+package httpapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/sukryu/IV-auth-services/internal/core/domain"
+)
+
+// mfaPrompt tells the login UI to ask for a second factor and send it with mfa_token.
+type mfaPrompt struct {
+	MFARequired bool     `json:"mfa_required"`
+	MFAToken    string   `json:"mfa_token"`
+	Methods     []string `json:"methods"`
+	ExpiresIn   int      `json:"expires_in"`
+}
+
+// totpEnrollmentResponse carries the secret the user adds to an authenticator app.
+type totpEnrollmentResponse struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"otpauth_uri"`
+}
+
+// recoveryCodesResponse carries recovery codes; they are shown to the user only once.
+type recoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// mfaMethodsResponse lists the second factors the user has enabled.
+type mfaMethodsResponse struct {
+	Methods []string `json:"methods"`
+}
+
+// completeMFA handles POST /v1/sessions/mfa, the second step of a login. The form carries
+// mfa_token from the prompt, the method and the user's response in code (a TOTP code, or
+// the PublicKeyCredential JSON for webauthn).
+func (h *Handler) completeMFA(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		h.writeError(w, domain.NewOAuthError(domain.OAuthErrInvalidRequest, "malformed form body"))
+		return
+	}
+
+	opts, tokenType, err := h.bindDPoP(r)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	form := r.PostForm
+	token, err := h.authService.CompleteMFA(r.Context(), form.Get("mfa_token"), form.Get("method"), form.Get("code"), opts...)
+	if err != nil {
+		h.writeMFAError(w, err)
+		return
+	}
+	h.writeLoginTokens(w, token, tokenType)
+}
+
+// listMFAMethods handles GET /v1/mfa.
+func (h *Handler) listMFAMethods(w http.ResponseWriter, r *http.Request) {
+	claims, err := h.authenticateUser(r)
+	if err != nil {
+		h.writeUnauthorized(w, err)
+		return
+	}
+
+	methods, err := h.mfa.EnabledMethods(r.Context(), claims.Subject)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	if methods == nil {
+		methods = []string{}
+	}
+	h.writeJSON(w, http.StatusOK, mfaMethodsResponse{Methods: methods})
+}
+
+// enrollTOTP handles POST /v1/mfa/totp and starts TOTP enrollment.
+func (h *Handler) enrollTOTP(w http.ResponseWriter, r *http.Request) {
+	claims, err := h.authenticateUser(r)
+	if err != nil {
+		h.writeUnauthorized(w, err)
+		return
+	}
+
+	enrollment, err := h.mfa.EnrollTOTP(r.Context(), claims.Subject)
+	if err != nil {
+		h.writeMFAError(w, err)
+		return
+	}
+	h.writeJSON(w, http.StatusOK, totpEnrollmentResponse{Secret: enrollment.Secret, ProvisioningURI: enrollment.ProvisioningURI})
+}
+
+// confirmTOTP handles POST /v1/mfa/totp/confirm with the first code from the authenticator
+// and returns the recovery codes issued with the enrollment.
+func (h *Handler) confirmTOTP(w http.ResponseWriter, r *http.Request) {
+	claims, err := h.authenticateUser(r)
+	if err != nil {
+		h.writeUnauthorized(w, err)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		h.writeError(w, domain.NewOAuthError(domain.OAuthErrInvalidRequest, "malformed form body"))
+		return
+	}
+
+	codes, err := h.mfa.ConfirmTOTP(r.Context(), claims.Subject, r.PostForm.Get("code"))
+	if err != nil {
+		h.writeMFAError(w, err)
+		return
+	}
+	h.writeJSON(w, http.StatusOK, recoveryCodesResponse{RecoveryCodes: codes})
+}
+
+// disableTOTP handles POST /v1/mfa/totp/disable with a current code from the authenticator.
+func (h *Handler) disableTOTP(w http.ResponseWriter, r *http.Request) {
+	h.withMFACode(w, r, h.mfa.DisableTOTP)
+}
+
+// regenerateRecoveryCodes handles POST /v1/mfa/recovery-codes. The access token must come
+// from a multi-factor login within the last few minutes; the previous codes stop working.
+func (h *Handler) regenerateRecoveryCodes(w http.ResponseWriter, r *http.Request) {
+	claims, err := h.authenticateUser(r)
+	if err != nil {
+		h.writeUnauthorized(w, err)
+		return
+	}
+
+	codes, err := h.mfa.RegenerateRecoveryCodes(r.Context(), claims.Subject, claims.AuthTime, claims.AMR)
+	if err != nil {
+		h.writeMFAError(w, err)
+		return
+	}
+	h.writeJSON(w, http.StatusOK, recoveryCodesResponse{RecoveryCodes: codes})
+}
+
+// withMFACode authenticates the user, reads the code form field and runs op.
+func (h *Handler) withMFACode(w http.ResponseWriter, r *http.Request, op func(ctx context.Context, userID, code string) error) {
+	claims, err := h.authenticateUser(r)
+	if err != nil {
+		h.writeUnauthorized(w, err)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		h.writeError(w, domain.NewOAuthError(domain.OAuthErrInvalidRequest, "malformed form body"))
+		return
+	}
+
+	if err := op(r.Context(), claims.Subject, r.PostForm.Get("code")); err != nil {
+		h.writeMFAError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeMFAError maps second-factor errors to responses; other errors are handled by writeError.
+func (h *Handler) writeMFAError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidMFACode):
+		h.writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "invalid_mfa_code"})
+	case errors.Is(err, domain.ErrMFAChallengeInvalid):
+		h.writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "invalid_mfa_token"})
+	case errors.Is(err, domain.ErrMFANotEnrolled):
+		h.writeJSON(w, http.StatusBadRequest, errorResponse{Error: "mfa_not_enrolled"})
+	case errors.Is(err, domain.ErrMFAAlreadyEnabled):
+		h.writeJSON(w, http.StatusConflict, errorResponse{Error: "mfa_already_enabled"})
+	case errors.Is(err, domain.ErrRecentMFARequired):
+		// 단계별 인증 요구 (RFC 9470 3)
+		w.Header().Set("WWW-Authenticate", `Bearer error="insufficient_user_authentication", max_age=600`)
+		h.writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "insufficient_user_authentication"})
+	default:
+		h.writeError(w, err)
+	}
+}
@@ -0,0 +1,289 @@
+package httpapi
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/sukryu/IV-auth-services/internal/core/domain"
+)
+
+// authorizeResponse tells the first-party login UI where to send the browser next.
+type authorizeResponse struct {
+	RedirectTo string `json:"redirect_to"`
+}
+
+// consentPrompt asks the login UI to show a consent screen before retrying the request.
+type consentPrompt struct {
+	ConsentRequired bool     `json:"consent_required"`
+	ClientID        string   `json:"client_id"`
+	ClientName      string   `json:"client_name"`
+	Scopes          []string `json:"scopes"`
+}
+
+// tokenResponse is a successful token endpoint response (RFC 6749 5.1).
+type tokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int    `json:"expires_in"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	IDToken      string `json:"id_token,omitempty"`
+	// IssuedTokenType은 토큰 교환 응답에만 포함 (RFC 8693 2.2.1)
+	IssuedTokenType string `json:"issued_token_type,omitempty"`
+}
+
+// consentResponse describes a consent the user granted to a client.
+type consentResponse struct {
+	ClientID  string    `json:"client_id"`
+	Scopes    []string  `json:"scopes"`
+	GrantedAt time.Time `json:"granted_at"`
+}
+
+// authorize handles GET /oauth2/authorize. It is called by the first-party login UI with the
+// signed-in user's access token and answers with the client redirect or a consent prompt.
+func (h *Handler) authorize(w http.ResponseWriter, r *http.Request) {
+	claims, err := h.authenticateUser(r)
+	if err != nil {
+		h.writeUnauthorized(w, err)
+		return
+	}
+
+	q := r.URL.Query()
+	req := authorizationRequestFrom(q)
+	// client_id, redirect_uri 오류는 클라이언트로 리다이렉트하지 않고 직접 응답
+	client, err := h.authzService.ValidateRedirect(r.Context(), req.ClientID, req.RedirectURI)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	if q.Get("response_type") != domain.ResponseTypeCode {
+		h.redirectError(w, req.RedirectURI, q.Get("state"), domain.NewOAuthError(domain.OAuthErrUnsupportedResponseType, ""))
+		return
+	}
+
+	h.issueCode(w, r, claims, client, req, q.Get("state"))
+}
+
+// decideConsent handles POST /oauth2/authorize with the user's decision on the consent screen.
+// The form repeats the authorization request parameters and adds decision=approve|deny.
+func (h *Handler) decideConsent(w http.ResponseWriter, r *http.Request) {
+	claims, err := h.authenticateUser(r)
+	if err != nil {
+		h.writeUnauthorized(w, err)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		h.writeError(w, domain.NewOAuthError(domain.OAuthErrInvalidRequest, "malformed form body"))
+		return
+	}
+
+	req := authorizationRequestFrom(r.PostForm)
+	state := r.PostForm.Get("state")
+	client, err := h.authzService.ValidateRedirect(r.Context(), req.ClientID, req.RedirectURI)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	if r.PostForm.Get("decision") != "approve" {
+		h.redirectError(w, req.RedirectURI, state, domain.NewOAuthError(domain.OAuthErrAccessDenied, "user denied the request"))
+		return
+	}
+
+	if err := h.authzService.GrantConsent(r.Context(), claims.Subject, client.ID(), req.Scopes); err != nil {
+		h.redirectError(w, req.RedirectURI, state, err)
+		return
+	}
+	h.issueCode(w, r, claims, client, req, state)
+}
+
+// issueCode requests an authorization code and answers with the redirect or a consent prompt.
+func (h *Handler) issueCode(w http.ResponseWriter, r *http.Request, user *domain.TokenClaims, client *domain.OAuthClient, req domain.AuthorizationRequest, state string) {
+	code, err := h.authzService.Authorize(r.Context(), user, req)
+	if err != nil {
+		var oauthErr *domain.OAuthError
+		if errors.As(err, &oauthErr) && oauthErr.Code() == domain.OAuthErrConsentRequired {
+			scopes := req.Scopes
+			if len(scopes) == 0 {
+				scopes = client.AllowedScopes()
+			}
+			h.writeJSON(w, http.StatusOK, consentPrompt{ConsentRequired: true, ClientID: client.ID(), ClientName: client.Name(), Scopes: scopes})
+			return
+		}
+		h.redirectError(w, req.RedirectURI, state, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, authorizeResponse{RedirectTo: redirectWith(req.RedirectURI, url.Values{"code": {code}}, state)})
+}
+
+// redirectError reports an authorization error to the client through its redirect URI (RFC 6749 4.1.2.1).
+func (h *Handler) redirectError(w http.ResponseWriter, redirectURI, state string, err error) {
+	var oauthErr *domain.OAuthError
+	if !errors.As(err, &oauthErr) {
+		h.writeError(w, err)
+		return
+	}
+
+	params := url.Values{"error": {oauthErr.Code()}}
+	if oauthErr.Description() != "" {
+		params.Set("error_description", oauthErr.Description())
+	}
+	h.writeJSON(w, http.StatusOK, authorizeResponse{RedirectTo: redirectWith(redirectURI, params, state)})
+}
+
+// token handles POST /oauth2/token for the authorization_code, refresh_token and,
+// when enabled, client_credentials, device_code and token exchange grants.
+func (h *Handler) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		h.writeError(w, domain.NewOAuthError(domain.OAuthErrInvalidRequest, "malformed form body"))
+		return
+	}
+	form := r.PostForm
+	clientID, clientSecret := clientCredentials(r)
+
+	var (
+		token           *domain.Token
+		tokenType       = "Bearer"
+		issuedTokenType string
+		err             error
+	)
+	switch form.Get("grant_type") {
+	case domain.GrantTypeAuthorizationCode, domain.GrantTypeRefreshToken:
+		var opts []domain.TokenOption
+		opts, tokenType, err = h.bindDPoP(r)
+		if err != nil {
+			break
+		}
+		if form.Get("grant_type") == domain.GrantTypeAuthorizationCode {
+			token, err = h.authzService.ExchangeCode(r.Context(), clientID, clientSecret,
+				form.Get("code"), form.Get("redirect_uri"), form.Get("code_verifier"), opts...)
+		} else {
+			token, err = h.authzService.Refresh(r.Context(), clientID, clientSecret, form.Get("refresh_token"), opts...)
+		}
+	case domain.GrantTypeDeviceCode:
+		if h.devices == nil {
+			err = domain.NewOAuthError(domain.OAuthErrUnsupportedGrantType, "")
+			break
+		}
+		var opts []domain.TokenOption
+		opts, tokenType, err = h.bindDPoP(r)
+		if err != nil {
+			break
+		}
+		token, err = h.devices.PollToken(r.Context(), clientID, clientSecret, form.Get("device_code"), opts...)
+	case domain.GrantTypeClientCredentials:
+		if h.clientCredentials == nil {
+			err = domain.NewOAuthError(domain.OAuthErrUnsupportedGrantType, "")
+			break
+		}
+		token, err = h.clientCredentials.IssueToken(r.Context(), clientID, clientSecret, strings.Fields(form.Get("scope")))
+	case domain.GrantTypeTokenExchange:
+		if h.tokenExchange == nil || h.clientCredentials == nil {
+			err = domain.NewOAuthError(domain.OAuthErrUnsupportedGrantType, "")
+			break
+		}
+		token, err = h.exchangeToken(r, clientID, clientSecret)
+		issuedTokenType = domain.TokenTypeIdentifierAccessToken
+	default:
+		err = domain.NewOAuthError(domain.OAuthErrUnsupportedGrantType, "")
+	}
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, tokenResponse{
+		AccessToken:     token.AccessToken(),
+		TokenType:       tokenType,
+		ExpiresIn:       int(time.Until(token.Expiry()).Seconds()),
+		RefreshToken:    token.RefreshToken(),
+		IDToken:         token.IDToken(),
+		IssuedTokenType: issuedTokenType,
+	})
+}
+
+// listConsents handles GET /v1/consents and returns the consents granted by the signed-in user.
+func (h *Handler) listConsents(w http.ResponseWriter, r *http.Request) {
+	claims, err := h.authenticateUser(r)
+	if err != nil {
+		h.writeUnauthorized(w, err)
+		return
+	}
+
+	consents, err := h.authzService.ListConsents(r.Context(), claims.Subject)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	resp := make([]consentResponse, 0, len(consents))
+	for _, c := range consents {
+		resp = append(resp, consentResponse{ClientID: c.ClientID(), Scopes: c.Scopes(), GrantedAt: c.GrantedAt()})
+	}
+	h.writeJSON(w, http.StatusOK, resp)
+}
+
+// revokeConsent handles DELETE /v1/consents/{client_id}.
+func (h *Handler) revokeConsent(w http.ResponseWriter, r *http.Request) {
+	claims, err := h.authenticateUser(r)
+	if err != nil {
+		h.writeUnauthorized(w, err)
+		return
+	}
+
+	if err := h.authzService.RevokeConsent(r.Context(), claims.Subject, r.PathValue("client_id")); err != nil {
+		if errors.Is(err, domain.ErrConsentNotFound) {
+			h.writeJSON(w, http.StatusNotFound, errorResponse{Error: "not_found"})
+			return
+		}
+		h.writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// authorizationRequestFrom reads the authorization request parameters.
+func authorizationRequestFrom(values url.Values) domain.AuthorizationRequest {
+	return domain.AuthorizationRequest{
+		ClientID:            values.Get("client_id"),
+		RedirectURI:         values.Get("redirect_uri"),
+		Scopes:              strings.Fields(values.Get("scope")),
+		CodeChallenge:       values.Get("code_challenge"),
+		CodeChallengeMethod: values.Get("code_challenge_method"),
+		Nonce:               values.Get("nonce"),
+	}
+}
+
+// clientCredentials reads client authentication from HTTP Basic (RFC 6749 2.3.1) or the form body.
+func clientCredentials(r *http.Request) (string, string) {
+	if id, secret, ok := r.BasicAuth(); ok {
+		// Basic 인증 값은 form-urlencoded로 인코딩되어 전달됨
+		decodedID, errID := url.QueryUnescape(id)
+		decodedSecret, errSecret := url.QueryUnescape(secret)
+		if errID == nil && errSecret == nil {
+			return decodedID, decodedSecret
+		}
+		return id, secret
+	}
+	return r.PostForm.Get("client_id"), r.PostForm.Get("client_secret")
+}
+
+// redirectWith appends params and state to the redirect URI, keeping its existing query.
+func redirectWith(redirectURI string, params url.Values, state string) string {
+	u, err := url.Parse(redirectURI)
+	if err != nil {
+		return redirectURI
+	}
+	q := u.Query()
+	for key, values := range params {
+		for _, v := range values {
+			q.Add(key, v)
+		}
+	}
+	if state != "" {
+		q.Set("state", state)
+	}
+	u.RawQuery = q.Encode()
+	return u.String()
+}
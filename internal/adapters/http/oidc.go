@@ -0,0 +1,119 @@
+package httpapi
+
+import (
+	"net/http"
+
+	"github.com/sukryu/IV-auth-services/internal/core/domain"
+	"go.uber.org/zap"
+)
+
+// discoveryDocument is the OpenID Provider metadata (OpenID Connect Discovery 1.0 section 3).
+type discoveryDocument struct {
+	Issuer                            string   `json:"issuer"`
+	AuthorizationEndpoint             string   `json:"authorization_endpoint"`
+	TokenEndpoint                     string   `json:"token_endpoint"`
+	UserInfoEndpoint                  string   `json:"userinfo_endpoint"`
+	JWKSURI                           string   `json:"jwks_uri"`
+	ScopesSupported                   []string `json:"scopes_supported"`
+	ResponseTypesSupported            []string `json:"response_types_supported"`
+	GrantTypesSupported               []string `json:"grant_types_supported"`
+	SubjectTypesSupported             []string `json:"subject_types_supported"`
+	IDTokenSigningAlgValuesSupported  []string `json:"id_token_signing_alg_values_supported"`
+	TokenEndpointAuthMethodsSupported []string `json:"token_endpoint_auth_methods_supported"`
+	CodeChallengeMethodsSupported     []string `json:"code_challenge_methods_supported"`
+	ClaimsSupported                   []string `json:"claims_supported"`
+	ACRValuesSupported                []string `json:"acr_values_supported"`
+	DPoPSigningAlgValuesSupported     []string `json:"dpop_signing_alg_values_supported,omitempty"`
+	DeviceAuthorizationEndpoint       string   `json:"device_authorization_endpoint,omitempty"`
+}
+
+// userInfoResponse is the userinfo endpoint response (OpenID Connect Core 5.3.2).
+type userInfoResponse struct {
+	Subject           string `json:"sub"`
+	PreferredUsername string `json:"preferred_username,omitempty"`
+	Email             string `json:"email,omitempty"`
+	EmailVerified     *bool  `json:"email_verified,omitempty"` // email과 함께만 포함
+	SubscriptionTier  string `json:"subscription_tier,omitempty"`
+}
+
+// discovery handles GET /.well-known/openid-configuration.
+func (h *Handler) discovery(w http.ResponseWriter, r *http.Request) {
+	grantTypes := []string{domain.GrantTypeAuthorizationCode, domain.GrantTypeRefreshToken}
+	if h.clientCredentials != nil {
+		grantTypes = append(grantTypes, domain.GrantTypeClientCredentials)
+	}
+	if h.devices != nil {
+		grantTypes = append(grantTypes, domain.GrantTypeDeviceCode)
+	}
+	if h.tokenExchange != nil && h.clientCredentials != nil {
+		grantTypes = append(grantTypes, domain.GrantTypeTokenExchange)
+	}
+
+	doc := discoveryDocument{
+		Issuer:                            h.issuer,
+		AuthorizationEndpoint:             h.loginURL,
+		TokenEndpoint:                     h.publicURL + "/oauth2/token",
+		UserInfoEndpoint:                  h.publicURL + "/oauth2/userinfo",
+		JWKSURI:                           h.publicURL + "/.well-known/jwks.json",
+		ScopesSupported:                   []string{domain.ScopeOpenID, domain.ScopeProfile, domain.ScopeEmail},
+		ResponseTypesSupported:            []string{domain.ResponseTypeCode},
+		GrantTypesSupported:               grantTypes,
+		SubjectTypesSupported:             []string{"public"},
+		IDTokenSigningAlgValuesSupported:  []string{"RS256"},
+		TokenEndpointAuthMethodsSupported: []string{"client_secret_basic", "client_secret_post", "none"},
+		CodeChallengeMethodsSupported:     []string{domain.CodeChallengeMethodS256},
+		ClaimsSupported: []string{
+			"iss", "sub", "aud", "exp", "iat", "auth_time", "nonce", "amr", "acr", "at_hash",
+			"preferred_username", "email", "email_verified", "subscription_tier",
+		},
+		ACRValuesSupported: []string{domain.ACRSingleFactor, domain.ACRMultiFactor},
+	}
+	if h.devices != nil {
+		doc.DeviceAuthorizationEndpoint = h.publicURL + "/oauth2/device_authorization"
+	}
+	if h.dpopEnabled {
+		doc.DPoPSigningAlgValuesSupported = []string{"ES256", "ES384", "RS256", "PS256", "EdDSA"}
+	}
+	w.Header().Set("Cache-Control", "public, max-age=3600")
+	h.writeJSON(w, http.StatusOK, doc)
+}
+
+// jwks handles GET /.well-known/jwks.json.
+func (h *Handler) jwks(w http.ResponseWriter, r *http.Request) {
+	keys, err := h.idTokenGen.JWKS()
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/jwk-set+json")
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(keys); err != nil {
+		h.logger.Warn("Failed to write response", zap.Error(err))
+	}
+}
+
+// userinfo handles GET and POST /oauth2/userinfo with an access token issued to a user.
+func (h *Handler) userinfo(w http.ResponseWriter, r *http.Request) {
+	claims, err := h.authenticateToken(r)
+	if err != nil {
+		h.writeUnauthorized(w, err)
+		return
+	}
+
+	info, err := h.userInfo.UserInfo(r.Context(), claims)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	resp := userInfoResponse{
+		Subject:           info.Subject,
+		PreferredUsername: info.Username,
+		Email:             info.Email,
+		SubscriptionTier:  info.SubscriptionTier,
+	}
+	if info.Email != "" {
+		resp.EmailVerified = &info.EmailVerified
+	}
+	h.writeJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,287 @@
+package httpapi
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/sukryu/IV-auth-services/internal/core/domain"
+	"go.uber.org/zap"
+)
+
+// maxCredentialBody bounds the size of a WebAuthn response body.
+const maxCredentialBody = 64 << 10
+
+// credentialDescriptor is a PublicKeyCredentialDescriptorJSON (WebAuthn Level 3 5.10.3).
+type credentialDescriptor struct {
+	Type       string   `json:"type"`
+	ID         string   `json:"id"`
+	Transports []string `json:"transports,omitempty"`
+}
+
+// credentialParameter is a PublicKeyCredentialParameters entry (WebAuthn 5.3).
+type credentialParameter struct {
+	Type string `json:"type"`
+	Alg  int    `json:"alg"`
+}
+
+// relyingPartyEntity identifies the service to the authenticator.
+type relyingPartyEntity struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// userEntity identifies the account a credential is created for.
+type userEntity struct {
+	ID          string `json:"id"` // base64url 사용자 ID
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName"`
+}
+
+// authenticatorSelection states which authenticators may be used for registration.
+type authenticatorSelection struct {
+	ResidentKey      string `json:"residentKey"`
+	UserVerification string `json:"userVerification"`
+}
+
+// creationOptionsResponse is a PublicKeyCredentialCreationOptionsJSON, ready for
+// PublicKeyCredential.parseCreationOptionsFromJSON.
+type creationOptionsResponse struct {
+	Challenge              string                 `json:"challenge"`
+	RP                     relyingPartyEntity     `json:"rp"`
+	User                   userEntity             `json:"user"`
+	PubKeyCredParams       []credentialParameter  `json:"pubKeyCredParams"`
+	Timeout                int64                  `json:"timeout"` // 밀리초
+	ExcludeCredentials     []credentialDescriptor `json:"excludeCredentials"`
+	AuthenticatorSelection authenticatorSelection `json:"authenticatorSelection"`
+	Attestation            string                 `json:"attestation"`
+}
+
+// requestOptionsResponse is a PublicKeyCredentialRequestOptionsJSON, ready for
+// PublicKeyCredential.parseRequestOptionsFromJSON.
+type requestOptionsResponse struct {
+	Challenge        string                 `json:"challenge"`
+	RPID             string                 `json:"rpId"`
+	Timeout          int64                  `json:"timeout"`
+	AllowCredentials []credentialDescriptor `json:"allowCredentials"`
+	UserVerification string                 `json:"userVerification"`
+}
+
+// passkeyResponse describes a registered passkey of the user.
+type passkeyResponse struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name,omitempty"`
+	Transports []string   `json:"transports"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+}
+
+// registerPasskeyRequest is the body of POST /v1/passkeys.
+type registerPasskeyRequest struct {
+	Name       string          `json:"name"`
+	Credential json.RawMessage `json:"credential"` // navigator.credentials.create() 결과의 toJSON()
+}
+
+// passkeyCreationOptions handles POST /v1/passkeys/options and starts a registration.
+func (h *Handler) passkeyCreationOptions(w http.ResponseWriter, r *http.Request) {
+	claims, err := h.authenticateUser(r)
+	if err != nil {
+		h.writeUnauthorized(w, err)
+		return
+	}
+
+	options, err := h.passkeys.BeginRegistration(r.Context(), claims.Subject)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	params := make([]credentialParameter, 0, len(options.Algorithms))
+	for _, alg := range options.Algorithms {
+		params = append(params, credentialParameter{Type: "public-key", Alg: alg})
+	}
+	h.writeJSON(w, http.StatusOK, creationOptionsResponse{
+		Challenge: options.Challenge,
+		RP:        relyingPartyEntity{ID: options.RPID, Name: options.RPName},
+		User: userEntity{
+			ID:          base64.RawURLEncoding.EncodeToString([]byte(options.UserHandle)),
+			Name:        options.UserName,
+			DisplayName: options.UserName,
+		},
+		PubKeyCredParams:   params,
+		Timeout:            options.Timeout.Milliseconds(),
+		ExcludeCredentials: credentialDescriptors(options.ExcludeCredentials),
+		// 검색 가능한 자격 증명을 선호하지만, 지원하지 않는 보안 키도 두 번째 인증 요소로 등록 가능
+		AuthenticatorSelection: authenticatorSelection{ResidentKey: "preferred", UserVerification: "preferred"},
+		Attestation:            "none",
+	})
+}
+
+// registerPasskey handles POST /v1/passkeys with the authenticator's registration response.
+func (h *Handler) registerPasskey(w http.ResponseWriter, r *http.Request) {
+	claims, err := h.authenticateUser(r)
+	if err != nil {
+		h.writeUnauthorized(w, err)
+		return
+	}
+
+	var req registerPasskeyRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxCredentialBody)).Decode(&req); err != nil {
+		h.writeError(w, domain.NewOAuthError(domain.OAuthErrInvalidRequest, "malformed request body"))
+		return
+	}
+
+	credential, err := h.passkeys.FinishRegistration(r.Context(), claims.Subject, req.Name, req.Credential)
+	if err != nil {
+		h.writePasskeyError(w, err)
+		return
+	}
+	h.writeJSON(w, http.StatusCreated, toPasskeyResponse(credential))
+}
+
+// listPasskeys handles GET /v1/passkeys.
+func (h *Handler) listPasskeys(w http.ResponseWriter, r *http.Request) {
+	claims, err := h.authenticateUser(r)
+	if err != nil {
+		h.writeUnauthorized(w, err)
+		return
+	}
+
+	credentials, err := h.passkeys.ListPasskeys(r.Context(), claims.Subject)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	resp := make([]passkeyResponse, 0, len(credentials))
+	for _, c := range credentials {
+		resp = append(resp, toPasskeyResponse(c))
+	}
+	h.writeJSON(w, http.StatusOK, resp)
+}
+
+// deletePasskey handles DELETE /v1/passkeys/{credential_id}.
+func (h *Handler) deletePasskey(w http.ResponseWriter, r *http.Request) {
+	claims, err := h.authenticateUser(r)
+	if err != nil {
+		h.writeUnauthorized(w, err)
+		return
+	}
+
+	credentialID, err := base64.RawURLEncoding.DecodeString(r.PathValue("credential_id"))
+	if err != nil {
+		h.writeJSON(w, http.StatusNotFound, errorResponse{Error: "not_found"})
+		return
+	}
+	if err := h.passkeys.DeletePasskey(r.Context(), claims.Subject, credentialID); err != nil {
+		h.writePasskeyError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// passkeyLoginOptions handles POST /v1/sessions/passkey/options and starts a passwordless login.
+func (h *Handler) passkeyLoginOptions(w http.ResponseWriter, r *http.Request) {
+	options, err := h.passkeys.BeginLogin(r.Context())
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	h.writeJSON(w, http.StatusOK, toRequestOptionsResponse(options))
+}
+
+// passkeyLogin handles POST /v1/sessions/passkey. The body is the authenticator's assertion;
+// a DPoP proof header binds the issued pair to the client's key as with password login.
+func (h *Handler) passkeyLogin(w http.ResponseWriter, r *http.Request) {
+	opts, tokenType, err := h.bindDPoP(r)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxCredentialBody))
+	if err != nil {
+		h.writeError(w, domain.NewOAuthError(domain.OAuthErrInvalidRequest, "malformed request body"))
+		return
+	}
+
+	token, err := h.authService.AuthenticatePasskey(r.Context(), body, opts...)
+	if err != nil {
+		// 실패 원인은 응답에 노출하지 않음
+		h.logger.Info("Passkey login failed", zap.Error(err))
+		h.writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "invalid_credentials"})
+		return
+	}
+	h.writeLoginTokens(w, token, tokenType)
+}
+
+// mfaPasskeyOptions handles POST /v1/sessions/mfa/passkey/options. The form carries the
+// mfa_token from the MFA prompt; the resulting assertion is sent to POST /v1/sessions/mfa
+// with method=webauthn and the PublicKeyCredential JSON as code.
+func (h *Handler) mfaPasskeyOptions(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		h.writeError(w, domain.NewOAuthError(domain.OAuthErrInvalidRequest, "malformed form body"))
+		return
+	}
+
+	options, err := h.authService.BeginMFAPasskey(r.Context(), r.PostForm.Get("mfa_token"))
+	if err != nil {
+		h.writeMFAError(w, err)
+		return
+	}
+	h.writeJSON(w, http.StatusOK, toRequestOptionsResponse(options))
+}
+
+// writePasskeyError maps passkey errors to responses; other errors are handled by writeError.
+func (h *Handler) writePasskeyError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrPasskeyInvalid):
+		h.writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid_passkey"})
+	case errors.Is(err, domain.ErrPasskeyNotFound):
+		h.writeJSON(w, http.StatusNotFound, errorResponse{Error: "not_found"})
+	case errors.Is(err, domain.ErrPasskeyAlreadyRegistered):
+		h.writeJSON(w, http.StatusConflict, errorResponse{Error: "passkey_already_registered"})
+	default:
+		h.writeError(w, err)
+	}
+}
+
+// toRequestOptionsResponse converts authentication ceremony options to their JSON form.
+func toRequestOptionsResponse(options *domain.PasskeyRequestOptions) requestOptionsResponse {
+	return requestOptionsResponse{
+		Challenge:        options.Challenge,
+		RPID:             options.RPID,
+		Timeout:          options.Timeout.Milliseconds(),
+		AllowCredentials: credentialDescriptors(options.AllowCredentials),
+		UserVerification: options.UserVerification,
+	}
+}
+
+// credentialDescriptors converts credential descriptors to their JSON form.
+func credentialDescriptors(descriptors []domain.WebAuthnCredentialDescriptor) []credentialDescriptor {
+	result := make([]credentialDescriptor, 0, len(descriptors))
+	for _, d := range descriptors {
+		result = append(result, credentialDescriptor{
+			Type:       "public-key",
+			ID:         base64.RawURLEncoding.EncodeToString(d.ID),
+			Transports: d.Transports,
+		})
+	}
+	return result
+}
+
+// toPasskeyResponse converts a credential to its JSON form.
+func toPasskeyResponse(c *domain.WebAuthnCredential) passkeyResponse {
+	transports := c.Transports()
+	if transports == nil {
+		transports = []string{}
+	}
+	return passkeyResponse{
+		ID:         base64.RawURLEncoding.EncodeToString(c.ID()),
+		Name:       c.Name(),
+		Transports: transports,
+		CreatedAt:  c.CreatedAt(),
+		LastUsedAt: c.LastUsedAt(),
+	}
+}
@@ -0,0 +1,55 @@
+package httpapi
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/sukryu/IV-auth-services/internal/core/domain"
+	"go.uber.org/zap"
+)
+
+// requestPasswordReset handles POST /v1/password-resets. The form carries the email address
+// to send a reset link to. The response is the same whether or not the address is registered.
+func (h *Handler) requestPasswordReset(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		h.writeError(w, domain.NewOAuthError(domain.OAuthErrInvalidRequest, "malformed form body"))
+		return
+	}
+
+	if err := h.passwordReset.RequestPasswordReset(r.Context(), r.PostForm.Get("email")); err != nil {
+		if errors.Is(err, domain.ErrRateLimited) {
+			h.writeJSON(w, http.StatusTooManyRequests, errorResponse{Error: "rate_limited"})
+			return
+		}
+		// 발송 실패도 같은 응답을 보내 주소 등록 여부가 드러나지 않도록 함
+		h.logger.Error("Failed to send password reset email", zap.Error(err))
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// completePasswordReset handles POST /v1/password-resets/complete. The form carries the
+// token from the emailed link and the new password.
+func (h *Handler) completePasswordReset(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		h.writeError(w, domain.NewOAuthError(domain.OAuthErrInvalidRequest, "malformed form body"))
+		return
+	}
+
+	err := h.passwordReset.CompletePasswordReset(r.Context(), r.PostForm.Get("token"), r.PostForm.Get("password"))
+	switch {
+	case err == nil:
+		w.WriteHeader(http.StatusNoContent)
+	case errors.Is(err, domain.ErrPasswordResetInvalid):
+		h.writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid_token"})
+	case isPasswordPolicyError(err):
+		h.writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid_password", Description: err.Error()})
+	default:
+		h.writeError(w, err)
+	}
+}
+
+// isPasswordPolicyError reports whether err is a rejection of the new password itself.
+func isPasswordPolicyError(err error) bool {
+	return errors.Is(err, domain.ErrPasswordEmpty) || errors.Is(err, domain.ErrPasswordTooShort) ||
+		errors.Is(err, domain.ErrPasswordUnchanged)
+}
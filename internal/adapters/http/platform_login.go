@@ -0,0 +1,115 @@
+package httpapi
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/sukryu/IV-auth-services/internal/core/domain"
+	"go.uber.org/zap"
+)
+
+// platformAuthorizationResponse is the response of GET /v1/sessions/platform/{platform}/authorize.
+type platformAuthorizationResponse struct {
+	AuthorizationURL string `json:"authorization_url"`
+}
+
+// platformAuthorize handles GET /v1/sessions/platform/{platform}/authorize. The state query
+// parameter is generated and checked by the client against the platform's redirect.
+func (h *Handler) platformAuthorize(w http.ResponseWriter, r *http.Request) {
+	state := r.URL.Query().Get("state")
+	if state == "" {
+		h.writeError(w, domain.NewOAuthError(domain.OAuthErrInvalidRequest, "state is required"))
+		return
+	}
+
+	authURL, err := h.platformLogin.AuthorizationURL(platformFromPath(r), state)
+	if err != nil {
+		h.writePlatformLoginError(w, err)
+		return
+	}
+	h.writeJSON(w, http.StatusOK, platformAuthorizationResponse{AuthorizationURL: authURL})
+}
+
+// platformLoginSession handles POST /v1/sessions/platform/{platform}. The form carries the
+// authorization code from the platform's redirect; the response is a token pair or an MFA
+// prompt, as with password login.
+func (h *Handler) platformLoginSession(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		h.writeError(w, domain.NewOAuthError(domain.OAuthErrInvalidRequest, "malformed form body"))
+		return
+	}
+	code := r.PostForm.Get("code")
+	if code == "" {
+		h.writeError(w, domain.NewOAuthError(domain.OAuthErrInvalidRequest, "code is required"))
+		return
+	}
+
+	opts, tokenType, err := h.bindDPoP(r)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	token, err := h.authService.AuthenticatePlatform(r.Context(), platformFromPath(r), code, opts...)
+	if err != nil {
+		if h.writeMFAPrompt(w, err) {
+			return
+		}
+		h.writePlatformLoginError(w, err)
+		return
+	}
+	h.writeLoginTokens(w, token, tokenType)
+}
+
+// writePlatformLoginError maps platform sign-in errors to responses.
+func (h *Handler) writePlatformLoginError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrPlatformNotSupported):
+		h.writeJSON(w, http.StatusNotFound, errorResponse{Error: "not_found"})
+	case errors.Is(err, domain.ErrPlatformEmailInUse):
+		// 기존 계정은 로그인 후 플랫폼 연결로만 연결 가능 (계정 탈취 방지)
+		h.writeJSON(w, http.StatusConflict, errorResponse{
+			Error:       "account_exists",
+			Description: "sign in to the existing account and link the platform from there",
+		})
+	case errors.Is(err, domain.ErrPlatformEmailRequired):
+		h.writeJSON(w, http.StatusBadRequest, errorResponse{
+			Error:       "email_required",
+			Description: "the platform account has no verified email address",
+		})
+	case errors.Is(err, domain.ErrEmailNotVerified):
+		h.writeJSON(w, http.StatusForbidden, errorResponse{
+			Error:       "email_not_verified",
+			Description: "verify the email address with the platform before signing in",
+		})
+	default:
+		h.logger.Info("Platform login failed", zap.Error(err))
+		h.writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "platform_login_failed"})
+	}
+}
+
+// unlinkPlatform handles DELETE /v1/platform-accounts/{platform_id} and removes the link
+// between the signed-in user and a platform account.
+func (h *Handler) unlinkPlatform(w http.ResponseWriter, r *http.Request) {
+	claims, err := h.authenticateUser(r)
+	if err != nil {
+		h.writeUnauthorized(w, err)
+		return
+	}
+
+	if err := h.platforms.RevokeAccount(r.Context(), claims.Subject, r.PathValue("platform_id")); err != nil {
+		if errors.Is(err, domain.ErrPlatformAccountNotFound) {
+			h.writeJSON(w, http.StatusNotFound, errorResponse{Error: "not_found"})
+			return
+		}
+		h.writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// platformFromPath returns the platform named in the request path, e.g. "twitch".
+func platformFromPath(r *http.Request) domain.PlatformType {
+	return domain.PlatformType(strings.ToUpper(r.PathValue("platform")))
+}
@@ -0,0 +1,437 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/sukryu/IV-auth-services/internal/config"
+	"github.com/sukryu/IV-auth-services/internal/core/domain"
+	"github.com/sukryu/IV-auth-services/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// Handler serves the browser-facing OAuth 2.0 endpoints that cannot be expressed over gRPC,
+// such as redirect-based authorization and form-encoded token requests.
+type Handler struct {
+	authService  domain.AuthService
+	authzService domain.AuthorizationService
+	publicURL    string
+	issuer       string
+	loginURL     string
+	dpopEnabled  bool
+	logger       *logger.Logger
+
+	// 선택 기능: 옵션을 지정한 경우에만 해당 엔드포인트 지원
+	clientCredentials domain.ClientCredentialsService
+	tokenExchange     domain.TokenExchangeService
+	userInfo          domain.UserInfoService
+	idTokenGen        domain.IDTokenGenerator
+	sessions          domain.SessionService
+	mfa               domain.MFAService
+	passkeys          domain.PasskeyService
+	magicLinks        domain.MagicLinkService
+	emailVerification domain.EmailVerificationService
+	passwordReset     domain.PasswordResetService
+	users             domain.UserManagementService
+	platformLogin     domain.PlatformLoginService
+	platforms         domain.PlatformService
+	stepUp            *domain.StepUpRequirement
+	devices           domain.DeviceAuthorizationService
+	apiKeys           domain.APIKeyService
+	impersonation     domain.ImpersonationService
+	guestsEnabled     bool
+}
+
+// HandlerOption configures optional endpoints of the handler.
+type HandlerOption func(*Handler)
+
+// WithClientCredentialsGrant enables the client_credentials grant at the token endpoint.
+func WithClientCredentialsGrant(svc domain.ClientCredentialsService) HandlerOption {
+	return func(h *Handler) {
+		h.clientCredentials = svc
+	}
+}
+
+// WithTokenExchange enables the token exchange grant (RFC 8693) at the token endpoint. The
+// calling service authenticates with its client credentials, so WithClientCredentialsGrant
+// must also be given.
+func WithTokenExchange(svc domain.TokenExchangeService) HandlerOption {
+	return func(h *Handler) {
+		h.tokenExchange = svc
+	}
+}
+
+// WithOpenIDConnect enables the OpenID Connect discovery, JWKS and userinfo endpoints.
+func WithOpenIDConnect(userInfo domain.UserInfoService, idTokenGen domain.IDTokenGenerator) HandlerOption {
+	return func(h *Handler) {
+		h.userInfo = userInfo
+		h.idTokenGen = idTokenGen
+	}
+}
+
+// WithSessionManagement enables the endpoints for listing and revoking the user's sessions.
+func WithSessionManagement(svc domain.SessionService) HandlerOption {
+	return func(h *Handler) {
+		h.sessions = svc
+	}
+}
+
+// WithMFA enables second-factor enrollment and the second step of the login.
+func WithMFA(svc domain.MFAService) HandlerOption {
+	return func(h *Handler) {
+		h.mfa = svc
+	}
+}
+
+// WithPasskeys enables passkey registration and passwordless login. Together with WithMFA,
+// passkeys can also be used as a second factor.
+func WithPasskeys(svc domain.PasskeyService) HandlerOption {
+	return func(h *Handler) {
+		h.passkeys = svc
+	}
+}
+
+// WithMagicLinks enables requesting and redeeming email login links.
+func WithMagicLinks(svc domain.MagicLinkService) HandlerOption {
+	return func(h *Handler) {
+		h.magicLinks = svc
+	}
+}
+
+// WithEmailVerification enables resending verification emails and confirming addresses.
+func WithEmailVerification(svc domain.EmailVerificationService) HandlerOption {
+	return func(h *Handler) {
+		h.emailVerification = svc
+	}
+}
+
+// WithPasswordReset enables requesting and completing password resets by email.
+func WithPasswordReset(svc domain.PasswordResetService) HandlerOption {
+	return func(h *Handler) {
+		h.passwordReset = svc
+	}
+}
+
+// WithUserManagement enables the signed-in user's account endpoints, such as changing the password.
+func WithUserManagement(svc domain.UserManagementService) HandlerOption {
+	return func(h *Handler) {
+		h.users = svc
+	}
+}
+
+// WithPlatformLogin enables signing in with external platform accounts such as Twitch.
+// The auth service must be created with domain.WithPlatformLogin for the same service.
+func WithPlatformLogin(svc domain.PlatformLoginService) HandlerOption {
+	return func(h *Handler) {
+		h.platformLogin = svc
+	}
+}
+
+// WithPlatformAccounts enables the endpoint where users unlink platform accounts.
+func WithPlatformAccounts(svc domain.PlatformService) HandlerOption {
+	return func(h *Handler) {
+		h.platforms = svc
+	}
+}
+
+// WithStepUp enables re-authentication and requires tokens used for sensitive operations,
+// such as removing a second factor, to meet requirement. The auth service must be created
+// with domain.WithReAuthentication.
+func WithStepUp(requirement domain.StepUpRequirement) HandlerOption {
+	return func(h *Handler) {
+		h.stepUp = &requirement
+	}
+}
+
+// WithDeviceAuthorization enables the device authorization grant (RFC 8628) for devices
+// such as TVs, and the endpoints where a signed-in user approves a device.
+func WithDeviceAuthorization(svc domain.DeviceAuthorizationService) HandlerOption {
+	return func(h *Handler) {
+		h.devices = svc
+	}
+}
+
+// WithAPIKeys enables the endpoints where users create, list and revoke personal API keys.
+// The auth service must be created with domain.WithAPIKeys for the keys to be accepted.
+func WithAPIKeys(svc domain.APIKeyService) HandlerOption {
+	return func(h *Handler) {
+		h.apiKeys = svc
+	}
+}
+
+// WithImpersonation enables the endpoints where admins start and end impersonating a user.
+func WithImpersonation(svc domain.ImpersonationService) HandlerOption {
+	return func(h *Handler) {
+		h.impersonation = svc
+	}
+}
+
+// WithGuests enables anonymous guest sessions and upgrading guests to regular users.
+// The auth service must be created with domain.WithGuests.
+func WithGuests() HandlerOption {
+	return func(h *Handler) {
+		h.guestsEnabled = true
+	}
+}
+
+// NewHandler creates a new Handler instance.
+func NewHandler(cfg *config.Config, log *logger.Logger, authService domain.AuthService, authzService domain.AuthorizationService, opts ...HandlerOption) *Handler {
+	h := &Handler{
+		authService:  authService,
+		authzService: authzService,
+		publicURL:    strings.TrimRight(cfg.HTTP.PublicURL, "/"),
+		issuer:       cfg.OIDC.Issuer,
+		loginURL:     cfg.OIDC.LoginURL,
+		dpopEnabled:  cfg.DPoP.Enabled,
+		logger:       log.With(zap.String("component", "http_handler")),
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// Routes returns the HTTP routes served by the handler.
+func (h *Handler) Routes() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /oauth2/authorize", sensitive(h.authorize))
+	mux.HandleFunc("POST /oauth2/authorize", sensitive(h.decideConsent))
+	mux.HandleFunc("POST /oauth2/token", h.token)
+	mux.HandleFunc("GET /v1/consents", h.listConsents)
+	mux.HandleFunc("DELETE /v1/consents/{client_id}", sensitive(h.revokeConsent))
+	mux.HandleFunc("POST /v1/sessions", h.login)
+	if h.devices != nil {
+		mux.HandleFunc("POST /oauth2/device_authorization", h.deviceAuthorization)
+		mux.HandleFunc("GET /v1/device", h.lookupDevice)
+		mux.HandleFunc("POST /v1/device", sensitive(h.decideDevice))
+	}
+	if h.sessions != nil {
+		mux.HandleFunc("GET /v1/sessions", h.listSessions)
+		mux.HandleFunc("DELETE /v1/sessions/{session_id}", sensitive(h.revokeSession))
+	}
+	if h.mfa != nil {
+		mux.HandleFunc("POST /v1/sessions/mfa", h.completeMFA)
+		mux.HandleFunc("GET /v1/mfa", h.listMFAMethods)
+		mux.HandleFunc("POST /v1/mfa/totp", sensitive(h.enrollTOTP))
+		mux.HandleFunc("POST /v1/mfa/totp/confirm", sensitive(h.confirmTOTP))
+		mux.HandleFunc("POST /v1/mfa/totp/disable", h.requireStepUp(h.disableTOTP))
+		mux.HandleFunc("POST /v1/mfa/recovery-codes", sensitive(h.regenerateRecoveryCodes))
+	}
+	if h.passkeys != nil {
+		mux.HandleFunc("POST /v1/passkeys/options", sensitive(h.passkeyCreationOptions))
+		mux.HandleFunc("POST /v1/passkeys", sensitive(h.registerPasskey))
+		mux.HandleFunc("GET /v1/passkeys", h.listPasskeys)
+		mux.HandleFunc("DELETE /v1/passkeys/{credential_id}", h.requireStepUp(h.deletePasskey))
+		mux.HandleFunc("POST /v1/sessions/passkey/options", h.passkeyLoginOptions)
+		mux.HandleFunc("POST /v1/sessions/passkey", h.passkeyLogin)
+		if h.mfa != nil {
+			mux.HandleFunc("POST /v1/sessions/mfa/passkey/options", h.mfaPasskeyOptions)
+		}
+	}
+	if h.magicLinks != nil {
+		mux.HandleFunc("POST /v1/magic-links", h.requestMagicLink)
+		mux.HandleFunc("POST /v1/sessions/magic-link", h.magicLinkLogin)
+	}
+	if h.emailVerification != nil {
+		mux.HandleFunc("POST /v1/email/verification", h.resendEmailVerification)
+		mux.HandleFunc("POST /v1/email/verify", h.verifyEmail)
+	}
+	if h.passwordReset != nil {
+		mux.HandleFunc("POST /v1/password-resets", h.requestPasswordReset)
+		mux.HandleFunc("POST /v1/password-resets/complete", h.completePasswordReset)
+	}
+	if h.platformLogin != nil {
+		mux.HandleFunc("GET /v1/sessions/platform/{platform}/authorize", h.platformAuthorize)
+		mux.HandleFunc("POST /v1/sessions/platform/{platform}", h.platformLoginSession)
+	}
+	if h.stepUp != nil {
+		mux.HandleFunc("POST /v1/sessions/reauthenticate", sensitive(h.reauthenticate))
+		if h.passkeys != nil && h.mfa != nil {
+			mux.HandleFunc("POST /v1/sessions/reauthenticate/passkey/options", sensitive(h.reauthenticatePasskeyOptions))
+		}
+	}
+	if h.users != nil {
+		mux.HandleFunc("POST /v1/password", sensitive(h.changePassword))
+		mux.HandleFunc("DELETE /v1/account", h.requireStepUp(h.deleteAccount))
+	}
+	if h.platforms != nil {
+		mux.HandleFunc("DELETE /v1/platform-accounts/{platform_id}", h.requireStepUp(h.unlinkPlatform))
+	}
+	if h.apiKeys != nil {
+		mux.HandleFunc("POST /v1/api-keys", h.requireStepUp(h.createAPIKey))
+		mux.HandleFunc("GET /v1/api-keys", h.listAPIKeys)
+		mux.HandleFunc("DELETE /v1/api-keys/{key_id}", sensitive(h.revokeAPIKey))
+	}
+	if h.guestsEnabled {
+		mux.HandleFunc("POST /v1/sessions/guest", h.guestLogin)
+		mux.HandleFunc("POST /v1/guest/upgrade", h.upgradeGuest)
+	}
+	if h.impersonation != nil {
+		mux.HandleFunc("POST /v1/admin/impersonations", h.requireStepUp(h.impersonate))
+		mux.HandleFunc("DELETE /v1/admin/impersonations/current", h.endImpersonation)
+	}
+	if h.userInfo != nil {
+		mux.HandleFunc("GET /.well-known/openid-configuration", h.discovery)
+		mux.HandleFunc("GET /.well-known/jwks.json", h.jwks)
+		mux.HandleFunc("GET /oauth2/userinfo", h.userinfo)
+		mux.HandleFunc("POST /oauth2/userinfo", h.userinfo)
+	}
+	return withClientMetadata(mux)
+}
+
+// Errors returned by authenticateUser for restricted tokens on a sensitive route.
+var (
+	errDelegatedTokenForbidden = errors.New("operation is not allowed with a delegated token")
+	errGuestTokenForbidden     = errors.New("operation is not allowed for guest users")
+)
+
+// sensitiveContextKey is the request context key marking a sensitive route.
+type sensitiveContextKey struct{}
+
+// sensitive marks a route that changes credentials, grants access to the account or signs
+// the user out of devices and apps:
+// authenticateUser rejects delegated tokens there, so a support agent impersonating the user
+// cannot take over the account, and tokens of guest users, who have no credentials yet.
+func sensitive(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		next(w, r.WithContext(context.WithValue(r.Context(), sensitiveContextKey{}, true)))
+	}
+}
+
+// checkSensitive rejects delegated and guest tokens on sensitive routes.
+func checkSensitive(r *http.Request, claims *domain.TokenClaims) error {
+	if isSensitive, _ := r.Context().Value(sensitiveContextKey{}).(bool); !isSensitive {
+		return nil
+	}
+	switch {
+	case claims.IsDelegated():
+		return errDelegatedTokenForbidden
+	case claims.IsGuest():
+		return errGuestTokenForbidden
+	}
+	return nil
+}
+
+// authenticateUser validates the access token of a first-party request and returns its claims.
+// Tokens restricted to another audience, such as those from the token exchange, are rejected.
+func (h *Handler) authenticateUser(r *http.Request) (*domain.TokenClaims, error) {
+	claims, err := h.authenticateToken(r)
+	if err != nil {
+		return nil, err
+	}
+	// 사용자 본인 토큰만 허용 (클라이언트 또는 제3자 앱에 발급된 토큰 거부)
+	if claims.ClientID != "" {
+		return nil, errors.New("token was issued to a client")
+	}
+	// 토큰 교환으로 다른 서비스용으로 제한된 토큰은 계정 관리에 사용할 수 없음
+	if len(claims.Audience) > 0 {
+		return nil, errors.New("token is intended for another service")
+	}
+	// API 키는 봇과 도구용이므로 계정 관리에 사용할 수 없음
+	if claims.Type == domain.TokenTypeAPIKey {
+		return nil, errors.New("api keys cannot be used for account management")
+	}
+	if err := checkSensitive(r, claims); err != nil {
+		return nil, err
+	}
+	if err := checkStepUp(r, claims); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// authenticateToken validates the access token sent with the request and returns its claims.
+// DPoP-bound tokens must be sent with the "DPoP" scheme and a proof header (RFC 9449 7.1).
+func (h *Handler) authenticateToken(r *http.Request) (*domain.TokenClaims, error) {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || token == "" {
+		return nil, errors.New("missing access token")
+	}
+
+	var (
+		claims *domain.TokenClaims
+		err    error
+	)
+	switch {
+	case strings.EqualFold(scheme, "Bearer"):
+		claims, err = h.authService.ValidateAccessToken(r.Context(), token)
+	case strings.EqualFold(scheme, "DPoP"):
+		claims, err = h.authService.ValidateDPoPBoundToken(r.Context(), token, r.Header.Get("DPoP"), r.Method, h.publicURL+r.URL.Path)
+	default:
+		return nil, errors.New("unsupported authorization scheme")
+	}
+	return claims, err
+}
+
+// bindDPoP verifies the optional DPoP proof of a token request. With a proof, the issued
+// tokens are bound to its key and the returned token type is "DPoP" (RFC 9449 5).
+func (h *Handler) bindDPoP(r *http.Request) ([]domain.TokenOption, string, error) {
+	proof := r.Header.Get("DPoP")
+	if proof == "" {
+		return nil, "Bearer", nil
+	}
+	verified, err := h.authService.VerifyDPoPProof(r.Context(), proof, r.Method, h.publicURL+r.URL.Path)
+	if err != nil {
+		return nil, "", domain.NewOAuthError(domain.OAuthErrInvalidDPoPProof, err.Error())
+	}
+	return []domain.TokenOption{domain.WithConfirmationKey(verified.Thumbprint())}, "DPoP", nil
+}
+
+// writeJSON writes v as a JSON response with the given status code.
+// Responses are not cached unless the caller already set a Cache-Control header.
+func (h *Handler) writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if w.Header().Get("Cache-Control") == "" {
+		w.Header().Set("Cache-Control", "no-store")
+	}
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		h.logger.Warn("Failed to write response", zap.Error(err))
+	}
+}
+
+// writeError writes an OAuth 2.0 error response (RFC 6749 5.2). Errors that are not
+// OAuthErrors are logged and reported as server_error without details.
+func (h *Handler) writeError(w http.ResponseWriter, err error) {
+	var oauthErr *domain.OAuthError
+	if !errors.As(err, &oauthErr) {
+		h.logger.Error("Request failed", zap.Error(err))
+		oauthErr = domain.NewOAuthError(domain.OAuthErrServerError, "")
+	}
+
+	status := http.StatusBadRequest
+	switch oauthErr.Code() {
+	case domain.OAuthErrInvalidClient:
+		status = http.StatusUnauthorized
+	case domain.OAuthErrInsufficientScope:
+		status = http.StatusForbidden
+	case domain.OAuthErrServerError:
+		status = http.StatusInternalServerError
+	}
+	h.writeJSON(w, status, errorResponse{Error: oauthErr.Code(), Description: oauthErr.Description()})
+}
+
+// writeUnauthorized rejects a request without a valid user access token.
+func (h *Handler) writeUnauthorized(w http.ResponseWriter, err error) {
+	var stepUpErr *domain.StepUpRequiredError
+	if errors.As(err, &stepUpErr) {
+		h.writeStepUpChallenge(w, stepUpErr)
+		return
+	}
+	if errors.Is(err, errDelegatedTokenForbidden) || errors.Is(err, errGuestTokenForbidden) {
+		h.writeJSON(w, http.StatusForbidden, errorResponse{Error: "forbidden", Description: err.Error()})
+		return
+	}
+	h.logger.Debug("Unauthenticated request", zap.Error(err))
+	w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+	h.writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "invalid_token"})
+}
+
+// errorResponse is the JSON body of an OAuth 2.0 error.
+type errorResponse struct {
+	Error       string `json:"error"`
+	Description string `json:"error_description,omitempty"`
+}
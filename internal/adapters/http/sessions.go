@@ -0,0 +1,155 @@
+package httpapi
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/sukryu/IV-auth-services/internal/core/domain"
+	"go.uber.org/zap"
+)
+
+// deviceNameHeader carries the device name chosen by first-party apps (e.g. "Jane's iPhone").
+const deviceNameHeader = "X-Device-Name"
+
+// deviceFingerprintHeader carries a stable device identifier generated by first-party apps,
+// used to recognize devices the user has signed in from before.
+const deviceFingerprintHeader = "X-Device-Fingerprint"
+
+// sessionResponse describes a signed-in device of the user.
+type sessionResponse struct {
+	ID              string    `json:"id"`
+	DeviceName      string    `json:"device_name,omitempty"`
+	UserAgent       string    `json:"user_agent,omitempty"`
+	IPAddress       string    `json:"ip_address,omitempty"`
+	CreatedAt       time.Time `json:"created_at"`
+	LastRefreshedAt time.Time `json:"last_refreshed_at"`
+	Current         bool      `json:"current"`
+}
+
+// withClientMetadata attaches the caller's IP address, user agent, device name and fingerprint
+// to the request context, where domain services read them when creating or refreshing sessions
+// and when scoring logins.
+func withClientMetadata(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ip, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
+			ip = r.RemoteAddr
+		}
+		ctx := domain.ContextWithClientMetadata(r.Context(), domain.ClientMetadata{
+			IPAddress:         ip,
+			UserAgent:         r.UserAgent(),
+			DeviceName:        r.Header.Get(deviceNameHeader),
+			DeviceFingerprint: r.Header.Get(deviceFingerprintHeader),
+		})
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// login handles POST /v1/sessions. The first-party login UI posts the user's credentials
+// (identifier, which is an email address or username, and password; username is accepted
+// in place of identifier for older clients) and receives a token pair, or an MFA prompt
+// when the user has a second factor. A DPoP proof header binds the pair to the client's key.
+func (h *Handler) login(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		h.writeError(w, domain.NewOAuthError(domain.OAuthErrInvalidRequest, "malformed form body"))
+		return
+	}
+
+	opts, tokenType, err := h.bindDPoP(r)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	identifier := r.PostForm.Get("identifier")
+	if identifier == "" {
+		identifier = r.PostForm.Get("username")
+	}
+	token, err := h.authService.Authenticate(r.Context(), identifier, r.PostForm.Get("password"), opts...)
+	if err != nil {
+		if h.writeMFAPrompt(w, err) {
+			return
+		}
+		// 실패 원인(사용자 없음, 비밀번호 불일치 등)은 응답에 노출하지 않음
+		h.logger.Info("Login failed", zap.Error(err))
+		h.writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "invalid_credentials"})
+		return
+	}
+
+	h.writeLoginTokens(w, token, tokenType)
+}
+
+// writeMFAPrompt writes the MFA prompt if err is an *MFARequiredError and reports whether it did.
+func (h *Handler) writeMFAPrompt(w http.ResponseWriter, err error) bool {
+	var mfaErr *domain.MFARequiredError
+	if !errors.As(err, &mfaErr) {
+		return false
+	}
+	h.writeJSON(w, http.StatusOK, mfaPrompt{
+		MFARequired: true,
+		MFAToken:    mfaErr.ChallengeToken,
+		Methods:     mfaErr.Methods,
+		ExpiresIn:   int(time.Until(mfaErr.ExpiresAt).Seconds()),
+	})
+	return true
+}
+
+// writeLoginTokens writes the token pair issued by a first-party login.
+func (h *Handler) writeLoginTokens(w http.ResponseWriter, token *domain.Token, tokenType string) {
+	h.writeJSON(w, http.StatusOK, tokenResponse{
+		AccessToken:  token.AccessToken(),
+		TokenType:    tokenType,
+		ExpiresIn:    int(time.Until(token.Expiry()).Seconds()),
+		RefreshToken: token.RefreshToken(),
+	})
+}
+
+// listSessions handles GET /v1/sessions and returns the signed-in user's active sessions.
+// The session of the calling token is flagged as current.
+func (h *Handler) listSessions(w http.ResponseWriter, r *http.Request) {
+	claims, err := h.authenticateUser(r)
+	if err != nil {
+		h.writeUnauthorized(w, err)
+		return
+	}
+
+	sessions, err := h.sessions.ListSessions(r.Context(), claims.Subject)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	resp := make([]sessionResponse, 0, len(sessions))
+	for _, s := range sessions {
+		resp = append(resp, sessionResponse{
+			ID:              s.ID(),
+			DeviceName:      s.DeviceName(),
+			UserAgent:       s.UserAgent(),
+			IPAddress:       s.IPAddress(),
+			CreatedAt:       s.CreatedAt(),
+			LastRefreshedAt: s.LastRefreshedAt(),
+			Current:         s.ID() == claims.SessionID,
+		})
+	}
+	h.writeJSON(w, http.StatusOK, resp)
+}
+
+// revokeSession handles DELETE /v1/sessions/{session_id} and signs that device out.
+func (h *Handler) revokeSession(w http.ResponseWriter, r *http.Request) {
+	claims, err := h.authenticateUser(r)
+	if err != nil {
+		h.writeUnauthorized(w, err)
+		return
+	}
+
+	if err := h.sessions.RevokeSession(r.Context(), claims.Subject, r.PathValue("session_id")); err != nil {
+		if errors.Is(err, domain.ErrSessionNotFound) {
+			h.writeJSON(w, http.StatusNotFound, errorResponse{Error: "not_found"})
+			return
+		}
+		h.writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,112 @@
+package httpapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/sukryu/IV-auth-services/internal/core/domain"
+	"go.uber.org/zap"
+)
+
+// stepUpContextKey is the request context key of the step-up requirement of a route.
+type stepUpContextKey struct{}
+
+// requireStepUp marks a route as sensitive (see sensitive) and, with WithStepUp, makes
+// authenticateUser reject tokens that do not meet the configured step-up requirement.
+func (h *Handler) requireStepUp(next http.HandlerFunc) http.HandlerFunc {
+	if h.stepUp == nil {
+		return sensitive(next)
+	}
+	requirement := *h.stepUp
+	return sensitive(func(w http.ResponseWriter, r *http.Request) {
+		next(w, r.WithContext(context.WithValue(r.Context(), stepUpContextKey{}, requirement)))
+	})
+}
+
+// checkStepUp applies the step-up requirement of the route, if any, to the token's claims.
+func checkStepUp(r *http.Request, claims *domain.TokenClaims) error {
+	requirement, ok := r.Context().Value(stepUpContextKey{}).(domain.StepUpRequirement)
+	if !ok {
+		return nil
+	}
+	return requirement.Check(claims)
+}
+
+// writeStepUpChallenge asks the client to authenticate again (RFC 9470 3).
+func (h *Handler) writeStepUpChallenge(w http.ResponseWriter, err *domain.StepUpRequiredError) {
+	challenge := `Bearer error="insufficient_user_authentication"`
+	if err.Requirement.MaxAge > 0 {
+		challenge += ", max_age=" + strconv.Itoa(int(err.Requirement.MaxAge.Seconds()))
+	}
+	if err.Requirement.ACR != "" {
+		challenge += `, acr_values="` + err.Requirement.ACR + `"`
+	}
+	w.Header().Set("WWW-Authenticate", challenge)
+	h.writeJSON(w, http.StatusUnauthorized, errorResponse{
+		Error:       "insufficient_user_authentication",
+		Description: "re-authenticate at /v1/sessions/reauthenticate and retry with the new token",
+	})
+}
+
+// reauthenticate handles POST /v1/sessions/reauthenticate. The form carries method
+// ("password" or an MFA method) and the password or code; the response is a short-lived
+// access token, bound to the same DPoP key as the calling token, without a refresh token.
+func (h *Handler) reauthenticate(w http.ResponseWriter, r *http.Request) {
+	claims, err := h.authenticateUser(r)
+	if err != nil {
+		h.writeUnauthorized(w, err)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		h.writeError(w, domain.NewOAuthError(domain.OAuthErrInvalidRequest, "malformed form body"))
+		return
+	}
+
+	method := r.PostForm.Get("method")
+	response := r.PostForm.Get("code")
+	if method == domain.ReAuthMethodPassword {
+		response = r.PostForm.Get("password")
+	}
+
+	token, err := h.authService.ReAuthenticate(r.Context(), claims, method, response)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrReAuthenticationFailed):
+			h.writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "invalid_credentials"})
+		case errors.Is(err, domain.ErrRateLimited):
+			h.writeJSON(w, http.StatusTooManyRequests, errorResponse{Error: "rate_limited"})
+		case errors.Is(err, domain.ErrMFANotEnrolled):
+			h.writeJSON(w, http.StatusBadRequest, errorResponse{Error: "mfa_not_enrolled"})
+		default:
+			h.logger.Error("Failed to re-authenticate", zap.Error(err))
+			h.writeError(w, err)
+		}
+		return
+	}
+
+	tokenType := "Bearer"
+	if claims.IsSenderConstrained() {
+		tokenType = "DPoP"
+	}
+	h.writeLoginTokens(w, token, tokenType)
+}
+
+// reauthenticatePasskeyOptions handles POST /v1/sessions/reauthenticate/passkey/options.
+// The resulting assertion is sent to POST /v1/sessions/reauthenticate with method=webauthn
+// and the PublicKeyCredential JSON as code.
+func (h *Handler) reauthenticatePasskeyOptions(w http.ResponseWriter, r *http.Request) {
+	claims, err := h.authenticateUser(r)
+	if err != nil {
+		h.writeUnauthorized(w, err)
+		return
+	}
+
+	options, err := h.passkeys.BeginFactor(r.Context(), claims.Subject)
+	if err != nil {
+		h.writePasskeyError(w, err)
+		return
+	}
+	h.writeJSON(w, http.StatusOK, toRequestOptionsResponse(options))
+}
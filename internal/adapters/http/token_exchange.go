@@ -0,0 +1,35 @@
+package httpapi
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/sukryu/IV-auth-services/internal/core/domain"
+)
+
+// exchangeToken handles the token exchange grant (RFC 8693). The calling service authenticates
+// with its client credentials and becomes the actor of the issued token; the subject token
+// must be a user access token. A single audience is required.
+func (h *Handler) exchangeToken(r *http.Request, clientID, clientSecret string) (*domain.Token, error) {
+	form := r.PostForm
+	if form.Get("subject_token_type") != domain.TokenTypeIdentifierAccessToken {
+		return nil, domain.NewOAuthError(domain.OAuthErrInvalidRequest, "subject_token_type must be "+domain.TokenTypeIdentifierAccessToken)
+	}
+	if requested := form.Get("requested_token_type"); requested != "" && requested != domain.TokenTypeIdentifierAccessToken {
+		return nil, domain.NewOAuthError(domain.OAuthErrInvalidRequest, "only access tokens can be requested")
+	}
+	// 행위자 토큰 위임 체인은 호출 서비스 인증으로 대신함
+	if form.Get("actor_token") != "" {
+		return nil, domain.NewOAuthError(domain.OAuthErrInvalidRequest, "actor_token is not supported")
+	}
+	audiences := form["audience"]
+	if len(audiences) != 1 {
+		return nil, domain.NewOAuthError(domain.OAuthErrInvalidTarget, "exactly one audience must be requested")
+	}
+
+	client, err := h.clientCredentials.AuthenticateClient(r.Context(), clientID, clientSecret)
+	if err != nil {
+		return nil, err
+	}
+	return h.tokenExchange.ExchangeToken(r.Context(), form.Get("subject_token"), client.ID(), audiences[0], strings.Fields(form.Get("scope")))
+}
@@ -0,0 +1,190 @@
+package mail
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"mime"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sukryu/IV-auth-services/internal/config"
+	"github.com/sukryu/IV-auth-services/internal/core/domain"
+	"github.com/sukryu/IV-auth-services/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// Mailer drivers selectable with mail.driver.
+const (
+	DriverSMTP   = "smtp"
+	DriverMemory = "memory"
+)
+
+// NewMailer returns the mailer selected by cfg.Mail.Driver.
+func NewMailer(cfg *config.Config, log *logger.Logger) (domain.Mailer, error) {
+	switch cfg.Mail.Driver {
+	case DriverSMTP:
+		return NewSMTPMailer(cfg, log)
+	case DriverMemory, "":
+		return NewMemoryMailer(log), nil
+	default:
+		return nil, errors.New("unsupported mail driver: " + cfg.Mail.Driver)
+	}
+}
+
+// SMTPMailer delivers mail through an SMTP relay, upgrading the connection with STARTTLS
+// whenever the server offers it.
+type SMTPMailer struct {
+	addr     string
+	host     string
+	from     string
+	username string
+	password string
+	timeout  time.Duration
+	logger   *logger.Logger
+}
+
+// NewSMTPMailer creates a new SMTPMailer instance.
+func NewSMTPMailer(cfg *config.Config, log *logger.Logger) (*SMTPMailer, error) {
+	if cfg.Mail.SMTP.Host == "" {
+		return nil, errors.New("smtp host must not be empty")
+	}
+	if _, err := domain.NewEmail(cfg.Mail.From); err != nil {
+		return nil, errors.New("invalid mail from address: " + err.Error())
+	}
+
+	return &SMTPMailer{
+		addr:     net.JoinHostPort(cfg.Mail.SMTP.Host, strconv.Itoa(cfg.Mail.SMTP.Port)),
+		host:     cfg.Mail.SMTP.Host,
+		from:     cfg.Mail.From,
+		username: cfg.Mail.SMTP.Username,
+		password: cfg.Mail.SMTP.Password,
+		timeout:  cfg.Mail.SMTP.Timeout,
+		logger:   log.With(zap.String("component", "smtp_mailer")),
+	}, nil
+}
+
+// Send delivers the message in a single SMTP transaction. Failures are also logged, since
+// services hand some messages off in the background where nobody reads the returned error.
+func (m *SMTPMailer) Send(ctx context.Context, msg domain.EmailMessage) error {
+	if err := m.deliver(ctx, msg); err != nil {
+		m.logger.Warn("Failed to send email", zap.String("subject", msg.Subject), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// deliver runs the SMTP conversation for a single message.
+func (m *SMTPMailer) deliver(ctx context.Context, msg domain.EmailMessage) error {
+	if !msg.To.IsValid() {
+		return errors.New("invalid recipient")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, m.timeout)
+	defer cancel()
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", m.addr)
+	if err != nil {
+		return errors.New("failed to connect to smtp server: " + err.Error())
+	}
+	// 전체 대화에 deadline을 걸어 응답 없는 서버에서 무한 대기하지 않도록 함
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	client, err := smtp.NewClient(conn, m.host)
+	if err != nil {
+		_ = conn.Close()
+		return errors.New("failed to start smtp session: " + err.Error())
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: m.host, MinVersion: tls.VersionTLS12}); err != nil {
+			return errors.New("failed to start tls: " + err.Error())
+		}
+	}
+	if m.username != "" {
+		// PlainAuth는 TLS 또는 localhost가 아니면 자격 증명 전송을 거부함
+		if err := client.Auth(smtp.PlainAuth("", m.username, m.password, m.host)); err != nil {
+			return errors.New("failed to authenticate to smtp server: " + err.Error())
+		}
+	}
+
+	if err := client.Mail(m.from); err != nil {
+		return errors.New("smtp MAIL FROM rejected: " + err.Error())
+	}
+	if err := client.Rcpt(msg.To.String()); err != nil {
+		return errors.New("smtp RCPT TO rejected: " + err.Error())
+	}
+	w, err := client.Data()
+	if err != nil {
+		return errors.New("smtp DATA rejected: " + err.Error())
+	}
+	if _, err := w.Write(m.buildMessage(msg)); err != nil {
+		_ = w.Close()
+		return errors.New("failed to write message: " + err.Error())
+	}
+	if err := w.Close(); err != nil {
+		return errors.New("smtp server rejected message: " + err.Error())
+	}
+	if err := client.Quit(); err != nil {
+		m.logger.Debug("Failed to close smtp session", zap.Error(err))
+	}
+	return nil
+}
+
+// buildMessage formats an RFC 5322 message with a UTF-8 plain-text body.
+func (m *SMTPMailer) buildMessage(msg domain.EmailMessage) []byte {
+	var b strings.Builder
+	b.WriteString("From: " + m.from + "\r\n")
+	b.WriteString("To: " + msg.To.String() + "\r\n")
+	// 제목은 인코딩된 단어로 보내 헤더 주입과 비 ASCII 문자 문제를 함께 방지
+	b.WriteString("Subject: " + mime.QEncoding.Encode("utf-8", msg.Subject) + "\r\n")
+	b.WriteString("Date: " + time.Now().Format(time.RFC1123Z) + "\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(strings.ReplaceAll(msg.Body, "\r\n", "\n"), "\n", "\r\n"))
+	return []byte(b.String())
+}
+
+// MemoryMailer keeps sent messages in memory instead of delivering them. It is meant for
+// development and tests, where the messages can be read back with Messages.
+type MemoryMailer struct {
+	mu       sync.Mutex
+	messages []domain.EmailMessage
+	logger   *logger.Logger
+}
+
+// NewMemoryMailer creates a new MemoryMailer instance.
+func NewMemoryMailer(log *logger.Logger) *MemoryMailer {
+	return &MemoryMailer{
+		logger: log.With(zap.String("component", "memory_mailer")),
+	}
+}
+
+// Send records the message.
+func (m *MemoryMailer) Send(ctx context.Context, msg domain.EmailMessage) error {
+	if !msg.To.IsValid() {
+		return errors.New("invalid recipient")
+	}
+
+	m.mu.Lock()
+	m.messages = append(m.messages, msg)
+	m.mu.Unlock()
+	m.logger.Debug("Recorded email", zap.String("subject", msg.Subject))
+	return nil
+}
+
+// Messages returns a copy of the messages sent so far, oldest first.
+func (m *MemoryMailer) Messages() []domain.EmailMessage {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]domain.EmailMessage(nil), m.messages...)
+}
@@ -0,0 +1,128 @@
+package platforms
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sukryu/IV-auth-services/internal/config"
+	"github.com/sukryu/IV-auth-services/internal/core/domain"
+	"github.com/sukryu/IV-auth-services/pkg/logger"
+)
+
+// maxResponseBody bounds the size of a platform API response.
+const maxResponseBody = 1 << 20
+
+// NewOAuthClients returns a sign-in client for every platform with a configured client ID.
+func NewOAuthClients(cfg *config.Config, log *logger.Logger) ([]domain.PlatformOAuthClient, error) {
+	httpClient := &http.Client{Timeout: cfg.Platforms.Timeout}
+
+	var clients []domain.PlatformOAuthClient
+	if cfg.Platforms.Twitch.ClientID != "" {
+		c, err := NewTwitchClient(cfg, httpClient, log)
+		if err != nil {
+			return nil, err
+		}
+		clients = append(clients, c)
+	}
+	if cfg.Platforms.YouTube.ClientID != "" {
+		c, err := NewYouTubeClient(cfg, httpClient, log)
+		if err != nil {
+			return nil, err
+		}
+		clients = append(clients, c)
+	}
+	return clients, nil
+}
+
+// oauthApp holds the OAuth 2.0 client registration at a platform.
+type oauthApp struct {
+	clientID     string
+	clientSecret string
+	redirectURL  string
+	authURL      string
+	tokenURL     string
+	scopes       []string
+}
+
+// tokenResponse is an OAuth 2.0 access token response (RFC 6749 5.1).
+type tokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int    `json:"expires_in"`
+	TokenType    string `json:"token_type"`
+}
+
+// expiresAt converts expires_in to an absolute time, or nil if the platform did not send it.
+func (t *tokenResponse) expiresAt() *time.Time {
+	if t.ExpiresIn <= 0 {
+		return nil
+	}
+	at := time.Now().Add(time.Duration(t.ExpiresIn) * time.Second)
+	return &at
+}
+
+// authorizationURL builds the consent page URL with the given extra parameters.
+func (a *oauthApp) authorizationURL(state string, extra url.Values) string {
+	query := url.Values{}
+	query.Set("client_id", a.clientID)
+	query.Set("redirect_uri", a.redirectURL)
+	query.Set("response_type", "code")
+	query.Set("scope", strings.Join(a.scopes, " "))
+	query.Set("state", state)
+	for k, v := range extra {
+		query[k] = v
+	}
+	return a.authURL + "?" + query.Encode()
+}
+
+// exchangeCode redeems an authorization code at the platform's token endpoint.
+func (a *oauthApp) exchangeCode(ctx context.Context, client *http.Client, code string) (*tokenResponse, error) {
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", a.redirectURL)
+	form.Set("client_id", a.clientID)
+	form.Set("client_secret", a.clientSecret)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	var token tokenResponse
+	if err := doJSON(client, req, &token); err != nil {
+		return nil, errors.New("token request failed: " + err.Error())
+	}
+	if token.AccessToken == "" {
+		return nil, errors.New("token response has no access token")
+	}
+	return &token, nil
+}
+
+// doJSON sends the request and decodes a successful JSON response into out.
+func doJSON(client *http.Client, req *http.Request, out interface{}) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		// 응답 본문에는 토큰이 포함될 수 있어 상태 코드만 노출
+		return errors.New("unexpected status " + strconv.Itoa(resp.StatusCode))
+	}
+	return json.Unmarshal(body, out)
+}
@@ -0,0 +1,98 @@
+package platforms
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/sukryu/IV-auth-services/internal/config"
+	"github.com/sukryu/IV-auth-services/internal/core/domain"
+	"github.com/sukryu/IV-auth-services/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// TwitchClient signs users in with their Twitch account.
+type TwitchClient struct {
+	app    oauthApp
+	apiURL string // Helix API 기준 URL
+	client *http.Client
+	logger *logger.Logger
+}
+
+// NewTwitchClient creates a new TwitchClient instance.
+func NewTwitchClient(cfg *config.Config, httpClient *http.Client, log *logger.Logger) (*TwitchClient, error) {
+	tc := cfg.Platforms.Twitch
+	if tc.ClientID == "" || tc.ClientSecret == "" || tc.RedirectURL == "" {
+		return nil, errors.New("twitch client id, client secret and redirect url must not be empty")
+	}
+	return &TwitchClient{
+		app: oauthApp{
+			clientID:     tc.ClientID,
+			clientSecret: tc.ClientSecret,
+			redirectURL:  tc.RedirectURL,
+			authURL:      tc.AuthURL,
+			tokenURL:     tc.TokenURL,
+			scopes:       []string{"user:read:email"},
+		},
+		apiURL: strings.TrimRight(tc.APIURL, "/"),
+		client: httpClient,
+		logger: log.With(zap.String("component", "twitch_client")),
+	}, nil
+}
+
+// Platform returns domain.PlatformTwitch.
+func (c *TwitchClient) Platform() domain.PlatformType {
+	return domain.PlatformTwitch
+}
+
+// AuthorizationURL returns the Twitch consent page URL.
+func (c *TwitchClient) AuthorizationURL(state string) string {
+	return c.app.authorizationURL(state, nil)
+}
+
+// twitchUsersResponse is the response of GET /helix/users.
+type twitchUsersResponse struct {
+	Data []struct {
+		ID    string `json:"id"`
+		Login string `json:"login"`
+		Email string `json:"email"`
+	} `json:"data"`
+}
+
+// ExchangeCode redeems the code and fetches the authorizing user from the Helix API.
+func (c *TwitchClient) ExchangeCode(ctx context.Context, code string) (*domain.PlatformIdentity, error) {
+	token, err := c.app.exchangeCode(ctx, c.client, code)
+	if err != nil {
+		c.logger.Warn("Failed to exchange twitch code", zap.Error(err))
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.apiURL+"/users", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token.AccessToken)
+	req.Header.Set("Client-Id", c.app.clientID)
+
+	var users twitchUsersResponse
+	if err := doJSON(c.client, req, &users); err != nil {
+		c.logger.Warn("Failed to fetch twitch user", zap.Error(err))
+		return nil, errors.New("failed to fetch twitch user: " + err.Error())
+	}
+	if len(users.Data) != 1 || users.Data[0].ID == "" {
+		return nil, errors.New("twitch returned no user")
+	}
+
+	user := users.Data[0]
+	return &domain.PlatformIdentity{
+		PlatformUserID: user.ID,
+		Username:       user.Login,
+		Email:          user.Email,
+		// Twitch는 확인된 주소만 반환
+		EmailVerified: user.Email != "",
+		AccessToken:   token.AccessToken,
+		RefreshToken:  token.RefreshToken,
+		ExpiresAt:     token.expiresAt(),
+	}, nil
+}
@@ -0,0 +1,124 @@
+package platforms
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/sukryu/IV-auth-services/internal/config"
+	"github.com/sukryu/IV-auth-services/internal/core/domain"
+	"github.com/sukryu/IV-auth-services/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// YouTubeClient signs users in with the Google account that owns their YouTube channel.
+// The channel ID is the platform user ID, so one Google account with several brand
+// channels maps to the channel chosen on the consent page.
+type YouTubeClient struct {
+	app         oauthApp
+	userInfoURL string // OpenID Connect userinfo (이메일)
+	apiURL      string // YouTube Data API 기준 URL
+	client      *http.Client
+	logger      *logger.Logger
+}
+
+// NewYouTubeClient creates a new YouTubeClient instance.
+func NewYouTubeClient(cfg *config.Config, httpClient *http.Client, log *logger.Logger) (*YouTubeClient, error) {
+	yc := cfg.Platforms.YouTube
+	if yc.ClientID == "" || yc.ClientSecret == "" || yc.RedirectURL == "" {
+		return nil, errors.New("youtube client id, client secret and redirect url must not be empty")
+	}
+	return &YouTubeClient{
+		app: oauthApp{
+			clientID:     yc.ClientID,
+			clientSecret: yc.ClientSecret,
+			redirectURL:  yc.RedirectURL,
+			authURL:      yc.AuthURL,
+			tokenURL:     yc.TokenURL,
+			scopes:       []string{"openid", "email", "https://www.googleapis.com/auth/youtube.readonly"},
+		},
+		userInfoURL: yc.UserInfoURL,
+		apiURL:      strings.TrimRight(yc.APIURL, "/"),
+		client:      httpClient,
+		logger:      log.With(zap.String("component", "youtube_client")),
+	}, nil
+}
+
+// Platform returns domain.PlatformYouTube.
+func (c *YouTubeClient) Platform() domain.PlatformType {
+	return domain.PlatformYouTube
+}
+
+// AuthorizationURL returns the Google consent page URL, requesting a refresh token.
+func (c *YouTubeClient) AuthorizationURL(state string) string {
+	return c.app.authorizationURL(state, url.Values{"access_type": {"offline"}})
+}
+
+// googleUserInfo is the OpenID Connect userinfo response of Google.
+type googleUserInfo struct {
+	Subject       string `json:"sub"`
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+}
+
+// youtubeChannelsResponse is the response of GET /channels?part=snippet&mine=true.
+type youtubeChannelsResponse struct {
+	Items []struct {
+		ID      string `json:"id"`
+		Snippet struct {
+			Title     string `json:"title"`
+			CustomURL string `json:"customUrl"` // "@handle"
+		} `json:"snippet"`
+	} `json:"items"`
+}
+
+// ExchangeCode redeems the code and fetches the user's email and YouTube channel.
+func (c *YouTubeClient) ExchangeCode(ctx context.Context, code string) (*domain.PlatformIdentity, error) {
+	token, err := c.app.exchangeCode(ctx, c.client, code)
+	if err != nil {
+		c.logger.Warn("Failed to exchange youtube code", zap.Error(err))
+		return nil, err
+	}
+
+	var info googleUserInfo
+	if err := c.get(ctx, c.userInfoURL, token.AccessToken, &info); err != nil {
+		c.logger.Warn("Failed to fetch google userinfo", zap.Error(err))
+		return nil, errors.New("failed to fetch google userinfo: " + err.Error())
+	}
+
+	var channels youtubeChannelsResponse
+	if err := c.get(ctx, c.apiURL+"/channels?part=snippet&mine=true", token.AccessToken, &channels); err != nil {
+		c.logger.Warn("Failed to fetch youtube channel", zap.Error(err))
+		return nil, errors.New("failed to fetch youtube channel: " + err.Error())
+	}
+	if len(channels.Items) == 0 || channels.Items[0].ID == "" {
+		return nil, errors.New("google account has no youtube channel")
+	}
+
+	channel := channels.Items[0]
+	username := strings.TrimPrefix(channel.Snippet.CustomURL, "@")
+	if username == "" {
+		username = channel.Snippet.Title
+	}
+	return &domain.PlatformIdentity{
+		PlatformUserID: channel.ID,
+		Username:       username,
+		Email:          info.Email,
+		EmailVerified:  info.EmailVerified,
+		AccessToken:    token.AccessToken,
+		RefreshToken:   token.RefreshToken,
+		ExpiresAt:      token.expiresAt(),
+	}, nil
+}
+
+// get sends an authorized GET request and decodes the JSON response.
+func (c *YouTubeClient) get(ctx context.Context, endpoint, accessToken string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	return doJSON(c.client, req, out)
+}
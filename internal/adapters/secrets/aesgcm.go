@@ -0,0 +1,65 @@
+package secrets
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+
+	"github.com/sukryu/IV-auth-services/internal/core/domain"
+)
+
+// AESGCMCipher implements domain.SecretCipher with AES-256-GCM. Each ciphertext carries its
+// own random nonce and is returned as base64(nonce || sealed).
+type AESGCMCipher struct {
+	aead cipher.AEAD
+}
+
+// NewAESGCMCipher creates a new AESGCMCipher from a base64-encoded 32-byte key.
+func NewAESGCMCipher(encodedKey string) (domain.SecretCipher, error) {
+	key, err := base64.StdEncoding.DecodeString(encodedKey)
+	if err != nil {
+		return nil, errors.New("failed to decode encryption key: " + err.Error())
+	}
+	if len(key) != 32 {
+		return nil, errors.New("encryption key must be 32 bytes")
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.New("failed to create cipher: " + err.Error())
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, errors.New("failed to create gcm: " + err.Error())
+	}
+	return &AESGCMCipher{aead: aead}, nil
+}
+
+// Encrypt seals plaintext with a fresh random nonce.
+func (c *AESGCMCipher) Encrypt(plaintext []byte) (string, error) {
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", errors.New("failed to generate nonce: " + err.Error())
+	}
+	sealed := c.aead.Seal(nonce, nonce, plaintext, nil)
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt opens a ciphertext produced by Encrypt.
+func (c *AESGCMCipher) Decrypt(ciphertext string) ([]byte, error) {
+	data, err := base64.StdEncoding.DecodeString(ciphertext)
+	if err != nil {
+		return nil, errors.New("failed to decode ciphertext: " + err.Error())
+	}
+	if len(data) < c.aead.NonceSize() {
+		return nil, errors.New("ciphertext is too short")
+	}
+	nonce, sealed := data[:c.aead.NonceSize()], data[c.aead.NonceSize():]
+	plaintext, err := c.aead.Open(nil, nonce, sealed, nil)
+	if err != nil {
+		return nil, errors.New("failed to decrypt: " + err.Error())
+	}
+	return plaintext, nil
+}
@@ -0,0 +1,120 @@
+package tokens
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/sukryu/IV-auth-services/internal/core/domain"
+)
+
+// setOptionalClaims adds the optional claims in extra to the claims set. JWT and PASETO
+// tokens share these names so both formats carry the same information.
+func setOptionalClaims(claims map[string]interface{}, extra domain.TokenClaims) {
+	if extra.TokenID != "" {
+		claims["jti"] = extra.TokenID
+	}
+	// DPoP 바인딩 (RFC 9449 6.1)
+	if extra.ConfirmationKeyThumbprint != "" {
+		claims["cnf"] = map[string]interface{}{"jkt": extra.ConfirmationKeyThumbprint}
+	}
+	if len(extra.Audience) > 0 {
+		claims["aud"] = extra.Audience
+	}
+	// scope는 공백 구분 문자열 (RFC 8693 4.2)
+	if len(extra.Scope) > 0 {
+		claims["scope"] = strings.Join(extra.Scope, " ")
+	}
+	if extra.ClientID != "" {
+		claims["client_id"] = extra.ClientID
+	}
+	if extra.Actor != nil {
+		claims["act"] = actorClaimMap(extra.Actor)
+	}
+	if !extra.AuthTime.IsZero() {
+		claims["auth_time"] = extra.AuthTime.Unix()
+	}
+	if len(extra.AMR) > 0 {
+		claims["amr"] = extra.AMR
+	}
+	if extra.ACR != "" {
+		claims["acr"] = extra.ACR
+	}
+	if extra.SessionID != "" {
+		claims["sid"] = extra.SessionID
+	}
+}
+
+// parseOptionalClaims reads the claims written by setOptionalClaims from a decoded claims set.
+func parseOptionalClaims(claims map[string]interface{}, out *domain.TokenClaims) error {
+	out.TokenID, _ = claims["jti"].(string)
+	out.Type, _ = claims["typ"].(string)
+	if cnf, ok := claims["cnf"].(map[string]interface{}); ok {
+		jkt, ok := cnf["jkt"].(string)
+		if !ok || jkt == "" {
+			return errors.New("invalid confirmation claim in token")
+		}
+		out.ConfirmationKeyThumbprint = jkt
+	}
+	// aud는 단일 문자열 또는 배열 (RFC 7519 4.1.3)
+	switch aud := claims["aud"].(type) {
+	case string:
+		out.Audience = []string{aud}
+	case []interface{}:
+		for _, a := range aud {
+			if s, ok := a.(string); ok {
+				out.Audience = append(out.Audience, s)
+			}
+		}
+	}
+	if scope, ok := claims["scope"].(string); ok && scope != "" {
+		out.Scope = strings.Fields(scope)
+	}
+	out.ClientID, _ = claims["client_id"].(string)
+	if authTime, ok := claims["auth_time"].(float64); ok {
+		out.AuthTime = time.Unix(int64(authTime), 0)
+	}
+	if amr, ok := claims["amr"].([]interface{}); ok {
+		for _, method := range amr {
+			if m, ok := method.(string); ok {
+				out.AMR = append(out.AMR, m)
+			}
+		}
+	}
+	out.ACR, _ = claims["acr"].(string)
+	out.SessionID, _ = claims["sid"].(string)
+	if act, ok := claims["act"].(map[string]interface{}); ok {
+		actor, err := parseActorClaim(act)
+		if err != nil {
+			return err
+		}
+		out.Actor = actor
+	}
+	return nil
+}
+
+// actorClaimMap converts a delegation chain into the nested act claim.
+func actorClaimMap(actor *domain.ActorClaim) map[string]interface{} {
+	act := map[string]interface{}{"sub": actor.Subject}
+	if actor.Actor != nil {
+		act["act"] = actorClaimMap(actor.Actor)
+	}
+	return act
+}
+
+// parseActorClaim converts a nested act claim into a delegation chain.
+func parseActorClaim(act map[string]interface{}) (*domain.ActorClaim, error) {
+	sub, ok := act["sub"].(string)
+	if !ok || sub == "" {
+		return nil, errors.New("invalid actor claim in token")
+	}
+	actor := &domain.ActorClaim{Subject: sub}
+	if prior, ok := act["act"].(map[string]interface{}); ok {
+		priorActor, err := parseActorClaim(prior)
+		if err != nil {
+			return nil, err
+		}
+		actor.Actor = priorActor
+	}
+	return actor, nil
+}
@@ -0,0 +1,238 @@
+package tokens
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"math/big"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/sukryu/IV-auth-services/internal/config"
+	"github.com/sukryu/IV-auth-services/internal/core/domain"
+	"github.com/sukryu/IV-auth-services/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// dpopAlgorithms lists the asymmetric algorithms accepted for DPoP proofs.
+var dpopAlgorithms = []string{"ES256", "ES384", "RS256", "PS256", "EdDSA"}
+
+// maxDPoPJTILength bounds the proof jti to the width of dpop_proof_replays.jti.
+const maxDPoPJTILength = 255
+
+// DPoPProofVerifier implements domain.DPoPVerifier for DPoP proof JWTs (RFC 9449).
+type DPoPProofVerifier struct {
+	maxAge    time.Duration // 증명 발급 후 허용되는 최대 경과 시간
+	clockSkew time.Duration
+	logger    *logger.Logger
+}
+
+// NewDPoPProofVerifier creates a new DPoPProofVerifier instance.
+func NewDPoPProofVerifier(cfg *config.Config, log *logger.Logger) (domain.DPoPVerifier, error) {
+	if cfg.DPoP.ProofMaxAge <= 0 {
+		return nil, errors.New("dpop proof max age must be positive")
+	}
+	return &DPoPProofVerifier{
+		maxAge:    cfg.DPoP.ProofMaxAge,
+		clockSkew: cfg.JWT.ClockSkew,
+		logger:    log.With(zap.String("component", "dpop_proof_verifier")),
+	}, nil
+}
+
+// Verify checks the proof signature against its embedded JWK, its freshness and its binding
+// to the request method, URI and (when given) access token.
+func (v *DPoPProofVerifier) Verify(proof, method, uri, accessToken string) (*domain.DPoPProof, error) {
+	if proof == "" {
+		return nil, errors.New("proof must not be empty")
+	}
+
+	var thumbprint string
+	token, err := jwt.Parse(proof, func(token *jwt.Token) (interface{}, error) {
+		if typ, _ := token.Header["typ"].(string); typ != "dpop+jwt" {
+			return nil, errors.New("unexpected proof type")
+		}
+		jwk, ok := token.Header["jwk"].(map[string]interface{})
+		if !ok {
+			return nil, errors.New("proof header has no jwk")
+		}
+		key, jkt, err := parsePublicJWK(jwk)
+		if err != nil {
+			return nil, err
+		}
+		thumbprint = jkt
+		return key, nil
+	}, jwt.WithValidMethods(dpopAlgorithms))
+	if err != nil {
+		v.logger.Debug("Failed to parse dpop proof", zap.Error(err))
+		return nil, errors.New("invalid proof: " + err.Error())
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return nil, errors.New("proof is not valid")
+	}
+
+	jti, _ := claims["jti"].(string)
+	if jti == "" {
+		return nil, errors.New("proof jti must not be empty")
+	}
+	// 재사용 기록 컬럼보다 긴 jti는 저장 단계에서 실패하므로 미리 거부
+	if len(jti) > maxDPoPJTILength {
+		return nil, errors.New("proof jti is too long")
+	}
+	if htm, _ := claims["htm"].(string); htm != method {
+		return nil, errors.New("proof htm does not match request method")
+	}
+	htu, _ := claims["htu"].(string)
+	if !sameHTU(htu, uri) {
+		return nil, errors.New("proof htu does not match request uri")
+	}
+
+	// 발급 시각 검증: 너무 오래되었거나 미래에 발급된 증명 거부
+	iat, err := claims.GetIssuedAt()
+	if err != nil || iat == nil {
+		return nil, errors.New("proof iat is required")
+	}
+	now := time.Now()
+	if iat.After(now.Add(v.clockSkew)) {
+		return nil, errors.New("proof issued in the future")
+	}
+	expiresAt := iat.Add(v.maxAge + v.clockSkew)
+	if now.After(expiresAt) {
+		return nil, errors.New("proof is too old")
+	}
+
+	if accessToken != "" {
+		sum := sha256.Sum256([]byte(accessToken))
+		if ath, _ := claims["ath"].(string); ath != base64.RawURLEncoding.EncodeToString(sum[:]) {
+			return nil, errors.New("proof ath does not match access token")
+		}
+	}
+
+	return domain.NewDPoPProof(thumbprint, jti, iat.Time, expiresAt)
+}
+
+// parsePublicJWK converts a public JWK into a crypto key and computes its RFC 7638 thumbprint.
+func parsePublicJWK(jwk map[string]interface{}) (crypto.PublicKey, string, error) {
+	if _, ok := jwk["d"]; ok {
+		return nil, "", errors.New("jwk must not contain a private key")
+	}
+
+	member := func(name string) (string, error) {
+		value, ok := jwk[name].(string)
+		if !ok || value == "" {
+			return "", errors.New("jwk member " + name + " is missing")
+		}
+		return value, nil
+	}
+	decode := func(name string) ([]byte, string, error) {
+		value, err := member(name)
+		if err != nil {
+			return nil, "", err
+		}
+		raw, err := base64.RawURLEncoding.DecodeString(value)
+		if err != nil {
+			return nil, "", errors.New("jwk member " + name + " is not base64url")
+		}
+		return raw, value, nil
+	}
+
+	kty, err := member("kty")
+	if err != nil {
+		return nil, "", err
+	}
+
+	// 썸프린트는 필수 멤버만 사전순으로 직렬화 (RFC 7638 3.2)
+	switch kty {
+	case "EC":
+		crv, err := member("crv")
+		if err != nil {
+			return nil, "", err
+		}
+		var curve elliptic.Curve
+		switch crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		default:
+			return nil, "", errors.New("unsupported curve: " + crv)
+		}
+		x, xs, err := decode("x")
+		if err != nil {
+			return nil, "", err
+		}
+		y, ys, err := decode("y")
+		if err != nil {
+			return nil, "", err
+		}
+		key := &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		if !curve.IsOnCurve(key.X, key.Y) {
+			return nil, "", errors.New("jwk point is not on curve")
+		}
+		return key, thumbprint(`{"crv":"` + crv + `","kty":"EC","x":"` + xs + `","y":"` + ys + `"}`), nil
+	case "RSA":
+		n, ns, err := decode("n")
+		if err != nil {
+			return nil, "", err
+		}
+		e, es, err := decode("e")
+		if err != nil {
+			return nil, "", err
+		}
+		if len(e) > 4 {
+			return nil, "", errors.New("rsa exponent too large")
+		}
+		key := &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+		if key.N.BitLen() < 2048 {
+			return nil, "", errors.New("rsa key too small")
+		}
+		return key, thumbprint(`{"e":"` + es + `","kty":"RSA","n":"` + ns + `"}`), nil
+	case "OKP":
+		crv, err := member("crv")
+		if err != nil {
+			return nil, "", err
+		}
+		if crv != "Ed25519" {
+			return nil, "", errors.New("unsupported curve: " + crv)
+		}
+		x, xs, err := decode("x")
+		if err != nil {
+			return nil, "", err
+		}
+		if len(x) != ed25519.PublicKeySize {
+			return nil, "", errors.New("invalid ed25519 key length")
+		}
+		return ed25519.PublicKey(x), thumbprint(`{"crv":"Ed25519","kty":"OKP","x":"` + xs + `"}`), nil
+	default:
+		return nil, "", errors.New("unsupported key type: " + kty)
+	}
+}
+
+// thumbprint returns the base64url SHA-256 digest of a canonical JWK.
+func thumbprint(canonical string) string {
+	sum := sha256.Sum256([]byte(canonical))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// sameHTU compares the htu claim with the request URI, ignoring query and fragment (RFC 9449 4.3).
+func sameHTU(htu, uri string) bool {
+	a, err := url.Parse(htu)
+	if err != nil || htu == "" {
+		return false
+	}
+	b, err := url.Parse(uri)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(a.Scheme, b.Scheme) &&
+		strings.EqualFold(a.Host, b.Host) &&
+		a.EscapedPath() == b.EscapedPath()
+}
@@ -0,0 +1,85 @@
+package tokens
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/sukryu/IV-auth-services/internal/config"
+	"github.com/sukryu/IV-auth-services/internal/core/domain"
+	"github.com/sukryu/IV-auth-services/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// Access and refresh token formats selectable with token.format.
+const (
+	FormatJWT    = "jwt"
+	FormatPASETO = "paseto"
+)
+
+// NewTokenGenerator returns the access and refresh token generator selected by cfg.Token.Format.
+// With PASETO and token.accept_jwt enabled, JWTs issued before the switch stay valid until they expire.
+// ID tokens are always JWTs (OpenID Connect Core 2), so jwtGen is required in both cases.
+func NewTokenGenerator(cfg *config.Config, log *logger.Logger, jwtGen *JWTTokenGenerator) (domain.TokenGenerator, error) {
+	switch cfg.Token.Format {
+	case FormatJWT, "":
+		return jwtGen, nil
+	case FormatPASETO:
+		pasetoGen, err := NewPASETOTokenGenerator(cfg, log)
+		if err != nil {
+			return nil, err
+		}
+		if !cfg.Token.AcceptJWT {
+			return pasetoGen, nil
+		}
+		log.Info("Accepting JWTs alongside PASETO tokens during migration")
+		return NewDualFormatTokenGenerator(pasetoGen, jwtGen, log), nil
+	default:
+		return nil, errors.New("unsupported token format: " + cfg.Token.Format)
+	}
+}
+
+// DualFormatTokenGenerator issues PASETO tokens and validates both PASETO tokens and JWTs,
+// choosing the verifier by the token's header.
+type DualFormatTokenGenerator struct {
+	paseto *PASETOTokenGenerator
+	jwt    *JWTTokenGenerator
+	logger *logger.Logger
+}
+
+// NewDualFormatTokenGenerator creates a new DualFormatTokenGenerator instance.
+func NewDualFormatTokenGenerator(pasetoGen *PASETOTokenGenerator, jwtGen *JWTTokenGenerator, log *logger.Logger) *DualFormatTokenGenerator {
+	return &DualFormatTokenGenerator{
+		paseto: pasetoGen,
+		jwt:    jwtGen,
+		logger: log.With(zap.String("component", "dual_format_token_generator")),
+	}
+}
+
+// GenerateAccessToken generates a PASETO access token.
+func (g *DualFormatTokenGenerator) GenerateAccessToken(userID string, expiry time.Time, extra domain.TokenClaims) (string, error) {
+	return g.paseto.GenerateAccessToken(userID, expiry, extra)
+}
+
+// GenerateRefreshToken generates a PASETO refresh token.
+func (g *DualFormatTokenGenerator) GenerateRefreshToken(userID string, expiry time.Time, extra domain.TokenClaims) (string, error) {
+	return g.paseto.GenerateRefreshToken(userID, expiry, extra)
+}
+
+// ValidateToken verifies a token of either format and returns the user ID if valid.
+func (g *DualFormatTokenGenerator) ValidateToken(tokenStr string) (string, error) {
+	claims, err := g.ParseToken(tokenStr)
+	if err != nil {
+		return "", err
+	}
+	return claims.Subject, nil
+}
+
+// ParseToken verifies a token of either format and returns its claims.
+func (g *DualFormatTokenGenerator) ParseToken(tokenStr string) (*domain.TokenClaims, error) {
+	if strings.HasPrefix(tokenStr, pasetoV4PublicHeader) {
+		return g.paseto.ParseToken(tokenStr)
+	}
+	g.logger.Debug("Validating legacy JWT")
+	return g.jwt.ParseToken(tokenStr)
+}
@@ -0,0 +1,95 @@
+package tokens
+
+import (
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/sukryu/IV-auth-services/internal/core/domain"
+	"go.uber.org/zap"
+)
+
+// GenerateIDToken signs an OpenID Connect ID token (OpenID Connect Core 2, 3.1.3.6).
+func (g *JWTTokenGenerator) GenerateIDToken(idClaims domain.IDTokenClaims) (string, error) {
+	if idClaims.Subject == "" || idClaims.Audience == "" {
+		return "", errors.New("subject and audience must not be empty")
+	}
+	if g.issuer == "" {
+		return "", errors.New("issuer is not configured")
+	}
+
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"iss": g.issuer,
+		"sub": idClaims.Subject,
+		"aud": idClaims.Audience,
+		"exp": idClaims.ExpiresAt.Unix(),
+		"iat": now.Unix(),
+	}
+	if idClaims.Nonce != "" {
+		claims["nonce"] = idClaims.Nonce
+	}
+	if !idClaims.AuthTime.IsZero() {
+		claims["auth_time"] = idClaims.AuthTime.Unix()
+	}
+	if len(idClaims.AMR) > 0 {
+		claims["amr"] = idClaims.AMR
+	}
+	if idClaims.ACR != "" {
+		claims["acr"] = idClaims.ACR
+	}
+	if idClaims.AccessToken != "" {
+		claims["at_hash"] = accessTokenHash(idClaims.AccessToken)
+	}
+
+	tokenString, err := g.signClaims(claims)
+	if err != nil {
+		g.logger.Error("Failed to sign id token", zap.Error(err), zap.String("user_id", idClaims.Subject))
+		return "", errors.New("failed to generate id token: " + err.Error())
+	}
+	return tokenString, nil
+}
+
+// JWKS returns the public signing key as a JSON Web Key Set.
+func (g *JWTTokenGenerator) JWKS() ([]byte, error) {
+	return json.Marshal(map[string]interface{}{
+		"keys": []map[string]string{rsaPublicJWK(g.publicKey, g.keyID)},
+	})
+}
+
+// accessTokenHash computes at_hash: the left half of the SHA-256 digest of the access token (RS256).
+func accessTokenHash(accessToken string) string {
+	sum := sha256.Sum256([]byte(accessToken))
+	return base64.RawURLEncoding.EncodeToString(sum[:len(sum)/2])
+}
+
+// rsaPublicJWK converts an RSA public key into its JWK representation (RFC 7518 6.3.1).
+func rsaPublicJWK(key *rsa.PublicKey, kid string) map[string]string {
+	n, e := rsaJWKMembers(key)
+	return map[string]string{
+		"kty": "RSA",
+		"use": "sig",
+		"alg": jwt.SigningMethodRS256.Alg(),
+		"kid": kid,
+		"n":   n,
+		"e":   e,
+	}
+}
+
+// rsaThumbprint computes the RFC 7638 thumbprint of an RSA public key, used as its kid.
+func rsaThumbprint(key *rsa.PublicKey) string {
+	n, e := rsaJWKMembers(key)
+	return thumbprint(`{"e":"` + e + `","kty":"RSA","n":"` + n + `"}`)
+}
+
+// rsaJWKMembers returns the base64url-encoded modulus and exponent of an RSA public key.
+func rsaJWKMembers(key *rsa.PublicKey) (string, string) {
+	n := base64.RawURLEncoding.EncodeToString(key.N.Bytes())
+	e := base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes())
+	return n, e
+}
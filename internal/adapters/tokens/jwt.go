@@ -1,9 +1,10 @@
 package tokens
 
 import (
+	"context"
 	"crypto/rsa"
+	"encoding/base64"
 	"errors"
-	"os"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -14,61 +15,47 @@ import (
 	"go.uber.org/zap"
 )
 
-// JWTTokenGenerator implements domain.TokenGenerator for JWT with RSA256.
+// signTimeout bounds how long token issuance waits for a remote signer.
+const signTimeout = 5 * time.Second
+
+// JWTTokenGenerator implements domain.TokenGenerator and domain.IDTokenGenerator for JWT with RSA256.
+// Signing is delegated to a Signer so the private key may live outside the service.
 type JWTTokenGenerator struct {
-	privateKey *rsa.PrivateKey
-	publicKey  *rsa.PublicKey
-	logger     *logger.Logger
+	signer    Signer
+	publicKey *rsa.PublicKey
+	keyID     string // 공개 키의 RFC 7638 thumbprint, JWKS 조회용 kid 헤더
+	issuer    string
+	clockSkew time.Duration // 노드 간 시계 오차 허용 범위
+	logger    *logger.Logger
 }
 
-// NewJWTTokenGenerator creates a new JWTTokenGenerator instance using RSA keys.
-func NewJWTTokenGenerator(cfg *config.Config, log *logger.Logger) (domain.TokenGenerator, error) {
-	// 개인 키 로드
-	privateKeyData, err := os.ReadFile(cfg.JWT.PrivateKeyPath)
-	if err != nil {
-		log.Error("Failed to read private key file", zap.Error(err))
-		return nil, errors.New("failed to read private key: " + err.Error())
-	}
-	privateKey, err := jwt.ParseRSAPrivateKeyFromPEM(privateKeyData)
-	if err != nil {
-		log.Error("Failed to parse private key", zap.Error(err))
-		return nil, errors.New("failed to parse private key: " + err.Error())
-	}
-
-	// 공개 키 로드
-	publicKeyData, err := os.ReadFile(cfg.JWT.PublicKeyPath)
-	if err != nil {
-		log.Error("Failed to read public key file", zap.Error(err))
-		return nil, errors.New("failed to read public key: " + err.Error())
+// NewJWTTokenGenerator creates a new JWTTokenGenerator instance that signs with signer.
+func NewJWTTokenGenerator(cfg *config.Config, log *logger.Logger, signer Signer) (*JWTTokenGenerator, error) {
+	if signer == nil {
+		return nil, errors.New("signer must not be nil")
 	}
-	publicKey, err := jwt.ParseRSAPublicKeyFromPEM(publicKeyData)
-	if err != nil {
-		log.Error("Failed to parse public key", zap.Error(err))
-		return nil, errors.New("failed to parse public key: " + err.Error())
+	if cfg.JWT.ClockSkew < 0 {
+		return nil, errors.New("jwt clock skew must not be negative")
 	}
 
+	publicKey := signer.Public()
 	return &JWTTokenGenerator{
-		privateKey: privateKey,
-		publicKey:  publicKey,
-		logger:     log.With(zap.String("component", "jwt_token_generator")),
+		signer:    signer,
+		publicKey: publicKey,
+		keyID:     rsaThumbprint(publicKey),
+		issuer:    cfg.OIDC.Issuer,
+		clockSkew: cfg.JWT.ClockSkew,
+		logger:    log.With(zap.String("component", "jwt_token_generator")),
 	}, nil
 }
 
 // GenerateAccessToken generates an access token for the given user with expiry.
-func (g *JWTTokenGenerator) GenerateAccessToken(userID string, expiry time.Time) (string, error) {
+func (g *JWTTokenGenerator) GenerateAccessToken(userID string, expiry time.Time, extra domain.TokenClaims) (string, error) {
 	if userID == "" {
 		return "", errors.New("user id must not be empty")
 	}
 
-	claims := jwt.MapClaims{
-		"sub": userID,
-		"exp": expiry.Unix(),
-		"iat": time.Now().Unix(),
-		"typ": "access",
-	}
-
-	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
-	tokenString, err := token.SignedString(g.privateKey)
+	tokenString, err := g.sign(userID, domain.TokenTypeAccess, expiry, extra)
 	if err != nil {
 		g.logger.Error("Failed to sign access token", zap.Error(err), zap.String("user_id", userID))
 		return "", errors.New("failed to generate access token: " + err.Error())
@@ -79,20 +66,12 @@ func (g *JWTTokenGenerator) GenerateAccessToken(userID string, expiry time.Time)
 }
 
 // GenerateRefreshToken generates a refresh token for the given user with expiry.
-func (g *JWTTokenGenerator) GenerateRefreshToken(userID string, expiry time.Time) (string, error) {
+func (g *JWTTokenGenerator) GenerateRefreshToken(userID string, expiry time.Time, extra domain.TokenClaims) (string, error) {
 	if userID == "" {
 		return "", errors.New("user id must not be empty")
 	}
 
-	claims := jwt.MapClaims{
-		"sub": userID,
-		"exp": expiry.Unix(),
-		"iat": time.Now().Unix(),
-		"typ": "refresh",
-	}
-
-	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
-	tokenString, err := token.SignedString(g.privateKey)
+	tokenString, err := g.sign(userID, domain.TokenTypeRefresh, expiry, extra)
 	if err != nil {
 		g.logger.Error("Failed to sign refresh token", zap.Error(err), zap.String("user_id", userID))
 		return "", errors.New("failed to generate refresh token: " + err.Error())
@@ -104,29 +83,88 @@ func (g *JWTTokenGenerator) GenerateRefreshToken(userID string, expiry time.Time
 
 // ValidateToken verifies the token and returns the user ID if valid.
 func (g *JWTTokenGenerator) ValidateToken(tokenStr string) (string, error) {
+	claims, err := g.ParseToken(tokenStr)
+	if err != nil {
+		return "", err
+	}
+	return claims.Subject, nil
+}
+
+// ParseToken verifies the token and returns its claims.
+// exp, nbf, iat are checked with the configured clock skew; a token whose iat lies
+// further in the future than the skew is rejected.
+func (g *JWTTokenGenerator) ParseToken(tokenStr string) (*domain.TokenClaims, error) {
 	if tokenStr == "" {
-		return "", errors.New("token must not be empty")
+		return nil, errors.New("token must not be empty")
 	}
 
 	token, err := jwt.Parse(tokenStr, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
-			return nil, errors.New("unexpected signing method: " + token.Header["alg"].(string))
-		}
 		return g.publicKey, nil
-	})
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg()}),
+		jwt.WithLeeway(g.clockSkew),
+		jwt.WithIssuedAt(),
+		jwt.WithExpirationRequired(),
+	)
 	if err != nil {
 		g.logger.Error("Failed to parse token", zap.Error(err))
-		return "", errors.New("invalid token: " + err.Error())
+		return nil, errors.New("invalid token: " + err.Error())
+	}
+
+	mapClaims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return nil, errors.New("token is not valid")
+	}
+
+	userID, ok := mapClaims["sub"].(string)
+	if !ok || userID == "" {
+		return nil, errors.New("invalid user id in token claims")
+	}
+
+	claims := &domain.TokenClaims{Subject: userID}
+	if exp, err := mapClaims.GetExpirationTime(); err == nil && exp != nil {
+		claims.ExpiresAt = exp.Time
+	}
+	if iat, err := mapClaims.GetIssuedAt(); err == nil && iat != nil {
+		claims.IssuedAt = iat.Time
+	}
+	if err := parseOptionalClaims(mapClaims, claims); err != nil {
+		return nil, err
+	}
+
+	g.logger.Debug("Token validated successfully", zap.String("user_id", userID))
+	return claims, nil
+}
+
+// sign builds the registered claims plus the optional claims in extra and signs them with RS256.
+func (g *JWTTokenGenerator) sign(userID, typ string, expiry time.Time, extra domain.TokenClaims) (string, error) {
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"sub": userID,
+		"exp": expiry.Unix(),
+		"iat": now.Unix(),
+		"nbf": now.Unix(),
+		"typ": typ,
 	}
+	setOptionalClaims(claims, extra)
 
-	if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
-		userID, ok := claims["sub"].(string)
-		if !ok || userID == "" {
-			return "", errors.New("invalid user id in token claims")
-		}
-		g.logger.Debug("Token validated successfully", zap.String("user_id", userID))
-		return userID, nil
+	return g.signClaims(claims)
+}
+
+// signClaims signs the claims with RS256 through the signer and sets the kid header.
+func (g *JWTTokenGenerator) signClaims(claims jwt.MapClaims) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = g.keyID
+	signingString, err := token.SigningString()
+	if err != nil {
+		return "", err
 	}
 
-	return "", errors.New("token is not valid")
+	ctx, cancel := context.WithTimeout(context.Background(), signTimeout)
+	defer cancel()
+	sig, err := g.signer.Sign(ctx, []byte(signingString))
+	if err != nil {
+		return "", err
+	}
+	return signingString + "." + base64.RawURLEncoding.EncodeToString(sig), nil
 }
@@ -0,0 +1,289 @@
+package tokens
+
+import (
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/sukryu/IV-auth-services/internal/config"
+	"github.com/sukryu/IV-auth-services/internal/core/domain"
+	"github.com/sukryu/IV-auth-services/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// pasetoV4PublicHeader prefixes every v4.public token. The version fixes the algorithm to
+// Ed25519, so there is no alg header an attacker could switch.
+const pasetoV4PublicHeader = "v4.public."
+
+// PASETOTokenGenerator implements domain.TokenGenerator with PASETO v4.public tokens.
+// Claims match JWTTokenGenerator; registered times are RFC 3339 strings as PASETO requires.
+type PASETOTokenGenerator struct {
+	privateKey ed25519.PrivateKey
+	publicKey  ed25519.PublicKey
+	keyID      string // 공개 키의 RFC 7638 thumbprint, footer의 kid
+	clockSkew  time.Duration
+	logger     *logger.Logger
+}
+
+// pasetoFooter is the unencrypted, authenticated footer of a token.
+type pasetoFooter struct {
+	KeyID string `json:"kid"`
+}
+
+// NewPASETOTokenGenerator creates a new PASETOTokenGenerator instance using Ed25519 keys.
+// The keys are read from PEM files, so the generator refuses to start when signer.type asks
+// for keys to stay in an external key manager; the Signer only produces RS256 signatures.
+func NewPASETOTokenGenerator(cfg *config.Config, log *logger.Logger) (*PASETOTokenGenerator, error) {
+	if cfg.Signer.Type != SignerTypeLocal && cfg.Signer.Type != "" {
+		return nil, errors.New("paseto tokens require signer type " + SignerTypeLocal + ", got " + cfg.Signer.Type)
+	}
+	privateKey, err := loadEd25519PrivateKey(cfg.PASETO.PrivateKeyPath)
+	if err != nil {
+		log.Error("Failed to load PASETO private key", zap.Error(err))
+		return nil, err
+	}
+	publicKey, err := loadEd25519PublicKey(cfg.PASETO.PublicKeyPath)
+	if err != nil {
+		log.Error("Failed to load PASETO public key", zap.Error(err))
+		return nil, err
+	}
+	if !publicKey.Equal(privateKey.Public()) {
+		return nil, errors.New("paseto public key does not match private key")
+	}
+
+	if cfg.JWT.ClockSkew < 0 {
+		return nil, errors.New("jwt clock skew must not be negative")
+	}
+
+	return &PASETOTokenGenerator{
+		privateKey: privateKey,
+		publicKey:  publicKey,
+		keyID:      ed25519Thumbprint(publicKey),
+		clockSkew:  cfg.JWT.ClockSkew,
+		logger:     log.With(zap.String("component", "paseto_token_generator")),
+	}, nil
+}
+
+// GenerateAccessToken generates an access token for the given user with expiry.
+func (g *PASETOTokenGenerator) GenerateAccessToken(userID string, expiry time.Time, extra domain.TokenClaims) (string, error) {
+	if userID == "" {
+		return "", errors.New("user id must not be empty")
+	}
+
+	tokenString, err := g.sign(userID, domain.TokenTypeAccess, expiry, extra)
+	if err != nil {
+		g.logger.Error("Failed to sign access token", zap.Error(err), zap.String("user_id", userID))
+		return "", errors.New("failed to generate access token: " + err.Error())
+	}
+
+	g.logger.Debug("Access token generated successfully", zap.String("user_id", userID))
+	return tokenString, nil
+}
+
+// GenerateRefreshToken generates a refresh token for the given user with expiry.
+func (g *PASETOTokenGenerator) GenerateRefreshToken(userID string, expiry time.Time, extra domain.TokenClaims) (string, error) {
+	if userID == "" {
+		return "", errors.New("user id must not be empty")
+	}
+
+	tokenString, err := g.sign(userID, domain.TokenTypeRefresh, expiry, extra)
+	if err != nil {
+		g.logger.Error("Failed to sign refresh token", zap.Error(err), zap.String("user_id", userID))
+		return "", errors.New("failed to generate refresh token: " + err.Error())
+	}
+
+	g.logger.Debug("Refresh token generated successfully", zap.String("user_id", userID))
+	return tokenString, nil
+}
+
+// ValidateToken verifies the token and returns the user ID if valid.
+func (g *PASETOTokenGenerator) ValidateToken(tokenStr string) (string, error) {
+	claims, err := g.ParseToken(tokenStr)
+	if err != nil {
+		return "", err
+	}
+	return claims.Subject, nil
+}
+
+// ParseToken verifies the token and returns its claims, applying the same exp, nbf and iat
+// checks with clock skew as JWTTokenGenerator.
+func (g *PASETOTokenGenerator) ParseToken(tokenStr string) (*domain.TokenClaims, error) {
+	if tokenStr == "" {
+		return nil, errors.New("token must not be empty")
+	}
+
+	payload, err := g.verify(tokenStr)
+	if err != nil {
+		g.logger.Error("Failed to parse token", zap.Error(err))
+		return nil, errors.New("invalid token: " + err.Error())
+	}
+
+	var raw map[string]interface{}
+	if err := json.Unmarshal(payload, &raw); err != nil {
+		return nil, errors.New("invalid token: malformed claims")
+	}
+
+	userID, ok := raw["sub"].(string)
+	if !ok || userID == "" {
+		return nil, errors.New("invalid user id in token claims")
+	}
+
+	now := time.Now()
+	exp, err := pasetoTime(raw, "exp")
+	if err != nil || exp.IsZero() {
+		return nil, errors.New("invalid token: token has no valid expiration")
+	}
+	if !now.Before(exp.Add(g.clockSkew)) {
+		return nil, errors.New("invalid token: token is expired")
+	}
+	nbf, err := pasetoTime(raw, "nbf")
+	if err != nil || now.Add(g.clockSkew).Before(nbf) {
+		return nil, errors.New("invalid token: token is not valid yet")
+	}
+	iat, err := pasetoTime(raw, "iat")
+	if err != nil || now.Add(g.clockSkew).Before(iat) {
+		return nil, errors.New("invalid token: token used before issued")
+	}
+
+	claims := &domain.TokenClaims{Subject: userID, ExpiresAt: exp, IssuedAt: iat}
+	if err := parseOptionalClaims(raw, claims); err != nil {
+		return nil, err
+	}
+
+	g.logger.Debug("Token validated successfully", zap.String("user_id", userID))
+	return claims, nil
+}
+
+// sign builds the registered claims plus the optional claims in extra and signs them.
+func (g *PASETOTokenGenerator) sign(userID, typ string, expiry time.Time, extra domain.TokenClaims) (string, error) {
+	now := time.Now().UTC()
+	claims := map[string]interface{}{
+		"sub": userID,
+		"exp": expiry.UTC().Format(time.RFC3339),
+		"iat": now.Format(time.RFC3339),
+		"nbf": now.Format(time.RFC3339),
+		"typ": typ,
+	}
+	setOptionalClaims(claims, extra)
+
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	footer, err := json.Marshal(pasetoFooter{KeyID: g.keyID})
+	if err != nil {
+		return "", err
+	}
+
+	// PASETO v4.public 서명 (implicit assertion 미사용)
+	sig := ed25519.Sign(g.privateKey, pasetoPAE([]byte(pasetoV4PublicHeader), payload, footer, nil))
+	return pasetoV4PublicHeader +
+		base64.RawURLEncoding.EncodeToString(append(payload, sig...)) + "." +
+		base64.RawURLEncoding.EncodeToString(footer), nil
+}
+
+// verify checks the token's header, footer key ID and signature and returns the payload.
+func (g *PASETOTokenGenerator) verify(tokenStr string) ([]byte, error) {
+	if !strings.HasPrefix(tokenStr, pasetoV4PublicHeader) {
+		return nil, errors.New("not a v4.public token")
+	}
+	body, encodedFooter, _ := strings.Cut(strings.TrimPrefix(tokenStr, pasetoV4PublicHeader), ".")
+
+	signed, err := base64.RawURLEncoding.DecodeString(body)
+	if err != nil || len(signed) <= ed25519.SignatureSize {
+		return nil, errors.New("malformed token body")
+	}
+	footer, err := base64.RawURLEncoding.DecodeString(encodedFooter)
+	if err != nil {
+		return nil, errors.New("malformed token footer")
+	}
+	var f pasetoFooter
+	if err := json.Unmarshal(footer, &f); err != nil || f.KeyID != g.keyID {
+		return nil, errors.New("unknown signing key")
+	}
+
+	payload := signed[:len(signed)-ed25519.SignatureSize]
+	sig := signed[len(signed)-ed25519.SignatureSize:]
+	if !ed25519.Verify(g.publicKey, pasetoPAE([]byte(pasetoV4PublicHeader), payload, footer, nil), sig) {
+		return nil, errors.New("signature is invalid")
+	}
+	return payload, nil
+}
+
+// pasetoPAE is the pre-authentication encoding of the pieces covered by the signature.
+func pasetoPAE(pieces ...[]byte) []byte {
+	out := binary.LittleEndian.AppendUint64(nil, uint64(len(pieces)))
+	for _, p := range pieces {
+		out = binary.LittleEndian.AppendUint64(out, uint64(len(p)))
+		out = append(out, p...)
+	}
+	return out
+}
+
+// pasetoTime reads an RFC 3339 time claim; a missing claim yields the zero time.
+func pasetoTime(claims map[string]interface{}, name string) (time.Time, error) {
+	v, ok := claims[name]
+	if !ok {
+		return time.Time{}, nil
+	}
+	s, ok := v.(string)
+	if !ok {
+		return time.Time{}, errors.New("invalid " + name + " claim")
+	}
+	return time.Parse(time.RFC3339, s)
+}
+
+// ed25519Thumbprint computes the RFC 7638 thumbprint of an Ed25519 public key (RFC 8037 2).
+func ed25519Thumbprint(key ed25519.PublicKey) string {
+	x := base64.RawURLEncoding.EncodeToString(key)
+	return thumbprint(`{"crv":"Ed25519","kty":"OKP","x":"` + x + `"}`)
+}
+
+// loadEd25519PrivateKey reads a PKCS #8 PEM-encoded Ed25519 private key.
+func loadEd25519PrivateKey(path string) (ed25519.PrivateKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.New("failed to read private key: " + err.Error())
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("failed to parse private key: no PEM block found")
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, errors.New("failed to parse private key: " + err.Error())
+	}
+	privateKey, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, errors.New("failed to parse private key: not an Ed25519 key")
+	}
+	return privateKey, nil
+}
+
+// loadEd25519PublicKey reads a PKIX PEM-encoded Ed25519 public key.
+func loadEd25519PublicKey(path string) (ed25519.PublicKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.New("failed to read public key: " + err.Error())
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("failed to parse public key: no PEM block found")
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, errors.New("failed to parse public key: " + err.Error())
+	}
+	publicKey, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return nil, errors.New("failed to parse public key: not an Ed25519 key")
+	}
+	return publicKey, nil
+}
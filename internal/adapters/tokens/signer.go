@@ -0,0 +1,71 @@
+package tokens
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"errors"
+	"os"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/sukryu/IV-auth-services/internal/config"
+	"github.com/sukryu/IV-auth-services/pkg/logger"
+)
+
+// Signer types selectable with signer.type.
+const (
+	SignerTypeLocal   = "local"
+	SignerTypeTransit = "transit"
+)
+
+// Signer produces RS256 (RSASSA-PKCS1-v1_5 with SHA-256) signatures for the token generator,
+// so the private key can stay in an external key manager instead of on disk.
+type Signer interface {
+	// Public returns the public key that verifies the signatures.
+	Public() *rsa.PublicKey
+	// Sign signs the SHA-256 digest of message.
+	Sign(ctx context.Context, message []byte) ([]byte, error)
+}
+
+// NewSigner returns the signer selected by cfg.Signer.Type.
+func NewSigner(cfg *config.Config, log *logger.Logger) (Signer, error) {
+	switch cfg.Signer.Type {
+	case SignerTypeLocal, "":
+		return NewLocalSigner(cfg.JWT.PrivateKeyPath)
+	case SignerTypeTransit:
+		return NewTransitSigner(cfg, log)
+	default:
+		return nil, errors.New("unsupported signer type: " + cfg.Signer.Type)
+	}
+}
+
+// LocalSigner signs with an RSA private key loaded from a PEM file.
+type LocalSigner struct {
+	privateKey *rsa.PrivateKey
+}
+
+// NewLocalSigner creates a new LocalSigner from a PEM-encoded RSA private key file.
+func NewLocalSigner(privateKeyPath string) (*LocalSigner, error) {
+	privateKeyData, err := os.ReadFile(privateKeyPath)
+	if err != nil {
+		return nil, errors.New("failed to read private key: " + err.Error())
+	}
+	privateKey, err := jwt.ParseRSAPrivateKeyFromPEM(privateKeyData)
+	if err != nil {
+		return nil, errors.New("failed to parse private key: " + err.Error())
+	}
+	return &LocalSigner{privateKey: privateKey}, nil
+}
+
+// Public returns the RSA public key.
+func (s *LocalSigner) Public() *rsa.PublicKey {
+	return &s.privateKey.PublicKey
+}
+
+// Sign signs the SHA-256 digest of message with the local private key.
+func (s *LocalSigner) Sign(_ context.Context, message []byte) ([]byte, error) {
+	digest := sha256.Sum256(message)
+	return rsa.SignPKCS1v15(rand.Reader, s.privateKey, crypto.SHA256, digest[:])
+}
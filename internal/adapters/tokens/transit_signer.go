@@ -0,0 +1,293 @@
+package tokens
+
+import (
+	"bytes"
+	"context"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sukryu/IV-auth-services/internal/config"
+	"github.com/sukryu/IV-auth-services/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// TransitSigner signs with a key held by a Vault transit secrets engine (or a compatible
+// service). Concurrent Sign calls arriving within the batch window are sent as one
+// batch_input request, and the public key is fetched once and cached.
+type TransitSigner struct {
+	client       *http.Client
+	address      string
+	token        string
+	mount        string
+	keyName      string
+	batchWindow  time.Duration
+	maxBatchSize int
+
+	// 서명 키 버전을 고정하여 Vault에서 키가 회전되어도 kid와 서명이 일치하도록 함
+	publicKey  *rsa.PublicKey
+	keyVersion int
+
+	mu      sync.Mutex
+	current *signBatch // 아직 전송되지 않은 배치
+	logger  *logger.Logger
+}
+
+// signBatch collects the digests of concurrent Sign calls sent in one request.
+type signBatch struct {
+	digests    [][]byte
+	full       chan struct{} // maxBatchSize에 도달하면 닫힘
+	done       chan struct{} // 결과가 채워지면 닫힘
+	signatures [][]byte
+	errs       []error
+}
+
+// NewTransitSigner creates a new TransitSigner and fetches the public key of the configured key.
+func NewTransitSigner(cfg *config.Config, log *logger.Logger) (*TransitSigner, error) {
+	tc := cfg.Signer.Transit
+	if tc.Address == "" || tc.KeyName == "" {
+		return nil, errors.New("transit signer address and key name must not be empty")
+	}
+	if tc.MaxBatchSize <= 0 {
+		return nil, errors.New("transit signer max batch size must be positive")
+	}
+	if tc.BatchWindow < 0 {
+		return nil, errors.New("transit signer batch window must not be negative")
+	}
+
+	s := &TransitSigner{
+		client:       &http.Client{Timeout: tc.Timeout},
+		address:      strings.TrimRight(tc.Address, "/"),
+		token:        tc.Token,
+		mount:        strings.Trim(tc.Mount, "/"),
+		keyName:      tc.KeyName,
+		batchWindow:  tc.BatchWindow,
+		maxBatchSize: tc.MaxBatchSize,
+		logger:       log.With(zap.String("component", "transit_signer")),
+	}
+	if err := s.loadPublicKey(context.Background()); err != nil {
+		s.logger.Error("Failed to load transit public key", zap.Error(err), zap.String("key_name", tc.KeyName))
+		return nil, err
+	}
+	return s, nil
+}
+
+// Public returns the cached public key of the pinned key version.
+func (s *TransitSigner) Public() *rsa.PublicKey {
+	return s.publicKey
+}
+
+// Sign signs the SHA-256 digest of message. Only the digest leaves the service.
+func (s *TransitSigner) Sign(ctx context.Context, message []byte) ([]byte, error) {
+	digest := sha256.Sum256(message)
+
+	s.mu.Lock()
+	leader := s.current == nil
+	if leader {
+		s.current = &signBatch{full: make(chan struct{}), done: make(chan struct{})}
+	}
+	batch := s.current
+	index := len(batch.digests)
+	batch.digests = append(batch.digests, digest[:])
+	if len(batch.digests) >= s.maxBatchSize {
+		// 가득 찬 배치는 닫고 다음 호출은 새 배치를 시작
+		s.current = nil
+		close(batch.full)
+	}
+	s.mu.Unlock()
+
+	// 배치를 시작한 호출이 대기 후 전송할 고루틴을 띄움
+	if leader {
+		go s.flush(batch)
+	}
+
+	select {
+	case <-batch.done:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	if batch.errs[index] != nil {
+		return nil, batch.errs[index]
+	}
+	return batch.signatures[index], nil
+}
+
+// flush waits for the batch window or a full batch, then signs the batch in one request.
+// It uses its own context so a cancelled caller does not fail the other callers in the batch.
+func (s *TransitSigner) flush(batch *signBatch) {
+	timer := time.NewTimer(s.batchWindow)
+	select {
+	case <-timer.C:
+	case <-batch.full:
+		timer.Stop()
+	}
+
+	s.mu.Lock()
+	if s.current == batch {
+		s.current = nil
+	}
+	s.mu.Unlock()
+
+	batch.signatures = make([][]byte, len(batch.digests))
+	batch.errs = make([]error, len(batch.digests))
+	if err := s.signBatch(context.Background(), batch); err != nil {
+		s.logger.Error("Failed to sign batch", zap.Error(err), zap.Int("batch_size", len(batch.digests)))
+		for i := range batch.errs {
+			batch.errs[i] = err
+		}
+	}
+	close(batch.done)
+}
+
+// transitSignRequest is the body of POST /v1/{mount}/sign/{name}/sha2-256.
+type transitSignRequest struct {
+	BatchInput         []transitSignInput `json:"batch_input"`
+	KeyVersion         int                `json:"key_version"`
+	Prehashed          bool               `json:"prehashed"`
+	SignatureAlgorithm string             `json:"signature_algorithm"`
+}
+
+type transitSignInput struct {
+	Input string `json:"input"`
+}
+
+// transitSignResponse is the response of the sign endpoint.
+type transitSignResponse struct {
+	Data struct {
+		BatchResults []struct {
+			Signature string `json:"signature"`
+			Error     string `json:"error"`
+		} `json:"batch_results"`
+	} `json:"data"`
+}
+
+// signBatch sends the batch to the transit engine and fills in the per-digest results.
+func (s *TransitSigner) signBatch(ctx context.Context, batch *signBatch) error {
+	req := transitSignRequest{
+		KeyVersion:         s.keyVersion,
+		Prehashed:          true,
+		SignatureAlgorithm: "pkcs1v15",
+	}
+	for _, digest := range batch.digests {
+		req.BatchInput = append(req.BatchInput, transitSignInput{Input: base64.StdEncoding.EncodeToString(digest)})
+	}
+
+	var resp transitSignResponse
+	if err := s.do(ctx, http.MethodPost, "/sign/"+s.keyName+"/sha2-256", req, &resp); err != nil {
+		return errors.New("failed to sign with transit: " + err.Error())
+	}
+	if len(resp.Data.BatchResults) != len(batch.digests) {
+		return fmt.Errorf("transit returned %d results for %d inputs", len(resp.Data.BatchResults), len(batch.digests))
+	}
+
+	for i, result := range resp.Data.BatchResults {
+		if result.Error != "" {
+			batch.errs[i] = errors.New("transit signing failed: " + result.Error)
+			continue
+		}
+		sig, err := parseTransitSignature(result.Signature, s.keyVersion)
+		if err != nil {
+			batch.errs[i] = err
+			continue
+		}
+		batch.signatures[i] = sig
+	}
+	return nil
+}
+
+// transitKeyResponse is the response of GET /v1/{mount}/keys/{name}.
+type transitKeyResponse struct {
+	Data struct {
+		LatestVersion int `json:"latest_version"`
+		Keys          map[string]struct {
+			PublicKey string `json:"public_key"`
+		} `json:"keys"`
+	} `json:"data"`
+}
+
+// loadPublicKey fetches the latest key version and caches its public key.
+func (s *TransitSigner) loadPublicKey(ctx context.Context) error {
+	var resp transitKeyResponse
+	if err := s.do(ctx, http.MethodGet, "/keys/"+s.keyName, nil, &resp); err != nil {
+		return errors.New("failed to read transit key: " + err.Error())
+	}
+
+	version := resp.Data.LatestVersion
+	key, ok := resp.Data.Keys[strconv.Itoa(version)]
+	if !ok || key.PublicKey == "" {
+		return errors.New("transit key has no public key for its latest version")
+	}
+	block, _ := pem.Decode([]byte(key.PublicKey))
+	if block == nil {
+		return errors.New("failed to parse transit public key: no PEM block found")
+	}
+	parsed, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return errors.New("failed to parse transit public key: " + err.Error())
+	}
+	publicKey, ok := parsed.(*rsa.PublicKey)
+	if !ok {
+		return errors.New("transit key is not an RSA key")
+	}
+
+	s.publicKey = publicKey
+	s.keyVersion = version
+	return nil
+}
+
+// do sends a request to the transit engine and decodes the JSON response into out.
+func (s *TransitSigner) do(ctx context.Context, method, path string, body, out interface{}) error {
+	var payload []byte
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		payload = data
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, s.address+"/v1/"+s.mount+path, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("X-Vault-Token", s.token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// parseTransitSignature decodes a "vault:v<version>:<base64>" signature and checks its key version.
+func parseTransitSignature(signature string, keyVersion int) ([]byte, error) {
+	parts := strings.SplitN(signature, ":", 3)
+	if len(parts) != 3 || parts[0] != "vault" {
+		return nil, errors.New("malformed transit signature")
+	}
+	if parts[1] != "v"+strconv.Itoa(keyVersion) {
+		return nil, errors.New("transit signature uses unexpected key version " + parts[1])
+	}
+	sig, err := base64.StdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, errors.New("malformed transit signature: " + err.Error())
+	}
+	return sig, nil
+}
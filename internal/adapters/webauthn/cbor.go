@@ -0,0 +1,159 @@
+package webauthn
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+)
+
+// cborMaxDepth bounds nesting so a crafted attestation object cannot exhaust the stack.
+const cborMaxDepth = 16
+
+// decodeCBOR decodes one CBOR data item (RFC 8949) and returns it together with the bytes
+// that follow it. Only the definite-length encodings produced by authenticators (CTAP2
+// canonical CBOR) are supported. Integers decode to int64, byte strings to []byte, text
+// strings to string, arrays to []interface{} and maps to map[interface{}]interface{}.
+func decodeCBOR(data []byte) (interface{}, []byte, error) {
+	return decodeCBORItem(data, 0)
+}
+
+func decodeCBORItem(data []byte, depth int) (interface{}, []byte, error) {
+	if depth > cborMaxDepth {
+		return nil, nil, errors.New("cbor nesting too deep")
+	}
+	if len(data) == 0 {
+		return nil, nil, errors.New("unexpected end of cbor data")
+	}
+
+	major := data[0] >> 5
+	info := data[0] & 0x1f
+	data = data[1:]
+
+	// 단순 값과 부동소수점은 인자 해석 방식이 다르므로 먼저 처리
+	if major == 7 {
+		return decodeCBORSimple(info, data)
+	}
+
+	arg, data, err := readCBORArgument(info, data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch major {
+	case 0: // 부호 없는 정수
+		if arg > math.MaxInt64 {
+			return nil, nil, errors.New("cbor integer overflows int64")
+		}
+		return int64(arg), data, nil
+	case 1: // 음의 정수 (-1 - arg)
+		if arg > math.MaxInt64 {
+			return nil, nil, errors.New("cbor integer overflows int64")
+		}
+		return -1 - int64(arg), data, nil
+	case 2, 3: // 바이트 문자열, 텍스트 문자열
+		if arg > uint64(len(data)) {
+			return nil, nil, errors.New("cbor string exceeds data")
+		}
+		value := data[:arg]
+		if major == 3 {
+			return string(value), data[arg:], nil
+		}
+		return append([]byte(nil), value...), data[arg:], nil
+	case 4: // 배열
+		if arg > uint64(len(data)) {
+			return nil, nil, errors.New("cbor array exceeds data")
+		}
+		items := make([]interface{}, 0, arg)
+		for i := uint64(0); i < arg; i++ {
+			var item interface{}
+			item, data, err = decodeCBORItem(data, depth+1)
+			if err != nil {
+				return nil, nil, err
+			}
+			items = append(items, item)
+		}
+		return items, data, nil
+	case 5: // 맵
+		if arg > uint64(len(data))/2 {
+			return nil, nil, errors.New("cbor map exceeds data")
+		}
+		entries := make(map[interface{}]interface{}, arg)
+		for i := uint64(0); i < arg; i++ {
+			var key, value interface{}
+			key, data, err = decodeCBORItem(data, depth+1)
+			if err != nil {
+				return nil, nil, err
+			}
+			switch key.(type) {
+			case int64, string:
+			default:
+				return nil, nil, errors.New("unsupported cbor map key type")
+			}
+			if _, dup := entries[key]; dup {
+				return nil, nil, errors.New("duplicate cbor map key")
+			}
+			value, data, err = decodeCBORItem(data, depth+1)
+			if err != nil {
+				return nil, nil, err
+			}
+			entries[key] = value
+		}
+		return entries, data, nil
+	case 6: // 태그: 태그 번호는 무시하고 내용만 반환
+		return decodeCBORItem(data, depth+1)
+	}
+	return nil, nil, errors.New("unsupported cbor major type")
+}
+
+// readCBORArgument reads the argument that follows the initial byte.
+func readCBORArgument(info byte, data []byte) (uint64, []byte, error) {
+	switch {
+	case info < 24:
+		return uint64(info), data, nil
+	case info == 24:
+		if len(data) < 1 {
+			return 0, nil, errors.New("unexpected end of cbor data")
+		}
+		return uint64(data[0]), data[1:], nil
+	case info == 25:
+		if len(data) < 2 {
+			return 0, nil, errors.New("unexpected end of cbor data")
+		}
+		return uint64(binary.BigEndian.Uint16(data)), data[2:], nil
+	case info == 26:
+		if len(data) < 4 {
+			return 0, nil, errors.New("unexpected end of cbor data")
+		}
+		return uint64(binary.BigEndian.Uint32(data)), data[4:], nil
+	case info == 27:
+		if len(data) < 8 {
+			return 0, nil, errors.New("unexpected end of cbor data")
+		}
+		return binary.BigEndian.Uint64(data), data[8:], nil
+	default:
+		return 0, nil, errors.New("indefinite-length cbor items are not supported")
+	}
+}
+
+// decodeCBORSimple decodes the simple values and floats of major type 7.
+func decodeCBORSimple(info byte, data []byte) (interface{}, []byte, error) {
+	switch info {
+	case 20:
+		return false, data, nil
+	case 21:
+		return true, data, nil
+	case 22, 23: // null, undefined
+		return nil, data, nil
+	case 26:
+		if len(data) < 4 {
+			return nil, nil, errors.New("unexpected end of cbor data")
+		}
+		return float64(math.Float32frombits(binary.BigEndian.Uint32(data))), data[4:], nil
+	case 27:
+		if len(data) < 8 {
+			return nil, nil, errors.New("unexpected end of cbor data")
+		}
+		return math.Float64frombits(binary.BigEndian.Uint64(data)), data[8:], nil
+	}
+	return nil, nil, errors.New("unsupported cbor simple value")
+}
@@ -0,0 +1,151 @@
+package webauthn
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"errors"
+	"math/big"
+)
+
+// COSE algorithm identifiers (RFC 9053, RFC 8812) accepted for credentials.
+const (
+	coseAlgES256 = -7
+	coseAlgEdDSA = -8
+	coseAlgRS256 = -257
+)
+
+// COSE key types and curves (RFC 9053 7).
+const (
+	coseKtyOKP = 1
+	coseKtyEC2 = 2
+	coseKtyRSA = 3
+
+	coseCrvP256    = 1
+	coseCrvEd25519 = 6
+)
+
+// COSE_Key map labels (RFC 9052 7.1, RFC 9053 7, RFC 8230 4).
+const (
+	coseKeyKty = 1
+	coseKeyAlg = 3
+	coseKeyCrv = -1 // EC2/OKP의 crv, RSA의 n
+	coseKeyX   = -2 // EC2/OKP의 x, RSA의 e
+	coseKeyY   = -3
+)
+
+// coseKey is a parsed credential public key together with its signature algorithm.
+type coseKey struct {
+	alg int64
+	key crypto.PublicKey
+}
+
+// parseCOSEKey decodes a COSE_Key and returns the bytes that follow it, so the key can
+// be located inside attested credential data.
+func parseCOSEKey(data []byte) (*coseKey, []byte, error) {
+	item, rest, err := decodeCBOR(data)
+	if err != nil {
+		return nil, nil, errors.New("invalid cose key: " + err.Error())
+	}
+	m, ok := item.(map[interface{}]interface{})
+	if !ok {
+		return nil, nil, errors.New("cose key is not a map")
+	}
+
+	intLabel := func(label int64) (int64, bool) {
+		v, ok := m[label].(int64)
+		return v, ok
+	}
+	bytesLabel := func(label int64) ([]byte, error) {
+		v, ok := m[label].([]byte)
+		if !ok || len(v) == 0 {
+			return nil, errors.New("cose key parameter is missing")
+		}
+		return v, nil
+	}
+
+	kty, ok := intLabel(coseKeyKty)
+	if !ok {
+		return nil, nil, errors.New("cose key type is missing")
+	}
+	alg, ok := intLabel(coseKeyAlg)
+	if !ok {
+		return nil, nil, errors.New("cose key algorithm is missing")
+	}
+
+	switch {
+	case kty == coseKtyEC2 && alg == coseAlgES256:
+		if crv, _ := intLabel(coseKeyCrv); crv != coseCrvP256 {
+			return nil, nil, errors.New("unsupported ec2 curve")
+		}
+		x, err := bytesLabel(coseKeyX)
+		if err != nil {
+			return nil, nil, err
+		}
+		y, err := bytesLabel(coseKeyY)
+		if err != nil {
+			return nil, nil, err
+		}
+		key := &ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		if !key.Curve.IsOnCurve(key.X, key.Y) {
+			return nil, nil, errors.New("cose key point is not on curve")
+		}
+		return &coseKey{alg: alg, key: key}, rest, nil
+	case kty == coseKtyOKP && alg == coseAlgEdDSA:
+		if crv, _ := intLabel(coseKeyCrv); crv != coseCrvEd25519 {
+			return nil, nil, errors.New("unsupported okp curve")
+		}
+		x, err := bytesLabel(coseKeyX)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(x) != ed25519.PublicKeySize {
+			return nil, nil, errors.New("invalid ed25519 key length")
+		}
+		return &coseKey{alg: alg, key: ed25519.PublicKey(x)}, rest, nil
+	case kty == coseKtyRSA && alg == coseAlgRS256:
+		n, err := bytesLabel(coseKeyCrv)
+		if err != nil {
+			return nil, nil, err
+		}
+		e, err := bytesLabel(coseKeyX)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(e) > 4 {
+			return nil, nil, errors.New("rsa exponent too large")
+		}
+		key := &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+		if key.N.BitLen() < 2048 {
+			return nil, nil, errors.New("rsa key too small")
+		}
+		return &coseKey{alg: alg, key: key}, rest, nil
+	default:
+		return nil, nil, errors.New("unsupported cose key type or algorithm")
+	}
+}
+
+// verify checks a signature over data. ES256 signatures are ASN.1 DER encoded (WebAuthn 6.5.6).
+func (k *coseKey) verify(data, signature []byte) error {
+	switch k.alg {
+	case coseAlgES256:
+		digest := sha256.Sum256(data)
+		if !ecdsa.VerifyASN1(k.key.(*ecdsa.PublicKey), digest[:], signature) {
+			return errors.New("invalid signature")
+		}
+		return nil
+	case coseAlgEdDSA:
+		if !ed25519.Verify(k.key.(ed25519.PublicKey), data, signature) {
+			return errors.New("invalid signature")
+		}
+		return nil
+	case coseAlgRS256:
+		digest := sha256.Sum256(data)
+		return rsa.VerifyPKCS1v15(k.key.(*rsa.PublicKey), crypto.SHA256, digest[:], signature)
+	default:
+		return errors.New("unsupported algorithm")
+	}
+}
@@ -0,0 +1,302 @@
+package webauthn
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/sukryu/IV-auth-services/internal/config"
+	"github.com/sukryu/IV-auth-services/internal/core/domain"
+	"github.com/sukryu/IV-auth-services/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// Authenticator data flags (WebAuthn 6.1).
+const (
+	flagUserPresent   = 0x01
+	flagUserVerified  = 0x04
+	flagAttestedData  = 0x40
+	flagExtensionData = 0x80
+)
+
+// authDataMinLength is rpIdHash(32) + flags(1) + signCount(4).
+const authDataMinLength = 37
+
+// Verifier implements domain.WebAuthnVerifier for a single relying party.
+// Attestation statements are not verified: registration requests "none" attestation,
+// so the service trusts the key the browser reports rather than the authenticator model.
+type Verifier struct {
+	rpIDHash [32]byte
+	origins  map[string]bool
+	logger   *logger.Logger
+}
+
+// NewVerifier creates a new Verifier instance.
+func NewVerifier(cfg *config.Config, log *logger.Logger) (domain.WebAuthnVerifier, error) {
+	if cfg.WebAuthn.RPID == "" {
+		return nil, errors.New("webauthn relying party id must not be empty")
+	}
+	if len(cfg.WebAuthn.Origins) == 0 {
+		return nil, errors.New("webauthn origins must not be empty")
+	}
+
+	origins := make(map[string]bool, len(cfg.WebAuthn.Origins))
+	for _, origin := range cfg.WebAuthn.Origins {
+		origins[strings.TrimRight(origin, "/")] = true
+	}
+	return &Verifier{
+		rpIDHash: sha256.Sum256([]byte(cfg.WebAuthn.RPID)),
+		origins:  origins,
+		logger:   log.With(zap.String("component", "webauthn_verifier")),
+	}, nil
+}
+
+// credentialJSON is the JSON serialization of a PublicKeyCredential (WebAuthn Level 3 5.1.8).
+type credentialJSON struct {
+	ID       string `json:"id"`
+	RawID    string `json:"rawId"`
+	Type     string `json:"type"`
+	Response struct {
+		ClientDataJSON    string   `json:"clientDataJSON"`
+		AttestationObject string   `json:"attestationObject"`
+		Transports        []string `json:"transports"`
+		AuthenticatorData string   `json:"authenticatorData"`
+		Signature         string   `json:"signature"`
+		UserHandle        string   `json:"userHandle"`
+	} `json:"response"`
+}
+
+// clientData is the collected client data (WebAuthn 5.8.1).
+type clientData struct {
+	Type        string `json:"type"`
+	Challenge   string `json:"challenge"`
+	Origin      string `json:"origin"`
+	CrossOrigin bool   `json:"crossOrigin"`
+}
+
+// authenticatorData is the parsed authenticator data (WebAuthn 6.1).
+type authenticatorData struct {
+	flags        byte
+	signCount    uint32
+	credentialID []byte
+	publicKey    []byte // attested credential data가 있을 때만 설정
+}
+
+// SupportedAlgorithms returns the COSE algorithms accepted for credentials, in order of preference.
+func (v *Verifier) SupportedAlgorithms() []int {
+	return []int{coseAlgES256, coseAlgEdDSA, coseAlgRS256}
+}
+
+// ParseRegistration verifies a registration response (WebAuthn 7.1, steps 5-21 except
+// the challenge comparison, which the caller does against its stored ceremony).
+func (v *Verifier) ParseRegistration(response []byte) (*domain.WebAuthnRegistration, error) {
+	cred, rawID, err := v.parseCredential(response)
+	if err != nil {
+		return nil, err
+	}
+	client, _, err := v.parseClientData(cred.Response.ClientDataJSON, "webauthn.create")
+	if err != nil {
+		return nil, err
+	}
+
+	attestationObject, err := decodeBase64URL(cred.Response.AttestationObject)
+	if err != nil {
+		return nil, errors.New("attestation object is not base64url")
+	}
+	item, rest, err := decodeCBOR(attestationObject)
+	if err != nil || len(rest) != 0 {
+		return nil, errors.New("invalid attestation object")
+	}
+	attestation, ok := item.(map[interface{}]interface{})
+	if !ok {
+		return nil, errors.New("attestation object is not a map")
+	}
+	rawAuthData, ok := attestation["authData"].([]byte)
+	if !ok {
+		return nil, errors.New("attestation object has no authData")
+	}
+
+	authData, err := v.parseAuthenticatorData(rawAuthData)
+	if err != nil {
+		return nil, err
+	}
+	if authData.publicKey == nil {
+		return nil, errors.New("authenticator data has no attested credential")
+	}
+	if !bytes.Equal(authData.credentialID, rawID) {
+		return nil, errors.New("credential id does not match attested credential")
+	}
+
+	transports := cred.Response.Transports
+	if transports == nil {
+		transports = []string{}
+	}
+	return &domain.WebAuthnRegistration{
+		Challenge:    client.Challenge,
+		CredentialID: authData.credentialID,
+		PublicKey:    authData.publicKey,
+		SignCount:    authData.signCount,
+		Transports:   transports,
+		UserVerified: authData.flags&flagUserVerified != 0,
+	}, nil
+}
+
+// ParseAssertion verifies an authentication response (WebAuthn 7.2, steps 11-16) and
+// prepares the data the signature covers.
+func (v *Verifier) ParseAssertion(response []byte) (*domain.WebAuthnAssertion, error) {
+	cred, rawID, err := v.parseCredential(response)
+	if err != nil {
+		return nil, err
+	}
+	client, rawClientData, err := v.parseClientData(cred.Response.ClientDataJSON, "webauthn.get")
+	if err != nil {
+		return nil, err
+	}
+
+	rawAuthData, err := decodeBase64URL(cred.Response.AuthenticatorData)
+	if err != nil {
+		return nil, errors.New("authenticator data is not base64url")
+	}
+	authData, err := v.parseAuthenticatorData(rawAuthData)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := decodeBase64URL(cred.Response.Signature)
+	if err != nil || len(signature) == 0 {
+		return nil, errors.New("signature is missing or not base64url")
+	}
+	userHandle, err := decodeBase64URL(cred.Response.UserHandle)
+	if err != nil {
+		return nil, errors.New("user handle is not base64url")
+	}
+
+	// 서명 대상: authenticatorData || SHA-256(clientDataJSON)
+	clientDataHash := sha256.Sum256(rawClientData)
+	signedData := append(append([]byte(nil), rawAuthData...), clientDataHash[:]...)
+
+	return &domain.WebAuthnAssertion{
+		Challenge:    client.Challenge,
+		CredentialID: rawID,
+		UserHandle:   string(userHandle),
+		SignCount:    authData.signCount,
+		UserVerified: authData.flags&flagUserVerified != 0,
+		SignedData:   signedData,
+		Signature:    signature,
+	}, nil
+}
+
+// VerifySignature checks the assertion signature with a stored COSE public key.
+func (v *Verifier) VerifySignature(publicKey []byte, assertion *domain.WebAuthnAssertion) error {
+	if assertion == nil {
+		return errors.New("assertion must not be nil")
+	}
+	key, _, err := parseCOSEKey(publicKey)
+	if err != nil {
+		return err
+	}
+	if err := key.verify(assertion.SignedData, assertion.Signature); err != nil {
+		v.logger.Debug("Failed to verify webauthn assertion signature", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// parseCredential decodes the PublicKeyCredential JSON and its raw credential ID.
+func (v *Verifier) parseCredential(response []byte) (*credentialJSON, []byte, error) {
+	var cred credentialJSON
+	if err := json.Unmarshal(response, &cred); err != nil {
+		return nil, nil, errors.New("malformed credential: " + err.Error())
+	}
+	if cred.Type != "public-key" {
+		return nil, nil, errors.New("unexpected credential type")
+	}
+	rawID, err := decodeBase64URL(cred.RawID)
+	if err != nil || len(rawID) == 0 {
+		return nil, nil, errors.New("credential raw id is missing or not base64url")
+	}
+	return &cred, rawID, nil
+}
+
+// parseClientData decodes clientDataJSON and checks its type and origin.
+func (v *Verifier) parseClientData(encoded, ceremonyType string) (*clientData, []byte, error) {
+	raw, err := decodeBase64URL(encoded)
+	if err != nil || len(raw) == 0 {
+		return nil, nil, errors.New("client data is missing or not base64url")
+	}
+	var client clientData
+	if err := json.Unmarshal(raw, &client); err != nil {
+		return nil, nil, errors.New("malformed client data: " + err.Error())
+	}
+	if client.Type != ceremonyType {
+		return nil, nil, errors.New("unexpected client data type")
+	}
+	if !v.origins[client.Origin] {
+		v.logger.Debug("Rejected webauthn origin", zap.String("origin", client.Origin))
+		return nil, nil, errors.New("origin is not allowed")
+	}
+	// 다른 origin의 iframe에서 실행된 세리머니는 허용하지 않음
+	if client.CrossOrigin {
+		return nil, nil, errors.New("cross-origin ceremonies are not allowed")
+	}
+	return &client, raw, nil
+}
+
+// parseAuthenticatorData checks the relying party ID hash and user presence, and extracts
+// the signature counter and, when present, the attested credential.
+func (v *Verifier) parseAuthenticatorData(data []byte) (*authenticatorData, error) {
+	if len(data) < authDataMinLength {
+		return nil, errors.New("authenticator data too short")
+	}
+	if !bytes.Equal(data[:32], v.rpIDHash[:]) {
+		return nil, errors.New("relying party id hash does not match")
+	}
+
+	result := &authenticatorData{
+		flags:     data[32],
+		signCount: binary.BigEndian.Uint32(data[33:37]),
+	}
+	if result.flags&flagUserPresent == 0 {
+		return nil, errors.New("user presence flag is not set")
+	}
+
+	rest := data[authDataMinLength:]
+	if result.flags&flagAttestedData != 0 {
+		// aaguid(16) + credentialIdLength(2) + credentialId + credentialPublicKey
+		if len(rest) < 18 {
+			return nil, errors.New("attested credential data too short")
+		}
+		idLength := int(binary.BigEndian.Uint16(rest[16:18]))
+		rest = rest[18:]
+		if idLength == 0 || idLength > 1023 || len(rest) < idLength {
+			return nil, errors.New("invalid credential id length")
+		}
+		result.credentialID = append([]byte(nil), rest[:idLength]...)
+		rest = rest[idLength:]
+
+		_, afterKey, err := parseCOSEKey(rest)
+		if err != nil {
+			return nil, err
+		}
+		result.publicKey = append([]byte(nil), rest[:len(rest)-len(afterKey)]...)
+		rest = afterKey
+	}
+	if result.flags&flagExtensionData != 0 {
+		var err error
+		if _, rest, err = decodeCBOR(rest); err != nil {
+			return nil, errors.New("invalid extension data: " + err.Error())
+		}
+	}
+	if len(rest) != 0 {
+		return nil, errors.New("unexpected trailing authenticator data")
+	}
+	return result, nil
+}
+
+// decodeBase64URL decodes base64url with or without padding, as browsers differ.
+func decodeBase64URL(s string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+}
@@ -2,7 +2,9 @@ package config
 
 import (
 	"fmt"
+	"net/url"
 	"strings"
+	"time"
 
 	"github.com/spf13/viper"
 )
@@ -13,6 +15,13 @@ type Config struct {
 	Server      struct {
 		Port int `mapstructure:"port"`
 	} `mapstructure:"server"`
+	Metrics struct {
+		Port int `mapstructure:"port"`
+	} `mapstructure:"metrics"`
+	HTTP struct {
+		Port      int    `mapstructure:"port"`
+		PublicURL string `mapstructure:"public_url"` // 외부에서 접근하는 기준 URL (DPoP htu 검증에 사용)
+	} `mapstructure:"http"`
 	Database struct {
 		Host     string `mapstructure:"host"`
 		Port     int    `mapstructure:"port"`
@@ -28,9 +37,175 @@ type Config struct {
 		Broker string `mapstructure:"broker"`
 	} `mapstructure:"kafka"`
 	JWT struct {
-		PrivateKeyPath string `mapstructure:"private_key_path"`
-		PublicKeyPath  string `mapstructure:"public_key_path"`
+		PrivateKeyPath string        `mapstructure:"private_key_path"` // signer.type이 local일 때 사용
+		ClockSkew      time.Duration `mapstructure:"clock_skew"`
 	} `mapstructure:"jwt"`
+	Signer struct {
+		Type    string `mapstructure:"type"` // local 또는 transit
+		Transit struct {
+			Address      string        `mapstructure:"address"`
+			Token        string        `mapstructure:"token"`
+			Mount        string        `mapstructure:"mount"`
+			KeyName      string        `mapstructure:"key_name"`
+			BatchWindow  time.Duration `mapstructure:"batch_window"` // 동시 서명 요청을 모으는 대기 시간
+			MaxBatchSize int           `mapstructure:"max_batch_size"`
+			Timeout      time.Duration `mapstructure:"timeout"`
+		} `mapstructure:"transit"`
+	} `mapstructure:"signer"`
+	Token struct {
+		Format    string `mapstructure:"format"`     // 액세스/리프레시 토큰 형식: jwt 또는 paseto (paseto는 signer.type local만 지원)
+		AcceptJWT bool   `mapstructure:"accept_jwt"` // paseto 전환 중 기존 JWT도 검증
+	} `mapstructure:"token"`
+	PASETO struct {
+		PrivateKeyPath string `mapstructure:"private_key_path"` // Ed25519 PKCS #8 PEM
+		PublicKeyPath  string `mapstructure:"public_key_path"`
+	} `mapstructure:"paseto"`
+	DPoP struct {
+		Enabled     bool          `mapstructure:"enabled"`
+		ProofMaxAge time.Duration `mapstructure:"proof_max_age"`
+	} `mapstructure:"dpop"`
+	TokenExchange struct {
+		Enabled bool          `mapstructure:"enabled"`
+		TTL     time.Duration `mapstructure:"ttl"` // 교환 토큰 수명 상한 (원래 토큰보다 길지 않음)
+		Actors  []struct {
+			ClientID  string   `mapstructure:"client_id"` // 호출 서비스의 OAuth 클라이언트 ID
+			Audiences []string `mapstructure:"audiences"`
+			Scopes    []string `mapstructure:"scopes"`
+		} `mapstructure:"actors"`
+	} `mapstructure:"token_exchange"`
+	OAuth struct {
+		AuthorizationCodeTTL time.Duration `mapstructure:"authorization_code_ttl"`
+	} `mapstructure:"oauth"`
+	OIDC struct {
+		Issuer   string `mapstructure:"issuer"`    // 비어 있으면 http.public_url 사용
+		LoginURL string `mapstructure:"login_url"` // 브라우저가 이동하는 로그인/동의 화면 (authorization_endpoint)
+	} `mapstructure:"oidc"`
+	MFA struct {
+		Enabled       bool          `mapstructure:"enabled"`
+		Issuer        string        `mapstructure:"issuer"`         // 인증 앱에 표시되는 서비스 이름
+		EncryptionKey string        `mapstructure:"encryption_key"` // TOTP 비밀 암호화용 base64 AES-256 키
+		TOTPDrift     int           `mapstructure:"totp_drift"`     // 허용하는 앞뒤 time step 수
+		ChallengeTTL  time.Duration `mapstructure:"challenge_ttl"`
+		MaxAttempts   int           `mapstructure:"max_attempts"` // 챌린지당 두 번째 인증 요소 시도 횟수
+	} `mapstructure:"mfa"`
+	WebAuthn struct {
+		Enabled bool          `mapstructure:"enabled"`
+		RPID    string        `mapstructure:"rp_id"`   // 비어 있으면 http.public_url의 호스트
+		RPName  string        `mapstructure:"rp_name"` // 인증기에 표시되는 서비스 이름
+		Origins []string      `mapstructure:"origins"` // 허용하는 웹 origin, 비어 있으면 http.public_url
+		Timeout time.Duration `mapstructure:"timeout"` // 등록/인증 세리머니 유효 시간
+	} `mapstructure:"webauthn"`
+	Mail struct {
+		Driver string `mapstructure:"driver"` // smtp 또는 memory (개발/테스트용)
+		From   string `mapstructure:"from"`
+		SMTP   struct {
+			Host     string        `mapstructure:"host"`
+			Port     int           `mapstructure:"port"`
+			Username string        `mapstructure:"username"`
+			Password string        `mapstructure:"password"`
+			Timeout  time.Duration `mapstructure:"timeout"`
+		} `mapstructure:"smtp"`
+	} `mapstructure:"mail"`
+	MagicLink struct {
+		Enabled    bool          `mapstructure:"enabled"`
+		URL        string        `mapstructure:"url"` // 토큰을 쿼리로 붙여 보내는 로그인 화면 주소
+		TTL        time.Duration `mapstructure:"ttl"`
+		RateLimit  int           `mapstructure:"rate_limit"` // 주소당 rate_window 동안 허용하는 요청 수
+		RateWindow time.Duration `mapstructure:"rate_window"`
+	} `mapstructure:"magic_link"`
+	EmailVerification struct {
+		Enabled                   bool          `mapstructure:"enabled"`
+		URL                       string        `mapstructure:"url"` // 토큰을 쿼리로 붙여 보내는 확인 화면 주소
+		TTL                       time.Duration `mapstructure:"ttl"`
+		ResendLimit               int           `mapstructure:"resend_limit"` // 사용자당 resend_window 동안 허용하는 발송 수
+		ResendWindow              time.Duration `mapstructure:"resend_window"`
+		RequireForPlatformLinking bool          `mapstructure:"require_for_platform_linking"` // 확인 전 플랫폼 연동 제한
+	} `mapstructure:"email_verification"`
+	PasswordReset struct {
+		Enabled    bool          `mapstructure:"enabled"`
+		URL        string        `mapstructure:"url"` // 토큰을 쿼리로 붙여 보내는 재설정 화면 주소
+		TTL        time.Duration `mapstructure:"ttl"`
+		RateLimit  int           `mapstructure:"rate_limit"` // 주소당 rate_window 동안 허용하는 요청 수
+		RateWindow time.Duration `mapstructure:"rate_window"`
+	} `mapstructure:"password_reset"`
+	DeviceAuthorization struct {
+		Enabled         bool          `mapstructure:"enabled"`
+		VerificationURL string        `mapstructure:"verification_url"` // 사용자가 기기 코드를 입력하는 화면 주소
+		TTL             time.Duration `mapstructure:"ttl"`
+		Interval        time.Duration `mapstructure:"interval"`        // 기기의 최소 폴링 간격
+		UserCodeLimit   int           `mapstructure:"user_code_limit"` // 사용자당 user_code_window 동안 허용하는 코드 입력 횟수
+		UserCodeWindow  time.Duration `mapstructure:"user_code_window"`
+	} `mapstructure:"device_authorization"`
+	StepUp struct {
+		Enabled       bool          `mapstructure:"enabled"`
+		MaxAge        time.Duration `mapstructure:"max_age"`      // 민감한 작업에 요구하는 인증 후 경과 시간 상한
+		TokenTTL      time.Duration `mapstructure:"token_ttl"`    // 재인증으로 발급하는 액세스 토큰 수명
+		MaxAttempts   int           `mapstructure:"max_attempts"` // 사용자당 attempt_window 동안 허용하는 재인증 시도 수
+		AttemptWindow time.Duration `mapstructure:"attempt_window"`
+	} `mapstructure:"step_up"`
+	APIKeys struct {
+		Enabled       bool     `mapstructure:"enabled"`
+		AllowedScopes []string `mapstructure:"allowed_scopes"` // 사용자가 API 키에 부여할 수 있는 범위
+		MaxPerUser    int      `mapstructure:"max_per_user"`   // 사용자당 보유 가능한 키 수 (0이면 제한 없음)
+	} `mapstructure:"api_keys"`
+	Guests struct {
+		Enabled    bool          `mapstructure:"enabled"`
+		Scopes     []string      `mapstructure:"scopes"`     // 게스트 토큰에 부여하는 범위 (guest 범위는 항상 포함)
+		RateLimit  int           `mapstructure:"rate_limit"` // IP당 rate_window 동안 허용하는 게스트 생성 수
+		RateWindow time.Duration `mapstructure:"rate_window"`
+		IdleTTL    time.Duration `mapstructure:"idle_ttl"` // 활성 세션이 없는 게스트를 마지막 로그인 후 삭제하기까지의 기간
+	} `mapstructure:"guests"`
+	LoginRisk struct {
+		Enabled bool `mapstructure:"enabled"`
+		GeoIP   struct {
+			URL     string        `mapstructure:"url"` // {ip} 자리에 주소를 넣어 조회, 비어 있으면 ASN·이동 신호 생략
+			Timeout time.Duration `mapstructure:"timeout"`
+		} `mapstructure:"geoip"`
+		NewDeviceScore        int           `mapstructure:"new_device_score"`
+		NewIPScore            int           `mapstructure:"new_ip_score"`
+		NewASNScore           int           `mapstructure:"new_asn_score"`
+		ImpossibleTravelScore int           `mapstructure:"impossible_travel_score"`
+		FailureScore          int           `mapstructure:"failure_score"`     // failure_window 동안 실패 한 번당 점수
+		MaxFailureScore       int           `mapstructure:"max_failure_score"` // 실패 점수 상한
+		MFAThreshold          int           `mapstructure:"mfa_threshold"`     // 이 점수 이상이면 MFA 요구, 수단이 없으면 이메일 코드 또는 차단 (0이면 사용 안 함)
+		BlockThreshold        int           `mapstructure:"block_threshold"`   // 이 점수 이상이면 차단 (0이면 사용 안 함)
+		MaxTravelSpeed        float64       `mapstructure:"max_travel_speed"`  // km/h
+		FailureWindow         time.Duration `mapstructure:"failure_window"`
+		HistorySize           int           `mapstructure:"history_size"` // 비교할 최근 성공 로그인 수
+		Retention             time.Duration `mapstructure:"retention"`    // 로그인 기록 보관 기간
+	} `mapstructure:"login_risk"`
+	Impersonation struct {
+		Enabled  bool          `mapstructure:"enabled"`
+		TokenTTL time.Duration `mapstructure:"token_ttl"` // 대리 실행 토큰 수명 (갱신 불가)
+	} `mapstructure:"impersonation"`
+	PlatformLogin struct {
+		Enabled bool `mapstructure:"enabled"` // 플랫폼 계정으로 로그인 및 가입 허용
+	} `mapstructure:"platform_login"`
+	Platforms struct {
+		Timeout time.Duration `mapstructure:"timeout"`
+		Twitch  struct {
+			ClientID     string `mapstructure:"client_id"` // 비어 있으면 Twitch 로그인 비활성화
+			ClientSecret string `mapstructure:"client_secret"`
+			RedirectURL  string `mapstructure:"redirect_url"`
+			AuthURL      string `mapstructure:"auth_url"`
+			TokenURL     string `mapstructure:"token_url"`
+			APIURL       string `mapstructure:"api_url"`
+		} `mapstructure:"twitch"`
+		YouTube struct {
+			ClientID     string `mapstructure:"client_id"` // 비어 있으면 YouTube 로그인 비활성화
+			ClientSecret string `mapstructure:"client_secret"`
+			RedirectURL  string `mapstructure:"redirect_url"`
+			AuthURL      string `mapstructure:"auth_url"`
+			TokenURL     string `mapstructure:"token_url"`
+			UserInfoURL  string `mapstructure:"userinfo_url"`
+			APIURL       string `mapstructure:"api_url"`
+		} `mapstructure:"youtube"`
+	} `mapstructure:"platforms"`
+	Blacklist struct {
+		PurgeInterval   time.Duration `mapstructure:"purge_interval"`
+		PurgeBatchSize  int           `mapstructure:"purge_batch_size"`
+		PurgeMaxBatches int           `mapstructure:"purge_max_batches"`
+	} `mapstructure:"blacklist"`
 }
 
 // LoadConfig loads configuration from environment variables and config file.
@@ -51,6 +226,9 @@ func LoadConfig() (*Config, error) {
 	// 기본값 설정
 	v.SetDefault("environment", "development")
 	v.SetDefault("server.port", 50051)
+	v.SetDefault("metrics.port", 9090)
+	v.SetDefault("http.port", 8080)
+	v.SetDefault("http.public_url", "http://localhost:8080")
 	v.SetDefault("database.host", "localhost")
 	v.SetDefault("database.port", 5432)
 	v.SetDefault("database.user", "auth_user")
@@ -60,7 +238,95 @@ func LoadConfig() (*Config, error) {
 	v.SetDefault("redis.addr", "localhost:6379")
 	v.SetDefault("kafka.broker", "localhost:9092")
 	v.SetDefault("jwt.private_key_path", "./certs/private.pem")
-	v.SetDefault("jwt.public_key_path", "./certs/public.pem")
+	v.SetDefault("jwt.clock_skew", "10s")
+	v.SetDefault("signer.type", "local")
+	v.SetDefault("signer.transit.token", "")
+	v.SetDefault("signer.transit.mount", "transit")
+	v.SetDefault("signer.transit.batch_window", "2ms")
+	v.SetDefault("signer.transit.max_batch_size", 64)
+	v.SetDefault("signer.transit.timeout", "2s")
+	v.SetDefault("token.format", "jwt")
+	v.SetDefault("token.accept_jwt", true)
+	v.SetDefault("paseto.private_key_path", "./certs/paseto_private.pem")
+	v.SetDefault("paseto.public_key_path", "./certs/paseto_public.pem")
+	v.SetDefault("dpop.enabled", false)
+	v.SetDefault("dpop.proof_max_age", "60s")
+	v.SetDefault("token_exchange.enabled", false)
+	v.SetDefault("token_exchange.ttl", "5m")
+	v.SetDefault("oauth.authorization_code_ttl", "1m")
+	v.SetDefault("mfa.enabled", false)
+	v.SetDefault("mfa.issuer", "IV")
+	v.SetDefault("mfa.encryption_key", "")
+	v.SetDefault("mfa.totp_drift", 1)
+	v.SetDefault("mfa.challenge_ttl", "5m")
+	v.SetDefault("mfa.max_attempts", 5)
+	v.SetDefault("webauthn.enabled", false)
+	v.SetDefault("webauthn.rp_name", "IV")
+	v.SetDefault("webauthn.timeout", "5m")
+	v.SetDefault("mail.driver", "memory")
+	v.SetDefault("mail.from", "no-reply@localhost")
+	v.SetDefault("mail.smtp.port", 587)
+	v.SetDefault("mail.smtp.timeout", "10s")
+	v.SetDefault("magic_link.enabled", false)
+	v.SetDefault("magic_link.ttl", "15m")
+	v.SetDefault("magic_link.rate_limit", 5)
+	v.SetDefault("magic_link.rate_window", "1h")
+	v.SetDefault("email_verification.enabled", false)
+	v.SetDefault("email_verification.ttl", "24h")
+	v.SetDefault("email_verification.resend_limit", 3)
+	v.SetDefault("email_verification.resend_window", "1h")
+	v.SetDefault("email_verification.require_for_platform_linking", true)
+	v.SetDefault("password_reset.enabled", false)
+	v.SetDefault("password_reset.ttl", "30m")
+	v.SetDefault("password_reset.rate_limit", 5)
+	v.SetDefault("password_reset.rate_window", "1h")
+	v.SetDefault("device_authorization.enabled", false)
+	v.SetDefault("device_authorization.ttl", "10m")
+	v.SetDefault("device_authorization.interval", "5s")
+	v.SetDefault("device_authorization.user_code_limit", 10)
+	v.SetDefault("device_authorization.user_code_window", "15m")
+	v.SetDefault("step_up.enabled", false)
+	v.SetDefault("step_up.max_age", "5m")
+	v.SetDefault("step_up.token_ttl", "5m")
+	v.SetDefault("step_up.max_attempts", 5)
+	v.SetDefault("step_up.attempt_window", "15m")
+	v.SetDefault("api_keys.enabled", false)
+	v.SetDefault("api_keys.allowed_scopes", []string{"openid", "profile", "chat:read", "chat:write"})
+	v.SetDefault("api_keys.max_per_user", 25)
+	v.SetDefault("guests.enabled", false)
+	v.SetDefault("guests.scopes", []string{"chat:read"})
+	v.SetDefault("guests.rate_limit", 10)
+	v.SetDefault("guests.rate_window", "1h")
+	v.SetDefault("guests.idle_ttl", "720h")
+	v.SetDefault("login_risk.enabled", false)
+	v.SetDefault("login_risk.geoip.url", "")
+	v.SetDefault("login_risk.geoip.timeout", "2s")
+	v.SetDefault("login_risk.new_device_score", 30)
+	v.SetDefault("login_risk.new_ip_score", 10)
+	v.SetDefault("login_risk.new_asn_score", 20)
+	v.SetDefault("login_risk.impossible_travel_score", 60)
+	v.SetDefault("login_risk.failure_score", 10)
+	v.SetDefault("login_risk.max_failure_score", 40)
+	v.SetDefault("login_risk.mfa_threshold", 40)
+	v.SetDefault("login_risk.block_threshold", 90)
+	v.SetDefault("login_risk.max_travel_speed", 1000)
+	v.SetDefault("login_risk.failure_window", "1h")
+	v.SetDefault("login_risk.history_size", 20)
+	v.SetDefault("login_risk.retention", "2160h")
+	v.SetDefault("impersonation.enabled", false)
+	v.SetDefault("impersonation.token_ttl", "15m")
+	v.SetDefault("platform_login.enabled", false)
+	v.SetDefault("platforms.timeout", "10s")
+	v.SetDefault("platforms.twitch.auth_url", "https://id.twitch.tv/oauth2/authorize")
+	v.SetDefault("platforms.twitch.token_url", "https://id.twitch.tv/oauth2/token")
+	v.SetDefault("platforms.twitch.api_url", "https://api.twitch.tv/helix")
+	v.SetDefault("platforms.youtube.auth_url", "https://accounts.google.com/o/oauth2/v2/auth")
+	v.SetDefault("platforms.youtube.token_url", "https://oauth2.googleapis.com/token")
+	v.SetDefault("platforms.youtube.userinfo_url", "https://openidconnect.googleapis.com/v1/userinfo")
+	v.SetDefault("platforms.youtube.api_url", "https://www.googleapis.com/youtube/v3")
+	v.SetDefault("blacklist.purge_interval", "10m")
+	v.SetDefault("blacklist.purge_batch_size", 1000)
+	v.SetDefault("blacklist.purge_max_batches", 100)
 
 	// 설정 파일 읽기 (없으면 기본값 사용)
 	if err := v.ReadInConfig(); err != nil {
@@ -85,6 +351,40 @@ func LoadConfig() (*Config, error) {
 		return nil, fmt.Errorf("database password is required")
 	}
 
+	if cfg.MFA.Enabled && cfg.MFA.EncryptionKey == "" {
+		return nil, fmt.Errorf("mfa encryption key is required when mfa is enabled")
+	}
+
+	// 파생 기본값
+	if cfg.OIDC.Issuer == "" {
+		cfg.OIDC.Issuer = strings.TrimRight(cfg.HTTP.PublicURL, "/")
+	}
+	if cfg.OIDC.LoginURL == "" {
+		cfg.OIDC.LoginURL = strings.TrimRight(cfg.HTTP.PublicURL, "/") + "/oauth2/authorize"
+	}
+	if cfg.MagicLink.URL == "" {
+		cfg.MagicLink.URL = strings.TrimRight(cfg.HTTP.PublicURL, "/") + "/login/magic-link"
+	}
+	if cfg.EmailVerification.URL == "" {
+		cfg.EmailVerification.URL = strings.TrimRight(cfg.HTTP.PublicURL, "/") + "/verify-email"
+	}
+	if cfg.PasswordReset.URL == "" {
+		cfg.PasswordReset.URL = strings.TrimRight(cfg.HTTP.PublicURL, "/") + "/reset-password"
+	}
+	if cfg.DeviceAuthorization.VerificationURL == "" {
+		cfg.DeviceAuthorization.VerificationURL = strings.TrimRight(cfg.HTTP.PublicURL, "/") + "/device"
+	}
+	if len(cfg.WebAuthn.Origins) == 0 {
+		cfg.WebAuthn.Origins = []string{strings.TrimRight(cfg.HTTP.PublicURL, "/")}
+	}
+	if cfg.WebAuthn.RPID == "" {
+		publicURL, err := url.Parse(cfg.HTTP.PublicURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid http public url: %w", err)
+		}
+		cfg.WebAuthn.RPID = publicURL.Hostname()
+	}
+
 	return &cfg, nil
 }
 
@@ -0,0 +1,359 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+)
+
+// TokenTypeAPIKey is the Type of the claims returned for a personal API key.
+const TokenTypeAPIKey = "api_key"
+
+// Audit actions recorded for personal API keys.
+const (
+	AuditActionAPIKeyCreated = "API_KEY_CREATED"
+	AuditActionAPIKeyRevoked = "API_KEY_REVOKED"
+)
+
+const auditEntityAPIKey = "API_KEY"
+
+var (
+	// ErrAPIKeyNotFound is returned when an API key does not exist, was revoked or belongs to another user.
+	ErrAPIKeyNotFound = errors.New("api key not found")
+	// ErrAPIKeyLimitReached is returned when a user already has the maximum number of API keys.
+	ErrAPIKeyLimitReached = errors.New("api key limit reached")
+)
+
+// API key format: "ivk_" followed by a public identifier and the secret, e.g.
+// "ivk_Ab3dEf9h_<secret>". The prefix up to the identifier is stored in plain text so
+// users can recognize their keys; only a hash of the full key is stored.
+const (
+	APIKeyPrefix        = "ivk_"
+	apiKeyIdentLength   = 8
+	apiKeySecretLength  = 40
+	apiKeyNameMaxLength = 100
+	apiKeyVisibleLength = len(APIKeyPrefix) + apiKeyIdentLength
+	apiKeyUsageThrottle = time.Minute // 마지막 사용 기록은 이 간격보다 자주 갱신하지 않음
+)
+
+// APIKey is a long-lived personal credential a user creates for bots and tools, such as
+// chat bots and OBS plugins. It acts on behalf of the user with the scopes chosen at creation.
+type APIKey struct {
+	id         string
+	userID     string
+	name       string
+	prefix     string // 키 앞부분 (식별용으로 평문 저장)
+	keyHash    string
+	scopes     []string
+	expiresAt  *time.Time // nil이면 만료 없음
+	lastUsedAt *time.Time
+	lastUsedIP string
+	createdAt  time.Time
+	revokedAt  *time.Time
+}
+
+// NewAPIKey generates a new API key and returns it with the raw key, which is shown to the
+// user once and cannot be recovered later.
+func NewAPIKey(id, userID, name string, scopes []string, expiresAt *time.Time) (*APIKey, string, error) {
+	if id == "" || userID == "" {
+		return nil, "", errors.New("api key id and user id must not be empty")
+	}
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, "", errors.New("api key name must not be empty")
+	}
+	if len(name) > apiKeyNameMaxLength {
+		return nil, "", errors.New("api key name is too long")
+	}
+	if len(scopes) == 0 {
+		return nil, "", errors.New("api key must have at least one scope")
+	}
+	if expiresAt != nil && !expiresAt.After(time.Now()) {
+		return nil, "", errors.New("api key expiry must be in the future")
+	}
+
+	raw := APIKeyPrefix + generateRandomString(apiKeyIdentLength) + "_" + generateRandomString(apiKeySecretLength)
+	return &APIKey{
+		id:        id,
+		userID:    userID,
+		name:      name,
+		prefix:    raw[:apiKeyVisibleLength],
+		keyHash:   hashOpaqueToken(raw),
+		scopes:    scopes,
+		expiresAt: expiresAt,
+		createdAt: time.Now(),
+	}, raw, nil
+}
+
+// NewAPIKeyFromStorage restores an APIKey loaded from storage.
+func NewAPIKeyFromStorage(id, userID, name, prefix, keyHash string, scopes []string, expiresAt, lastUsedAt *time.Time, lastUsedIP string, createdAt time.Time, revokedAt *time.Time) (*APIKey, error) {
+	if id == "" || userID == "" || keyHash == "" {
+		return nil, errors.New("api key id, user id and key hash must not be empty")
+	}
+
+	return &APIKey{
+		id:         id,
+		userID:     userID,
+		name:       name,
+		prefix:     prefix,
+		keyHash:    keyHash,
+		scopes:     scopes,
+		expiresAt:  expiresAt,
+		lastUsedAt: lastUsedAt,
+		lastUsedIP: lastUsedIP,
+		createdAt:  createdAt,
+		revokedAt:  revokedAt,
+	}, nil
+}
+
+// HashAPIKey returns the hash under which a raw API key is stored.
+func HashAPIKey(raw string) string {
+	return hashOpaqueToken(raw)
+}
+
+// IsAPIKey reports whether a bearer credential has the API key format rather than being a token.
+func IsAPIKey(credential string) bool {
+	return strings.HasPrefix(credential, APIKeyPrefix)
+}
+
+// ID returns the API key's unique identifier.
+func (k *APIKey) ID() string {
+	return k.id
+}
+
+// UserID returns the ID of the user who owns the key.
+func (k *APIKey) UserID() string {
+	return k.userID
+}
+
+// Name returns the name the user gave the key.
+func (k *APIKey) Name() string {
+	return k.name
+}
+
+// Prefix returns the visible beginning of the key, e.g. "ivk_Ab3dEf9h".
+func (k *APIKey) Prefix() string {
+	return k.prefix
+}
+
+// KeyHash returns the hash of the full key.
+func (k *APIKey) KeyHash() string {
+	return k.keyHash
+}
+
+// Scopes returns the scopes granted to the key.
+func (k *APIKey) Scopes() []string {
+	return k.scopes
+}
+
+// ExpiresAt returns the expiry of the key, or nil if it does not expire.
+func (k *APIKey) ExpiresAt() *time.Time {
+	return k.expiresAt
+}
+
+// LastUsedAt returns when the key was last used, or nil if it was never used.
+func (k *APIKey) LastUsedAt() *time.Time {
+	return k.lastUsedAt
+}
+
+// LastUsedIP returns the IP address the key was last used from.
+func (k *APIKey) LastUsedIP() string {
+	return k.lastUsedIP
+}
+
+// CreatedAt returns the time when the key was created.
+func (k *APIKey) CreatedAt() time.Time {
+	return k.createdAt
+}
+
+// RevokedAt returns the time when the key was revoked, or nil if it is not revoked.
+func (k *APIKey) RevokedAt() *time.Time {
+	return k.revokedAt
+}
+
+// IsActive reports whether the key is neither revoked nor expired.
+func (k *APIKey) IsActive() bool {
+	if k.revokedAt != nil {
+		return false
+	}
+	return k.expiresAt == nil || time.Now().Before(*k.expiresAt)
+}
+
+// Revoke disables the key; requests using it are rejected from then on.
+func (k *APIKey) Revoke() error {
+	if k.revokedAt != nil {
+		return errors.New("api key is already revoked")
+	}
+	now := time.Now()
+	k.revokedAt = &now
+	return nil
+}
+
+// RecordUsage records a request made with the key and reports whether the stored usage
+// needs updating. Usage from the same IP address is recorded at most once per minute.
+func (k *APIKey) RecordUsage(ip string, now time.Time) bool {
+	if k.lastUsedAt != nil && ip == k.lastUsedIP && now.Sub(*k.lastUsedAt) < apiKeyUsageThrottle {
+		return false
+	}
+	k.lastUsedAt = &now
+	k.lastUsedIP = ip
+	return true
+}
+
+// Claims returns the claims a request authenticated with the key acts with.
+func (k *APIKey) Claims() *TokenClaims {
+	claims := &TokenClaims{
+		Subject:  k.userID,
+		TokenID:  k.id,
+		Type:     TokenTypeAPIKey,
+		IssuedAt: k.createdAt,
+		Scope:    k.scopes,
+	}
+	if k.expiresAt != nil {
+		claims.ExpiresAt = *k.expiresAt
+	}
+	return claims
+}
+
+// APIKeyService defines operations for users to manage personal API keys and for
+// validating requests made with them.
+type APIKeyService interface {
+	// CreateAPIKey creates a key for the user and returns it with the raw key, which is only
+	// available at this point. expiresAt is optional.
+	CreateAPIKey(ctx context.Context, userID, name string, scopes []string, expiresAt *time.Time) (*APIKey, string, error)
+	// ListAPIKeys returns the user's keys that are not revoked, including expired ones.
+	ListAPIKeys(ctx context.Context, userID string) ([]*APIKey, error)
+	// RevokeAPIKey revokes one of the user's keys.
+	RevokeAPIKey(ctx context.Context, userID, keyID string) error
+	// ValidateAPIKey verifies a raw key, records its use from the client in ctx and returns
+	// claims with Type TokenTypeAPIKey.
+	ValidateAPIKey(ctx context.Context, raw string) (*TokenClaims, error)
+}
+
+// apiKeyService implements APIKeyService with domain logic.
+type apiKeyService struct {
+	apiKeyRepo    APIKeyRepository
+	userRepo      UserRepository
+	auditRepo     AuditLogRepository
+	allowedScopes []string
+	maxPerUser    int
+}
+
+// NewAPIKeyService creates a new instance of apiKeyService. Keys may only be granted
+// allowedScopes, and each user may hold at most maxPerUser unrevoked keys (0 for no limit).
+func NewAPIKeyService(apiKeyRepo APIKeyRepository, userRepo UserRepository, auditRepo AuditLogRepository, allowedScopes []string, maxPerUser int) APIKeyService {
+	return &apiKeyService{
+		apiKeyRepo:    apiKeyRepo,
+		userRepo:      userRepo,
+		auditRepo:     auditRepo,
+		allowedScopes: allowedScopes,
+		maxPerUser:    maxPerUser,
+	}
+}
+
+// CreateAPIKey creates a key with the requested scopes for the user.
+func (s *apiKeyService) CreateAPIKey(ctx context.Context, userID, name string, scopes []string, expiresAt *time.Time) (*APIKey, string, error) {
+	if userID == "" {
+		return nil, "", errors.New("user id must not be empty")
+	}
+	for _, scope := range scopes {
+		if !containsString(s.allowedScopes, scope) {
+			return nil, "", NewOAuthError(OAuthErrInvalidScope, "scope "+scope+" cannot be granted to an api key")
+		}
+	}
+
+	keys, err := s.apiKeyRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, "", errors.New("failed to find api keys: " + err.Error())
+	}
+	if s.maxPerUser > 0 && len(keys) >= s.maxPerUser {
+		return nil, "", ErrAPIKeyLimitReached
+	}
+
+	key, raw, err := NewAPIKey(generateRandomString(36), userID, name, scopes, expiresAt)
+	if err != nil {
+		// 이름, 범위, 만료 시각 등 입력 오류
+		return nil, "", NewOAuthError(OAuthErrInvalidRequest, err.Error())
+	}
+	if err := s.apiKeyRepo.Save(ctx, key); err != nil {
+		return nil, "", errors.New("failed to save api key: " + err.Error())
+	}
+
+	keyID := key.ID()
+	recordAudit(ctx, s.auditRepo, AuditActionAPIKeyCreated, auditEntityAPIKey, &userID, &keyID, map[string]interface{}{
+		"name":   key.Name(),
+		"prefix": key.Prefix(),
+		"scopes": scopes,
+	})
+	return key, raw, nil
+}
+
+// ListAPIKeys returns the user's unrevoked keys.
+func (s *apiKeyService) ListAPIKeys(ctx context.Context, userID string) ([]*APIKey, error) {
+	if userID == "" {
+		return nil, errors.New("user id must not be empty")
+	}
+
+	keys, err := s.apiKeyRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, errors.New("failed to find api keys: " + err.Error())
+	}
+	return keys, nil
+}
+
+// RevokeAPIKey revokes a key owned by the user.
+func (s *apiKeyService) RevokeAPIKey(ctx context.Context, userID, keyID string) error {
+	if userID == "" || keyID == "" {
+		return errors.New("user id and api key id must not be empty")
+	}
+
+	key, err := s.apiKeyRepo.FindByID(ctx, keyID)
+	if err != nil {
+		return errors.New("failed to find api key: " + err.Error())
+	}
+	// 다른 사용자의 키는 존재 여부를 노출하지 않음
+	if key == nil || key.UserID() != userID || key.RevokedAt() != nil {
+		return ErrAPIKeyNotFound
+	}
+
+	if err := key.Revoke(); err != nil {
+		return err
+	}
+	if err := s.apiKeyRepo.Save(ctx, key); err != nil {
+		return errors.New("failed to revoke api key: " + err.Error())
+	}
+
+	recordAudit(ctx, s.auditRepo, AuditActionAPIKeyRevoked, auditEntityAPIKey, &userID, &keyID, map[string]interface{}{"prefix": key.Prefix()})
+	return nil
+}
+
+// ValidateAPIKey verifies a raw key. Keys of users who are no longer active are rejected.
+func (s *apiKeyService) ValidateAPIKey(ctx context.Context, raw string) (*TokenClaims, error) {
+	if !IsAPIKey(raw) {
+		return nil, errors.New("invalid api key format")
+	}
+
+	key, err := s.apiKeyRepo.FindByHash(ctx, HashAPIKey(raw))
+	if err != nil {
+		return nil, errors.New("failed to find api key: " + err.Error())
+	}
+	if key == nil || !key.IsActive() {
+		return nil, errors.New("api key is invalid, expired or revoked")
+	}
+
+	user, err := s.userRepo.FindByID(ctx, key.UserID())
+	if err != nil {
+		return nil, errors.New("failed to find user: " + err.Error())
+	}
+	if user == nil || user.Status() != UserStatusActive {
+		return nil, errors.New("api key owner is not active")
+	}
+
+	// 사용 기록 실패로 요청을 거부하지 않음
+	now := time.Now()
+	if key.RecordUsage(ClientMetadataFromContext(ctx).IPAddress, now) {
+		_ = s.apiKeyRepo.RecordUsage(ctx, key.ID(), now, key.LastUsedIP())
+	}
+	return key.Claims(), nil
+}
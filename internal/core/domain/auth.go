@@ -3,17 +3,52 @@ package domain
 import (
 	"context"
 	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
+	"strings"
 	"time"
 )
 
+// ErrInvalidCredentials is returned by Authenticate for an unknown identifier or a wrong password.
+var ErrInvalidCredentials = errors.New("invalid credentials")
+
 // AuthService defines the authentication-related operations.
 type AuthService interface {
-	Authenticate(ctx context.Context, username, password string) (*Token, error)
-	GenerateTokenPair(userID string) (*Token, error)
+	// Authenticate verifies a password login. The identifier is an email address if it
+	// contains "@", otherwise a username.
+	Authenticate(ctx context.Context, identifier, password string, opts ...TokenOption) (*Token, error)
+	GenerateTokenPair(userID string, opts ...TokenOption) (*Token, error)
 	Logout(tokenID string) error
 	ValidateToken(tokenStr string) (string, error) // Returns userID
-	RefreshToken(refreshTokenStr string) (*Token, error)
+	// ValidateAccessToken verifies a bearer access token. With WithAPIKeys, personal API keys
+	// are accepted as well and yield claims of Type TokenTypeAPIKey.
+	ValidateAccessToken(ctx context.Context, tokenStr string) (*TokenClaims, error)
+	ValidateDPoPBoundToken(ctx context.Context, tokenStr, proof, method, uri string) (*TokenClaims, error)
+	VerifyDPoPProof(ctx context.Context, proof, method, uri string) (*DPoPProof, error)
+	RefreshToken(ctx context.Context, refreshTokenStr string, opts ...TokenOption) (*Token, error)
+	// CompleteMFA exchanges the challenge token returned with MFARequiredError and a
+	// second-factor response for a token pair.
+	CompleteMFA(ctx context.Context, challengeToken, method, response string, opts ...TokenOption) (*Token, error)
+	// BeginMFAPasskey starts a passkey ceremony for the user of a pending MFA challenge.
+	BeginMFAPasskey(ctx context.Context, challengeToken string) (*PasskeyRequestOptions, error)
+	// AuthenticatePasskey verifies a passwordless passkey login and returns a token pair.
+	AuthenticatePasskey(ctx context.Context, response []byte, opts ...TokenOption) (*Token, error)
+	// AuthenticateMagicLink redeems an emailed login link and returns a token pair, or an
+	// *MFARequiredError when the user has a second factor.
+	AuthenticateMagicLink(ctx context.Context, token string, opts ...TokenOption) (*Token, error)
+	// AuthenticatePlatform signs in with an external platform's authorization code and returns
+	// a token pair, or an *MFARequiredError when the user has a second factor.
+	AuthenticatePlatform(ctx context.Context, platform PlatformType, code string, opts ...TokenOption) (*Token, error)
+	// ReAuthenticate verifies the password or a second factor of the signed-in user again and
+	// returns a short-lived access token that satisfies step-up requirements.
+	ReAuthenticate(ctx context.Context, claims *TokenClaims, method, response string) (*Token, error)
+	// AuthenticateGuest creates an anonymous guest user and returns a token pair limited to
+	// the guest scopes.
+	AuthenticateGuest(ctx context.Context, opts ...TokenOption) (*Token, error)
+	// UpgradeGuest turns the guest of claims into a regular user with the given credentials
+	// and returns an unrestricted token pair for the same user ID.
+	UpgradeGuest(ctx context.Context, claims *TokenClaims, upgrade GuestUpgrade, opts ...TokenOption) (*Token, error)
 }
 
 // authService implements AuthService with domain logic.
@@ -22,32 +57,227 @@ type authService struct {
 	tokenRepo TokenRepository
 	tokenGen  TokenGenerator
 	eventPub  EventPublisher
+
+	// DPoP는 선택 기능으로, WithDPoP 옵션을 지정한 경우에만 활성화
+	dpopVerifier   DPoPVerifier
+	dpopReplayRepo DPoPReplayRepository
+
+	// 세션 추적은 WithSessions 옵션을 지정한 경우에만 활성화
+	sessionRepo SessionRepository
+
+	// 다단계 인증은 WithMFA 옵션을 지정한 경우에만 활성화
+	mfa              MFAService
+	mfaChallengeRepo MFAChallengeRepository
+	mfaChallengeTTL  time.Duration
+	mfaMaxAttempts   int
+
+	// 패스키 로그인은 WithPasskeys 옵션을 지정한 경우에만 활성화
+	passkeys PasskeyService
+
+	// 이메일 로그인 링크는 WithMagicLinks 옵션을 지정한 경우에만 활성화
+	magicLinks MagicLinkService
+
+	// 외부 플랫폼 로그인은 WithPlatformLogin 옵션을 지정한 경우에만 활성화
+	platformLogin PlatformLoginService
+
+	// 재인증(step-up)은 WithReAuthentication 옵션을 지정한 경우에만 활성화
+	reAuthTokenTTL      time.Duration
+	reAuthRateLimitRepo RateLimitRepository
+	reAuthMaxAttempts   int
+	reAuthWindow        time.Duration
+
+	// 개인 API 키는 WithAPIKeys 옵션을 지정한 경우에만 허용
+	apiKeys APIKeyService
+
+	// 게스트 사용자는 WithGuests 옵션을 지정한 경우에만 허용
+	guestsEnabled      bool
+	guestRateLimitRepo RateLimitRepository
+	guestScopes        []string
+	guestLimit         int
+	guestWindow        time.Duration
+	guestVerification  EmailVerificationService // 비밀번호로 업그레이드한 게스트에게 이메일 확인 메일 발송, nil이면 생략
+
+	// 로그인 위험 평가는 WithLoginRisk 옵션을 지정한 경우에만 활성화
+	loginRisk  LoginRiskService
+	riskMailer Mailer // 두 번째 인증 수단이 없는 사용자에게 확인 코드를 보내는 메일러, nil이면 차단
+}
+
+// Lifetimes of tokens issued by GenerateTokenPair.
+const (
+	accessTokenTTL  = 15 * time.Minute   // 15분 만료
+	refreshTokenTTL = 7 * 24 * time.Hour // 7일 만료
+)
+
+// AuthServiceOption configures optional features of the authentication service.
+type AuthServiceOption func(*authService)
+
+// WithDPoP enables DPoP (RFC 9449) proof validation with replay protection on proof identifiers.
+func WithDPoP(verifier DPoPVerifier, replayRepo DPoPReplayRepository) AuthServiceOption {
+	return func(s *authService) {
+		s.dpopVerifier = verifier
+		s.dpopReplayRepo = replayRepo
+	}
+}
+
+// WithSessions enables server-side sessions: each login creates a Session referenced by the
+// sid claim, and tokens of revoked or expired sessions are rejected.
+func WithSessions(sessionRepo SessionRepository) AuthServiceOption {
+	return func(s *authService) {
+		s.sessionRepo = sessionRepo
+	}
+}
+
+// WithMFA enables second factors: users with a confirmed factor receive an MFARequiredError
+// from Authenticate and finish logging in with CompleteMFA. A challenge accepts at most
+// maxAttempts responses before it is discarded.
+func WithMFA(mfa MFAService, challengeRepo MFAChallengeRepository, challengeTTL time.Duration, maxAttempts int) AuthServiceOption {
+	return func(s *authService) {
+		s.mfa = mfa
+		s.mfaChallengeRepo = challengeRepo
+		s.mfaChallengeTTL = challengeTTL
+		s.mfaMaxAttempts = maxAttempts
+	}
+}
+
+// WithPasskeys enables passwordless login with WebAuthn passkeys.
+func WithPasskeys(passkeys PasskeyService) AuthServiceOption {
+	return func(s *authService) {
+		s.passkeys = passkeys
+	}
+}
+
+// WithMagicLinks enables passwordless login with single-use links sent by email.
+func WithMagicLinks(magicLinks MagicLinkService) AuthServiceOption {
+	return func(s *authService) {
+		s.magicLinks = magicLinks
+	}
+}
+
+// WithPlatformLogin enables signing in with external platform accounts such as Twitch.
+func WithPlatformLogin(platformLogin PlatformLoginService) AuthServiceOption {
+	return func(s *authService) {
+		s.platformLogin = platformLogin
+	}
+}
+
+// WithAPIKeys accepts personal API keys wherever bearer access tokens are validated.
+func WithAPIKeys(apiKeys APIKeyService) AuthServiceOption {
+	return func(s *authService) {
+		s.apiKeys = apiKeys
+	}
+}
+
+// WithLoginRisk scores password, magic link and platform logins before they complete and
+// records every login attempt. High-risk logins are blocked with ErrLoginBlocked. Logins that
+// require MFA are challenged with the user's second factors; users without one get a code
+// sent to their verified email address through mailer, which requires WithMFA, and are
+// blocked if mailer is nil. Passkey logins are phishing-resistant and are recorded but not
+// scored.
+func WithLoginRisk(loginRisk LoginRiskService, mailer Mailer) AuthServiceOption {
+	return func(s *authService) {
+		s.loginRisk = loginRisk
+		s.riskMailer = mailer
+	}
 }
 
-// TokenGenerator defines the interface for generating tokens (placeholder).
+// TokenGenerator defines the interface for generating and verifying tokens.
 type TokenGenerator interface {
-	GenerateAccessToken(userID string, expiry time.Time) (string, error)
-	GenerateRefreshToken(userID string, expiry time.Time) (string, error)
+	// GenerateAccessToken signs an access token; optional claims such as jti and cnf are taken from claims.
+	GenerateAccessToken(userID string, expiry time.Time, claims TokenClaims) (string, error)
+	// GenerateRefreshToken signs a refresh token; optional claims such as jti and cnf are taken from claims.
+	GenerateRefreshToken(userID string, expiry time.Time, claims TokenClaims) (string, error)
 	ValidateToken(tokenStr string) (string, error) // Returns userID
+	// ParseToken verifies the token and returns all of its claims.
+	ParseToken(tokenStr string) (*TokenClaims, error)
 }
 
 // NewAuthService creates a new instance of authService.
-func NewAuthService(userRepo UserRepository, tokenRepo TokenRepository, tokenGen TokenGenerator, eventPub EventPublisher) AuthService {
-	return &authService{
+func NewAuthService(userRepo UserRepository, tokenRepo TokenRepository, tokenGen TokenGenerator, eventPub EventPublisher, opts ...AuthServiceOption) AuthService {
+	s := &authService{
 		userRepo:  userRepo,
 		tokenRepo: tokenRepo,
 		tokenGen:  tokenGen,
 		eventPub:  eventPub,
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
-// Authenticate verifies user credentials and returns a token pair.
-func (s *authService) Authenticate(ctx context.Context, username, password string) (*Token, error) {
-	if username == "" || password == "" {
-		return nil, errors.New("username and password must not be empty")
+// Authenticate verifies user credentials and returns a token pair. When MFA is enabled and
+// the user has a confirmed second factor, it returns an *MFARequiredError instead.
+// Unknown identifiers and wrong passwords fail with the same error and take the same time.
+func (s *authService) Authenticate(ctx context.Context, identifier, password string, opts ...TokenOption) (*Token, error) {
+	if identifier == "" || password == "" {
+		return nil, errors.New("identifier and password must not be empty")
 	}
 
-	user, err := s.userRepo.FindByUsername(ctx, username)
+	user, err := s.findByIdentifier(ctx, identifier)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil || user.Status() == UserStatusDeleted {
+		verifyDummyPassword(password)
+		return nil, ErrInvalidCredentials
+	}
+
+	if !user.PasswordHash().Verify(password) {
+		_ = s.eventPub.Publish(&LoginFailed{userID: user.ID(), timestamp: time.Now()})
+		s.recordLoginAttempt(ctx, user.ID(), nil, false)
+		return nil, ErrInvalidCredentials
+	}
+
+	return s.loginOrChallenge(ctx, user, []string{AMRPassword}, opts)
+}
+
+// CompleteMFA verifies the second factor for a pending challenge and finishes the login.
+// Each call counts as an attempt, so a challenge cannot be used to guess codes indefinitely.
+func (s *authService) CompleteMFA(ctx context.Context, challengeToken, method, response string, opts ...TokenOption) (*Token, error) {
+	if s.mfa == nil {
+		return nil, errors.New("mfa is not enabled")
+	}
+	if challengeToken == "" {
+		return nil, ErrMFAChallengeInvalid
+	}
+
+	tokenHash := hashOpaqueToken(challengeToken)
+	challenge, err := s.mfaChallengeRepo.IncrementAttempts(ctx, tokenHash)
+	if err != nil {
+		return nil, errors.New("failed to find mfa challenge: " + err.Error())
+	}
+	if challenge == nil || challenge.IsExpired() {
+		return nil, ErrMFAChallengeInvalid
+	}
+	if challenge.Attempts() > s.mfaMaxAttempts {
+		_, _ = s.mfaChallengeRepo.Consume(ctx, tokenHash)
+		return nil, ErrMFAChallengeInvalid
+	}
+
+	var factorAMR string
+	if method == MFAMethodEmail {
+		factorAMR, err = verifyEmailCode(challenge, response)
+	} else {
+		factorAMR, err = s.mfa.VerifyFactor(ctx, challenge.UserID(), method, response)
+	}
+	if err != nil {
+		if errors.Is(err, ErrInvalidMFACode) {
+			_ = s.eventPub.Publish(&LoginFailed{userID: challenge.UserID(), timestamp: time.Now()})
+			s.recordLoginAttempt(ctx, challenge.UserID(), nil, false)
+		}
+		return nil, err
+	}
+
+	// 동시에 같은 챌린지로 성공한 요청 중 하나만 토큰을 받음
+	consumed, err := s.mfaChallengeRepo.Consume(ctx, tokenHash)
+	if err != nil {
+		return nil, errors.New("failed to consume mfa challenge: " + err.Error())
+	}
+	if consumed == nil {
+		return nil, ErrMFAChallengeInvalid
+	}
+
+	user, err := s.userRepo.FindByID(ctx, challenge.UserID())
 	if err != nil {
 		return nil, errors.New("failed to find user: " + err.Error())
 	}
@@ -55,12 +285,202 @@ func (s *authService) Authenticate(ctx context.Context, username, password strin
 		return nil, errors.New("user not found")
 	}
 
-	if !user.PasswordHash().Verify(password) {
-		_ = s.eventPub.Publish(&LoginFailed{userID: user.ID(), timestamp: time.Now()})
-		return nil, errors.New("invalid password")
+	amr := append(append([]string{}, challenge.AMR()...), factorAMR)
+	// 이메일 코드는 위험 확인일 뿐 두 번째 인증 수단이 아니므로 단일 요소(aal1)로 유지
+	if method != MFAMethodEmail {
+		amr = append(amr, AMRMFA)
 	}
+	return s.completeLogin(ctx, user, challenge.AuthTime(), amr, nil, opts)
+}
 
-	token, err := s.GenerateTokenPair(user.ID())
+// BeginMFAPasskey starts a passkey ceremony for the user of a pending MFA challenge.
+// Looking up the challenge does not count as an attempt; the response sent to CompleteMFA does.
+func (s *authService) BeginMFAPasskey(ctx context.Context, challengeToken string) (*PasskeyRequestOptions, error) {
+	if s.mfa == nil || s.passkeys == nil {
+		return nil, errors.New("passkey second factor is not enabled")
+	}
+	if challengeToken == "" {
+		return nil, ErrMFAChallengeInvalid
+	}
+
+	challenge, err := s.mfaChallengeRepo.FindByTokenHash(ctx, hashOpaqueToken(challengeToken))
+	if err != nil {
+		return nil, errors.New("failed to find mfa challenge: " + err.Error())
+	}
+	if challenge == nil || challenge.IsExpired() || challenge.Attempts() >= s.mfaMaxAttempts {
+		return nil, ErrMFAChallengeInvalid
+	}
+	return s.passkeys.BeginFactor(ctx, challenge.UserID())
+}
+
+// AuthenticatePasskey verifies a passwordless passkey login and issues a token pair.
+// The passkey requires user verification, so it counts as multi-factor on its own and
+// no MFA challenge follows.
+func (s *authService) AuthenticatePasskey(ctx context.Context, response []byte, opts ...TokenOption) (*Token, error) {
+	if s.passkeys == nil {
+		return nil, errors.New("passkeys are not enabled")
+	}
+
+	userID, err := s.passkeys.FinishLogin(ctx, response)
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, errors.New("failed to find user: " + err.Error())
+	}
+	if user == nil {
+		return nil, errors.New("user not found")
+	}
+
+	// 인증기 소유(hwk)와 사용자 검증(생체 인식 또는 PIN)을 함께 거침
+	return s.completeLogin(ctx, user, time.Now(), []string{AMRHardwareKey, AMRMFA}, nil, opts)
+}
+
+// findByIdentifier resolves a login identifier as an email address if it contains "@",
+// otherwise as a username. It returns nil if no user matches.
+func (s *authService) findByIdentifier(ctx context.Context, identifier string) (*User, error) {
+	var (
+		user *User
+		err  error
+	)
+	if strings.Contains(identifier, "@") {
+		email, emailErr := NewEmail(identifier)
+		if emailErr != nil {
+			return nil, nil // 형식이 잘못된 주소는 없는 사용자와 같게 처리
+		}
+		user, err = s.userRepo.FindByEmail(ctx, email)
+	} else {
+		user, err = s.userRepo.FindByUsername(ctx, identifier)
+	}
+	if err != nil {
+		return nil, errors.New("failed to find user: " + err.Error())
+	}
+	return user, nil
+}
+
+// AuthenticateMagicLink redeems an emailed login link. The link proves control of the
+// mailbox only, so users with a second factor still complete an MFA challenge.
+func (s *authService) AuthenticateMagicLink(ctx context.Context, token string, opts ...TokenOption) (*Token, error) {
+	if s.magicLinks == nil {
+		return nil, errors.New("magic links are not enabled")
+	}
+
+	userID, err := s.magicLinks.Redeem(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, errors.New("failed to find user: " + err.Error())
+	}
+	if user == nil {
+		return nil, errors.New("user not found")
+	}
+
+	return s.loginOrChallenge(ctx, user, []string{AMREmail}, opts)
+}
+
+// AuthenticatePlatform signs in with an external platform account, registering a new user
+// on first use. Users with a second factor still complete an MFA challenge.
+func (s *authService) AuthenticatePlatform(ctx context.Context, platform PlatformType, code string, opts ...TokenOption) (*Token, error) {
+	if s.platformLogin == nil {
+		return nil, ErrPlatformNotSupported
+	}
+
+	user, err := s.platformLogin.Login(ctx, platform, code)
+	if err != nil {
+		return nil, err
+	}
+	return s.loginOrChallenge(ctx, user, []string{AMRFederated}, opts)
+}
+
+// loginOrChallenge finishes a first-factor login, or returns an *MFARequiredError when
+// MFA is enabled and the user has a confirmed second factor. When login risk scoring is
+// enabled, high-risk logins fail with ErrLoginBlocked, and logins requiring MFA from users
+// without a second factor are challenged with a code sent by email.
+func (s *authService) loginOrChallenge(ctx context.Context, user *User, amr []string, opts []TokenOption) (*Token, error) {
+	authTime := time.Now()
+	var methods []string
+	if s.mfa != nil {
+		var err error
+		methods, err = s.mfa.EnabledMethods(ctx, user.ID())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var assessment *RiskAssessment
+	if s.loginRisk != nil {
+		emailFactor := len(methods) == 0 && s.canEmailChallenge(user, amr)
+		var err error
+		assessment, err = s.loginRisk.Assess(ctx, user.ID(), len(methods) > 0 || emailFactor)
+		if err != nil {
+			return nil, err
+		}
+		switch assessment.Decision {
+		case RiskDecisionBlock:
+			return nil, ErrLoginBlocked
+		case RiskDecisionRequireMFA:
+			if len(methods) == 0 {
+				return nil, s.issueEmailChallenge(ctx, user, authTime, amr)
+			}
+		}
+	}
+
+	if len(methods) > 0 {
+		return nil, s.issueMFAChallenge(ctx, user.ID(), authTime, amr, methods)
+	}
+	return s.completeLogin(ctx, user, authTime, amr, assessment, opts)
+}
+
+// issueMFAChallenge stores a pending challenge and returns the MFARequiredError carrying its token.
+func (s *authService) issueMFAChallenge(ctx context.Context, userID string, authTime time.Time, amr []string, methods []string) error {
+	rawToken, challenge, err := s.newMFAChallenge(userID, authTime, amr)
+	if err != nil {
+		return err
+	}
+	return s.saveMFAChallenge(ctx, rawToken, challenge, methods)
+}
+
+// newMFAChallenge creates a challenge for the user and returns it with its raw token.
+func (s *authService) newMFAChallenge(userID string, authTime time.Time, amr []string) (string, *MFAChallenge, error) {
+	rawToken := generateRandomString(43)
+	challenge, err := NewMFAChallenge(hashOpaqueToken(rawToken), userID, authTime, amr, time.Now().Add(s.mfaChallengeTTL))
+	if err != nil {
+		return "", nil, err
+	}
+	return rawToken, challenge, nil
+}
+
+// saveMFAChallenge stores the challenge and returns the MFARequiredError offering methods.
+func (s *authService) saveMFAChallenge(ctx context.Context, rawToken string, challenge *MFAChallenge, methods []string) error {
+	if err := s.mfaChallengeRepo.Save(ctx, challenge); err != nil {
+		return errors.New("failed to save mfa challenge: " + err.Error())
+	}
+	return &MFARequiredError{ChallengeToken: rawToken, Methods: methods, ExpiresAt: challenge.ExpiresAt()}
+}
+
+// completeLogin starts a session when sessions are enabled, issues the token pair and
+// records the login once every required factor has been verified. assessment is the risk
+// assessment made in the same request, if any.
+func (s *authService) completeLogin(ctx context.Context, user *User, authTime time.Time, amr []string, assessment *RiskAssessment, opts []TokenOption) (*Token, error) {
+	// 비밀번호 외의 방식(로그인 링크, 패스키, 플랫폼)으로도 삭제된 계정에 로그인하지 못하게 함
+	if user.Status() == UserStatusDeleted {
+		return nil, ErrInvalidCredentials
+	}
+	authOpts := append([]TokenOption{WithAuthentication(authTime, amr...)}, opts...)
+	if s.sessionRepo != nil {
+		session, err := NewSession(generateRandomString(32), user.ID(), ClientMetadataFromContext(ctx), refreshTokenTTL)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.sessionRepo.Save(ctx, session); err != nil {
+			return nil, errors.New("failed to save session: " + err.Error())
+		}
+		authOpts = append(authOpts, WithSessionID(session.ID()))
+	}
+	token, err := s.GenerateTokenPair(user.ID(), authOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -71,25 +491,43 @@ func (s *authService) Authenticate(ctx context.Context, username, password strin
 	}
 
 	_ = s.eventPub.Publish(&LoginSucceeded{userID: user.ID(), timestamp: time.Now()})
+	if !user.IsGuest() {
+		s.recordLoginAttempt(ctx, user.ID(), assessment, true)
+	}
 	return token, nil
 }
 
+// recordLoginAttempt adds the login to the history used for risk scoring, if enabled.
+func (s *authService) recordLoginAttempt(ctx context.Context, userID string, assessment *RiskAssessment, succeeded bool) {
+	if s.loginRisk == nil {
+		return
+	}
+	// 기록 실패로 로그인 결과가 바뀌지 않도록 오류는 무시
+	_ = s.loginRisk.RecordLogin(ctx, userID, assessment, succeeded)
+}
+
 // GenerateTokenPair generates a new access and refresh token pair for a user.
-func (s *authService) GenerateTokenPair(userID string) (*Token, error) {
+// Options add optional claims (e.g. a DPoP key binding) to both tokens.
+func (s *authService) GenerateTokenPair(userID string, opts ...TokenOption) (*Token, error) {
 	if userID == "" {
 		return nil, errors.New("user id must not be empty")
 	}
 
 	// JTI 생성
 	jti := generateRandomString(16)
-	accessExpiry := time.Now().Add(15 * time.Minute)    // 15분 만료
-	refreshExpiry := time.Now().Add(7 * 24 * time.Hour) // 7일 만료
+	accessExpiry := time.Now().Add(accessTokenTTL)
+	refreshExpiry := time.Now().Add(refreshTokenTTL)
+
+	accessClaims := applyTokenOptions(opts)
+	accessClaims.TokenID = jti
+	refreshClaims := applyTokenOptions(opts)
+	refreshClaims.TokenID = generateRandomString(16)
 
-	accessToken, err := s.tokenGen.GenerateAccessToken(userID, accessExpiry)
+	accessToken, err := s.tokenGen.GenerateAccessToken(userID, accessExpiry, accessClaims)
 	if err != nil {
 		return nil, errors.New("failed to generate access token: " + err.Error())
 	}
-	refreshToken, err := s.tokenGen.GenerateRefreshToken(userID, refreshExpiry)
+	refreshToken, err := s.tokenGen.GenerateRefreshToken(userID, refreshExpiry, refreshClaims)
 	if err != nil {
 		return nil, errors.New("failed to generate refresh token: " + err.Error())
 	}
@@ -97,62 +535,123 @@ func (s *authService) GenerateTokenPair(userID string) (*Token, error) {
 	return NewToken(accessToken, refreshToken, jti, accessExpiry)
 }
 
-// Logout adds a token to the blacklist.
+// Logout adds a token to the blacklist and ends the session it belongs to.
 func (s *authService) Logout(tokenID string) error {
 	if tokenID == "" {
 		return errors.New("token id must not be empty")
 	}
 
-	userID, err := s.tokenGen.ValidateToken(tokenID)
+	claims, err := s.tokenGen.ParseToken(tokenID)
 	if err != nil {
 		return errors.New("invalid token: " + err.Error())
 	}
 
 	// 블랙리스트에 추가 (7일 후 만료로 가정)
 	expiresAt := time.Now().Add(7 * 24 * time.Hour)
-	if err := s.tokenRepo.BlacklistToken(context.Background(), tokenID, userID, "logout", expiresAt); err != nil {
+	if err := s.tokenRepo.BlacklistToken(context.Background(), tokenID, claims.Subject, "logout", expiresAt); err != nil {
 		return errors.New("failed to blacklist token: " + err.Error())
 	}
 
+	// 같은 세션의 다른 토큰(리프레시 토큰 포함)도 무효화
+	if s.sessionRepo != nil && claims.SessionID != "" {
+		session, err := s.sessionRepo.FindByID(context.Background(), claims.SessionID)
+		if err != nil {
+			return errors.New("failed to find session: " + err.Error())
+		}
+		if session != nil && session.RevokedAt() == nil {
+			_ = session.Revoke()
+			if err := s.sessionRepo.Save(context.Background(), session); err != nil {
+				return errors.New("failed to revoke session: " + err.Error())
+			}
+		}
+	}
+
 	return nil
 }
 
-// ValidateToken verifies the validity of a token and returns the user ID.
+// ValidateToken verifies the validity of a bearer token and returns the user ID.
+// Tokens issued to clients via the client credentials grant have no user and are rejected.
 func (s *authService) ValidateToken(tokenStr string) (string, error) {
-	if tokenStr == "" {
-		return "", errors.New("token must not be empty")
+	claims, err := s.ValidateAccessToken(context.Background(), tokenStr)
+	if err != nil {
+		return "", err
+	}
+	if claims.IsClientToken() {
+		return "", errors.New("token was issued to a client, not a user")
 	}
+	return claims.Subject, nil
+}
 
-	userID, err := s.tokenGen.ValidateToken(tokenStr)
+// ValidateAccessToken verifies a bearer access token and returns its claims.
+// Sender-constrained tokens are rejected here; they must be presented with a DPoP proof.
+func (s *authService) ValidateAccessToken(ctx context.Context, tokenStr string) (*TokenClaims, error) {
+	if s.apiKeys != nil && IsAPIKey(tokenStr) {
+		return s.apiKeys.ValidateAPIKey(ctx, tokenStr)
+	}
+
+	claims, err := s.verifyToken(ctx, tokenStr)
 	if err != nil {
-		return "", errors.New("invalid token: " + err.Error())
+		return nil, err
+	}
+	if claims.IsSenderConstrained() {
+		return nil, errors.New("token is bound to a DPoP key and requires a proof")
 	}
+	return claims, nil
+}
 
-	// 블랙리스트 확인
-	isBlacklisted, err := s.tokenRepo.IsBlacklisted(context.Background(), tokenStr)
+// ValidateDPoPBoundToken verifies an access token together with the DPoP proof sent alongside it.
+// The proof must be signed by the key the token is bound to and must not have been used before.
+func (s *authService) ValidateDPoPBoundToken(ctx context.Context, tokenStr, proof, method, uri string) (*TokenClaims, error) {
+	if s.dpopVerifier == nil {
+		return nil, errors.New("dpop is not enabled")
+	}
+
+	claims, err := s.verifyToken(ctx, tokenStr)
 	if err != nil {
-		return "", errors.New("failed to check blacklist: " + err.Error())
+		return nil, err
 	}
-	if isBlacklisted {
-		return "", errors.New("token is blacklisted")
+	if !claims.IsSenderConstrained() {
+		return nil, errors.New("token is not bound to a DPoP key")
 	}
 
-	return userID, nil
+	verified, err := s.checkDPoPProof(ctx, proof, method, uri, tokenStr)
+	if err != nil {
+		return nil, err
+	}
+	if verified.Thumbprint() != claims.ConfirmationKeyThumbprint {
+		return nil, errors.New("dpop proof key does not match token binding")
+	}
+	return claims, nil
+}
+
+// VerifyDPoPProof verifies a DPoP proof presented at token issuance.
+// Callers bind the issued tokens with WithConfirmationKey(proof.Thumbprint()).
+func (s *authService) VerifyDPoPProof(ctx context.Context, proof, method, uri string) (*DPoPProof, error) {
+	if s.dpopVerifier == nil {
+		return nil, errors.New("dpop is not enabled")
+	}
+	return s.checkDPoPProof(ctx, proof, method, uri, "")
 }
 
 // RefreshToken generates a new token pair using a valid refresh token.
-func (s *authService) RefreshToken(refreshTokenStr string) (*Token, error) {
+// A refresh token bound to a DPoP key can only be redeemed with a proof from the same key,
+// passed by the caller as WithConfirmationKey. The client, scope, audience and session of
+// the refresh token are carried over to the new pair.
+func (s *authService) RefreshToken(ctx context.Context, refreshTokenStr string, opts ...TokenOption) (*Token, error) {
 	if refreshTokenStr == "" {
 		return nil, errors.New("refresh token must not be empty")
 	}
 
-	userID, err := s.tokenGen.ValidateToken(refreshTokenStr)
+	claims, err := s.tokenGen.ParseToken(refreshTokenStr)
 	if err != nil {
 		return nil, errors.New("invalid refresh token: " + err.Error())
 	}
+	if claims.Type != TokenTypeRefresh {
+		return nil, errors.New("token is not a refresh token")
+	}
 
 	// 블랙리스트 확인
-	isBlacklisted, err := s.tokenRepo.IsBlacklisted(context.Background(), refreshTokenStr)
+	isBlacklisted, err := s.tokenRepo.IsBlacklisted(ctx, refreshTokenStr)
 	if err != nil {
 		return nil, errors.New("failed to check blacklist: " + err.Error())
 	}
@@ -160,7 +659,105 @@ func (s *authService) RefreshToken(refreshTokenStr string) (*Token, error) {
 		return nil, errors.New("refresh token is blacklisted")
 	}
 
-	return s.GenerateTokenPair(userID)
+	// DPoP 바인딩 유지: 기존 키와 다른 키로 갱신 불가
+	requested := applyTokenOptions(opts)
+	if claims.IsSenderConstrained() && requested.ConfirmationKeyThumbprint != claims.ConfirmationKeyThumbprint {
+		return nil, errors.New("dpop proof key does not match refresh token binding")
+	}
+
+	// 세션 갱신 시각 기록 및 만료 연장
+	session, err := s.activeSession(ctx, claims.SessionID)
+	if err != nil {
+		return nil, err
+	}
+	if session != nil {
+		session.Touch(ClientMetadataFromContext(ctx), refreshTokenTTL)
+		// 조회 이후 세션이 폐기됐다면 갱신하지 않고 거부
+		touched, err := s.sessionRepo.Touch(ctx, session)
+		if err != nil {
+			return nil, errors.New("failed to update session: " + err.Error())
+		}
+		if !touched {
+			return nil, errors.New("session has been revoked or expired")
+		}
+	}
+
+	// 기존 토큰의 클라이언트와 범위를 마지막에 적용해 갱신으로 권한이 넓어지지 않도록 함
+	carried := []TokenOption{
+		WithClientID(claims.ClientID),
+		WithScope(claims.Scope...),
+		WithAudience(claims.Audience...),
+		WithAuthentication(claims.AuthTime, claims.AMR...),
+		WithSessionID(claims.SessionID),
+	}
+	return s.GenerateTokenPair(claims.Subject, append(opts, carried...)...)
+}
+
+// verifyToken checks the signature, type and blacklist status of an access token.
+func (s *authService) verifyToken(ctx context.Context, tokenStr string) (*TokenClaims, error) {
+	if tokenStr == "" {
+		return nil, errors.New("token must not be empty")
+	}
+
+	claims, err := s.tokenGen.ParseToken(tokenStr)
+	if err != nil {
+		return nil, errors.New("invalid token: " + err.Error())
+	}
+	if claims.Type != TokenTypeAccess {
+		return nil, errors.New("token is not an access token")
+	}
+
+	// 블랙리스트 확인
+	isBlacklisted, err := s.tokenRepo.IsBlacklisted(ctx, tokenStr)
+	if err != nil {
+		return nil, errors.New("failed to check blacklist: " + err.Error())
+	}
+	if isBlacklisted {
+		return nil, errors.New("token is blacklisted")
+	}
+
+	if _, err := s.activeSession(ctx, claims.SessionID); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// activeSession loads the session a token belongs to and fails if it was revoked or expired.
+// It returns nil when sessions are disabled or the token carries no sid claim.
+func (s *authService) activeSession(ctx context.Context, sessionID string) (*Session, error) {
+	if s.sessionRepo == nil || sessionID == "" {
+		return nil, nil
+	}
+
+	session, err := s.sessionRepo.FindByID(ctx, sessionID)
+	if err != nil {
+		return nil, errors.New("failed to find session: " + err.Error())
+	}
+	if session == nil || !session.IsActive() {
+		return nil, errors.New("session has been revoked or expired")
+	}
+	return session, nil
+}
+
+// checkDPoPProof verifies a proof and records its jti so it cannot be replayed.
+func (s *authService) checkDPoPProof(ctx context.Context, proof, method, uri, accessToken string) (*DPoPProof, error) {
+	if proof == "" {
+		return nil, errors.New("dpop proof must not be empty")
+	}
+
+	verified, err := s.dpopVerifier.Verify(proof, method, uri, accessToken)
+	if err != nil {
+		return nil, errors.New("invalid dpop proof: " + err.Error())
+	}
+
+	fresh, err := s.dpopReplayRepo.MarkUsed(ctx, verified.JTI(), verified.ExpiresAt())
+	if err != nil {
+		return nil, errors.New("failed to record dpop proof: " + err.Error())
+	}
+	if !fresh {
+		return nil, errors.New("dpop proof has already been used")
+	}
+	return verified, nil
 }
 
 // generateRandomString generates a random string of given length.
@@ -173,3 +770,10 @@ func generateRandomString(length int) string {
 	}
 	return string(b)
 }
+
+// hashOpaqueToken returns the hex-encoded SHA-256 hash of a high-entropy random token.
+// 무작위 토큰은 엔트로피가 충분하므로 솔트 없는 해시로 저장 및 조회
+func hashOpaqueToken(raw string) string {
+	sum := sha256.Sum256([]byte(raw))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,394 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// OAuth 2.0 grant and response types for the authorization code flow (RFC 6749 4.1, 6).
+const (
+	ResponseTypeCode           = "code"
+	GrantTypeAuthorizationCode = "authorization_code"
+	GrantTypeRefreshToken      = "refresh_token"
+)
+
+// Audit actions recorded for user consent to third-party clients.
+const (
+	AuditActionApplicationRegistered = "APPLICATION_REGISTERED"
+	AuditActionConsentGranted        = "CONSENT_GRANTED"
+	AuditActionConsentRevoked        = "CONSENT_REVOKED"
+)
+
+// ErrConsentNotFound is returned when revoking a consent the user never granted.
+var ErrConsentNotFound = errors.New("consent not found")
+
+// AuthorizationRequest holds the parameters of an authorization request (RFC 6749 4.1.1, RFC 7636 4.3).
+type AuthorizationRequest struct {
+	ClientID            string
+	RedirectURI         string
+	Scopes              []string
+	CodeChallenge       string
+	CodeChallengeMethod string
+	Nonce               string // OpenID Connect replay protection, echoed in the ID token
+}
+
+// AuthorizationService defines the OAuth 2.0 authorization code flow with PKCE for
+// first-party and third-party applications, and management of user consent.
+type AuthorizationService interface {
+	// RegisterApplication registers a client for the authorization code flow. The raw secret is
+	// returned only once and is empty for public clients.
+	RegisterApplication(ctx context.Context, name string, redirectURIs, allowedScopes []string, public, firstParty bool) (*OAuthClient, string, error)
+	// ValidateRedirect checks the client and redirect URI of an authorization request.
+	// When it fails the user must not be redirected back to the client (RFC 6749 4.1.2.1).
+	ValidateRedirect(ctx context.Context, clientID, redirectURI string) (*OAuthClient, error)
+	// Authorize issues an authorization code for the user identified by the claims of their
+	// access token. It returns an OAuthError with OAuthErrConsentRequired when the user has
+	// not yet approved the scopes.
+	Authorize(ctx context.Context, user *TokenClaims, req AuthorizationRequest) (string, error)
+	// GrantConsent records the user's approval of the scopes for the client.
+	GrantConsent(ctx context.Context, userID, clientID string, scopes []string) error
+	// ExchangeCode redeems an authorization code for a token pair after verifying the PKCE code verifier.
+	// An ID token is attached when the openid scope was granted.
+	ExchangeCode(ctx context.Context, clientID, clientSecret, code, redirectURI, codeVerifier string, opts ...TokenOption) (*Token, error)
+	// Refresh redeems a refresh token. Tokens issued to a client require that client's
+	// credentials and a consent that has not been revoked.
+	Refresh(ctx context.Context, clientID, clientSecret, refreshToken string, opts ...TokenOption) (*Token, error)
+	// ListConsents returns the consents granted by the user.
+	ListConsents(ctx context.Context, userID string) ([]*Consent, error)
+	// RevokeConsent removes the user's consent for the client; its refresh tokens stop working
+	// and outstanding access tokens expire naturally.
+	RevokeConsent(ctx context.Context, userID, clientID string) error
+}
+
+// authorizationService implements AuthorizationService with domain logic.
+type authorizationService struct {
+	authService AuthService
+	tokenGen    TokenGenerator
+	idTokenGen  IDTokenGenerator
+	clientRepo  OAuthClientRepository
+	codeRepo    AuthorizationCodeRepository
+	consentRepo ConsentRepository
+	auditRepo   AuditLogRepository
+	codeTTL     time.Duration
+}
+
+// NewAuthorizationService creates a new instance of authorizationService.
+// codeTTL is the lifetime of authorization codes; RFC 6749 recommends at most 10 minutes.
+func NewAuthorizationService(authService AuthService, tokenGen TokenGenerator, idTokenGen IDTokenGenerator, clientRepo OAuthClientRepository, codeRepo AuthorizationCodeRepository, consentRepo ConsentRepository, auditRepo AuditLogRepository, codeTTL time.Duration) AuthorizationService {
+	return &authorizationService{
+		authService: authService,
+		tokenGen:    tokenGen,
+		idTokenGen:  idTokenGen,
+		clientRepo:  clientRepo,
+		codeRepo:    codeRepo,
+		consentRepo: consentRepo,
+		auditRepo:   auditRepo,
+		codeTTL:     codeTTL,
+	}
+}
+
+// RegisterApplication registers a confidential or public client with its redirect URIs.
+func (s *authorizationService) RegisterApplication(ctx context.Context, name string, redirectURIs, allowedScopes []string, public, firstParty bool) (*OAuthClient, string, error) {
+	if len(redirectURIs) == 0 {
+		return nil, "", errors.New("at least one redirect uri must be registered")
+	}
+
+	var (
+		client    *OAuthClient
+		rawSecret string
+		err       error
+	)
+	if public {
+		client, err = NewPublicOAuthClient(generateRandomString(32), name, allowedScopes, accessTokenTTL)
+	} else {
+		rawSecret = generateRandomString(48)
+		secretHash, hashErr := NewPassword(rawSecret)
+		if hashErr != nil {
+			return nil, "", errors.New("failed to hash client secret: " + hashErr.Error())
+		}
+		client, err = NewOAuthClient(generateRandomString(32), name, secretHash, allowedScopes, accessTokenTTL)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	if err := client.SetRedirectURIs(redirectURIs); err != nil {
+		return nil, "", err
+	}
+	client.SetFirstParty(firstParty)
+
+	if err := s.clientRepo.Save(ctx, client); err != nil {
+		return nil, "", errors.New("failed to save client: " + err.Error())
+	}
+
+	clientID := client.ID()
+	recordAudit(ctx, s.auditRepo, AuditActionApplicationRegistered, auditEntityOAuthClient, nil, &clientID, map[string]interface{}{
+		"name": name, "redirect_uris": redirectURIs, "scopes": allowedScopes, "public": public, "first_party": firstParty,
+	})
+	return client, rawSecret, nil
+}
+
+// ValidateRedirect checks that the client exists and the redirect URI exactly matches a registered one.
+func (s *authorizationService) ValidateRedirect(ctx context.Context, clientID, redirectURI string) (*OAuthClient, error) {
+	if clientID == "" || redirectURI == "" {
+		return nil, NewOAuthError(OAuthErrInvalidRequest, "client_id and redirect_uri are required")
+	}
+
+	client, err := s.clientRepo.FindByID(ctx, clientID)
+	if err != nil {
+		return nil, errors.New("failed to find client: " + err.Error())
+	}
+	if client == nil {
+		return nil, NewOAuthError(OAuthErrInvalidClient, "unknown client")
+	}
+	if !client.AllowsRedirectURI(redirectURI) {
+		return nil, NewOAuthError(OAuthErrInvalidRequest, "redirect_uri is not registered for client")
+	}
+	return client, nil
+}
+
+// Authorize validates the request and issues a single-use authorization code.
+// When no scope is requested, all scopes registered for the client are requested.
+func (s *authorizationService) Authorize(ctx context.Context, user *TokenClaims, req AuthorizationRequest) (string, error) {
+	if user == nil || user.Subject == "" {
+		return "", errors.New("user must not be empty")
+	}
+	userID := user.Subject
+
+	client, err := s.ValidateRedirect(ctx, req.ClientID, req.RedirectURI)
+	if err != nil {
+		return "", err
+	}
+
+	// 모든 클라이언트에 PKCE(S256) 필수
+	if req.CodeChallengeMethod != CodeChallengeMethodS256 || !isValidCodeChallenge(req.CodeChallenge) {
+		return "", NewOAuthError(OAuthErrInvalidRequest, "code_challenge with method S256 is required")
+	}
+
+	scopes := req.Scopes
+	if len(scopes) == 0 {
+		scopes = client.AllowedScopes()
+	}
+	if !client.AllowsScopes(scopes) {
+		return "", NewOAuthError(OAuthErrInvalidScope, "requested scope is not allowed for client")
+	}
+
+	if !client.IsFirstParty() {
+		consent, err := s.consentRepo.Find(ctx, userID, client.ID())
+		if err != nil {
+			return "", errors.New("failed to find consent: " + err.Error())
+		}
+		if consent == nil || !consent.Covers(scopes) {
+			return "", NewOAuthError(OAuthErrConsentRequired, "user has not approved the requested scopes")
+		}
+	}
+
+	rawCode := generateRandomString(43)
+	code, err := NewAuthorizationCode(hashOpaqueToken(rawCode), client.ID(), userID, req.RedirectURI, scopes, req.CodeChallenge, time.Now().Add(s.codeTTL))
+	if err != nil {
+		return "", err
+	}
+	code.SetAuthenticationContext(req.Nonce, user.AuthTime, user.AMR)
+	if err := s.codeRepo.Save(ctx, code); err != nil {
+		return "", errors.New("failed to save authorization code: " + err.Error())
+	}
+	return rawCode, nil
+}
+
+// GrantConsent records or extends the user's consent for the client.
+func (s *authorizationService) GrantConsent(ctx context.Context, userID, clientID string, scopes []string) error {
+	if userID == "" || clientID == "" {
+		return errors.New("user id and client id must not be empty")
+	}
+
+	client, err := s.clientRepo.FindByID(ctx, clientID)
+	if err != nil {
+		return errors.New("failed to find client: " + err.Error())
+	}
+	if client == nil {
+		return NewOAuthError(OAuthErrInvalidClient, "unknown client")
+	}
+	if len(scopes) == 0 {
+		scopes = client.AllowedScopes()
+	}
+	if !client.AllowsScopes(scopes) {
+		return NewOAuthError(OAuthErrInvalidScope, "requested scope is not allowed for client")
+	}
+
+	consent, err := s.consentRepo.Find(ctx, userID, clientID)
+	if err != nil {
+		return errors.New("failed to find consent: " + err.Error())
+	}
+	if consent == nil {
+		if consent, err = NewConsent(userID, clientID, scopes); err != nil {
+			return err
+		}
+	} else {
+		consent.AddScopes(scopes)
+	}
+	if err := s.consentRepo.Save(ctx, consent); err != nil {
+		return errors.New("failed to save consent: " + err.Error())
+	}
+
+	recordAudit(ctx, s.auditRepo, AuditActionConsentGranted, auditEntityOAuthClient, &userID, &clientID, map[string]interface{}{"scopes": consent.Scopes()})
+	return nil
+}
+
+// ExchangeCode authenticates the client, consumes the code and issues a token pair scoped to the grant.
+func (s *authorizationService) ExchangeCode(ctx context.Context, clientID, clientSecret, code, redirectURI, codeVerifier string, opts ...TokenOption) (*Token, error) {
+	if code == "" || redirectURI == "" || codeVerifier == "" {
+		return nil, NewOAuthError(OAuthErrInvalidRequest, "code, redirect_uri and code_verifier are required")
+	}
+
+	client, err := s.authenticateClient(ctx, clientID, clientSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	// 코드는 조회와 동시에 삭제되므로 검증에 실패해도 재사용 불가
+	grant, err := s.codeRepo.Consume(ctx, hashOpaqueToken(code))
+	if err != nil {
+		return nil, errors.New("failed to consume authorization code: " + err.Error())
+	}
+	if grant == nil || grant.ClientID() != client.ID() || grant.IsExpired() {
+		return nil, NewOAuthError(OAuthErrInvalidGrant, "authorization code is invalid or expired")
+	}
+	if grant.RedirectURI() != redirectURI {
+		return nil, NewOAuthError(OAuthErrInvalidGrant, "redirect_uri does not match authorization request")
+	}
+	if !grant.VerifyCodeVerifier(codeVerifier) {
+		return nil, NewOAuthError(OAuthErrInvalidGrant, "code_verifier does not match code_challenge")
+	}
+
+	token, err := s.authService.GenerateTokenPair(grant.UserID(), append(opts,
+		WithClientID(client.ID()),
+		WithScope(grant.Scopes()...),
+		WithAuthentication(grant.AuthTime(), grant.AMR()...),
+	)...)
+	if err != nil {
+		return nil, err
+	}
+
+	if containsString(grant.Scopes(), ScopeOpenID) {
+		idToken, err := s.idTokenGen.GenerateIDToken(IDTokenClaims{
+			Subject:     grant.UserID(),
+			Audience:    client.ID(),
+			Nonce:       grant.Nonce(),
+			AuthTime:    grant.AuthTime(),
+			AMR:         grant.AMR(),
+			ACR:         ACRForAMR(grant.AMR()),
+			ExpiresAt:   token.Expiry(),
+			AccessToken: token.AccessToken(),
+		})
+		if err != nil {
+			return nil, errors.New("failed to generate id token: " + err.Error())
+		}
+		token.SetIDToken(idToken)
+	}
+	return token, nil
+}
+
+// Refresh redeems a refresh token, enforcing client authentication and consent for client tokens.
+// Refresh tokens from a first-party login carry no client and are redeemed without client credentials.
+func (s *authorizationService) Refresh(ctx context.Context, clientID, clientSecret, refreshToken string, opts ...TokenOption) (*Token, error) {
+	if refreshToken == "" {
+		return nil, NewOAuthError(OAuthErrInvalidRequest, "refresh_token is required")
+	}
+
+	claims, err := s.tokenGen.ParseToken(refreshToken)
+	if err != nil {
+		return nil, NewOAuthError(OAuthErrInvalidGrant, "refresh token is invalid")
+	}
+
+	if claims.ClientID != "" || clientID != "" {
+		client, err := s.authenticateClient(ctx, clientID, clientSecret)
+		if err != nil {
+			return nil, err
+		}
+		if claims.ClientID != client.ID() {
+			return nil, NewOAuthError(OAuthErrInvalidGrant, "refresh token was not issued to client")
+		}
+		if !client.IsFirstParty() {
+			consent, err := s.consentRepo.Find(ctx, claims.Subject, client.ID())
+			if err != nil {
+				return nil, errors.New("failed to find consent: " + err.Error())
+			}
+			if consent == nil || !consent.Covers(claims.Scope) {
+				return nil, NewOAuthError(OAuthErrInvalidGrant, "consent has been revoked")
+			}
+		}
+	}
+
+	token, err := s.authService.RefreshToken(ctx, refreshToken, opts...)
+	if err != nil {
+		return nil, NewOAuthError(OAuthErrInvalidGrant, err.Error())
+	}
+	return token, nil
+}
+
+// ListConsents returns the consents granted by the user.
+func (s *authorizationService) ListConsents(ctx context.Context, userID string) ([]*Consent, error) {
+	if userID == "" {
+		return nil, errors.New("user id must not be empty")
+	}
+
+	consents, err := s.consentRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, errors.New("failed to list consents: " + err.Error())
+	}
+	return consents, nil
+}
+
+// RevokeConsent removes the user's consent for the client.
+func (s *authorizationService) RevokeConsent(ctx context.Context, userID, clientID string) error {
+	if userID == "" || clientID == "" {
+		return errors.New("user id and client id must not be empty")
+	}
+
+	consent, err := s.consentRepo.Find(ctx, userID, clientID)
+	if err != nil {
+		return errors.New("failed to find consent: " + err.Error())
+	}
+	if consent == nil {
+		return ErrConsentNotFound
+	}
+	if err := s.consentRepo.Delete(ctx, userID, clientID); err != nil {
+		return errors.New("failed to revoke consent: " + err.Error())
+	}
+
+	recordAudit(ctx, s.auditRepo, AuditActionConsentRevoked, auditEntityOAuthClient, &userID, &clientID, map[string]interface{}{"scopes": consent.Scopes()})
+	return nil
+}
+
+// authenticateClient verifies the client credentials presented at the token endpoint.
+func (s *authorizationService) authenticateClient(ctx context.Context, clientID, clientSecret string) (*OAuthClient, error) {
+	return authenticateOAuthClient(ctx, s.clientRepo, clientID, clientSecret)
+}
+
+// authenticateOAuthClient verifies client credentials presented at the token endpoint.
+// Public clients send no secret; confidential clients must send a matching one.
+func authenticateOAuthClient(ctx context.Context, clientRepo OAuthClientRepository, clientID, clientSecret string) (*OAuthClient, error) {
+	if clientID == "" {
+		return nil, NewOAuthError(OAuthErrInvalidClient, "client authentication is required")
+	}
+
+	client, err := clientRepo.FindByID(ctx, clientID)
+	if err != nil {
+		return nil, errors.New("failed to find client: " + err.Error())
+	}
+	if client == nil {
+		// 존재하지 않는 클라이언트도 동일한 시간이 걸리도록 더미 검증 수행
+		verifyDummyPassword(clientSecret)
+		return nil, NewOAuthError(OAuthErrInvalidClient, "client authentication failed")
+	}
+	if client.IsPublic() {
+		if clientSecret != "" {
+			return nil, NewOAuthError(OAuthErrInvalidClient, "public clients must not send a secret")
+		}
+		return client, nil
+	}
+	if !client.VerifySecret(clientSecret) {
+		return nil, NewOAuthError(OAuthErrInvalidClient, "client authentication failed")
+	}
+	return client, nil
+}
@@ -0,0 +1,156 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"time"
+)
+
+// CodeChallengeMethodS256 is the only PKCE method accepted (RFC 7636 4.2); "plain" is rejected.
+const CodeChallengeMethodS256 = "S256"
+
+// AuthorizationCode is a short-lived, single-use code issued by the authorize endpoint (RFC 6749 4.1).
+// Only a hash of the code is stored so a database leak cannot be replayed at the token endpoint.
+type AuthorizationCode struct {
+	codeHash      string
+	clientID      string
+	userID        string
+	redirectURI   string
+	scopes        []string
+	codeChallenge string // PKCE S256 challenge
+	expiresAt     time.Time
+	createdAt     time.Time
+
+	// OpenID Connect: ID 토큰 발급 시 그대로 전달
+	nonce    string
+	authTime time.Time
+	amr      []string
+}
+
+// NewAuthorizationCode creates a new AuthorizationCode instance.
+func NewAuthorizationCode(codeHash, clientID, userID, redirectURI string, scopes []string, codeChallenge string, expiresAt time.Time) (*AuthorizationCode, error) {
+	if codeHash == "" {
+		return nil, errors.New("code hash must not be empty")
+	}
+	if clientID == "" || userID == "" {
+		return nil, errors.New("client id and user id must not be empty")
+	}
+	if redirectURI == "" {
+		return nil, errors.New("redirect uri must not be empty")
+	}
+	if codeChallenge == "" {
+		return nil, errors.New("code challenge must not be empty")
+	}
+
+	return &AuthorizationCode{
+		codeHash:      codeHash,
+		clientID:      clientID,
+		userID:        userID,
+		redirectURI:   redirectURI,
+		scopes:        scopes,
+		codeChallenge: codeChallenge,
+		expiresAt:     expiresAt,
+		createdAt:     time.Now(),
+	}, nil
+}
+
+// CodeHash returns the SHA-256 hash of the code.
+func (c *AuthorizationCode) CodeHash() string {
+	return c.codeHash
+}
+
+// ClientID returns the client the code was issued to.
+func (c *AuthorizationCode) ClientID() string {
+	return c.clientID
+}
+
+// UserID returns the user who authorized the client.
+func (c *AuthorizationCode) UserID() string {
+	return c.userID
+}
+
+// RedirectURI returns the redirect URI used in the authorization request.
+func (c *AuthorizationCode) RedirectURI() string {
+	return c.redirectURI
+}
+
+// Scopes returns the scopes granted with the code.
+func (c *AuthorizationCode) Scopes() []string {
+	return c.scopes
+}
+
+// CodeChallenge returns the PKCE code challenge.
+func (c *AuthorizationCode) CodeChallenge() string {
+	return c.codeChallenge
+}
+
+// ExpiresAt returns the time when the code expires.
+func (c *AuthorizationCode) ExpiresAt() time.Time {
+	return c.expiresAt
+}
+
+// CreatedAt returns the time when the code was issued.
+func (c *AuthorizationCode) CreatedAt() time.Time {
+	return c.createdAt
+}
+
+// Nonce returns the OpenID Connect nonce sent with the authorization request.
+func (c *AuthorizationCode) Nonce() string {
+	return c.nonce
+}
+
+// AuthTime returns when the user authenticated before authorizing the client.
+func (c *AuthorizationCode) AuthTime() time.Time {
+	return c.authTime
+}
+
+// AMR returns the authentication methods the user used before authorizing the client.
+func (c *AuthorizationCode) AMR() []string {
+	return c.amr
+}
+
+// SetAuthenticationContext records the nonce and the user's authentication for the ID token.
+func (c *AuthorizationCode) SetAuthenticationContext(nonce string, authTime time.Time, amr []string) {
+	c.nonce = nonce
+	c.authTime = authTime
+	c.amr = amr
+}
+
+// IsExpired checks if the code has expired.
+func (c *AuthorizationCode) IsExpired() bool {
+	return time.Now().After(c.expiresAt)
+}
+
+// VerifyCodeVerifier checks the PKCE code verifier against the stored S256 challenge.
+func (c *AuthorizationCode) VerifyCodeVerifier(verifier string) bool {
+	if !isValidCodeVerifier(verifier) {
+		return false
+	}
+	sum := sha256.Sum256([]byte(verifier))
+	expected := base64.RawURLEncoding.EncodeToString(sum[:])
+	return subtle.ConstantTimeCompare([]byte(expected), []byte(c.codeChallenge)) == 1
+}
+
+// isValidCodeVerifier checks the verifier length and alphabet (RFC 7636 4.1).
+func isValidCodeVerifier(verifier string) bool {
+	if len(verifier) < 43 || len(verifier) > 128 {
+		return false
+	}
+	for _, r := range verifier {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case r == '-' || r == '.' || r == '_' || r == '~':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// isValidCodeChallenge checks that an S256 challenge is a base64url-encoded SHA-256 digest.
+func isValidCodeChallenge(challenge string) bool {
+	decoded, err := base64.RawURLEncoding.DecodeString(challenge)
+	return err == nil && len(decoded) == sha256.Size
+}
@@ -0,0 +1,27 @@
+package domain
+
+import "context"
+
+// ClientMetadata describes the device a request comes from. The transport layer attaches it
+// to the request context so domain services can record it without depending on transport types.
+type ClientMetadata struct {
+	IPAddress  string
+	UserAgent  string
+	DeviceName string
+	// DeviceFingerprint는 앱이 기기마다 생성해 보내는 식별자 (로그인 위험 평가에 사용)
+	DeviceFingerprint string
+}
+
+// clientMetadataKey is the context key for ClientMetadata.
+type clientMetadataKey struct{}
+
+// ContextWithClientMetadata returns a copy of ctx carrying the client metadata.
+func ContextWithClientMetadata(ctx context.Context, metadata ClientMetadata) context.Context {
+	return context.WithValue(ctx, clientMetadataKey{}, metadata)
+}
+
+// ClientMetadataFromContext returns the client metadata attached to ctx, or an empty value.
+func ClientMetadataFromContext(ctx context.Context) ClientMetadata {
+	metadata, _ := ctx.Value(clientMetadataKey{}).(ClientMetadata)
+	return metadata
+}
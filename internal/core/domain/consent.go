@@ -0,0 +1,88 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// Consent records the scopes a user has granted to a third-party OAuth client.
+// Deleting the consent revokes the client's ability to obtain new tokens for the user.
+type Consent struct {
+	userID    string
+	clientID  string
+	scopes    []string
+	grantedAt time.Time
+	updatedAt time.Time
+}
+
+// NewConsent creates a new Consent instance.
+func NewConsent(userID, clientID string, scopes []string) (*Consent, error) {
+	if userID == "" || clientID == "" {
+		return nil, errors.New("user id and client id must not be empty")
+	}
+
+	now := time.Now()
+	return &Consent{
+		userID:    userID,
+		clientID:  clientID,
+		scopes:    scopes,
+		grantedAt: now,
+		updatedAt: now,
+	}, nil
+}
+
+// NewConsentFromStorage restores a Consent loaded from storage with its original timestamps.
+func NewConsentFromStorage(userID, clientID string, scopes []string, grantedAt, updatedAt time.Time) (*Consent, error) {
+	consent, err := NewConsent(userID, clientID, scopes)
+	if err != nil {
+		return nil, err
+	}
+	consent.grantedAt = grantedAt
+	consent.updatedAt = updatedAt
+	return consent, nil
+}
+
+// UserID returns the user who granted the consent.
+func (c *Consent) UserID() string {
+	return c.userID
+}
+
+// ClientID returns the client the consent was granted to.
+func (c *Consent) ClientID() string {
+	return c.clientID
+}
+
+// Scopes returns the granted scopes.
+func (c *Consent) Scopes() []string {
+	return c.scopes
+}
+
+// GrantedAt returns the time when the consent was first granted.
+func (c *Consent) GrantedAt() time.Time {
+	return c.grantedAt
+}
+
+// UpdatedAt returns the time when the consent was last extended.
+func (c *Consent) UpdatedAt() time.Time {
+	return c.updatedAt
+}
+
+// Covers reports whether every requested scope has been granted.
+func (c *Consent) Covers(scopes []string) bool {
+	for _, scope := range scopes {
+		if !containsString(c.scopes, scope) {
+			return false
+		}
+	}
+	return true
+}
+
+// AddScopes extends the consent with newly granted scopes.
+func (c *Consent) AddScopes(scopes []string) {
+	for _, scope := range scopes {
+		if !containsString(c.scopes, scope) {
+			c.scopes = append(c.scopes, scope)
+		}
+	}
+	c.updatedAt = time.Now()
+}
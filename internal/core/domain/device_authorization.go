@@ -0,0 +1,477 @@
+package domain
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// GrantTypeDeviceCode is the grant type of the device authorization grant (RFC 8628 3.4).
+const GrantTypeDeviceCode = "urn:ietf:params:oauth:grant-type:device_code"
+
+// AuditActionDeviceAuthorized is recorded when a user approves a device authorization request.
+const AuditActionDeviceAuthorized = "DEVICE_AUTHORIZED"
+
+// ErrUserCodeInvalid is returned when a user code is unknown, expired or already decided.
+var ErrUserCodeInvalid = errors.New("user code is invalid or expired")
+
+// User code format: eight consonants without look-alike characters, shown as two groups of
+// four (e.g. "WDJB-MJHT", RFC 8628 6.1).
+const (
+	userCodeLength   = 8
+	userCodeAlphabet = "BCDFGHJKLMNPQRSTVWXZ"
+)
+
+// slowDownIncrement is added to the polling interval after each slow_down error (RFC 8628 3.5).
+const slowDownIncrement = 5 * time.Second
+
+// DeviceAuthorizationStatus is the state of a device authorization request.
+type DeviceAuthorizationStatus string
+
+const (
+	DeviceAuthorizationPending  DeviceAuthorizationStatus = "PENDING"
+	DeviceAuthorizationApproved DeviceAuthorizationStatus = "APPROVED"
+	DeviceAuthorizationDenied   DeviceAuthorizationStatus = "DENIED"
+)
+
+// DeviceAuthorization is a pending authorization of a device without a convenient browser
+// (RFC 8628). The device polls with the device code while the user approves the user code
+// on another device. Only hashes of both codes are stored.
+type DeviceAuthorization struct {
+	deviceCodeHash string
+	userCodeHash   string
+	clientID       string
+	scopes         []string
+	status         DeviceAuthorizationStatus
+	interval       time.Duration
+	lastPolledAt   *time.Time
+	expiresAt      time.Time
+	createdAt      time.Time
+
+	// 승인한 사용자와 인증 정보 (승인 후에만 설정)
+	userID   string
+	authTime time.Time
+	amr      []string
+}
+
+// NewDeviceAuthorization creates a new pending DeviceAuthorization instance.
+func NewDeviceAuthorization(deviceCodeHash, userCodeHash, clientID string, scopes []string, interval time.Duration, expiresAt time.Time) (*DeviceAuthorization, error) {
+	if deviceCodeHash == "" || userCodeHash == "" {
+		return nil, errors.New("device code hash and user code hash must not be empty")
+	}
+	if clientID == "" {
+		return nil, errors.New("client id must not be empty")
+	}
+	if interval <= 0 {
+		return nil, errors.New("polling interval must be positive")
+	}
+
+	return &DeviceAuthorization{
+		deviceCodeHash: deviceCodeHash,
+		userCodeHash:   userCodeHash,
+		clientID:       clientID,
+		scopes:         scopes,
+		status:         DeviceAuthorizationPending,
+		interval:       interval,
+		expiresAt:      expiresAt,
+		createdAt:      time.Now(),
+	}, nil
+}
+
+// NewDeviceAuthorizationFromStorage restores a DeviceAuthorization loaded from storage.
+func NewDeviceAuthorizationFromStorage(deviceCodeHash, userCodeHash, clientID string, scopes []string, status DeviceAuthorizationStatus, interval time.Duration, lastPolledAt *time.Time, userID string, authTime time.Time, amr []string, expiresAt, createdAt time.Time) (*DeviceAuthorization, error) {
+	d, err := NewDeviceAuthorization(deviceCodeHash, userCodeHash, clientID, scopes, interval, expiresAt)
+	if err != nil {
+		return nil, err
+	}
+	switch status {
+	case DeviceAuthorizationPending, DeviceAuthorizationApproved, DeviceAuthorizationDenied:
+	default:
+		return nil, errors.New("invalid device authorization status")
+	}
+	d.status = status
+	d.lastPolledAt = lastPolledAt
+	d.userID = userID
+	d.authTime = authTime
+	d.amr = amr
+	d.createdAt = createdAt
+	return d, nil
+}
+
+// DeviceCodeHash returns the SHA-256 hash of the device code.
+func (d *DeviceAuthorization) DeviceCodeHash() string {
+	return d.deviceCodeHash
+}
+
+// UserCodeHash returns the SHA-256 hash of the normalized user code.
+func (d *DeviceAuthorization) UserCodeHash() string {
+	return d.userCodeHash
+}
+
+// ClientID returns the client that requested the authorization.
+func (d *DeviceAuthorization) ClientID() string {
+	return d.clientID
+}
+
+// Scopes returns the requested scopes.
+func (d *DeviceAuthorization) Scopes() []string {
+	return d.scopes
+}
+
+// Status returns the state of the request.
+func (d *DeviceAuthorization) Status() DeviceAuthorizationStatus {
+	return d.status
+}
+
+// Interval returns the minimum time the device must wait between polls.
+func (d *DeviceAuthorization) Interval() time.Duration {
+	return d.interval
+}
+
+// LastPolledAt returns when the device last polled, if it has.
+func (d *DeviceAuthorization) LastPolledAt() *time.Time {
+	return d.lastPolledAt
+}
+
+// UserID returns the user who approved the request, empty until approved.
+func (d *DeviceAuthorization) UserID() string {
+	return d.userID
+}
+
+// AuthTime returns when the approving user authenticated.
+func (d *DeviceAuthorization) AuthTime() time.Time {
+	return d.authTime
+}
+
+// AMR returns how the approving user authenticated.
+func (d *DeviceAuthorization) AMR() []string {
+	return d.amr
+}
+
+// ExpiresAt returns when the device and user codes expire.
+func (d *DeviceAuthorization) ExpiresAt() time.Time {
+	return d.expiresAt
+}
+
+// CreatedAt returns when the request was made.
+func (d *DeviceAuthorization) CreatedAt() time.Time {
+	return d.createdAt
+}
+
+// IsExpired reports whether the codes have expired.
+func (d *DeviceAuthorization) IsExpired() bool {
+	return time.Now().After(d.expiresAt)
+}
+
+// Approve records the user's approval together with how they authenticated.
+func (d *DeviceAuthorization) Approve(userID string, authTime time.Time, amr []string) error {
+	if userID == "" {
+		return errors.New("user id must not be empty")
+	}
+	if d.status != DeviceAuthorizationPending {
+		return errors.New("device authorization was already decided")
+	}
+	d.status = DeviceAuthorizationApproved
+	d.userID = userID
+	d.authTime = authTime
+	d.amr = amr
+	return nil
+}
+
+// Deny records that the user rejected the request.
+func (d *DeviceAuthorization) Deny() error {
+	if d.status != DeviceAuthorizationPending {
+		return errors.New("device authorization was already decided")
+	}
+	d.status = DeviceAuthorizationDenied
+	return nil
+}
+
+// Poll records a poll by the device at now and reports whether it came before the interval
+// elapsed. Polling too fast also increases the interval (RFC 8628 3.5).
+func (d *DeviceAuthorization) Poll(now time.Time) bool {
+	tooFast := d.lastPolledAt != nil && now.Sub(*d.lastPolledAt) < d.interval
+	if tooFast {
+		d.interval += slowDownIncrement
+	}
+	d.lastPolledAt = &now
+	return tooFast
+}
+
+// newUserCode returns a random user code formatted as two dash-separated groups.
+func newUserCode() (string, error) {
+	b := make([]byte, userCodeLength)
+	max := 256 - 256%len(userCodeAlphabet) // 모듈로 편향 제거
+	for i := 0; i < len(b); {
+		var r [1]byte
+		if _, err := rand.Read(r[:]); err != nil {
+			return "", errors.New("failed to generate user code: " + err.Error())
+		}
+		if int(r[0]) >= max {
+			continue
+		}
+		b[i] = userCodeAlphabet[int(r[0])%len(userCodeAlphabet)]
+		i++
+	}
+	half := userCodeLength / 2
+	return string(b[:half]) + "-" + string(b[half:]), nil
+}
+
+// HashUserCode returns the lookup hash of a user code. Dashes, spaces and letter case are ignored.
+func HashUserCode(userCode string) string {
+	normalized := strings.Map(func(r rune) rune {
+		if r == '-' || r == ' ' {
+			return -1
+		}
+		return r
+	}, strings.ToUpper(userCode))
+	return hashOpaqueToken(normalized)
+}
+
+// DeviceAuthorizationGrant is the device authorization response (RFC 8628 3.2).
+type DeviceAuthorizationGrant struct {
+	DeviceCode              string
+	UserCode                string
+	VerificationURI         string
+	VerificationURIComplete string
+	ExpiresAt               time.Time
+	Interval                time.Duration
+}
+
+// DeviceVerification describes a pending request to the user who entered its user code.
+type DeviceVerification struct {
+	ClientID   string
+	ClientName string
+	Scopes     []string
+}
+
+// DeviceAuthorizationService defines the OAuth 2.0 device authorization grant (RFC 8628).
+type DeviceAuthorizationService interface {
+	// StartDeviceAuthorization authenticates the client and issues a device code and user code.
+	StartDeviceAuthorization(ctx context.Context, clientID, clientSecret string, scopes []string) (*DeviceAuthorizationGrant, error)
+	// LookupUserCode returns what the signed-in user is asked to approve.
+	LookupUserCode(ctx context.Context, user *TokenClaims, userCode string) (*DeviceVerification, error)
+	// DecideUserCode records the signed-in user's approval or denial of the request.
+	DecideUserCode(ctx context.Context, user *TokenClaims, userCode string, approve bool) error
+	// PollToken redeems an approved device code for a token pair. Until the user decides,
+	// it returns an OAuthError with OAuthErrAuthorizationPending or OAuthErrSlowDown.
+	PollToken(ctx context.Context, clientID, clientSecret, deviceCode string, opts ...TokenOption) (*Token, error)
+}
+
+// deviceAuthorizationService implements DeviceAuthorizationService with domain logic.
+type deviceAuthorizationService struct {
+	authService     AuthService
+	authzService    AuthorizationService
+	clientRepo      OAuthClientRepository
+	deviceRepo      DeviceAuthorizationRepository
+	rateLimitRepo   RateLimitRepository
+	auditRepo       AuditLogRepository
+	verificationURI string // 사용자가 코드를 입력하는 화면 주소
+	ttl             time.Duration
+	interval        time.Duration
+	userCodeLimit   int // 사용자당 userCodeWindow 동안 허용하는 사용자 코드 입력 횟수
+	userCodeWindow  time.Duration
+}
+
+// NewDeviceAuthorizationService creates a new instance of deviceAuthorizationService.
+// Codes expire after ttl, and devices are asked to poll at most once per interval. Each user
+// may enter at most userCodeLimit user codes per userCodeWindow, so the short codes cannot
+// be guessed (RFC 8628 5.1).
+func NewDeviceAuthorizationService(authService AuthService, authzService AuthorizationService, clientRepo OAuthClientRepository, deviceRepo DeviceAuthorizationRepository, rateLimitRepo RateLimitRepository, auditRepo AuditLogRepository, verificationURI string, ttl, interval time.Duration, userCodeLimit int, userCodeWindow time.Duration) DeviceAuthorizationService {
+	return &deviceAuthorizationService{
+		authService:     authService,
+		authzService:    authzService,
+		clientRepo:      clientRepo,
+		deviceRepo:      deviceRepo,
+		rateLimitRepo:   rateLimitRepo,
+		auditRepo:       auditRepo,
+		verificationURI: verificationURI,
+		ttl:             ttl,
+		interval:        interval,
+		userCodeLimit:   userCodeLimit,
+		userCodeWindow:  userCodeWindow,
+	}
+}
+
+// StartDeviceAuthorization issues the codes for a new request. When no scope is requested,
+// all scopes registered for the client are requested.
+func (s *deviceAuthorizationService) StartDeviceAuthorization(ctx context.Context, clientID, clientSecret string, scopes []string) (*DeviceAuthorizationGrant, error) {
+	client, err := authenticateOAuthClient(ctx, s.clientRepo, clientID, clientSecret)
+	if err != nil {
+		return nil, err
+	}
+	if len(scopes) == 0 {
+		scopes = client.AllowedScopes()
+	}
+	if !client.AllowsScopes(scopes) {
+		return nil, NewOAuthError(OAuthErrInvalidScope, "requested scope is not allowed for client")
+	}
+
+	deviceCode := generateRandomString(43)
+	userCode, err := newUserCode()
+	if err != nil {
+		return nil, err
+	}
+	expiresAt := time.Now().Add(s.ttl)
+	authorization, err := NewDeviceAuthorization(hashOpaqueToken(deviceCode), HashUserCode(userCode), client.ID(), scopes, s.interval, expiresAt)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.deviceRepo.Save(ctx, authorization); err != nil {
+		return nil, errors.New("failed to save device authorization: " + err.Error())
+	}
+
+	return &DeviceAuthorizationGrant{
+		DeviceCode:              deviceCode,
+		UserCode:                userCode,
+		VerificationURI:         s.verificationURI,
+		VerificationURIComplete: s.verificationURI + "?user_code=" + url.QueryEscape(userCode),
+		ExpiresAt:               expiresAt,
+		Interval:                s.interval,
+	}, nil
+}
+
+// LookupUserCode returns the client and scopes of a pending request.
+func (s *deviceAuthorizationService) LookupUserCode(ctx context.Context, user *TokenClaims, userCode string) (*DeviceVerification, error) {
+	if user == nil || user.Subject == "" {
+		return nil, errors.New("user must not be empty")
+	}
+	authorization, err := s.pendingByUserCode(ctx, user.Subject, userCode)
+	if err != nil {
+		return nil, err
+	}
+	client, err := s.clientRepo.FindByID(ctx, authorization.ClientID())
+	if err != nil {
+		return nil, errors.New("failed to find client: " + err.Error())
+	}
+	if client == nil {
+		return nil, ErrUserCodeInvalid
+	}
+	return &DeviceVerification{ClientID: client.ID(), ClientName: client.Name(), Scopes: authorization.Scopes()}, nil
+}
+
+// DecideUserCode approves or denies a pending request. Approving a third-party client also
+// records the user's consent, so the device's refresh tokens keep working.
+func (s *deviceAuthorizationService) DecideUserCode(ctx context.Context, user *TokenClaims, userCode string, approve bool) error {
+	if user == nil || user.Subject == "" {
+		return errors.New("user must not be empty")
+	}
+	authorization, err := s.pendingByUserCode(ctx, user.Subject, userCode)
+	if err != nil {
+		return err
+	}
+
+	if !approve {
+		if err := authorization.Deny(); err != nil {
+			return ErrUserCodeInvalid
+		}
+		return s.decide(ctx, authorization)
+	}
+
+	if err := s.authzService.GrantConsent(ctx, user.Subject, authorization.ClientID(), authorization.Scopes()); err != nil {
+		return err
+	}
+	if err := authorization.Approve(user.Subject, user.AuthTime, user.AMR); err != nil {
+		return ErrUserCodeInvalid
+	}
+	if err := s.decide(ctx, authorization); err != nil {
+		return err
+	}
+
+	userID, clientID := user.Subject, authorization.ClientID()
+	recordAudit(ctx, s.auditRepo, AuditActionDeviceAuthorized, auditEntityOAuthClient, &userID, &clientID, map[string]interface{}{"scopes": authorization.Scopes()})
+	return nil
+}
+
+// PollToken answers a device's token request (RFC 8628 3.4, 3.5).
+func (s *deviceAuthorizationService) PollToken(ctx context.Context, clientID, clientSecret, deviceCode string, opts ...TokenOption) (*Token, error) {
+	if deviceCode == "" {
+		return nil, NewOAuthError(OAuthErrInvalidRequest, "device_code is required")
+	}
+	client, err := authenticateOAuthClient(ctx, s.clientRepo, clientID, clientSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	deviceCodeHash := hashOpaqueToken(deviceCode)
+	authorization, err := s.deviceRepo.FindByDeviceCodeHash(ctx, deviceCodeHash)
+	if err != nil {
+		return nil, errors.New("failed to find device authorization: " + err.Error())
+	}
+	if authorization == nil || authorization.ClientID() != client.ID() {
+		return nil, NewOAuthError(OAuthErrInvalidGrant, "device code is invalid")
+	}
+	if authorization.IsExpired() {
+		return nil, NewOAuthError(OAuthErrExpiredToken, "device code has expired")
+	}
+
+	switch authorization.Status() {
+	case DeviceAuthorizationDenied:
+		_, _ = s.deviceRepo.Consume(ctx, deviceCodeHash)
+		return nil, NewOAuthError(OAuthErrAccessDenied, "user denied the request")
+	case DeviceAuthorizationPending:
+		tooFast := authorization.Poll(time.Now())
+		// 폴링 상태만 기록해 동시에 저장된 승인이나 거부를 덮어쓰지 않음
+		if err := s.deviceRepo.RecordPoll(ctx, authorization); err != nil {
+			return nil, errors.New("failed to record device poll: " + err.Error())
+		}
+		if tooFast {
+			return nil, NewOAuthError(OAuthErrSlowDown, "")
+		}
+		return nil, NewOAuthError(OAuthErrAuthorizationPending, "")
+	}
+
+	// 동시에 폴링한 요청 중 하나만 토큰을 받음
+	approved, err := s.deviceRepo.Consume(ctx, deviceCodeHash)
+	if err != nil {
+		return nil, errors.New("failed to consume device authorization: " + err.Error())
+	}
+	if approved == nil || approved.Status() != DeviceAuthorizationApproved {
+		return nil, NewOAuthError(OAuthErrInvalidGrant, "device code is invalid")
+	}
+
+	return s.authService.GenerateTokenPair(approved.UserID(), append(opts,
+		WithClientID(client.ID()),
+		WithScope(approved.Scopes()...),
+		WithAuthentication(approved.AuthTime(), approved.AMR()...),
+	)...)
+}
+
+// decide stores the decision if the request is still pending. A request decided or redeemed
+// in the meantime keeps its state.
+func (s *deviceAuthorizationService) decide(ctx context.Context, authorization *DeviceAuthorization) error {
+	decided, err := s.deviceRepo.Decide(ctx, authorization)
+	if err != nil {
+		return errors.New("failed to save device authorization: " + err.Error())
+	}
+	if !decided {
+		return ErrUserCodeInvalid
+	}
+	return nil
+}
+
+// pendingByUserCode loads the undecided, unexpired request with the given user code. Every
+// attempt counts against the user's limit, whether or not the code exists.
+func (s *deviceAuthorizationService) pendingByUserCode(ctx context.Context, userID, userCode string) (*DeviceAuthorization, error) {
+	if userCode == "" {
+		return nil, ErrUserCodeInvalid
+	}
+	count, err := s.rateLimitRepo.Increment(ctx, "device_user_code:"+userID, s.userCodeWindow)
+	if err != nil {
+		return nil, errors.New("failed to check rate limit: " + err.Error())
+	}
+	if count > s.userCodeLimit {
+		return nil, ErrRateLimited
+	}
+	authorization, err := s.deviceRepo.FindByUserCodeHash(ctx, HashUserCode(userCode))
+	if err != nil {
+		return nil, errors.New("failed to find device authorization: " + err.Error())
+	}
+	if authorization == nil || authorization.IsExpired() || authorization.Status() != DeviceAuthorizationPending {
+		return nil, ErrUserCodeInvalid
+	}
+	return authorization, nil
+}
@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// DPoPProof represents a verified DPoP proof JWT (RFC 9449).
+type DPoPProof struct {
+	thumbprint string
+	jti        string
+	issuedAt   time.Time
+	expiresAt  time.Time
+}
+
+// NewDPoPProof creates a new DPoPProof instance.
+// expiresAt is the time after which the proof is no longer accepted and its jti may be forgotten.
+func NewDPoPProof(thumbprint, jti string, issuedAt, expiresAt time.Time) (*DPoPProof, error) {
+	if thumbprint == "" {
+		return nil, errors.New("key thumbprint must not be empty")
+	}
+	if jti == "" {
+		return nil, errors.New("proof jti must not be empty")
+	}
+	if issuedAt.IsZero() {
+		return nil, errors.New("proof issued at must not be zero")
+	}
+	if !expiresAt.After(issuedAt) {
+		return nil, errors.New("proof expiry must be after issued at")
+	}
+
+	return &DPoPProof{
+		thumbprint: thumbprint,
+		jti:        jti,
+		issuedAt:   issuedAt,
+		expiresAt:  expiresAt,
+	}, nil
+}
+
+// Thumbprint returns the RFC 7638 JWK thumbprint of the proof's public key.
+func (p *DPoPProof) Thumbprint() string {
+	return p.thumbprint
+}
+
+// JTI returns the unique identifier of the proof.
+func (p *DPoPProof) JTI() string {
+	return p.jti
+}
+
+// IssuedAt returns the time the proof was created by the client.
+func (p *DPoPProof) IssuedAt() time.Time {
+	return p.issuedAt
+}
+
+// ExpiresAt returns the time after which the proof is rejected as stale.
+func (p *DPoPProof) ExpiresAt() time.Time {
+	return p.expiresAt
+}
+
+// DPoPVerifier defines the interface for verifying DPoP proof JWTs.
+type DPoPVerifier interface {
+	// Verify checks the proof signature, freshness and binding to the HTTP method and URI.
+	// accessToken is empty at token issuance; otherwise the proof's ath claim must match it.
+	Verify(proof, method, uri, accessToken string) (*DPoPProof, error)
+}
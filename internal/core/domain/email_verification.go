@@ -0,0 +1,221 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// AuditActionEmailVerified is recorded when a user proves ownership of their email address.
+const AuditActionEmailVerified = "EMAIL_VERIFIED"
+
+var (
+	// ErrEmailVerificationInvalid is returned when a verification link is unknown, expired or already used.
+	ErrEmailVerificationInvalid = errors.New("email verification link is invalid or expired")
+	// ErrEmailAlreadyVerified is returned when a verification email is requested for a verified address.
+	ErrEmailAlreadyVerified = errors.New("email address is already verified")
+	// ErrEmailNotVerified is returned when an operation requires a verified email address.
+	ErrEmailNotVerified = errors.New("email address is not verified")
+)
+
+// EmailVerificationToken is a pending confirmation of a user's email address. The raw token
+// goes into the emailed link; only its hash is stored.
+type EmailVerificationToken struct {
+	tokenHash string
+	userID    string
+	email     Email // 발송 시점의 주소, 이후 변경되면 링크 무효
+	expiresAt time.Time
+	createdAt time.Time
+}
+
+// NewEmailVerificationToken creates a new EmailVerificationToken instance.
+func NewEmailVerificationToken(tokenHash, userID string, email Email, expiresAt time.Time) (*EmailVerificationToken, error) {
+	if tokenHash == "" {
+		return nil, errors.New("token hash must not be empty")
+	}
+	if userID == "" {
+		return nil, errors.New("user id must not be empty")
+	}
+	if !email.IsValid() {
+		return nil, errors.New("invalid email")
+	}
+
+	return &EmailVerificationToken{
+		tokenHash: tokenHash,
+		userID:    userID,
+		email:     email,
+		expiresAt: expiresAt,
+		createdAt: time.Now(),
+	}, nil
+}
+
+// NewEmailVerificationTokenFromStorage restores an EmailVerificationToken loaded from storage.
+func NewEmailVerificationTokenFromStorage(tokenHash, userID string, email Email, expiresAt, createdAt time.Time) (*EmailVerificationToken, error) {
+	token, err := NewEmailVerificationToken(tokenHash, userID, email, expiresAt)
+	if err != nil {
+		return nil, err
+	}
+	token.createdAt = createdAt
+	return token, nil
+}
+
+// TokenHash returns the SHA-256 hash of the verification token.
+func (t *EmailVerificationToken) TokenHash() string {
+	return t.tokenHash
+}
+
+// UserID returns the user whose address is being verified.
+func (t *EmailVerificationToken) UserID() string {
+	return t.userID
+}
+
+// Email returns the address the link was sent to.
+func (t *EmailVerificationToken) Email() Email {
+	return t.email
+}
+
+// ExpiresAt returns when the link expires.
+func (t *EmailVerificationToken) ExpiresAt() time.Time {
+	return t.expiresAt
+}
+
+// CreatedAt returns when the link was issued.
+func (t *EmailVerificationToken) CreatedAt() time.Time {
+	return t.createdAt
+}
+
+// IsExpired reports whether the link can no longer be used.
+func (t *EmailVerificationToken) IsExpired() bool {
+	return time.Now().After(t.expiresAt)
+}
+
+// EmailVerificationService defines operations for confirming ownership of email addresses.
+type EmailVerificationService interface {
+	// RequestVerification emails a verification link to the user's current address.
+	// Requests are throttled per user and return ErrRateLimited when exceeded.
+	RequestVerification(ctx context.Context, userID string) error
+	// VerifyEmail consumes a verification token and marks the address as verified.
+	VerifyEmail(ctx context.Context, token string) error
+}
+
+// emailVerificationService implements EmailVerificationService with domain logic.
+type emailVerificationService struct {
+	userRepo      UserRepository
+	tokenRepo     EmailVerificationTokenRepository
+	rateLimitRepo RateLimitRepository
+	mailer        Mailer
+	auditRepo     AuditLogRepository
+	eventPub      EventPublisher
+	linkURL       string        // 토큰을 붙여 보내는 확인 화면 주소
+	ttl           time.Duration // 링크 유효 시간
+	resendLimit   int           // 사용자당 window 동안 허용하는 발송 수
+	resendWindow  time.Duration
+}
+
+// NewEmailVerificationService creates a new instance of emailVerificationService.
+func NewEmailVerificationService(userRepo UserRepository, tokenRepo EmailVerificationTokenRepository, rateLimitRepo RateLimitRepository, mailer Mailer, auditRepo AuditLogRepository, eventPub EventPublisher, linkURL string, ttl time.Duration, resendLimit int, resendWindow time.Duration) EmailVerificationService {
+	return &emailVerificationService{
+		userRepo:      userRepo,
+		tokenRepo:     tokenRepo,
+		rateLimitRepo: rateLimitRepo,
+		mailer:        mailer,
+		auditRepo:     auditRepo,
+		eventPub:      eventPub,
+		linkURL:       linkURL,
+		ttl:           ttl,
+		resendLimit:   resendLimit,
+		resendWindow:  resendWindow,
+	}
+}
+
+// RequestVerification issues a single-use verification link for the user's current address.
+// Links sent earlier stay valid until they expire.
+func (s *emailVerificationService) RequestVerification(ctx context.Context, userID string) error {
+	if userID == "" {
+		return errors.New("user id must not be empty")
+	}
+
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return errors.New("failed to find user: " + err.Error())
+	}
+	if user == nil {
+		return errors.New("user not found")
+	}
+	if user.EmailVerified() {
+		return ErrEmailAlreadyVerified
+	}
+
+	count, err := s.rateLimitRepo.Increment(ctx, "email_verification:"+user.ID(), s.resendWindow)
+	if err != nil {
+		return errors.New("failed to check rate limit: " + err.Error())
+	}
+	if count > s.resendLimit {
+		return ErrRateLimited
+	}
+
+	rawToken := generateRandomString(43)
+	token, err := NewEmailVerificationToken(hashOpaqueToken(rawToken), user.ID(), user.Email(), time.Now().Add(s.ttl))
+	if err != nil {
+		return err
+	}
+	if err := s.tokenRepo.Save(ctx, token); err != nil {
+		return errors.New("failed to save email verification token: " + err.Error())
+	}
+
+	link, err := tokenLink(s.linkURL, rawToken)
+	if err != nil {
+		return err
+	}
+	msg := EmailMessage{
+		To:      user.Email(),
+		Subject: "Confirm your email address",
+		Body: "Hi " + user.Username() + ",\n\n" +
+			"Please confirm this is your email address by opening the link below. It expires in " + s.ttl.String() + ".\n\n" +
+			link + "\n\n" +
+			"If you did not create an account, you can ignore this email.\n",
+	}
+	if err := s.mailer.Send(ctx, msg); err != nil {
+		return errors.New("failed to send verification email: " + err.Error())
+	}
+	return nil
+}
+
+// VerifyEmail marks the user's address as verified. The link is rejected if the address
+// changed after it was sent.
+func (s *emailVerificationService) VerifyEmail(ctx context.Context, rawToken string) error {
+	if rawToken == "" {
+		return ErrEmailVerificationInvalid
+	}
+
+	token, err := s.tokenRepo.Consume(ctx, hashOpaqueToken(rawToken))
+	if err != nil {
+		return errors.New("failed to consume email verification token: " + err.Error())
+	}
+	if token == nil || token.IsExpired() {
+		return ErrEmailVerificationInvalid
+	}
+
+	user, err := s.userRepo.FindByID(ctx, token.UserID())
+	if err != nil {
+		return errors.New("failed to find user: " + err.Error())
+	}
+	if user == nil || user.Email() != token.Email() {
+		return ErrEmailVerificationInvalid
+	}
+	if user.EmailVerified() {
+		return nil // 같은 주소로 보낸 다른 링크로 이미 확인됨
+	}
+
+	user.SetEmailVerified(true)
+	if err := s.userRepo.SaveUser(ctx, user); err != nil {
+		return errors.New("failed to update user: " + err.Error())
+	}
+
+	userID := user.ID()
+	recordAudit(ctx, s.auditRepo, AuditActionEmailVerified, auditEntityUser, &userID, &userID, map[string]interface{}{
+		"email": user.Email().String(),
+	})
+	_ = s.eventPub.Publish(&UserUpdated{userID: userID, timestamp: time.Now()})
+	return nil
+}
@@ -329,3 +329,212 @@ func (e *PlatformDisconnected) PlatformID() string {
 func (e *PlatformDisconnected) Timestamp() time.Time {
 	return e.timestamp
 }
+
+// TokenExchanged represents an event when a service exchanges a user's token for a delegated one.
+type TokenExchanged struct {
+	userID    string
+	actor     string
+	audience  string
+	timestamp time.Time
+}
+
+// NewTokenExchanged creates a new TokenExchanged event.
+func NewTokenExchanged(userID, actor, audience string, timestamp time.Time) (*TokenExchanged, error) {
+	if userID == "" {
+		return nil, errors.New("user id must not be empty")
+	}
+	if actor == "" {
+		return nil, errors.New("actor must not be empty")
+	}
+	if timestamp.IsZero() {
+		return nil, errors.New("timestamp must not be zero")
+	}
+	return &TokenExchanged{
+		userID:    userID,
+		actor:     actor,
+		audience:  audience,
+		timestamp: timestamp,
+	}, nil
+}
+
+// EventName returns the name of the TokenExchanged event.
+func (e *TokenExchanged) EventName() string {
+	return "TokenExchanged"
+}
+
+// UserID returns the ID of the user the delegated token was issued for.
+func (e *TokenExchanged) UserID() string {
+	return e.userID
+}
+
+// Actor returns the service acting on behalf of the user.
+func (e *TokenExchanged) Actor() string {
+	return e.actor
+}
+
+// Audience returns the audience of the delegated token.
+func (e *TokenExchanged) Audience() string {
+	return e.audience
+}
+
+// Timestamp returns the time when the event occurred.
+func (e *TokenExchanged) Timestamp() time.Time {
+	return e.timestamp
+}
+
+// SessionRevoked represents an event when a user signs a device out remotely.
+type SessionRevoked struct {
+	userID    string
+	sessionID string
+	timestamp time.Time
+}
+
+// NewSessionRevoked creates a new SessionRevoked event.
+func NewSessionRevoked(userID, sessionID string, timestamp time.Time) (*SessionRevoked, error) {
+	if userID == "" {
+		return nil, errors.New("user id must not be empty")
+	}
+	if sessionID == "" {
+		return nil, errors.New("session id must not be empty")
+	}
+	if timestamp.IsZero() {
+		return nil, errors.New("timestamp must not be zero")
+	}
+	return &SessionRevoked{
+		userID:    userID,
+		sessionID: sessionID,
+		timestamp: timestamp,
+	}, nil
+}
+
+// EventName returns the name of the SessionRevoked event.
+func (e *SessionRevoked) EventName() string {
+	return "SessionRevoked"
+}
+
+// UserID returns the ID of the user who owned the session.
+func (e *SessionRevoked) UserID() string {
+	return e.userID
+}
+
+// SessionID returns the ID of the revoked session.
+func (e *SessionRevoked) SessionID() string {
+	return e.sessionID
+}
+
+// Timestamp returns the time when the event occurred.
+func (e *SessionRevoked) Timestamp() time.Time {
+	return e.timestamp
+}
+
+// PasswordChanged represents an event when a user's password is replaced, either by the
+// user or through a password reset.
+type PasswordChanged struct {
+	userID    string
+	reason    string // "change" 또는 "reset"
+	timestamp time.Time
+}
+
+// NewPasswordChanged creates a new PasswordChanged event.
+func NewPasswordChanged(userID, reason string, timestamp time.Time) (*PasswordChanged, error) {
+	if userID == "" {
+		return nil, errors.New("user id must not be empty")
+	}
+	if reason == "" {
+		return nil, errors.New("reason must not be empty")
+	}
+	if timestamp.IsZero() {
+		return nil, errors.New("timestamp must not be zero")
+	}
+	return &PasswordChanged{
+		userID:    userID,
+		reason:    reason,
+		timestamp: timestamp,
+	}, nil
+}
+
+// EventName returns the name of the PasswordChanged event.
+func (e *PasswordChanged) EventName() string {
+	return "PasswordChanged"
+}
+
+// UserID returns the ID of the user whose password changed.
+func (e *PasswordChanged) UserID() string {
+	return e.userID
+}
+
+// Reason returns how the password was changed.
+func (e *PasswordChanged) Reason() string {
+	return e.reason
+}
+
+// Timestamp returns the time when the event occurred.
+func (e *PasswordChanged) Timestamp() time.Time {
+	return e.timestamp
+}
+
+// SuspiciousLogin represents an event when a login scores high enough to be challenged or blocked.
+type SuspiciousLogin struct {
+	userID    string
+	decision  RiskDecision
+	score     int
+	signals   []string
+	ipAddress string
+	timestamp time.Time
+}
+
+// NewSuspiciousLogin creates a new SuspiciousLogin event.
+func NewSuspiciousLogin(userID string, decision RiskDecision, score int, signals []string, ipAddress string, timestamp time.Time) (*SuspiciousLogin, error) {
+	if userID == "" {
+		return nil, errors.New("user id must not be empty")
+	}
+	if decision == "" {
+		return nil, errors.New("decision must not be empty")
+	}
+	if timestamp.IsZero() {
+		return nil, errors.New("timestamp must not be zero")
+	}
+	return &SuspiciousLogin{
+		userID:    userID,
+		decision:  decision,
+		score:     score,
+		signals:   signals,
+		ipAddress: ipAddress,
+		timestamp: timestamp,
+	}, nil
+}
+
+// EventName returns the name of the SuspiciousLogin event.
+func (e *SuspiciousLogin) EventName() string {
+	return "SuspiciousLogin"
+}
+
+// UserID returns the ID of the user who tried to log in.
+func (e *SuspiciousLogin) UserID() string {
+	return e.userID
+}
+
+// Decision returns whether the login was allowed, challenged or blocked.
+func (e *SuspiciousLogin) Decision() RiskDecision {
+	return e.decision
+}
+
+// Score returns the risk score of the login.
+func (e *SuspiciousLogin) Score() int {
+	return e.score
+}
+
+// Signals returns the risk signals raised by the login.
+func (e *SuspiciousLogin) Signals() []string {
+	return e.signals
+}
+
+// IPAddress returns the IP address the login came from.
+func (e *SuspiciousLogin) IPAddress() string {
+	return e.ipAddress
+}
+
+// Timestamp returns the time when the event occurred.
+func (e *SuspiciousLogin) Timestamp() time.Time {
+	return e.timestamp
+}
@@ -0,0 +1,177 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+)
+
+// ScopeGuest is carried by every token issued to a guest user, next to the configured guest scopes.
+const ScopeGuest = "guest"
+
+// guestUsernamePrefix starts the generated username of a guest, e.g. "guest_k3x9q2mz7a".
+const guestUsernamePrefix = "guest_"
+
+var (
+	// ErrGuestsDisabled is returned when guest sessions are not enabled.
+	ErrGuestsDisabled = errors.New("guest sessions are not enabled")
+	// ErrNotGuest is returned when upgrading a user who is not a guest.
+	ErrNotGuest = errors.New("user is not a guest")
+	// ErrUsernameTaken is returned when the requested username belongs to another user.
+	ErrUsernameTaken = errors.New("username is already taken")
+	// ErrEmailInUse is returned when the requested email address belongs to another user.
+	ErrEmailInUse = errors.New("an account with this email address already exists")
+)
+
+// GuestUpgrade holds the credentials a guest attaches to become a regular user: either a
+// username, email address and password, or a platform authorization code.
+type GuestUpgrade struct {
+	Username string
+	Email    string
+	Password string
+
+	// Platform와 Code가 지정되면 플랫폼 로그인으로 전환
+	Platform PlatformType
+	Code     string
+}
+
+// WithGuests enables anonymous guest users. Guest tokens are limited to ScopeGuest and scopes,
+// and at most limit guests are created per IP address within window.
+func WithGuests(rateLimitRepo RateLimitRepository, scopes []string, limit int, window time.Duration) AuthServiceOption {
+	return func(s *authService) {
+		s.guestsEnabled = true
+		s.guestRateLimitRepo = rateLimitRepo
+		s.guestScopes = appendUnique(append([]string(nil), scopes...), ScopeGuest)
+		s.guestLimit = limit
+		s.guestWindow = window
+	}
+}
+
+// WithGuestVerification sends a verification email to every guest that upgrades with an email
+// address and password.
+func WithGuestVerification(svc EmailVerificationService) AuthServiceOption {
+	return func(s *authService) {
+		s.guestVerification = svc
+	}
+}
+
+// AuthenticateGuest creates a guest user and returns a token pair restricted to the guest scopes.
+func (s *authService) AuthenticateGuest(ctx context.Context, opts ...TokenOption) (*Token, error) {
+	if !s.guestsEnabled {
+		return nil, ErrGuestsDisabled
+	}
+
+	count, err := s.guestRateLimitRepo.Increment(ctx, "guest:"+ClientMetadataFromContext(ctx).IPAddress, s.guestWindow)
+	if err != nil {
+		return nil, errors.New("failed to check rate limit: " + err.Error())
+	}
+	if count > s.guestLimit {
+		return nil, ErrRateLimited
+	}
+
+	user, err := NewGuestUser(generateRandomString(36), guestUsernamePrefix+strings.ToLower(generateRandomString(10)))
+	if err != nil {
+		return nil, err
+	}
+	if err := s.userRepo.SaveUser(ctx, user); err != nil {
+		return nil, errors.New("failed to save user: " + err.Error())
+	}
+	_ = s.eventPub.Publish(&UserCreated{userID: user.ID(), timestamp: user.CreatedAt()})
+
+	// 게스트 범위는 호출자 옵션보다 나중에 적용해 덮어쓸 수 없도록 함
+	return s.completeLogin(ctx, user, time.Now(), nil, nil, append(opts, WithScope(s.guestScopes...)))
+}
+
+// UpgradeGuest attaches credentials to the guest of claims and returns an unrestricted token
+// pair. The user keeps its ID; the guest's sessions are signed out.
+func (s *authService) UpgradeGuest(ctx context.Context, claims *TokenClaims, upgrade GuestUpgrade, opts ...TokenOption) (*Token, error) {
+	if !s.guestsEnabled {
+		return nil, ErrGuestsDisabled
+	}
+	if claims == nil || !claims.IsGuest() || claims.IsDelegated() {
+		return nil, ErrNotGuest
+	}
+
+	user, err := s.userRepo.FindByID(ctx, claims.Subject)
+	if err != nil {
+		return nil, errors.New("failed to find user: " + err.Error())
+	}
+	if user == nil || !user.IsGuest() {
+		return nil, ErrNotGuest
+	}
+
+	var amr []string
+	if upgrade.Platform != "" {
+		if s.platformLogin == nil {
+			return nil, ErrPlatformNotSupported
+		}
+		if err := s.platformLogin.UpgradeGuest(ctx, user, upgrade.Platform, upgrade.Code); err != nil {
+			return nil, err
+		}
+		amr = []string{AMRFederated}
+	} else {
+		if err := s.upgradeWithPassword(ctx, user, upgrade); err != nil {
+			return nil, err
+		}
+		amr = []string{AMRPassword}
+		if s.guestVerification != nil {
+			// 발송에 실패해도 업그레이드는 유지하고, 사용자가 재발송을 요청할 수 있음
+			_ = s.guestVerification.RequestVerification(ctx, user.ID())
+		}
+	}
+
+	// 게스트 범위로 발급된 토큰은 더 이상 사용하지 않음
+	if s.sessionRepo != nil {
+		if _, err := s.sessionRepo.RevokeAllByUserID(ctx, user.ID(), "", time.Now()); err != nil {
+			return nil, errors.New("failed to revoke guest sessions: " + err.Error())
+		}
+	}
+	_ = s.eventPub.Publish(&UserUpdated{userID: user.ID(), timestamp: time.Now()})
+
+	return s.completeLogin(ctx, user, time.Now(), amr, nil, opts)
+}
+
+// upgradeWithPassword checks that the username and email address are free and applies the
+// password policy before upgrading the guest.
+func (s *authService) upgradeWithPassword(ctx context.Context, user *User, upgrade GuestUpgrade) error {
+	username := strings.TrimSpace(upgrade.Username)
+	if username == "" || upgrade.Email == "" || upgrade.Password == "" {
+		return NewOAuthError(OAuthErrInvalidRequest, "username, email, and password must not be empty")
+	}
+	// 로그인 식별자에서 "@"는 이메일을 뜻하므로 사용자 이름에 허용하지 않음
+	if strings.Contains(username, "@") {
+		return NewOAuthError(OAuthErrInvalidRequest, "username must not contain @")
+	}
+	email, err := NewEmail(upgrade.Email)
+	if err != nil {
+		return NewOAuthError(OAuthErrInvalidRequest, err.Error())
+	}
+	pwd, err := NewPassword(upgrade.Password)
+	if err != nil {
+		return err
+	}
+
+	existing, err := s.userRepo.FindByUsername(ctx, username)
+	if err != nil {
+		return errors.New("failed to find user: " + err.Error())
+	}
+	if existing != nil {
+		return ErrUsernameTaken
+	}
+	existing, err = s.userRepo.FindByEmail(ctx, email)
+	if err != nil {
+		return errors.New("failed to find user: " + err.Error())
+	}
+	if existing != nil {
+		return ErrEmailInUse
+	}
+
+	if err := user.Upgrade(username, email, pwd); err != nil {
+		return err
+	}
+	if err := s.userRepo.SaveUser(ctx, user); err != nil {
+		return errors.New("failed to save user: " + err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,179 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+)
+
+// Audit actions recorded for support-agent impersonation.
+const (
+	AuditActionImpersonationStarted = "IMPERSONATION_STARTED"
+	AuditActionImpersonationEnded   = "IMPERSONATION_ENDED"
+	AuditActionImpersonationDenied  = "IMPERSONATION_DENIED"
+)
+
+// impersonationReasonMaxLength bounds the reason an admin gives for impersonating a user.
+const impersonationReasonMaxLength = 500
+
+var (
+	// ErrImpersonationForbidden is returned when the caller may not impersonate the target user.
+	ErrImpersonationForbidden = errors.New("impersonation is not allowed")
+	// ErrImpersonationReasonRequired is returned when no reason is given for an impersonation.
+	ErrImpersonationReasonRequired = errors.New("a reason is required to impersonate a user")
+	// ErrImpersonationTargetNotFound is returned when the user to impersonate does not exist.
+	ErrImpersonationTargetNotFound = errors.New("user to impersonate not found")
+	// ErrNotImpersonating is returned when ending an impersonation with a token that is not one.
+	ErrNotImpersonating = errors.New("token is not an impersonation token")
+)
+
+// ImpersonationService lets support agents act as a user to see what the user sees.
+type ImpersonationService interface {
+	// Impersonate issues a short-lived access token for the target user whose act claim
+	// identifies the admin. The admin must hold the ADMIN role and give a reason.
+	Impersonate(ctx context.Context, admin *TokenClaims, targetUserID, reason string, opts ...TokenOption) (*Token, error)
+	// EndImpersonation revokes an impersonation token before it expires.
+	EndImpersonation(ctx context.Context, claims *TokenClaims, accessToken string) error
+}
+
+// impersonationService implements ImpersonationService with domain logic.
+type impersonationService struct {
+	userRepo  UserRepository
+	roleRepo  RoleRepository
+	tokenRepo TokenRepository
+	tokenGen  TokenGenerator
+	auditRepo AuditLogRepository
+	tokenTTL  time.Duration
+}
+
+// NewImpersonationService creates a new instance of impersonationService. Impersonation
+// tokens are valid for tokenTTL and cannot be refreshed.
+func NewImpersonationService(userRepo UserRepository, roleRepo RoleRepository, tokenRepo TokenRepository, tokenGen TokenGenerator, auditRepo AuditLogRepository, tokenTTL time.Duration) ImpersonationService {
+	return &impersonationService{
+		userRepo:  userRepo,
+		roleRepo:  roleRepo,
+		tokenRepo: tokenRepo,
+		tokenGen:  tokenGen,
+		auditRepo: auditRepo,
+		tokenTTL:  tokenTTL,
+	}
+}
+
+// Impersonate checks that the caller is an admin acting as themselves and issues the token.
+// Admins cannot impersonate other admins. Every attempt is recorded in the audit log.
+func (s *impersonationService) Impersonate(ctx context.Context, admin *TokenClaims, targetUserID, reason string, opts ...TokenOption) (*Token, error) {
+	if admin == nil || admin.Subject == "" {
+		return nil, errors.New("admin claims must not be empty")
+	}
+	if targetUserID == "" {
+		return nil, errors.New("target user id must not be empty")
+	}
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, ErrImpersonationReasonRequired
+	}
+	if len(reason) > impersonationReasonMaxLength {
+		return nil, errors.New("impersonation reason is too long")
+	}
+
+	adminID := admin.Subject
+	// 위임된 토큰(대리 실행 중인 토큰 포함)으로 다시 대리 실행할 수 없음
+	if admin.IsDelegated() || admin.IsClientToken() {
+		s.auditDenied(ctx, adminID, targetUserID, reason, "delegated_token")
+		return nil, ErrImpersonationForbidden
+	}
+	if adminID == targetUserID {
+		return nil, ErrImpersonationForbidden
+	}
+
+	isAdmin, err := s.hasRole(ctx, adminID, RoleAdmin)
+	if err != nil {
+		return nil, err
+	}
+	if !isAdmin {
+		s.auditDenied(ctx, adminID, targetUserID, reason, "not_admin")
+		return nil, ErrImpersonationForbidden
+	}
+
+	target, err := s.userRepo.FindByID(ctx, targetUserID)
+	if err != nil {
+		return nil, errors.New("failed to find user: " + err.Error())
+	}
+	if target == nil || target.Status() == UserStatusDeleted {
+		return nil, ErrImpersonationTargetNotFound
+	}
+	// 관리자 권한 상승을 막기 위해 다른 관리자는 대리 실행 불가
+	targetIsAdmin, err := s.hasRole(ctx, targetUserID, RoleAdmin)
+	if err != nil {
+		return nil, err
+	}
+	if targetIsAdmin {
+		s.auditDenied(ctx, adminID, targetUserID, reason, "target_is_admin")
+		return nil, ErrImpersonationForbidden
+	}
+
+	claims := applyTokenOptions(opts)
+	claims.Actor = &ActorClaim{Subject: adminID}
+	claims.TokenID = generateRandomString(16)
+	expiry := time.Now().Add(s.tokenTTL)
+	accessToken, err := s.tokenGen.GenerateAccessToken(targetUserID, expiry, claims)
+	if err != nil {
+		return nil, errors.New("failed to generate access token: " + err.Error())
+	}
+
+	recordAudit(ctx, s.auditRepo, AuditActionImpersonationStarted, auditEntityUser, &adminID, &targetUserID, map[string]interface{}{
+		"reason":     reason,
+		"jti":        claims.TokenID,
+		"expires_at": expiry,
+		"ip_address": ClientMetadataFromContext(ctx).IPAddress,
+	})
+	return NewAccessToken(accessToken, claims.TokenID, expiry)
+}
+
+// EndImpersonation blacklists the impersonation token and records the end of the impersonation.
+func (s *impersonationService) EndImpersonation(ctx context.Context, claims *TokenClaims, accessToken string) error {
+	if claims == nil || accessToken == "" {
+		return errors.New("token must not be empty")
+	}
+	if !claims.IsDelegated() {
+		return ErrNotImpersonating
+	}
+	// 토큰 교환으로 위임된 서비스 토큰과 구분: 대리 실행 주체는 관리자
+	isAdmin, err := s.hasRole(ctx, claims.Actor.Subject, RoleAdmin)
+	if err != nil {
+		return err
+	}
+	if !isAdmin {
+		return ErrNotImpersonating
+	}
+
+	if err := s.tokenRepo.BlacklistToken(ctx, accessToken, claims.Subject, "impersonation_ended", claims.ExpiresAt); err != nil {
+		return errors.New("failed to blacklist token: " + err.Error())
+	}
+
+	adminID := claims.Actor.Subject
+	targetUserID := claims.Subject
+	recordAudit(ctx, s.auditRepo, AuditActionImpersonationEnded, auditEntityUser, &adminID, &targetUserID, map[string]interface{}{
+		"jti": claims.TokenID,
+	})
+	return nil
+}
+
+// hasRole reports whether the user is assigned the named role.
+func (s *impersonationService) hasRole(ctx context.Context, userID, role string) (bool, error) {
+	roles, err := s.roleRepo.FindNamesByUserID(ctx, userID)
+	if err != nil {
+		return false, errors.New("failed to find roles: " + err.Error())
+	}
+	return containsString(roles, role), nil
+}
+
+// auditDenied records a rejected impersonation attempt.
+func (s *impersonationService) auditDenied(ctx context.Context, adminID, targetUserID, reason, cause string) {
+	recordAudit(ctx, s.auditRepo, AuditActionImpersonationDenied, auditEntityUser, &adminID, &targetUserID, map[string]interface{}{
+		"reason":     reason,
+		"cause":      cause,
+		"ip_address": ClientMetadataFromContext(ctx).IPAddress,
+	})
+}
@@ -0,0 +1,474 @@
+package domain
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"errors"
+	"math"
+	"math/big"
+	"time"
+)
+
+// RiskDecision is the outcome of scoring a login.
+type RiskDecision string
+
+// Decisions made by RiskPolicy.Evaluate.
+const (
+	RiskDecisionAllow      RiskDecision = "ALLOW"
+	RiskDecisionRequireMFA RiskDecision = "REQUIRE_MFA"
+	RiskDecisionBlock      RiskDecision = "BLOCK"
+)
+
+// Signals that contribute to the risk score of a login.
+const (
+	RiskSignalNewDevice        = "new_device"
+	RiskSignalNewIP            = "new_ip"
+	RiskSignalNewASN           = "new_asn"
+	RiskSignalImpossibleTravel = "impossible_travel"
+	RiskSignalRecentFailures   = "recent_failures"
+)
+
+// AuditActionLoginRiskAssessed is recorded for every scored login.
+const AuditActionLoginRiskAssessed = "LOGIN_RISK_ASSESSED"
+
+// travelToleranceKm ignores moves shorter than the typical error of IP geolocation.
+const travelToleranceKm = 100
+
+// earthRadiusKm is the mean radius of the earth used for great-circle distances.
+const earthRadiusKm = 6371.0
+
+// emailCodeDigits is the length of the code emailed to confirm a high-risk login.
+const emailCodeDigits = 6
+
+// ErrLoginBlocked is returned when a login is rejected because its risk score is too high.
+var ErrLoginBlocked = errors.New("login blocked due to suspicious activity")
+
+// IPLocation is the network and approximate position of an IP address.
+type IPLocation struct {
+	ASN         string // 예: "AS15169"
+	CountryCode string
+	Latitude    float64
+	Longitude   float64
+}
+
+// IPLocator resolves the location of IP addresses.
+type IPLocator interface {
+	// Locate returns the location of ip, or nil if it is unknown (e.g. a private address).
+	Locate(ctx context.Context, ip string) (*IPLocation, error)
+}
+
+// LoginAttempt records a successful or failed login of a user, kept to recognize the
+// devices and networks the user normally signs in from.
+type LoginAttempt struct {
+	id                string
+	userID            string
+	ipAddress         string
+	deviceFingerprint string // 기기 식별자 또는 User-Agent의 해시
+	location          *IPLocation
+	succeeded         bool
+	createdAt         time.Time
+	expiresAt         time.Time
+}
+
+// NewLoginAttempt creates a new LoginAttempt from the client metadata that is kept for retention.
+func NewLoginAttempt(id, userID string, metadata ClientMetadata, location *IPLocation, succeeded bool, retention time.Duration) (*LoginAttempt, error) {
+	if id == "" {
+		return nil, errors.New("login attempt id must not be empty")
+	}
+	if userID == "" {
+		return nil, errors.New("user id must not be empty")
+	}
+	if retention <= 0 {
+		return nil, errors.New("login attempt retention must be positive")
+	}
+
+	now := time.Now()
+	return &LoginAttempt{
+		id:                id,
+		userID:            userID,
+		ipAddress:         metadata.IPAddress,
+		deviceFingerprint: DeviceFingerprint(metadata),
+		location:          location,
+		succeeded:         succeeded,
+		createdAt:         now,
+		expiresAt:         now.Add(retention),
+	}, nil
+}
+
+// NewLoginAttemptFromStorage restores a LoginAttempt loaded from storage.
+func NewLoginAttemptFromStorage(id, userID, ipAddress, deviceFingerprint string, location *IPLocation, succeeded bool, createdAt, expiresAt time.Time) (*LoginAttempt, error) {
+	if id == "" || userID == "" {
+		return nil, errors.New("login attempt id and user id must not be empty")
+	}
+	return &LoginAttempt{
+		id:                id,
+		userID:            userID,
+		ipAddress:         ipAddress,
+		deviceFingerprint: deviceFingerprint,
+		location:          location,
+		succeeded:         succeeded,
+		createdAt:         createdAt,
+		expiresAt:         expiresAt,
+	}, nil
+}
+
+// DeviceFingerprint returns the fingerprint stored for the client's device: a hash of the
+// device identifier sent by the app, or of the user agent if there is none.
+func DeviceFingerprint(metadata ClientMetadata) string {
+	switch {
+	case metadata.DeviceFingerprint != "":
+		return hashOpaqueToken("device:" + metadata.DeviceFingerprint)
+	case metadata.UserAgent != "":
+		return hashOpaqueToken("ua:" + metadata.UserAgent)
+	default:
+		return ""
+	}
+}
+
+// ID returns the login attempt's unique identifier.
+func (a *LoginAttempt) ID() string {
+	return a.id
+}
+
+// UserID returns the ID of the user who tried to log in.
+func (a *LoginAttempt) UserID() string {
+	return a.userID
+}
+
+// IPAddress returns the IP address the login came from.
+func (a *LoginAttempt) IPAddress() string {
+	return a.ipAddress
+}
+
+// DeviceFingerprint returns the fingerprint of the device the login came from, or "" if unknown.
+func (a *LoginAttempt) DeviceFingerprint() string {
+	return a.deviceFingerprint
+}
+
+// Location returns the location of the IP address, or nil if it is unknown.
+func (a *LoginAttempt) Location() *IPLocation {
+	return a.location
+}
+
+// Succeeded reports whether the login succeeded.
+func (a *LoginAttempt) Succeeded() bool {
+	return a.succeeded
+}
+
+// CreatedAt returns when the login was attempted.
+func (a *LoginAttempt) CreatedAt() time.Time {
+	return a.createdAt
+}
+
+// ExpiresAt returns when the record is purged.
+func (a *LoginAttempt) ExpiresAt() time.Time {
+	return a.expiresAt
+}
+
+// RiskPolicy holds the score of each risk signal and the thresholds of the decisions.
+// A threshold of zero disables that decision.
+type RiskPolicy struct {
+	NewDeviceScore        int
+	NewIPScore            int
+	NewASNScore           int
+	ImpossibleTravelScore int
+	FailureScore          int // 최근 실패 한 번당 점수
+	MaxFailureScore       int // 최근 실패 점수의 상한
+
+	MFAThreshold   int
+	BlockThreshold int
+
+	MaxTravelSpeed float64       // km/h, 이보다 빠른 이동은 불가능한 이동으로 판단
+	FailureWindow  time.Duration // 최근 실패로 세는 기간
+	HistorySize    int           // 비교에 사용하는 최근 성공 로그인 수
+}
+
+// RiskAssessment is the scored outcome of a login.
+type RiskAssessment struct {
+	Score      int
+	Signals    []string
+	Decision   RiskDecision
+	Suspicious bool        // 점수가 MFA 기준 이상
+	Location   *IPLocation // 평가에 사용한 클라이언트 위치, RecordLogin에서 다시 조회하지 않도록 보관
+}
+
+// Evaluate scores attempt against the user's recent successful logins (newest first) and the
+// number of failed logins within the failure window. A login scoring at least MFAThreshold
+// requires MFA, or is blocked when mfaAvailable is false because the user has no factor to
+// prove the login with.
+// The first login of a user has nothing to compare against and is scored on failures only.
+func (p RiskPolicy) Evaluate(attempt *LoginAttempt, history []*LoginAttempt, recentFailures int, mfaAvailable bool) *RiskAssessment {
+	assessment := &RiskAssessment{Decision: RiskDecisionAllow}
+	add := func(signal string, score int) {
+		assessment.Score += score
+		assessment.Signals = append(assessment.Signals, signal)
+	}
+
+	if len(history) > 0 {
+		if attempt.deviceFingerprint != "" && !seenInHistory(history, func(a *LoginAttempt) bool {
+			return a.deviceFingerprint == attempt.deviceFingerprint
+		}) {
+			add(RiskSignalNewDevice, p.NewDeviceScore)
+		}
+		if attempt.ipAddress != "" && !seenInHistory(history, func(a *LoginAttempt) bool {
+			return a.ipAddress == attempt.ipAddress
+		}) {
+			add(RiskSignalNewIP, p.NewIPScore)
+		}
+		if attempt.location != nil {
+			// 위치를 알 수 없던 기록만 있다면 ASN 변경을 판단하지 않음
+			located := seenInHistory(history, func(a *LoginAttempt) bool { return a.location != nil })
+			if located && !seenInHistory(history, func(a *LoginAttempt) bool {
+				return a.location != nil && a.location.ASN == attempt.location.ASN
+			}) {
+				add(RiskSignalNewASN, p.NewASNScore)
+			}
+			if p.isImpossibleTravel(attempt, history) {
+				add(RiskSignalImpossibleTravel, p.ImpossibleTravelScore)
+			}
+		}
+	}
+
+	if recentFailures > 0 {
+		score := recentFailures * p.FailureScore
+		if p.MaxFailureScore > 0 && score > p.MaxFailureScore {
+			score = p.MaxFailureScore
+		}
+		add(RiskSignalRecentFailures, score)
+	}
+
+	switch {
+	case p.BlockThreshold > 0 && assessment.Score >= p.BlockThreshold:
+		assessment.Decision = RiskDecisionBlock
+	case p.MFAThreshold > 0 && assessment.Score >= p.MFAThreshold:
+		if mfaAvailable {
+			assessment.Decision = RiskDecisionRequireMFA
+		} else {
+			assessment.Decision = RiskDecisionBlock
+		}
+	}
+	assessment.Suspicious = assessment.Decision != RiskDecisionAllow
+	return assessment
+}
+
+// isImpossibleTravel reports whether reaching the attempt's location from the latest located
+// login would require moving faster than MaxTravelSpeed.
+func (p RiskPolicy) isImpossibleTravel(attempt *LoginAttempt, history []*LoginAttempt) bool {
+	if p.MaxTravelSpeed <= 0 {
+		return false
+	}
+	for _, previous := range history {
+		if previous.location == nil {
+			continue
+		}
+		distance := distanceKm(previous.location, attempt.location)
+		if distance <= travelToleranceKm {
+			return false
+		}
+		hours := attempt.createdAt.Sub(previous.createdAt).Hours()
+		if hours <= 0 {
+			return true
+		}
+		return distance/hours > p.MaxTravelSpeed
+	}
+	return false
+}
+
+// seenInHistory reports whether any login in history matches.
+func seenInHistory(history []*LoginAttempt, match func(*LoginAttempt) bool) bool {
+	for _, a := range history {
+		if match(a) {
+			return true
+		}
+	}
+	return false
+}
+
+// distanceKm returns the great-circle distance between two locations (haversine formula).
+func distanceKm(from, to *IPLocation) float64 {
+	toRadians := func(deg float64) float64 { return deg * math.Pi / 180 }
+	dLat := toRadians(to.Latitude - from.Latitude)
+	dLon := toRadians(to.Longitude - from.Longitude)
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRadians(from.Latitude))*math.Cos(toRadians(to.Latitude))*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKm * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// LoginRiskService scores logins on the device, network and location they come from and on
+// recent failed attempts.
+type LoginRiskService interface {
+	// Assess scores a login of the user from the client in ctx. mfaAvailable tells whether the
+	// user can complete an MFA challenge. Every decision is recorded in the audit log, and a
+	// SuspiciousLogin event is published for logins scoring at least the MFA threshold.
+	Assess(ctx context.Context, userID string, mfaAvailable bool) (*RiskAssessment, error)
+	// RecordLogin stores a successful or failed login of the user from the client in ctx.
+	// assessment is the one made for the same request, whose location is reused; if it is
+	// nil, the client's location is looked up.
+	RecordLogin(ctx context.Context, userID string, assessment *RiskAssessment, succeeded bool) error
+}
+
+// loginRiskService implements LoginRiskService with domain logic.
+type loginRiskService struct {
+	attemptRepo LoginAttemptRepository
+	locator     IPLocator
+	auditRepo   AuditLogRepository
+	eventPub    EventPublisher
+	policy      RiskPolicy
+	retention   time.Duration
+}
+
+// NewLoginRiskService creates a new instance of loginRiskService. Login attempts are kept for
+// retention. locator may be nil, in which case the ASN and travel signals are not evaluated.
+func NewLoginRiskService(attemptRepo LoginAttemptRepository, locator IPLocator, auditRepo AuditLogRepository, eventPub EventPublisher, policy RiskPolicy, retention time.Duration) LoginRiskService {
+	return &loginRiskService{
+		attemptRepo: attemptRepo,
+		locator:     locator,
+		auditRepo:   auditRepo,
+		eventPub:    eventPub,
+		policy:      policy,
+		retention:   retention,
+	}
+}
+
+// Assess compares the login with the user's recent history and records the decision.
+func (s *loginRiskService) Assess(ctx context.Context, userID string, mfaAvailable bool) (*RiskAssessment, error) {
+	metadata := ClientMetadataFromContext(ctx)
+	attempt, err := NewLoginAttempt(generateRandomString(36), userID, metadata, s.locate(ctx, metadata.IPAddress), false, s.retention)
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := s.attemptRepo.FindRecentSuccessful(ctx, userID, s.policy.HistorySize)
+	if err != nil {
+		return nil, errors.New("failed to find login history: " + err.Error())
+	}
+	failures, err := s.attemptRepo.CountFailedSince(ctx, userID, time.Now().Add(-s.policy.FailureWindow))
+	if err != nil {
+		return nil, errors.New("failed to count failed logins: " + err.Error())
+	}
+
+	assessment := s.policy.Evaluate(attempt, history, failures, mfaAvailable)
+	assessment.Location = attempt.Location()
+
+	values := map[string]interface{}{
+		"decision":      string(assessment.Decision),
+		"score":         assessment.Score,
+		"signals":       assessment.Signals,
+		"mfa_available": mfaAvailable,
+		"ip_address":    metadata.IPAddress,
+	}
+	if assessment.Location != nil {
+		values["asn"] = assessment.Location.ASN
+		values["country_code"] = assessment.Location.CountryCode
+	}
+	recordAudit(ctx, s.auditRepo, AuditActionLoginRiskAssessed, auditEntityUser, &userID, &userID, values)
+
+	if assessment.Suspicious {
+		_ = s.eventPub.Publish(&SuspiciousLogin{
+			userID:    userID,
+			decision:  assessment.Decision,
+			score:     assessment.Score,
+			signals:   assessment.Signals,
+			ipAddress: metadata.IPAddress,
+			timestamp: time.Now(),
+		})
+	}
+	return assessment, nil
+}
+
+// RecordLogin stores the login so later logins from the same device and network are recognized.
+func (s *loginRiskService) RecordLogin(ctx context.Context, userID string, assessment *RiskAssessment, succeeded bool) error {
+	metadata := ClientMetadataFromContext(ctx)
+	var location *IPLocation
+	if assessment != nil {
+		location = assessment.Location
+	} else {
+		location = s.locate(ctx, metadata.IPAddress)
+	}
+	attempt, err := NewLoginAttempt(generateRandomString(36), userID, metadata, location, succeeded, s.retention)
+	if err != nil {
+		return err
+	}
+	if err := s.attemptRepo.Save(ctx, attempt); err != nil {
+		return errors.New("failed to save login attempt: " + err.Error())
+	}
+	return nil
+}
+
+// locate resolves the IP address, returning nil when no locator is configured or the lookup fails.
+func (s *loginRiskService) locate(ctx context.Context, ip string) *IPLocation {
+	if s.locator == nil || ip == "" {
+		return nil
+	}
+	// 위치 조회 서비스 장애로 로그인이 실패하지 않도록 위치 신호만 생략
+	location, err := s.locator.Locate(ctx, ip)
+	if err != nil {
+		return nil
+	}
+	return location
+}
+
+// canEmailChallenge reports whether a login that requires MFA can be confirmed with a code
+// sent to the user's email address. A login that started from an emailed link cannot,
+// since the same mailbox would prove both factors.
+func (s *authService) canEmailChallenge(user *User, amr []string) bool {
+	return s.riskMailer != nil && s.mfaChallengeRepo != nil &&
+		user.Email() != "" && user.EmailVerified() && !containsString(amr, AMREmail)
+}
+
+// issueEmailChallenge emails a one-time code to the user and returns the MFARequiredError
+// for a challenge that accepts only that code.
+func (s *authService) issueEmailChallenge(ctx context.Context, user *User, authTime time.Time, amr []string) error {
+	rawToken, challenge, err := s.newMFAChallenge(user.ID(), authTime, amr)
+	if err != nil {
+		return err
+	}
+	code, err := generateNumericCode(emailCodeDigits)
+	if err != nil {
+		return err
+	}
+	challenge.SetEmailCodeHash(emailCodeHash(challenge.TokenHash(), code))
+
+	msg := EmailMessage{
+		To:      user.Email(),
+		Subject: "Confirm your sign-in",
+		Body: "We noticed a sign-in to your account from a new device or location.\n\n" +
+			"Your confirmation code is " + code + ". If this wasn't you, change your password.",
+	}
+	if err := s.riskMailer.Send(ctx, msg); err != nil {
+		return errors.New("failed to send sign-in code: " + err.Error())
+	}
+	return s.saveMFAChallenge(ctx, rawToken, challenge, []string{MFAMethodEmail})
+}
+
+// verifyEmailCode checks the code sent for the challenge and returns the amr value it satisfies.
+func verifyEmailCode(challenge *MFAChallenge, code string) (string, error) {
+	expected := challenge.EmailCodeHash()
+	if expected == "" || code == "" {
+		return "", ErrInvalidMFACode
+	}
+	if subtle.ConstantTimeCompare([]byte(emailCodeHash(challenge.TokenHash(), code)), []byte(expected)) != 1 {
+		return "", ErrInvalidMFACode
+	}
+	return AMREmail, nil
+}
+
+// emailCodeHash hashes a short code together with the challenge it belongs to, so a stored
+// hash cannot be matched against other challenges.
+func emailCodeHash(challengeTokenHash, code string) string {
+	return hashOpaqueToken(challengeTokenHash + ":" + code)
+}
+
+// generateNumericCode returns a uniformly random code of the given number of digits.
+func generateNumericCode(digits int) (string, error) {
+	code := make([]byte, digits)
+	for i := range code {
+		n, err := rand.Int(rand.Reader, big.NewInt(10))
+		if err != nil {
+			return "", errors.New("failed to generate code: " + err.Error())
+		}
+		code[i] = byte('0' + n.Int64())
+	}
+	return string(code), nil
+}
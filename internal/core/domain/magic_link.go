@@ -0,0 +1,200 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// AMREmail is the amr value for proof of control of the user's email address. RFC 8176
+// registers no value for this, so a private value is used.
+const AMREmail = "email"
+
+var (
+	// ErrMagicLinkInvalid is returned when a login link is unknown, expired or already used.
+	ErrMagicLinkInvalid = errors.New("magic link is invalid or expired")
+	// ErrRateLimited is returned when too many requests were made for the same key.
+	ErrRateLimited = errors.New("too many requests")
+)
+
+// MagicLinkToken is a pending passwordless login sent to a user's email address.
+// The raw token goes into the link; only its hash is stored.
+type MagicLinkToken struct {
+	tokenHash string
+	userID    string
+	email     Email // 발송 시점의 주소, 이후 변경되면 링크 무효
+	expiresAt time.Time
+	createdAt time.Time
+}
+
+// NewMagicLinkToken creates a new MagicLinkToken instance.
+func NewMagicLinkToken(tokenHash, userID string, email Email, expiresAt time.Time) (*MagicLinkToken, error) {
+	if tokenHash == "" {
+		return nil, errors.New("token hash must not be empty")
+	}
+	if userID == "" {
+		return nil, errors.New("user id must not be empty")
+	}
+	if !email.IsValid() {
+		return nil, errors.New("invalid email")
+	}
+
+	return &MagicLinkToken{
+		tokenHash: tokenHash,
+		userID:    userID,
+		email:     email,
+		expiresAt: expiresAt,
+		createdAt: time.Now(),
+	}, nil
+}
+
+// NewMagicLinkTokenFromStorage restores a MagicLinkToken loaded from storage.
+func NewMagicLinkTokenFromStorage(tokenHash, userID string, email Email, expiresAt, createdAt time.Time) (*MagicLinkToken, error) {
+	token, err := NewMagicLinkToken(tokenHash, userID, email, expiresAt)
+	if err != nil {
+		return nil, err
+	}
+	token.createdAt = createdAt
+	return token, nil
+}
+
+// TokenHash returns the SHA-256 hash of the link token.
+func (t *MagicLinkToken) TokenHash() string {
+	return t.tokenHash
+}
+
+// UserID returns the user the link logs in.
+func (t *MagicLinkToken) UserID() string {
+	return t.userID
+}
+
+// Email returns the address the link was sent to.
+func (t *MagicLinkToken) Email() Email {
+	return t.email
+}
+
+// ExpiresAt returns when the link expires.
+func (t *MagicLinkToken) ExpiresAt() time.Time {
+	return t.expiresAt
+}
+
+// CreatedAt returns when the link was issued.
+func (t *MagicLinkToken) CreatedAt() time.Time {
+	return t.createdAt
+}
+
+// IsExpired reports whether the link can no longer be redeemed.
+func (t *MagicLinkToken) IsExpired() bool {
+	return time.Now().After(t.expiresAt)
+}
+
+// MagicLinkService defines operations for passwordless login by email.
+type MagicLinkService interface {
+	// RequestLink emails a login link if the address belongs to a user. It behaves the same
+	// for unknown addresses, so callers cannot learn which addresses are registered.
+	RequestLink(ctx context.Context, email string) error
+	// Redeem consumes a link token and returns the user it logs in.
+	Redeem(ctx context.Context, token string) (string, error)
+}
+
+// magicLinkService implements MagicLinkService with domain logic.
+type magicLinkService struct {
+	userRepo      UserRepository
+	tokenRepo     MagicLinkTokenRepository
+	rateLimitRepo RateLimitRepository
+	mailer        Mailer
+	linkURL       string        // 토큰을 붙여 보내는 로그인 화면 주소
+	ttl           time.Duration // 링크 유효 시간
+	rateLimit     int           // 주소당 window 동안 허용하는 요청 수
+	rateWindow    time.Duration
+}
+
+// NewMagicLinkService creates a new instance of magicLinkService.
+func NewMagicLinkService(userRepo UserRepository, tokenRepo MagicLinkTokenRepository, rateLimitRepo RateLimitRepository, mailer Mailer, linkURL string, ttl time.Duration, rateLimit int, rateWindow time.Duration) MagicLinkService {
+	return &magicLinkService{
+		userRepo:      userRepo,
+		tokenRepo:     tokenRepo,
+		rateLimitRepo: rateLimitRepo,
+		mailer:        mailer,
+		linkURL:       linkURL,
+		ttl:           ttl,
+		rateLimit:     rateLimit,
+		rateWindow:    rateWindow,
+	}
+}
+
+// RequestLink issues a single-use login link and emails it to the user. Requests are limited
+// per address whether or not the address is registered, and the email is sent in the
+// background so the response time does not tell the two apart either.
+func (s *magicLinkService) RequestLink(ctx context.Context, rawEmail string) error {
+	email, err := NewEmail(rawEmail)
+	if err != nil {
+		return nil // 형식이 잘못된 주소도 등록되지 않은 주소와 같게 처리
+	}
+
+	count, err := s.rateLimitRepo.Increment(ctx, "magic_link:"+email.String(), s.rateWindow)
+	if err != nil {
+		return errors.New("failed to check rate limit: " + err.Error())
+	}
+	if count > s.rateLimit {
+		return ErrRateLimited
+	}
+
+	user, err := s.userRepo.FindByEmail(ctx, email)
+	if err != nil {
+		return errors.New("failed to find user: " + err.Error())
+	}
+	if user == nil {
+		return nil
+	}
+
+	rawToken := generateRandomString(43)
+	token, err := NewMagicLinkToken(hashOpaqueToken(rawToken), user.ID(), email, time.Now().Add(s.ttl))
+	if err != nil {
+		return err
+	}
+	if err := s.tokenRepo.Save(ctx, token); err != nil {
+		return errors.New("failed to save magic link: " + err.Error())
+	}
+
+	link, err := tokenLink(s.linkURL, rawToken)
+	if err != nil {
+		return err
+	}
+
+	msg := EmailMessage{
+		To:      email,
+		Subject: "Your sign-in link",
+		Body: "Hi " + user.Username() + ",\n\n" +
+			"Use the link below to sign in. It expires in " + s.ttl.String() + " and can be used once.\n\n" +
+			link + "\n\n" +
+			"If you did not request this, you can ignore this email.\n",
+	}
+	sendDetached(ctx, s.mailer, msg)
+	return nil
+}
+
+// Redeem consumes a link token. The link is rejected if the user's email address changed
+// after it was sent.
+func (s *magicLinkService) Redeem(ctx context.Context, rawToken string) (string, error) {
+	if rawToken == "" {
+		return "", ErrMagicLinkInvalid
+	}
+
+	token, err := s.tokenRepo.Consume(ctx, hashOpaqueToken(rawToken))
+	if err != nil {
+		return "", errors.New("failed to consume magic link: " + err.Error())
+	}
+	if token == nil || token.IsExpired() {
+		return "", ErrMagicLinkInvalid
+	}
+
+	user, err := s.userRepo.FindByID(ctx, token.UserID())
+	if err != nil {
+		return "", errors.New("failed to find user: " + err.Error())
+	}
+	if user == nil || user.Email() != token.Email() {
+		return "", ErrMagicLinkInvalid
+	}
+	return user.ID(), nil
+}
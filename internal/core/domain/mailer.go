@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"net/url"
+)
+
+// EmailMessage is a plain-text email sent to a user.
+type EmailMessage struct {
+	To      Email
+	Subject string
+	Body    string
+}
+
+// Mailer delivers transactional emails such as login links.
+type Mailer interface {
+	// Send delivers the message or returns an error if it could not be handed off.
+	Send(ctx context.Context, msg EmailMessage) error
+}
+
+// sendDetached hands msg to the mailer in the background, so a request that sends mail
+// answers as quickly as one that does not and the response does not reveal whether the
+// address is registered. The send gets a context that outlives the request.
+func sendDetached(ctx context.Context, mailer Mailer, msg EmailMessage) {
+	ctx = context.WithoutCancel(ctx)
+	go func() {
+		_ = mailer.Send(ctx, msg) // 실패 기록은 메일러 어댑터가 담당
+	}()
+}
+
+// tokenLink appends rawToken as the token query parameter of baseURL, producing the link
+// that is emailed to the user.
+func tokenLink(baseURL, rawToken string) (string, error) {
+	link, err := url.Parse(baseURL)
+	if err != nil {
+		return "", errors.New("invalid link url: " + err.Error())
+	}
+	query := link.Query()
+	query.Set("token", rawToken)
+	link.RawQuery = query.Encode()
+	return link.String(), nil
+}
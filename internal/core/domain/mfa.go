@@ -0,0 +1,405 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Second factors a user can enroll.
+const (
+	MFAMethodTOTP = "totp"
+	// MFAMethodRecoveryCode is offered only alongside another enabled factor.
+	MFAMethodRecoveryCode = "recovery_code"
+	// MFAMethodEmail is a code sent to the verified email address. It cannot be enrolled and
+	// is offered only when login risk scoring requires MFA from a user without another factor.
+	MFAMethodEmail = "email"
+)
+
+// Authentication method references for second factors (RFC 8176 2).
+const (
+	AMROTP = "otp"
+	AMRMFA = "mfa"
+)
+
+// Audit actions recorded for second-factor enrollment.
+const (
+	AuditActionMFAEnrolled            = "MFA_ENROLLED"
+	AuditActionMFADisabled            = "MFA_DISABLED"
+	AuditActionRecoveryCodesGenerated = "MFA_RECOVERY_CODES_GENERATED"
+	AuditActionRecoveryCodeUsed       = "MFA_RECOVERY_CODE_USED"
+)
+
+// recentMFAWindow is how long after a multi-factor login the user may regenerate recovery codes.
+const recentMFAWindow = 10 * time.Minute
+
+// auditEntityUser is the audit log entity type for users.
+const auditEntityUser = "USER"
+
+var (
+	// ErrInvalidMFACode is returned when a second-factor code is wrong or was already used.
+	ErrInvalidMFACode = errors.New("invalid mfa code")
+	// ErrMFAChallengeInvalid is returned when an MFA challenge token is unknown, expired or exhausted.
+	ErrMFAChallengeInvalid = errors.New("mfa challenge is invalid or expired")
+	// ErrMFANotEnrolled is returned when the user has not enrolled the requested method.
+	ErrMFANotEnrolled = errors.New("mfa method is not enrolled")
+	// ErrMFAAlreadyEnabled is returned when enrolling a method that is already active.
+	ErrMFAAlreadyEnabled = errors.New("mfa method is already enabled")
+	// ErrRecentMFARequired is returned when an operation needs a recent multi-factor login.
+	ErrRecentMFARequired = errors.New("recent multi-factor authentication is required")
+)
+
+// MFARequiredError is returned by Authenticate when the password was correct but the user
+// must present a second factor. The challenge token is exchanged for tokens with CompleteMFA.
+type MFARequiredError struct {
+	ChallengeToken string
+	Methods        []string
+	ExpiresAt      time.Time
+}
+
+// Error implements the error interface.
+func (e *MFARequiredError) Error() string {
+	return "multi-factor authentication required"
+}
+
+// SecretCipher encrypts secrets that must be readable again, such as TOTP seeds, before they are stored.
+type SecretCipher interface {
+	Encrypt(plaintext []byte) (string, error)
+	Decrypt(ciphertext string) ([]byte, error)
+}
+
+// TOTPEnrollment holds what the user needs to add the account to an authenticator app.
+// It is returned only once, when enrollment starts.
+type TOTPEnrollment struct {
+	Secret          string // 수동 입력용 base32
+	ProvisioningURI string // QR 코드로 표시할 otpauth:// URI
+}
+
+// MFAService defines operations for enrolling and verifying second factors.
+type MFAService interface {
+	// EnrollTOTP starts TOTP enrollment, replacing any unconfirmed enrollment.
+	EnrollTOTP(ctx context.Context, userID string) (*TOTPEnrollment, error)
+	// ConfirmTOTP activates the TOTP credential once the user enters a valid code and
+	// returns a new set of recovery codes, which are not shown again.
+	ConfirmTOTP(ctx context.Context, userID, code string) ([]string, error)
+	// DisableTOTP removes the TOTP credential after checking a current code.
+	DisableTOTP(ctx context.Context, userID, code string) error
+	// EnabledMethods returns the confirmed second factors of a user.
+	EnabledMethods(ctx context.Context, userID string) ([]string, error)
+	// VerifyFactor checks a second-factor response and returns the amr value it satisfies.
+	VerifyFactor(ctx context.Context, userID, method, response string) (string, error)
+	// RegenerateRecoveryCodes replaces the user's recovery codes. The caller must have completed
+	// a multi-factor login within the last ten minutes, as shown by authTime and amr.
+	RegenerateRecoveryCodes(ctx context.Context, userID string, authTime time.Time, amr []string) ([]string, error)
+}
+
+// mfaService implements MFAService with domain logic.
+type mfaService struct {
+	userRepo     UserRepository
+	totpRepo     TOTPCredentialRepository
+	recoveryRepo RecoveryCodeRepository
+	cipher       SecretCipher
+	auditRepo    AuditLogRepository
+	issuer       string // 인증 앱에 표시되는 서비스 이름
+	totpDrift    int    // 허용하는 앞뒤 time step 수
+
+	// 패스키를 두 번째 인증 요소로 쓰는 기능은 WithPasskeyFactor 옵션을 지정한 경우에만 활성화
+	passkeys PasskeyService
+}
+
+// MFAServiceOption configures optional second factors of the MFA service.
+type MFAServiceOption func(*mfaService)
+
+// WithPasskeyFactor lets users with a registered passkey use it as a second factor.
+func WithPasskeyFactor(passkeys PasskeyService) MFAServiceOption {
+	return func(s *mfaService) {
+		s.passkeys = passkeys
+	}
+}
+
+// NewMFAService creates a new instance of mfaService.
+func NewMFAService(userRepo UserRepository, totpRepo TOTPCredentialRepository, recoveryRepo RecoveryCodeRepository, cipher SecretCipher, auditRepo AuditLogRepository, issuer string, totpDrift int, opts ...MFAServiceOption) MFAService {
+	s := &mfaService{
+		userRepo:     userRepo,
+		totpRepo:     totpRepo,
+		recoveryRepo: recoveryRepo,
+		cipher:       cipher,
+		auditRepo:    auditRepo,
+		issuer:       issuer,
+		totpDrift:    totpDrift,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// EnrollTOTP generates a new secret and stores it encrypted until the user confirms it.
+func (s *mfaService) EnrollTOTP(ctx context.Context, userID string) (*TOTPEnrollment, error) {
+	if userID == "" {
+		return nil, errors.New("user id must not be empty")
+	}
+
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, errors.New("failed to find user: " + err.Error())
+	}
+	if user == nil {
+		return nil, errors.New("user not found")
+	}
+	existing, err := s.totpRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, errors.New("failed to find totp credential: " + err.Error())
+	}
+	if existing != nil && existing.IsConfirmed() {
+		return nil, ErrMFAAlreadyEnabled
+	}
+
+	secret, err := NewTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+	encrypted, err := s.cipher.Encrypt(secret)
+	if err != nil {
+		return nil, errors.New("failed to encrypt totp secret: " + err.Error())
+	}
+	credential, err := NewTOTPCredential(userID, encrypted)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.totpRepo.Save(ctx, credential); err != nil {
+		return nil, errors.New("failed to save totp credential: " + err.Error())
+	}
+
+	return &TOTPEnrollment{
+		Secret:          EncodeTOTPSecret(secret),
+		ProvisioningURI: TOTPProvisioningURI(s.issuer, user.Username(), secret),
+	}, nil
+}
+
+// ConfirmTOTP verifies the first code from the authenticator, activates the credential and
+// issues a fresh set of recovery codes.
+func (s *mfaService) ConfirmTOTP(ctx context.Context, userID, code string) ([]string, error) {
+	if userID == "" {
+		return nil, errors.New("user id must not be empty")
+	}
+
+	credential, err := s.totpRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, errors.New("failed to find totp credential: " + err.Error())
+	}
+	if credential == nil {
+		return nil, ErrMFANotEnrolled
+	}
+	if credential.IsConfirmed() {
+		return nil, ErrMFAAlreadyEnabled
+	}
+
+	step, err := s.matchTOTP(credential, code)
+	if err != nil {
+		return nil, err
+	}
+	if err := credential.Confirm(step); err != nil {
+		return nil, err
+	}
+	if err := s.totpRepo.Save(ctx, credential); err != nil {
+		return nil, errors.New("failed to save totp credential: " + err.Error())
+	}
+
+	recordAudit(ctx, s.auditRepo, AuditActionMFAEnrolled, auditEntityUser, &userID, &userID, map[string]interface{}{"method": MFAMethodTOTP})
+	return s.replaceRecoveryCodes(ctx, userID)
+}
+
+// DisableTOTP removes a confirmed TOTP credential after checking a current code.
+func (s *mfaService) DisableTOTP(ctx context.Context, userID, code string) error {
+	if userID == "" {
+		return errors.New("user id must not be empty")
+	}
+
+	credential, err := s.confirmedTOTP(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if err := s.verifyTOTP(ctx, credential, code); err != nil {
+		return err
+	}
+	if err := s.totpRepo.Delete(ctx, userID); err != nil {
+		return errors.New("failed to delete totp credential: " + err.Error())
+	}
+
+	recordAudit(ctx, s.auditRepo, AuditActionMFADisabled, auditEntityUser, &userID, &userID, map[string]interface{}{"method": MFAMethodTOTP})
+	return nil
+}
+
+// EnabledMethods returns the confirmed second factors of a user.
+func (s *mfaService) EnabledMethods(ctx context.Context, userID string) ([]string, error) {
+	if userID == "" {
+		return nil, errors.New("user id must not be empty")
+	}
+
+	var methods []string
+	credential, err := s.totpRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, errors.New("failed to find totp credential: " + err.Error())
+	}
+	if credential != nil && credential.IsConfirmed() {
+		methods = append(methods, MFAMethodTOTP)
+	}
+	if s.passkeys != nil {
+		passkeys, err := s.passkeys.ListPasskeys(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		if len(passkeys) > 0 {
+			methods = append(methods, MFAMethodWebAuthn)
+		}
+	}
+	// 복구 코드는 다른 인증 요소를 대신할 뿐, 단독으로 MFA를 요구하지 않음
+	if len(methods) > 0 {
+		codes, err := s.recoveryRepo.FindUnusedByUserID(ctx, userID)
+		if err != nil {
+			return nil, errors.New("failed to find recovery codes: " + err.Error())
+		}
+		if len(codes) > 0 {
+			methods = append(methods, MFAMethodRecoveryCode)
+		}
+	}
+	return methods, nil
+}
+
+// VerifyFactor checks a second-factor response for the given method.
+func (s *mfaService) VerifyFactor(ctx context.Context, userID, method, response string) (string, error) {
+	if userID == "" {
+		return "", errors.New("user id must not be empty")
+	}
+
+	switch method {
+	case MFAMethodTOTP:
+		credential, err := s.confirmedTOTP(ctx, userID)
+		if err != nil {
+			return "", err
+		}
+		if err := s.verifyTOTP(ctx, credential, response); err != nil {
+			return "", err
+		}
+		return AMROTP, nil
+	case MFAMethodWebAuthn:
+		if s.passkeys == nil {
+			return "", ErrMFANotEnrolled
+		}
+		// 응답은 PublicKeyCredential JSON, 검증 실패는 잘못된 코드와 같이 취급
+		if err := s.passkeys.VerifyFactor(ctx, userID, []byte(response)); err != nil {
+			if errors.Is(err, ErrPasskeyInvalid) {
+				return "", ErrInvalidMFACode
+			}
+			return "", err
+		}
+		return AMRHardwareKey, nil
+	case MFAMethodRecoveryCode:
+		if err := s.consumeRecoveryCode(ctx, userID, response); err != nil {
+			return "", err
+		}
+		return AMROTP, nil
+	default:
+		return "", ErrMFANotEnrolled
+	}
+}
+
+// RegenerateRecoveryCodes replaces the user's recovery codes after checking that the caller
+// recently completed a multi-factor login, so a stolen access token alone cannot mint codes.
+func (s *mfaService) RegenerateRecoveryCodes(ctx context.Context, userID string, authTime time.Time, amr []string) ([]string, error) {
+	if userID == "" {
+		return nil, errors.New("user id must not be empty")
+	}
+	if !containsString(amr, AMRMFA) || time.Since(authTime) > recentMFAWindow {
+		return nil, ErrRecentMFARequired
+	}
+
+	methods, err := s.EnabledMethods(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(methods) == 0 {
+		return nil, ErrMFANotEnrolled
+	}
+	return s.replaceRecoveryCodes(ctx, userID)
+}
+
+// replaceRecoveryCodes generates a new set of recovery codes, invalidating the old set.
+func (s *mfaService) replaceRecoveryCodes(ctx context.Context, userID string) ([]string, error) {
+	codes, raws, err := GenerateRecoveryCodes(userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.recoveryRepo.ReplaceForUser(ctx, userID, codes); err != nil {
+		return nil, errors.New("failed to save recovery codes: " + err.Error())
+	}
+
+	recordAudit(ctx, s.auditRepo, AuditActionRecoveryCodesGenerated, auditEntityUser, &userID, &userID, map[string]interface{}{"count": len(codes)})
+	return raws, nil
+}
+
+// consumeRecoveryCode finds the unused code matching rawCode and marks it used.
+func (s *mfaService) consumeRecoveryCode(ctx context.Context, userID, rawCode string) error {
+	codes, err := s.recoveryRepo.FindUnusedByUserID(ctx, userID)
+	if err != nil {
+		return errors.New("failed to find recovery codes: " + err.Error())
+	}
+	for _, code := range codes {
+		if !code.Matches(rawCode) {
+			continue
+		}
+		used, err := s.recoveryRepo.MarkUsed(ctx, code.ID(), time.Now())
+		if err != nil {
+			return errors.New("failed to consume recovery code: " + err.Error())
+		}
+		if !used {
+			return ErrInvalidMFACode
+		}
+		recordAudit(ctx, s.auditRepo, AuditActionRecoveryCodeUsed, auditEntityUser, &userID, &userID, map[string]interface{}{"remaining": len(codes) - 1})
+		return nil
+	}
+	return ErrInvalidMFACode
+}
+
+// confirmedTOTP loads the user's TOTP credential and fails unless it is confirmed.
+func (s *mfaService) confirmedTOTP(ctx context.Context, userID string) (*TOTPCredential, error) {
+	credential, err := s.totpRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, errors.New("failed to find totp credential: " + err.Error())
+	}
+	if credential == nil || !credential.IsConfirmed() {
+		return nil, ErrMFANotEnrolled
+	}
+	return credential, nil
+}
+
+// verifyTOTP checks the code and atomically records its time step, so a code cannot be
+// replayed and no code older than the last accepted one is accepted.
+func (s *mfaService) verifyTOTP(ctx context.Context, credential *TOTPCredential, code string) error {
+	step, err := s.matchTOTP(credential, code)
+	if err != nil {
+		return err
+	}
+	fresh, err := s.totpRepo.MarkStepUsed(ctx, credential.UserID(), step)
+	if err != nil {
+		return errors.New("failed to record totp use: " + err.Error())
+	}
+	if !fresh {
+		return ErrInvalidMFACode
+	}
+	return nil
+}
+
+// matchTOTP decrypts the secret and returns the time step the code belongs to.
+func (s *mfaService) matchTOTP(credential *TOTPCredential, code string) (int64, error) {
+	secret, err := s.cipher.Decrypt(credential.EncryptedSecret())
+	if err != nil {
+		return 0, errors.New("failed to decrypt totp secret: " + err.Error())
+	}
+	step, ok := MatchTOTPCode(secret, code, time.Now(), s.totpDrift)
+	if !ok {
+		return 0, ErrInvalidMFACode
+	}
+	return step, nil
+}
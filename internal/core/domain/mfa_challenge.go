@@ -0,0 +1,102 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// MFAChallenge is issued when a user passed the first factor but must still present a second one.
+// The raw challenge token goes to the client; only its hash is stored.
+type MFAChallenge struct {
+	tokenHash string
+	userID    string
+	authTime  time.Time // 첫 번째 인증 요소를 통과한 시각
+	amr       []string  // 지금까지 사용한 인증 방법
+	attempts  int
+
+	// 위험도가 높은 로그인에서 이메일로 보낸 확인 코드의 해시, 이메일 인증을 요구하지 않으면 빈 문자열
+	emailCodeHash string
+	expiresAt     time.Time
+	createdAt     time.Time
+}
+
+// NewMFAChallenge creates a new MFAChallenge instance.
+func NewMFAChallenge(tokenHash, userID string, authTime time.Time, amr []string, expiresAt time.Time) (*MFAChallenge, error) {
+	if tokenHash == "" {
+		return nil, errors.New("token hash must not be empty")
+	}
+	if userID == "" {
+		return nil, errors.New("user id must not be empty")
+	}
+
+	return &MFAChallenge{
+		tokenHash: tokenHash,
+		userID:    userID,
+		authTime:  authTime,
+		amr:       amr,
+		expiresAt: expiresAt,
+		createdAt: time.Now(),
+	}, nil
+}
+
+// NewMFAChallengeFromStorage restores an MFAChallenge loaded from storage.
+func NewMFAChallengeFromStorage(tokenHash, userID string, authTime time.Time, amr []string, attempts int, emailCodeHash string, expiresAt, createdAt time.Time) (*MFAChallenge, error) {
+	challenge, err := NewMFAChallenge(tokenHash, userID, authTime, amr, expiresAt)
+	if err != nil {
+		return nil, err
+	}
+	challenge.attempts = attempts
+	challenge.emailCodeHash = emailCodeHash
+	challenge.createdAt = createdAt
+	return challenge, nil
+}
+
+// TokenHash returns the SHA-256 hash of the challenge token.
+func (c *MFAChallenge) TokenHash() string {
+	return c.tokenHash
+}
+
+// UserID returns the user being authenticated.
+func (c *MFAChallenge) UserID() string {
+	return c.userID
+}
+
+// AuthTime returns when the user passed the first factor.
+func (c *MFAChallenge) AuthTime() time.Time {
+	return c.authTime
+}
+
+// AMR returns the authentication methods used so far.
+func (c *MFAChallenge) AMR() []string {
+	return c.amr
+}
+
+// Attempts returns the number of second-factor attempts made against the challenge.
+func (c *MFAChallenge) Attempts() int {
+	return c.attempts
+}
+
+// EmailCodeHash returns the hash of the code emailed for the challenge, or "" if none was sent.
+func (c *MFAChallenge) EmailCodeHash() string {
+	return c.emailCodeHash
+}
+
+// SetEmailCodeHash records the hash of a code emailed to the user as the second factor.
+func (c *MFAChallenge) SetEmailCodeHash(codeHash string) {
+	c.emailCodeHash = codeHash
+}
+
+// ExpiresAt returns the time when the challenge expires.
+func (c *MFAChallenge) ExpiresAt() time.Time {
+	return c.expiresAt
+}
+
+// CreatedAt returns the time when the challenge was issued.
+func (c *MFAChallenge) CreatedAt() time.Time {
+	return c.createdAt
+}
+
+// IsExpired checks if the challenge has expired.
+func (c *MFAChallenge) IsExpired() bool {
+	return time.Now().After(c.expiresAt)
+}
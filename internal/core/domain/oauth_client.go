@@ -0,0 +1,193 @@
+package domain
+
+import (
+	"errors"
+	"net/url"
+	"time"
+)
+
+// OAuthClientType defines whether a client can keep a secret (RFC 6749 2.1).
+type OAuthClientType string
+
+const (
+	OAuthClientConfidential OAuthClientType = "CONFIDENTIAL"
+	OAuthClientPublic       OAuthClientType = "PUBLIC"
+)
+
+// OAuthClient represents a registered OAuth client: an internal job or bot using the client
+// credentials grant, or an application using the authorization code flow.
+type OAuthClient struct {
+	id            string
+	name          string
+	clientType    OAuthClientType
+	secretHash    Password // 클라이언트 시크릿도 사용자 비밀번호와 동일하게 Argon2id로 해싱
+	allowedScopes []string
+	redirectURIs  []string
+	firstParty    bool // ImmersiVerse 자체 앱은 동의 화면 생략
+	tokenTTL      time.Duration
+	createdAt     time.Time
+	updatedAt     time.Time
+}
+
+// NewOAuthClient creates a new OAuthClient instance.
+func NewOAuthClient(id, name string, secretHash Password, allowedScopes []string, tokenTTL time.Duration) (*OAuthClient, error) {
+	if id == "" {
+		return nil, errors.New("client id must not be empty")
+	}
+	if name == "" {
+		return nil, errors.New("client name must not be empty")
+	}
+	if len(secretHash.Hash()) == 0 {
+		return nil, errors.New("client secret hash must not be empty")
+	}
+	if tokenTTL <= 0 {
+		return nil, errors.New("client token ttl must be positive")
+	}
+
+	now := time.Now()
+	return &OAuthClient{
+		id:            id,
+		name:          name,
+		clientType:    OAuthClientConfidential,
+		secretHash:    secretHash,
+		allowedScopes: allowedScopes,
+		tokenTTL:      tokenTTL,
+		createdAt:     now,
+		updatedAt:     now,
+	}, nil
+}
+
+// NewPublicOAuthClient creates a client that cannot keep a secret, such as a browser overlay
+// or mobile app. Public clients must use PKCE and cannot use the client credentials grant.
+func NewPublicOAuthClient(id, name string, allowedScopes []string, tokenTTL time.Duration) (*OAuthClient, error) {
+	if id == "" {
+		return nil, errors.New("client id must not be empty")
+	}
+	if name == "" {
+		return nil, errors.New("client name must not be empty")
+	}
+	if tokenTTL <= 0 {
+		return nil, errors.New("client token ttl must be positive")
+	}
+
+	now := time.Now()
+	return &OAuthClient{
+		id:            id,
+		name:          name,
+		clientType:    OAuthClientPublic,
+		allowedScopes: allowedScopes,
+		tokenTTL:      tokenTTL,
+		createdAt:     now,
+		updatedAt:     now,
+	}, nil
+}
+
+// ID returns the client's unique identifier.
+func (c *OAuthClient) ID() string {
+	return c.id
+}
+
+// Name returns the client's display name.
+func (c *OAuthClient) Name() string {
+	return c.name
+}
+
+// Type returns whether the client is confidential or public.
+func (c *OAuthClient) Type() OAuthClientType {
+	return c.clientType
+}
+
+// IsPublic reports whether the client cannot authenticate with a secret.
+func (c *OAuthClient) IsPublic() bool {
+	return c.clientType == OAuthClientPublic
+}
+
+// SecretHash returns the hashed client secret.
+func (c *OAuthClient) SecretHash() Password {
+	return c.secretHash
+}
+
+// AllowedScopes returns the scopes the client may request.
+func (c *OAuthClient) AllowedScopes() []string {
+	return c.allowedScopes
+}
+
+// RedirectURIs returns the registered redirect URIs for the authorization code flow.
+func (c *OAuthClient) RedirectURIs() []string {
+	return c.redirectURIs
+}
+
+// IsFirstParty reports whether the client is an ImmersiVerse application that needs no consent.
+func (c *OAuthClient) IsFirstParty() bool {
+	return c.firstParty
+}
+
+// TokenTTL returns the lifetime of access tokens issued to the client.
+func (c *OAuthClient) TokenTTL() time.Duration {
+	return c.tokenTTL
+}
+
+// CreatedAt returns the time when the client was registered.
+func (c *OAuthClient) CreatedAt() time.Time {
+	return c.createdAt
+}
+
+// UpdatedAt returns the time when the client was last updated.
+func (c *OAuthClient) UpdatedAt() time.Time {
+	return c.updatedAt
+}
+
+// VerifySecret checks if the provided raw secret matches the stored hash.
+// Public clients have no secret and never verify.
+func (c *OAuthClient) VerifySecret(rawSecret string) bool {
+	if c.IsPublic() {
+		return false
+	}
+	return c.secretHash.Verify(rawSecret)
+}
+
+// SetRedirectURIs replaces the registered redirect URIs after validating them.
+// URIs must be absolute, without fragment, and use https unless they target a loopback host.
+func (c *OAuthClient) SetRedirectURIs(uris []string) error {
+	for _, raw := range uris {
+		u, err := url.Parse(raw)
+		if err != nil || !u.IsAbs() || u.Host == "" {
+			return errors.New("redirect uri must be absolute: " + raw)
+		}
+		if u.Fragment != "" {
+			return errors.New("redirect uri must not contain a fragment: " + raw)
+		}
+		if u.Scheme != "https" && !(u.Scheme == "http" && isLoopbackHost(u.Hostname())) {
+			return errors.New("redirect uri must use https: " + raw)
+		}
+	}
+	c.redirectURIs = uris
+	c.updatedAt = time.Now()
+	return nil
+}
+
+// SetFirstParty marks the client as an ImmersiVerse application.
+func (c *OAuthClient) SetFirstParty(firstParty bool) {
+	c.firstParty = firstParty
+	c.updatedAt = time.Now()
+}
+
+// AllowsRedirectURI reports whether uri exactly matches a registered redirect URI.
+func (c *OAuthClient) AllowsRedirectURI(uri string) bool {
+	return uri != "" && containsString(c.redirectURIs, uri)
+}
+
+// AllowsScopes reports whether every requested scope is registered for the client.
+func (c *OAuthClient) AllowsScopes(scopes []string) bool {
+	for _, scope := range scopes {
+		if !containsString(c.allowedScopes, scope) {
+			return false
+		}
+	}
+	return true
+}
+
+// isLoopbackHost reports whether host refers to the local machine (RFC 8252 7.3).
+func isLoopbackHost(host string) bool {
+	return host == "localhost" || host == "127.0.0.1" || host == "::1"
+}
@@ -0,0 +1,54 @@
+package domain
+
+// OAuth 2.0 error codes (RFC 6749 4.1.2.1, 5.2, RFC 6750 3.1, RFC 8628 3.5, RFC 8693 2.2.2, RFC 9449 and OpenID Connect Core 3.1.2.6).
+const (
+	OAuthErrInvalidRequest          = "invalid_request"
+	OAuthErrInvalidClient           = "invalid_client"
+	OAuthErrInvalidGrant            = "invalid_grant"
+	OAuthErrUnauthorizedClient      = "unauthorized_client"
+	OAuthErrUnsupportedGrantType    = "unsupported_grant_type"
+	OAuthErrUnsupportedResponseType = "unsupported_response_type"
+	OAuthErrInvalidScope            = "invalid_scope"
+	OAuthErrInsufficientScope       = "insufficient_scope"
+	OAuthErrAccessDenied            = "access_denied"
+	OAuthErrConsentRequired         = "consent_required"
+	OAuthErrInvalidDPoPProof        = "invalid_dpop_proof"
+	OAuthErrServerError             = "server_error"
+	OAuthErrAuthorizationPending    = "authorization_pending"
+	OAuthErrSlowDown                = "slow_down"
+	OAuthErrExpiredToken            = "expired_token"
+	OAuthErrInvalidTarget           = "invalid_target"
+)
+
+// OAuthError is an error carrying an OAuth 2.0 error code so the transport layer can
+// return it to the client unchanged.
+type OAuthError struct {
+	code        string
+	description string
+}
+
+// NewOAuthError creates a new OAuthError instance.
+func NewOAuthError(code, description string) *OAuthError {
+	return &OAuthError{
+		code:        code,
+		description: description,
+	}
+}
+
+// Error returns the error code and description.
+func (e *OAuthError) Error() string {
+	if e.description == "" {
+		return e.code
+	}
+	return e.code + ": " + e.description
+}
+
+// Code returns the OAuth 2.0 error code.
+func (e *OAuthError) Code() string {
+	return e.code
+}
+
+// Description returns the human-readable error description.
+func (e *OAuthError) Description() string {
+	return e.description
+}
@@ -0,0 +1,101 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// OpenID Connect scopes (OpenID Connect Core 3.1.2.1, 5.4).
+const (
+	ScopeOpenID  = "openid"
+	ScopeProfile = "profile"
+	ScopeEmail   = "email"
+)
+
+// AMRPassword is the authentication method reference for password login (RFC 8176 2).
+const AMRPassword = "pwd"
+
+// IDTokenClaims holds the claims of an OpenID Connect ID token (OpenID Connect Core 2).
+type IDTokenClaims struct {
+	Subject   string
+	Audience  string // 토큰을 요청한 클라이언트 ID
+	Nonce     string
+	AuthTime  time.Time
+	AMR       []string
+	ACR       string
+	ExpiresAt time.Time
+	// AccessToken is the access token issued alongside, used to compute at_hash.
+	AccessToken string
+}
+
+// IDTokenGenerator defines the interface for signing ID tokens and publishing the
+// keys relying parties use to verify them.
+type IDTokenGenerator interface {
+	// GenerateIDToken signs an ID token with the issuer's key.
+	GenerateIDToken(claims IDTokenClaims) (string, error)
+	// JWKS returns the JSON Web Key Set (RFC 7517 5) of the public signing keys.
+	JWKS() ([]byte, error)
+}
+
+// UserInfo holds the claims about a user returned by the userinfo endpoint (OpenID Connect Core 5.3).
+// Fields not covered by the access token's scopes are left empty.
+type UserInfo struct {
+	Subject          string
+	Username         string
+	SubscriptionTier string
+	Email            string
+	EmailVerified    bool
+}
+
+// UserInfoService defines the OpenID Connect userinfo operation.
+type UserInfoService interface {
+	// UserInfo returns the claims about the token's subject permitted by the token's scopes.
+	UserInfo(ctx context.Context, claims *TokenClaims) (*UserInfo, error)
+}
+
+// userInfoService implements UserInfoService with domain logic.
+type userInfoService struct {
+	userRepo UserRepository
+}
+
+// NewUserInfoService creates a new instance of userInfoService.
+func NewUserInfoService(userRepo UserRepository) UserInfoService {
+	return &userInfoService{
+		userRepo: userRepo,
+	}
+}
+
+// UserInfo loads the user and filters the claims by scope. Tokens without a scope come
+// from first-party logins and may read every claim.
+func (s *userInfoService) UserInfo(ctx context.Context, claims *TokenClaims) (*UserInfo, error) {
+	if claims == nil || claims.Subject == "" {
+		return nil, errors.New("token claims must not be empty")
+	}
+	if claims.IsClientToken() {
+		return nil, NewOAuthError(OAuthErrInvalidRequest, "token was not issued for a user")
+	}
+	unrestricted := len(claims.Scope) == 0
+	if !unrestricted && !claims.HasScope(ScopeOpenID) {
+		return nil, NewOAuthError(OAuthErrInsufficientScope, "openid scope is required")
+	}
+
+	user, err := s.userRepo.FindByID(ctx, claims.Subject)
+	if err != nil {
+		return nil, errors.New("failed to find user: " + err.Error())
+	}
+	if user == nil {
+		return nil, NewOAuthError(OAuthErrInvalidRequest, "user not found")
+	}
+
+	info := &UserInfo{Subject: user.ID()}
+	if unrestricted || claims.HasScope(ScopeProfile) {
+		info.Username = user.Username()
+		info.SubscriptionTier = user.SubscriptionTier()
+	}
+	if unrestricted || claims.HasScope(ScopeEmail) {
+		info.Email = user.Email().String()
+		info.EmailVerified = user.EmailVerified()
+	}
+	return info, nil
+}
@@ -0,0 +1,366 @@
+package domain
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"time"
+)
+
+// MFAMethodWebAuthn is the second-factor method backed by a registered passkey.
+const MFAMethodWebAuthn = "webauthn"
+
+// AMRHardwareKey is the amr value for proof of possession of a hardware-secured key (RFC 8176 2).
+const AMRHardwareKey = "hwk"
+
+// Audit actions recorded for passkey management.
+const (
+	AuditActionPasskeyRegistered = "PASSKEY_REGISTERED"
+	AuditActionPasskeyDeleted    = "PASSKEY_DELETED"
+)
+
+// User verification requirements sent to the authenticator.
+const (
+	userVerificationRequired  = "required"
+	userVerificationPreferred = "preferred"
+)
+
+var (
+	// ErrPasskeyInvalid is returned when a WebAuthn response does not verify or its ceremony is unknown or expired.
+	ErrPasskeyInvalid = errors.New("passkey response is invalid")
+	// ErrPasskeyNotFound is returned when the user has no credential with the given ID.
+	ErrPasskeyNotFound = errors.New("passkey not found")
+	// ErrPasskeyAlreadyRegistered is returned when a credential ID is registered already.
+	ErrPasskeyAlreadyRegistered = errors.New("passkey is already registered")
+)
+
+// WebAuthnCredentialDescriptor identifies a credential in allow and exclude lists.
+type WebAuthnCredentialDescriptor struct {
+	ID         []byte
+	Transports []string
+}
+
+// PasskeyCreationOptions holds the parameters of a registration ceremony
+// (navigator.credentials.create).
+type PasskeyCreationOptions struct {
+	Challenge          string
+	RPID               string
+	RPName             string
+	UserHandle         string // WebAuthn user.id, 사용자 ID
+	UserName           string
+	Algorithms         []int // COSE 알고리즘 식별자
+	ExcludeCredentials []WebAuthnCredentialDescriptor
+	Timeout            time.Duration
+}
+
+// PasskeyRequestOptions holds the parameters of an authentication ceremony
+// (navigator.credentials.get).
+type PasskeyRequestOptions struct {
+	Challenge        string
+	RPID             string
+	AllowCredentials []WebAuthnCredentialDescriptor // 비어 있으면 검색 가능한 자격 증명 사용
+	UserVerification string
+	Timeout          time.Duration
+}
+
+// PasskeyService defines operations for registering passkeys and authenticating with them.
+type PasskeyService interface {
+	// BeginRegistration starts a registration ceremony for a signed-in user.
+	BeginRegistration(ctx context.Context, userID string) (*PasskeyCreationOptions, error)
+	// FinishRegistration verifies the registration response and stores the new credential.
+	FinishRegistration(ctx context.Context, userID, name string, response []byte) (*WebAuthnCredential, error)
+	// BeginLogin starts a passwordless login with a discoverable credential.
+	BeginLogin(ctx context.Context) (*PasskeyRequestOptions, error)
+	// FinishLogin verifies a passwordless login response and returns the authenticated user ID.
+	FinishLogin(ctx context.Context, response []byte) (string, error)
+	// BeginFactor starts an authentication ceremony that uses a passkey as a second factor.
+	BeginFactor(ctx context.Context, userID string) (*PasskeyRequestOptions, error)
+	// VerifyFactor verifies a second-factor response for the given user.
+	VerifyFactor(ctx context.Context, userID string, response []byte) error
+	// ListPasskeys returns the credentials registered by a user.
+	ListPasskeys(ctx context.Context, userID string) ([]*WebAuthnCredential, error)
+	// DeletePasskey removes one of the user's credentials.
+	DeletePasskey(ctx context.Context, userID string, credentialID []byte) error
+}
+
+// passkeyService implements PasskeyService with domain logic.
+type passkeyService struct {
+	userRepo       UserRepository
+	credentialRepo WebAuthnCredentialRepository
+	ceremonyRepo   WebAuthnCeremonyRepository
+	verifier       WebAuthnVerifier
+	auditRepo      AuditLogRepository
+	rpID           string // 자격 증명이 묶이는 도메인
+	rpName         string
+	timeout        time.Duration // 세리머니 유효 시간
+}
+
+// NewPasskeyService creates a new instance of passkeyService.
+func NewPasskeyService(userRepo UserRepository, credentialRepo WebAuthnCredentialRepository, ceremonyRepo WebAuthnCeremonyRepository, verifier WebAuthnVerifier, auditRepo AuditLogRepository, rpID, rpName string, timeout time.Duration) PasskeyService {
+	return &passkeyService{
+		userRepo:       userRepo,
+		credentialRepo: credentialRepo,
+		ceremonyRepo:   ceremonyRepo,
+		verifier:       verifier,
+		auditRepo:      auditRepo,
+		rpID:           rpID,
+		rpName:         rpName,
+		timeout:        timeout,
+	}
+}
+
+// BeginRegistration starts a registration ceremony. Credentials the user already has are
+// excluded so the same authenticator is not registered twice.
+func (s *passkeyService) BeginRegistration(ctx context.Context, userID string) (*PasskeyCreationOptions, error) {
+	if userID == "" {
+		return nil, errors.New("user id must not be empty")
+	}
+
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, errors.New("failed to find user: " + err.Error())
+	}
+	if user == nil {
+		return nil, errors.New("user not found")
+	}
+	existing, err := s.ListPasskeys(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	challenge, err := s.startCeremony(ctx, userID, WebAuthnCeremonyRegistration)
+	if err != nil {
+		return nil, err
+	}
+	return &PasskeyCreationOptions{
+		Challenge:          challenge,
+		RPID:               s.rpID,
+		RPName:             s.rpName,
+		UserHandle:         user.ID(),
+		UserName:           user.Username(),
+		Algorithms:         s.verifier.SupportedAlgorithms(),
+		ExcludeCredentials: descriptors(existing),
+		Timeout:            s.timeout,
+	}, nil
+}
+
+// FinishRegistration verifies the registration response against the user's pending ceremony
+// and stores the credential.
+func (s *passkeyService) FinishRegistration(ctx context.Context, userID, name string, response []byte) (*WebAuthnCredential, error) {
+	if userID == "" {
+		return nil, errors.New("user id must not be empty")
+	}
+
+	registration, err := s.verifier.ParseRegistration(response)
+	if err != nil {
+		return nil, ErrPasskeyInvalid
+	}
+	if _, err := s.consumeCeremony(ctx, registration.Challenge, WebAuthnCeremonyRegistration, userID); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.credentialRepo.FindByID(ctx, registration.CredentialID)
+	if err != nil {
+		return nil, errors.New("failed to find passkey: " + err.Error())
+	}
+	if existing != nil {
+		return nil, ErrPasskeyAlreadyRegistered
+	}
+
+	credential, err := NewWebAuthnCredential(registration.CredentialID, userID, name, registration.PublicKey, registration.SignCount, registration.Transports)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.credentialRepo.Save(ctx, credential); err != nil {
+		return nil, errors.New("failed to save passkey: " + err.Error())
+	}
+
+	credentialID := base64.RawURLEncoding.EncodeToString(credential.ID())
+	recordAudit(ctx, s.auditRepo, AuditActionPasskeyRegistered, auditEntityUser, &userID, &userID, map[string]interface{}{"credential_id": credentialID})
+	return credential, nil
+}
+
+// BeginLogin starts a passwordless login. No username is asked for, so the response does
+// not reveal whether an account exists; the authenticator offers its discoverable credentials.
+func (s *passkeyService) BeginLogin(ctx context.Context) (*PasskeyRequestOptions, error) {
+	challenge, err := s.startCeremony(ctx, "", WebAuthnCeremonyLogin)
+	if err != nil {
+		return nil, err
+	}
+	return &PasskeyRequestOptions{
+		Challenge:        challenge,
+		RPID:             s.rpID,
+		UserVerification: userVerificationRequired,
+		Timeout:          s.timeout,
+	}, nil
+}
+
+// FinishLogin verifies a passwordless login. User verification is required, because the
+// passkey replaces the password instead of adding to it.
+func (s *passkeyService) FinishLogin(ctx context.Context, response []byte) (string, error) {
+	credential, err := s.verifyAssertion(ctx, response, WebAuthnCeremonyLogin, "", true)
+	if err != nil {
+		return "", err
+	}
+	return credential.UserID(), nil
+}
+
+// BeginFactor starts an authentication ceremony limited to the user's credentials.
+func (s *passkeyService) BeginFactor(ctx context.Context, userID string) (*PasskeyRequestOptions, error) {
+	if userID == "" {
+		return nil, errors.New("user id must not be empty")
+	}
+
+	credentials, err := s.ListPasskeys(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(credentials) == 0 {
+		return nil, ErrMFANotEnrolled
+	}
+	challenge, err := s.startCeremony(ctx, userID, WebAuthnCeremonyFactor)
+	if err != nil {
+		return nil, err
+	}
+	return &PasskeyRequestOptions{
+		Challenge:        challenge,
+		RPID:             s.rpID,
+		AllowCredentials: descriptors(credentials),
+		UserVerification: userVerificationPreferred,
+		Timeout:          s.timeout,
+	}, nil
+}
+
+// VerifyFactor verifies a second-factor response. User presence is enough here because the
+// password was already checked.
+func (s *passkeyService) VerifyFactor(ctx context.Context, userID string, response []byte) error {
+	if userID == "" {
+		return errors.New("user id must not be empty")
+	}
+	_, err := s.verifyAssertion(ctx, response, WebAuthnCeremonyFactor, userID, false)
+	return err
+}
+
+// ListPasskeys returns the credentials registered by a user.
+func (s *passkeyService) ListPasskeys(ctx context.Context, userID string) ([]*WebAuthnCredential, error) {
+	if userID == "" {
+		return nil, errors.New("user id must not be empty")
+	}
+
+	credentials, err := s.credentialRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, errors.New("failed to find passkeys: " + err.Error())
+	}
+	return credentials, nil
+}
+
+// DeletePasskey removes one of the user's credentials.
+func (s *passkeyService) DeletePasskey(ctx context.Context, userID string, credentialID []byte) error {
+	if userID == "" {
+		return errors.New("user id must not be empty")
+	}
+	if len(credentialID) == 0 {
+		return ErrPasskeyNotFound
+	}
+
+	deleted, err := s.credentialRepo.Delete(ctx, userID, credentialID)
+	if err != nil {
+		return errors.New("failed to delete passkey: " + err.Error())
+	}
+	if !deleted {
+		return ErrPasskeyNotFound
+	}
+
+	id := base64.RawURLEncoding.EncodeToString(credentialID)
+	recordAudit(ctx, s.auditRepo, AuditActionPasskeyDeleted, auditEntityUser, &userID, &userID, map[string]interface{}{"credential_id": id})
+	return nil
+}
+
+// verifyAssertion runs the checks shared by passwordless login and second-factor
+// authentication and returns the credential that signed the assertion.
+func (s *passkeyService) verifyAssertion(ctx context.Context, response []byte, purpose, userID string, requireUV bool) (*WebAuthnCredential, error) {
+	assertion, err := s.verifier.ParseAssertion(response)
+	if err != nil {
+		return nil, ErrPasskeyInvalid
+	}
+	if _, err := s.consumeCeremony(ctx, assertion.Challenge, purpose, userID); err != nil {
+		return nil, err
+	}
+	if requireUV && !assertion.UserVerified {
+		return nil, ErrPasskeyInvalid
+	}
+
+	credential, err := s.credentialRepo.FindByID(ctx, assertion.CredentialID)
+	if err != nil {
+		return nil, errors.New("failed to find passkey: " + err.Error())
+	}
+	if credential == nil {
+		return nil, ErrPasskeyInvalid
+	}
+	// 세리머니 대상 사용자 또는 인증기가 반환한 user handle과 소유자가 다르면 거부
+	if userID != "" && credential.UserID() != userID {
+		return nil, ErrPasskeyInvalid
+	}
+	if assertion.UserHandle != "" && assertion.UserHandle != credential.UserID() {
+		return nil, ErrPasskeyInvalid
+	}
+	if err := s.verifier.VerifySignature(credential.PublicKey(), assertion); err != nil {
+		return nil, ErrPasskeyInvalid
+	}
+
+	// 서명 카운터가 증가하지 않으면 복제된 인증기로 간주 (카운터를 쓰지 않는 인증기는 항상 0)
+	fresh, err := s.credentialRepo.MarkUsed(ctx, credential.ID(), assertion.SignCount, time.Now())
+	if err != nil {
+		return nil, errors.New("failed to record passkey use: " + err.Error())
+	}
+	if !fresh {
+		return nil, ErrPasskeyInvalid
+	}
+	return credential, nil
+}
+
+// startCeremony stores a pending ceremony and returns its base64url challenge.
+func (s *passkeyService) startCeremony(ctx context.Context, userID, purpose string) (string, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", errors.New("failed to generate challenge: " + err.Error())
+	}
+	// 브라우저는 clientDataJSON에 challenge를 base64url(패딩 없음)로 다시 인코딩해 돌려줌
+	challenge := base64.RawURLEncoding.EncodeToString(raw)
+
+	ceremony, err := NewWebAuthnCeremony(hashOpaqueToken(challenge), userID, purpose, time.Now().Add(s.timeout))
+	if err != nil {
+		return "", err
+	}
+	if err := s.ceremonyRepo.Save(ctx, ceremony); err != nil {
+		return "", errors.New("failed to save webauthn ceremony: " + err.Error())
+	}
+	return challenge, nil
+}
+
+// consumeCeremony redeems the ceremony of a challenge once and checks that it was started
+// for the same purpose and user.
+func (s *passkeyService) consumeCeremony(ctx context.Context, challenge, purpose, userID string) (*WebAuthnCeremony, error) {
+	if challenge == "" {
+		return nil, ErrPasskeyInvalid
+	}
+
+	ceremony, err := s.ceremonyRepo.Consume(ctx, hashOpaqueToken(challenge))
+	if err != nil {
+		return nil, errors.New("failed to consume webauthn ceremony: " + err.Error())
+	}
+	if ceremony == nil || ceremony.IsExpired() || ceremony.Purpose() != purpose || ceremony.UserID() != userID {
+		return nil, ErrPasskeyInvalid
+	}
+	return ceremony, nil
+}
+
+// descriptors lists credentials for allowCredentials and excludeCredentials.
+func descriptors(credentials []*WebAuthnCredential) []WebAuthnCredentialDescriptor {
+	result := make([]WebAuthnCredentialDescriptor, 0, len(credentials))
+	for _, c := range credentials {
+		result = append(result, WebAuthnCredentialDescriptor{ID: c.ID(), Transports: c.Transports()})
+	}
+	return result
+}
@@ -4,10 +4,24 @@ import (
 	"crypto/rand"
 	"crypto/subtle"
 	"errors"
+	"sync"
 
 	"golang.org/x/crypto/argon2"
 )
 
+// Password policy violations returned by NewPassword.
+var (
+	ErrPasswordEmpty    = errors.New("password must not be empty")
+	ErrPasswordTooShort = errors.New("password must be at least 8 characters")
+	// ErrPasswordUnchanged is returned when a new password equals the current one.
+	ErrPasswordUnchanged = errors.New("new password must differ from the current password")
+)
+
+var (
+	dummyPasswordOnce sync.Once
+	dummyPassword     Password
+)
+
 // Password represents a securely hashed password.
 type Password struct {
 	hash []byte // Argon2id로 해싱된 값
@@ -17,10 +31,10 @@ type Password struct {
 // NewPassword creates a new Password instance by hashing the raw password.
 func NewPassword(rawPassword string) (Password, error) {
 	if rawPassword == "" {
-		return Password{}, errors.New("password must not be empty")
+		return Password{}, ErrPasswordEmpty
 	}
 	if len(rawPassword) < 8 {
-		return Password{}, errors.New("password must be at least 8 characters")
+		return Password{}, ErrPasswordTooShort
 	}
 
 	// 솔트 생성
@@ -69,3 +83,12 @@ func (p Password) Verify(rawPassword string) bool {
 func (p Password) Change(rawPassword string) (Password, error) {
 	return NewPassword(rawPassword)
 }
+
+// verifyDummyPassword runs a verification against a throwaway hash so that lookups of
+// unknown principals take as long as a failed password check.
+func verifyDummyPassword(rawPassword string) {
+	dummyPasswordOnce.Do(func() {
+		dummyPassword, _ = NewPassword(generateRandomString(32))
+	})
+	_ = dummyPassword.Verify(rawPassword)
+}
@@ -0,0 +1,234 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// AuditActionPasswordReset is recorded when a password is replaced through a reset link.
+const AuditActionPasswordReset = "PASSWORD_RESET"
+
+// Reasons carried by PasswordChanged events.
+const (
+	PasswordChangeReasonChange = "change"
+	PasswordChangeReasonReset  = "reset"
+)
+
+// ErrPasswordResetInvalid is returned when a reset link is unknown, expired or already used.
+var ErrPasswordResetInvalid = errors.New("password reset link is invalid or expired")
+
+// PasswordResetToken is a pending password reset sent to a user's email address.
+// The raw token goes into the link; only its hash is stored.
+type PasswordResetToken struct {
+	tokenHash string
+	userID    string
+	email     Email // 발송 시점의 주소, 이후 변경되면 링크 무효
+	expiresAt time.Time
+	createdAt time.Time
+}
+
+// NewPasswordResetToken creates a new PasswordResetToken instance.
+func NewPasswordResetToken(tokenHash, userID string, email Email, expiresAt time.Time) (*PasswordResetToken, error) {
+	if tokenHash == "" {
+		return nil, errors.New("token hash must not be empty")
+	}
+	if userID == "" {
+		return nil, errors.New("user id must not be empty")
+	}
+	if !email.IsValid() {
+		return nil, errors.New("invalid email")
+	}
+
+	return &PasswordResetToken{
+		tokenHash: tokenHash,
+		userID:    userID,
+		email:     email,
+		expiresAt: expiresAt,
+		createdAt: time.Now(),
+	}, nil
+}
+
+// NewPasswordResetTokenFromStorage restores a PasswordResetToken loaded from storage.
+func NewPasswordResetTokenFromStorage(tokenHash, userID string, email Email, expiresAt, createdAt time.Time) (*PasswordResetToken, error) {
+	token, err := NewPasswordResetToken(tokenHash, userID, email, expiresAt)
+	if err != nil {
+		return nil, err
+	}
+	token.createdAt = createdAt
+	return token, nil
+}
+
+// TokenHash returns the SHA-256 hash of the reset token.
+func (t *PasswordResetToken) TokenHash() string {
+	return t.tokenHash
+}
+
+// UserID returns the user whose password the link resets.
+func (t *PasswordResetToken) UserID() string {
+	return t.userID
+}
+
+// Email returns the address the link was sent to.
+func (t *PasswordResetToken) Email() Email {
+	return t.email
+}
+
+// ExpiresAt returns when the link expires.
+func (t *PasswordResetToken) ExpiresAt() time.Time {
+	return t.expiresAt
+}
+
+// CreatedAt returns when the link was issued.
+func (t *PasswordResetToken) CreatedAt() time.Time {
+	return t.createdAt
+}
+
+// IsExpired reports whether the link can no longer be used.
+func (t *PasswordResetToken) IsExpired() bool {
+	return time.Now().After(t.expiresAt)
+}
+
+// PasswordResetService defines operations for recovering an account by email.
+type PasswordResetService interface {
+	// RequestPasswordReset emails a reset link if the address belongs to a user. It behaves
+	// the same for unknown addresses, so callers cannot learn which addresses are registered.
+	RequestPasswordReset(ctx context.Context, email string) error
+	// CompletePasswordReset consumes a reset token, sets the new password and signs the
+	// user out everywhere.
+	CompletePasswordReset(ctx context.Context, token, newPassword string) error
+}
+
+// passwordResetService implements PasswordResetService with domain logic.
+type passwordResetService struct {
+	userRepo      UserRepository
+	tokenRepo     PasswordResetTokenRepository
+	sessionRepo   SessionRepository
+	rateLimitRepo RateLimitRepository
+	mailer        Mailer
+	auditRepo     AuditLogRepository
+	eventPub      EventPublisher
+	linkURL       string        // 토큰을 붙여 보내는 재설정 화면 주소
+	ttl           time.Duration // 링크 유효 시간
+	rateLimit     int           // 주소당 window 동안 허용하는 요청 수
+	rateWindow    time.Duration
+}
+
+// NewPasswordResetService creates a new instance of passwordResetService.
+func NewPasswordResetService(userRepo UserRepository, tokenRepo PasswordResetTokenRepository, sessionRepo SessionRepository, rateLimitRepo RateLimitRepository, mailer Mailer, auditRepo AuditLogRepository, eventPub EventPublisher, linkURL string, ttl time.Duration, rateLimit int, rateWindow time.Duration) PasswordResetService {
+	return &passwordResetService{
+		userRepo:      userRepo,
+		tokenRepo:     tokenRepo,
+		sessionRepo:   sessionRepo,
+		rateLimitRepo: rateLimitRepo,
+		mailer:        mailer,
+		auditRepo:     auditRepo,
+		eventPub:      eventPub,
+		linkURL:       linkURL,
+		ttl:           ttl,
+		rateLimit:     rateLimit,
+		rateWindow:    rateWindow,
+	}
+}
+
+// RequestPasswordReset issues a single-use reset link and emails it to the user. Requests
+// are limited per address whether or not the address is registered, and the email is sent
+// in the background so the response time does not tell the two apart either.
+func (s *passwordResetService) RequestPasswordReset(ctx context.Context, rawEmail string) error {
+	email, err := NewEmail(rawEmail)
+	if err != nil {
+		return nil // 형식이 잘못된 주소도 등록되지 않은 주소와 같게 처리
+	}
+
+	count, err := s.rateLimitRepo.Increment(ctx, "password_reset:"+email.String(), s.rateWindow)
+	if err != nil {
+		return errors.New("failed to check rate limit: " + err.Error())
+	}
+	if count > s.rateLimit {
+		return ErrRateLimited
+	}
+
+	user, err := s.userRepo.FindByEmail(ctx, email)
+	if err != nil {
+		return errors.New("failed to find user: " + err.Error())
+	}
+	if user == nil {
+		return nil
+	}
+
+	rawToken := generateRandomString(43)
+	token, err := NewPasswordResetToken(hashOpaqueToken(rawToken), user.ID(), email, time.Now().Add(s.ttl))
+	if err != nil {
+		return err
+	}
+	if err := s.tokenRepo.Save(ctx, token); err != nil {
+		return errors.New("failed to save password reset token: " + err.Error())
+	}
+
+	link, err := tokenLink(s.linkURL, rawToken)
+	if err != nil {
+		return err
+	}
+	msg := EmailMessage{
+		To:      email,
+		Subject: "Reset your password",
+		Body: "Hi " + user.Username() + ",\n\n" +
+			"Use the link below to choose a new password. It expires in " + s.ttl.String() + " and can be used once.\n\n" +
+			link + "\n\n" +
+			"If you did not request a password reset, you can ignore this email; your password stays the same.\n",
+	}
+	sendDetached(ctx, s.mailer, msg)
+	return nil
+}
+
+// CompletePasswordReset sets a new password for the user of a reset token. The new password
+// is checked before the token is consumed, so a rejected password does not use up the link.
+// All of the user's sessions and other outstanding reset links are revoked.
+func (s *passwordResetService) CompletePasswordReset(ctx context.Context, rawToken, newPassword string) error {
+	if rawToken == "" {
+		return ErrPasswordResetInvalid
+	}
+	pwd, err := NewPassword(newPassword)
+	if err != nil {
+		return err
+	}
+
+	token, err := s.tokenRepo.Consume(ctx, hashOpaqueToken(rawToken))
+	if err != nil {
+		return errors.New("failed to consume password reset token: " + err.Error())
+	}
+	if token == nil || token.IsExpired() {
+		return ErrPasswordResetInvalid
+	}
+
+	user, err := s.userRepo.FindByID(ctx, token.UserID())
+	if err != nil {
+		return errors.New("failed to find user: " + err.Error())
+	}
+	if user == nil || user.Email() != token.Email() {
+		return ErrPasswordResetInvalid
+	}
+
+	user.SetPasswordHash(pwd)
+	// 메일함을 열 수 있었으므로 주소 소유도 확인된 것으로 봄
+	user.SetEmailVerified(true)
+	if err := s.userRepo.SaveUser(ctx, user); err != nil {
+		return errors.New("failed to update password: " + err.Error())
+	}
+
+	now := time.Now()
+	if err := s.tokenRepo.DeleteByUserID(ctx, user.ID()); err != nil {
+		return errors.New("failed to delete password reset tokens: " + err.Error())
+	}
+	revoked, err := s.sessionRepo.RevokeAllByUserID(ctx, user.ID(), "", now)
+	if err != nil {
+		return errors.New("failed to revoke sessions: " + err.Error())
+	}
+
+	userID := user.ID()
+	recordAudit(ctx, s.auditRepo, AuditActionPasswordReset, auditEntityUser, &userID, &userID, map[string]interface{}{
+		"revoked_sessions": revoked,
+	})
+	_ = s.eventPub.Publish(&PasswordChanged{userID: userID, reason: PasswordChangeReasonReset, timestamp: now})
+	return nil
+}
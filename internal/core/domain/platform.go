@@ -6,6 +6,10 @@ import (
 	"time"
 )
 
+// ErrPlatformAccountNotFound is returned when a platform account does not exist or
+// belongs to another user.
+var ErrPlatformAccountNotFound = errors.New("platform account not found")
+
 // PlatformService defines operations for managing platform accounts.
 type PlatformService interface {
 	LinkAccount(ctx context.Context, userID, platform string, authCode string) (*PlatformAccount, error)
@@ -16,14 +20,32 @@ type PlatformService interface {
 type platformService struct {
 	platformRepo PlatformAccountRepository
 	eventPub     EventPublisher
+
+	// 이메일 확인 전 연동 제한은 WithVerifiedEmailRequired 옵션을 지정한 경우에만 활성화
+	userRepo UserRepository
+}
+
+// PlatformServiceOption configures optional features of the platform service.
+type PlatformServiceOption func(*platformService)
+
+// WithVerifiedEmailRequired rejects linking platform accounts until the user has verified
+// their email address.
+func WithVerifiedEmailRequired(userRepo UserRepository) PlatformServiceOption {
+	return func(s *platformService) {
+		s.userRepo = userRepo
+	}
 }
 
 // NewPlatformService creates a new instance of platformService.
-func NewPlatformService(platformRepo PlatformAccountRepository, eventPub EventPublisher) PlatformService {
-	return &platformService{
+func NewPlatformService(platformRepo PlatformAccountRepository, eventPub EventPublisher, opts ...PlatformServiceOption) PlatformService {
+	s := &platformService{
 		platformRepo: platformRepo,
 		eventPub:     eventPub,
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 // LinkAccount links an external platform account to a user.
@@ -37,6 +59,19 @@ func (s *platformService) LinkAccount(ctx context.Context, userID, platformStr,
 		return nil, errors.New("invalid platform type")
 	}
 
+	if s.userRepo != nil {
+		user, err := s.userRepo.FindByID(ctx, userID)
+		if err != nil {
+			return nil, errors.New("failed to find user: " + err.Error())
+		}
+		if user == nil {
+			return nil, errors.New("user not found")
+		}
+		if !user.EmailVerified() {
+			return nil, ErrEmailNotVerified
+		}
+	}
+
 	// 임시로 OAuth 교환 결과 가정
 	platformID := generateRandomString(36)
 	platformUserID := "platform-" + generateRandomString(8)
@@ -69,7 +104,7 @@ func (s *platformService) RevokeAccount(ctx context.Context, userID, platformID
 		return errors.New("failed to find platform account: " + err.Error())
 	}
 	if account == nil || account.UserID() != userID {
-		return errors.New("platform account not found or not owned by user")
+		return ErrPlatformAccountNotFound
 	}
 
 	if err := s.platformRepo.Delete(ctx, platformID); err != nil {
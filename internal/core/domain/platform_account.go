@@ -109,6 +109,19 @@ func (p *PlatformAccount) UpdatedAt() time.Time {
 	return p.updatedAt
 }
 
+// UpdateTokens replaces the platform OAuth tokens after a new authorization.
+func (p *PlatformAccount) UpdateTokens(platformUsername, accessToken, refreshToken string, tokenExpiresAt *time.Time) {
+	if platformUsername != "" {
+		p.platformUsername = platformUsername
+	}
+	p.accessToken = accessToken
+	if refreshToken != "" {
+		p.refreshToken = refreshToken // 플랫폼이 새 리프레시 토큰을 주지 않으면 기존 값 유지
+	}
+	p.tokenExpiresAt = tokenExpiresAt
+	p.updatedAt = time.Now()
+}
+
 // isValidPlatform checks if the platform type is supported.
 func isValidPlatform(p PlatformType) bool {
 	switch p {
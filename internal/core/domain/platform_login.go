@@ -0,0 +1,304 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+)
+
+// AMRFederated is the amr value for a login delegated to an external platform. RFC 8176
+// registers no value for this, so a private value is used.
+const AMRFederated = "fed"
+
+// Maximum length of a username derived from a platform profile.
+const platformUsernameMaxLength = 30
+
+var (
+	// ErrPlatformNotSupported is returned for a platform without a configured sign-in client.
+	ErrPlatformNotSupported = errors.New("sign-in with this platform is not supported")
+	// ErrPlatformEmailRequired is returned when a new account cannot be created because the
+	// platform did not share an email address.
+	ErrPlatformEmailRequired = errors.New("platform did not provide an email address")
+	// ErrPlatformEmailInUse is returned when the platform's email address already belongs to
+	// an account. The user must log in to that account and link the platform instead, so a
+	// platform account cannot take over an existing account by claiming its address.
+	ErrPlatformEmailInUse = errors.New("an account with this email address already exists")
+	// ErrPlatformAccountLinked is returned when a guest upgrades with a platform identity that
+	// already belongs to an account; the guest should log in with the platform instead.
+	ErrPlatformAccountLinked = errors.New("platform account is already linked to a user")
+)
+
+// PlatformIdentity is the profile and tokens returned by a platform for an authorization code.
+type PlatformIdentity struct {
+	PlatformUserID string
+	Username       string
+	Email          string // 플랫폼이 제공하지 않으면 빈 문자열
+	EmailVerified  bool
+	AccessToken    string
+	RefreshToken   string
+	ExpiresAt      *time.Time
+}
+
+// PlatformOAuthClient is the OAuth 2.0 client of one external platform.
+type PlatformOAuthClient interface {
+	// Platform returns the platform the client signs in with.
+	Platform() PlatformType
+	// AuthorizationURL returns the platform's consent page URL carrying state.
+	AuthorizationURL(state string) string
+	// ExchangeCode redeems an authorization code and fetches the user's platform profile.
+	ExchangeCode(ctx context.Context, code string) (*PlatformIdentity, error)
+}
+
+// PlatformLoginService defines operations for signing in with an external platform account.
+type PlatformLoginService interface {
+	// AuthorizationURL returns where to send the browser to start signing in with platform.
+	AuthorizationURL(platform PlatformType, state string) (string, error)
+	// Login redeems the platform's authorization code and returns the linked user, creating
+	// a new user if the platform identity is not linked yet.
+	Login(ctx context.Context, platform PlatformType, code string) (*User, error)
+	// UpgradeGuest redeems the platform's authorization code and turns the guest into a
+	// regular user linked to the platform identity.
+	UpgradeGuest(ctx context.Context, guest *User, platform PlatformType, code string) error
+}
+
+// platformLoginService implements PlatformLoginService with domain logic.
+type platformLoginService struct {
+	userRepo     UserRepository
+	platformRepo PlatformAccountRepository
+	clients      map[PlatformType]PlatformOAuthClient
+	eventPub     EventPublisher
+
+	// 플랫폼이 확인하지 않은 이메일로 계정을 만들거나 게스트를 전환하지 않음
+	requireVerifiedEmail bool
+}
+
+// PlatformLoginServiceOption configures optional features of the platform login service.
+type PlatformLoginServiceOption func(*platformLoginService)
+
+// WithVerifiedPlatformEmailRequired rejects creating or upgrading an account linked to a
+// platform identity whose email address the platform has not verified.
+func WithVerifiedPlatformEmailRequired() PlatformLoginServiceOption {
+	return func(s *platformLoginService) {
+		s.requireVerifiedEmail = true
+	}
+}
+
+// NewPlatformLoginService creates a new instance of platformLoginService.
+func NewPlatformLoginService(userRepo UserRepository, platformRepo PlatformAccountRepository, eventPub EventPublisher, clients []PlatformOAuthClient, opts ...PlatformLoginServiceOption) PlatformLoginService {
+	byPlatform := make(map[PlatformType]PlatformOAuthClient, len(clients))
+	for _, c := range clients {
+		byPlatform[c.Platform()] = c
+	}
+	s := &platformLoginService{
+		userRepo:     userRepo,
+		platformRepo: platformRepo,
+		clients:      byPlatform,
+		eventPub:     eventPub,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// AuthorizationURL returns the consent page URL of the platform's sign-in client.
+func (s *platformLoginService) AuthorizationURL(platform PlatformType, state string) (string, error) {
+	client, ok := s.clients[platform]
+	if !ok {
+		return "", ErrPlatformNotSupported
+	}
+	if state == "" {
+		return "", errors.New("state must not be empty")
+	}
+	return client.AuthorizationURL(state), nil
+}
+
+// Login resolves the platform identity to a user. A linked identity logs in its user and
+// refreshes the stored platform tokens; an unknown identity gets a new user.
+func (s *platformLoginService) Login(ctx context.Context, platform PlatformType, code string) (*User, error) {
+	identity, err := s.exchangeCode(ctx, platform, code)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := s.platformRepo.FindByPlatformUserID(ctx, platform, identity.PlatformUserID)
+	if err != nil {
+		return nil, errors.New("failed to find platform account: " + err.Error())
+	}
+	if account != nil {
+		account.UpdateTokens(identity.Username, identity.AccessToken, identity.RefreshToken, identity.ExpiresAt)
+		if err := s.platformRepo.Save(ctx, account); err != nil {
+			return nil, errors.New("failed to save platform account: " + err.Error())
+		}
+		user, err := s.userRepo.FindByID(ctx, account.UserID())
+		if err != nil {
+			return nil, errors.New("failed to find user: " + err.Error())
+		}
+		if user == nil {
+			return nil, errors.New("user not found")
+		}
+		return user, nil
+	}
+
+	return s.createUser(ctx, platform, identity)
+}
+
+// UpgradeGuest gives the guest the platform profile's username and email address and links
+// the identity to it. Like a new platform user, the guest gets a random password.
+func (s *platformLoginService) UpgradeGuest(ctx context.Context, guest *User, platform PlatformType, code string) error {
+	if guest == nil || !guest.IsGuest() {
+		return errors.New("user is not a guest")
+	}
+	identity, err := s.exchangeCode(ctx, platform, code)
+	if err != nil {
+		return err
+	}
+
+	account, err := s.platformRepo.FindByPlatformUserID(ctx, platform, identity.PlatformUserID)
+	if err != nil {
+		return errors.New("failed to find platform account: " + err.Error())
+	}
+	if account != nil {
+		return ErrPlatformAccountLinked
+	}
+
+	username, email, pwd, err := s.accountDetails(ctx, identity)
+	if err != nil {
+		return err
+	}
+	if err := guest.Upgrade(username, email, pwd); err != nil {
+		return err
+	}
+	guest.SetEmailVerified(identity.EmailVerified)
+	if err := s.userRepo.SaveUser(ctx, guest); err != nil {
+		return errors.New("failed to save user: " + err.Error())
+	}
+
+	return s.linkIdentity(ctx, guest.ID(), platform, identity)
+}
+
+// exchangeCode redeems an authorization code with the platform's sign-in client.
+func (s *platformLoginService) exchangeCode(ctx context.Context, platform PlatformType, code string) (*PlatformIdentity, error) {
+	client, ok := s.clients[platform]
+	if !ok {
+		return nil, ErrPlatformNotSupported
+	}
+	if code == "" {
+		return nil, errors.New("authorization code must not be empty")
+	}
+
+	identity, err := client.ExchangeCode(ctx, code)
+	if err != nil {
+		return nil, errors.New("failed to exchange platform code: " + err.Error())
+	}
+	return identity, nil
+}
+
+// createUser registers a new user from a platform profile and links the identity to it.
+// The user gets a random password and can set one later through a password reset.
+func (s *platformLoginService) createUser(ctx context.Context, platform PlatformType, identity *PlatformIdentity) (*User, error) {
+	username, email, pwd, err := s.accountDetails(ctx, identity)
+	if err != nil {
+		return nil, err
+	}
+	user, err := NewUser(generateRandomString(36), username, email, pwd, nil, "FREE")
+	if err != nil {
+		return nil, err
+	}
+	user.SetEmailVerified(identity.EmailVerified)
+	if err := s.userRepo.SaveUser(ctx, user); err != nil {
+		return nil, errors.New("failed to save user: " + err.Error())
+	}
+
+	_ = s.eventPub.Publish(&UserCreated{userID: user.ID(), timestamp: user.CreatedAt()})
+	if err := s.linkIdentity(ctx, user.ID(), platform, identity); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// accountDetails derives the username, email address and a random password of an account
+// created from a platform profile. The profile must share an email address not yet in use,
+// verified by the platform when WithVerifiedPlatformEmailRequired is set.
+func (s *platformLoginService) accountDetails(ctx context.Context, identity *PlatformIdentity) (string, Email, Password, error) {
+	if identity.Email == "" {
+		return "", "", Password{}, ErrPlatformEmailRequired
+	}
+	if s.requireVerifiedEmail && !identity.EmailVerified {
+		return "", "", Password{}, ErrEmailNotVerified
+	}
+	email, err := NewEmail(identity.Email)
+	if err != nil {
+		return "", "", Password{}, ErrPlatformEmailRequired
+	}
+	existing, err := s.userRepo.FindByEmail(ctx, email)
+	if err != nil {
+		return "", "", Password{}, errors.New("failed to find user: " + err.Error())
+	}
+	if existing != nil {
+		return "", "", Password{}, ErrPlatformEmailInUse
+	}
+
+	username, err := s.availableUsername(ctx, identity.Username)
+	if err != nil {
+		return "", "", Password{}, err
+	}
+	pwd, err := NewPassword(generateRandomString(32))
+	if err != nil {
+		return "", "", Password{}, err
+	}
+	return username, email, pwd, nil
+}
+
+// linkIdentity stores the platform identity as a platform account of the user.
+func (s *platformLoginService) linkIdentity(ctx context.Context, userID string, platform PlatformType, identity *PlatformIdentity) error {
+	account, err := NewPlatformAccount(generateRandomString(36), userID, platform, identity.PlatformUserID,
+		identity.Username, identity.AccessToken, identity.RefreshToken, identity.ExpiresAt)
+	if err != nil {
+		return err
+	}
+	if err := s.platformRepo.Save(ctx, account); err != nil {
+		return errors.New("failed to save platform account: " + err.Error())
+	}
+
+	_ = s.eventPub.Publish(&PlatformConnected{userID: userID, platformID: account.ID(), timestamp: time.Now()})
+	return nil
+}
+
+// availableUsername derives a username from the platform name, adding a random suffix
+// when the name is already taken.
+func (s *platformLoginService) availableUsername(ctx context.Context, platformUsername string) (string, error) {
+	base := sanitizeUsername(platformUsername)
+	candidate := base
+	for i := 0; i < 5; i++ {
+		existing, err := s.userRepo.FindByUsername(ctx, candidate)
+		if err != nil {
+			return "", errors.New("failed to find user: " + err.Error())
+		}
+		if existing == nil {
+			return candidate, nil
+		}
+		candidate = base + "_" + generateRandomString(5)
+	}
+	return "", errors.New("failed to find an available username")
+}
+
+// sanitizeUsername keeps the characters allowed in usernames. "@" is never kept, so the
+// result cannot be mistaken for an email address at login.
+func sanitizeUsername(name string) string {
+	cleaned := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_', r == '.', r == '-':
+			return r
+		}
+		return -1
+	}, name)
+	if len(cleaned) > platformUsernameMaxLength {
+		cleaned = cleaned[:platformUsernameMaxLength]
+	}
+	if cleaned == "" {
+		cleaned = "user"
+	}
+	return cleaned
+}
@@ -0,0 +1,147 @@
+package domain
+
+import (
+	"crypto/rand"
+	"errors"
+	"strings"
+	"time"
+)
+
+// Recovery code format: 10 characters from an alphabet without look-alike characters,
+// shown as two groups of five (e.g. "K7QXM-2HDPA").
+const (
+	recoveryCodeCount    = 10
+	recoveryCodeLength   = 10
+	recoveryCodeAlphabet = "23456789ABCDEFGHJKMNPQRSTUVWXYZ"
+)
+
+// RecoveryCode is a one-time code that can replace the second factor when the user has
+// lost access to it. Only an Argon2id hash of the code is stored.
+type RecoveryCode struct {
+	id        string
+	userID    string
+	hash      Password
+	usedAt    *time.Time
+	createdAt time.Time
+}
+
+// NewRecoveryCode creates a new RecoveryCode instance by hashing the raw code.
+func NewRecoveryCode(userID, rawCode string) (*RecoveryCode, error) {
+	if userID == "" {
+		return nil, errors.New("user id must not be empty")
+	}
+	hash, err := NewPassword(normalizeRecoveryCode(rawCode))
+	if err != nil {
+		return nil, errors.New("failed to hash recovery code: " + err.Error())
+	}
+
+	return &RecoveryCode{
+		id:        generateRandomString(16),
+		userID:    userID,
+		hash:      hash,
+		createdAt: time.Now(),
+	}, nil
+}
+
+// NewRecoveryCodeFromStorage restores a RecoveryCode loaded from storage.
+func NewRecoveryCodeFromStorage(id, userID string, hash Password, usedAt *time.Time, createdAt time.Time) (*RecoveryCode, error) {
+	if id == "" {
+		return nil, errors.New("recovery code id must not be empty")
+	}
+	if userID == "" {
+		return nil, errors.New("user id must not be empty")
+	}
+
+	return &RecoveryCode{
+		id:        id,
+		userID:    userID,
+		hash:      hash,
+		usedAt:    usedAt,
+		createdAt: createdAt,
+	}, nil
+}
+
+// ID returns the identifier of the code.
+func (c *RecoveryCode) ID() string {
+	return c.id
+}
+
+// UserID returns the owner of the code.
+func (c *RecoveryCode) UserID() string {
+	return c.userID
+}
+
+// Hash returns the hashed code.
+func (c *RecoveryCode) Hash() Password {
+	return c.hash
+}
+
+// UsedAt returns when the code was consumed, if it was.
+func (c *RecoveryCode) UsedAt() *time.Time {
+	return c.usedAt
+}
+
+// CreatedAt returns when the code was generated.
+func (c *RecoveryCode) CreatedAt() time.Time {
+	return c.createdAt
+}
+
+// IsUsed reports whether the code was already consumed.
+func (c *RecoveryCode) IsUsed() bool {
+	return c.usedAt != nil
+}
+
+// Matches reports whether rawCode is this code. Dashes, spaces and letter case are ignored.
+func (c *RecoveryCode) Matches(rawCode string) bool {
+	return c.hash.Verify(normalizeRecoveryCode(rawCode))
+}
+
+// GenerateRecoveryCodes creates a new set of recovery codes for a user and returns them
+// together with their raw values, which are shown to the user once.
+func GenerateRecoveryCodes(userID string) ([]*RecoveryCode, []string, error) {
+	codes := make([]*RecoveryCode, 0, recoveryCodeCount)
+	raws := make([]string, 0, recoveryCodeCount)
+	for i := 0; i < recoveryCodeCount; i++ {
+		raw, err := newRecoveryCodeString()
+		if err != nil {
+			return nil, nil, err
+		}
+		code, err := NewRecoveryCode(userID, raw)
+		if err != nil {
+			return nil, nil, err
+		}
+		codes = append(codes, code)
+		raws = append(raws, raw)
+	}
+	return codes, raws, nil
+}
+
+// newRecoveryCodeString returns a random code formatted as two dash-separated groups.
+func newRecoveryCodeString() (string, error) {
+	b := make([]byte, recoveryCodeLength)
+	max := 256 - 256%len(recoveryCodeAlphabet) // 모듈로 편향 제거
+	for i := 0; i < len(b); {
+		var r [1]byte
+		if _, err := rand.Read(r[:]); err != nil {
+			return "", errors.New("failed to generate recovery code: " + err.Error())
+		}
+		if int(r[0]) >= max {
+			continue
+		}
+		b[i] = recoveryCodeAlphabet[int(r[0])%len(recoveryCodeAlphabet)]
+		i++
+	}
+	half := recoveryCodeLength / 2
+	return string(b[:half]) + "-" + string(b[half:]), nil
+}
+
+// normalizeRecoveryCode strips separators and upper-cases the code before hashing.
+func normalizeRecoveryCode(raw string) string {
+	raw = strings.ToUpper(raw)
+	return strings.Map(func(r rune) rune {
+		if r == '-' || r == ' ' {
+			return -1
+		}
+		return r
+	}, raw)
+}
@@ -11,6 +11,17 @@ type UserRepository interface {
 	SaveUser(ctx context.Context, user *User) error
 	// FindByUsername retrieves a user by username from the storage.
 	FindByUsername(ctx context.Context, username string) (*User, error)
+	// FindByID retrieves a user by ID from the storage, or nil if it does not exist.
+	FindByID(ctx context.Context, id string) (*User, error)
+	// FindByEmail retrieves a user by email address, compared case-insensitively, or nil if none exists.
+	FindByEmail(ctx context.Context, email Email) (*User, error)
+}
+
+// GuestUserRepository defines the interface for removing abandoned guest users.
+type GuestUserRepository interface {
+	// PurgeExpired deletes up to limit guest users without an active session whose idle
+	// period ended before the given time, and returns the number of rows removed.
+	PurgeExpired(ctx context.Context, before time.Time, limit int) (int64, error)
 }
 
 // PlatformAccountRepository defines the interface for platform account data access.
@@ -21,6 +32,8 @@ type PlatformAccountRepository interface {
 	FindByID(ctx context.Context, id string) (*PlatformAccount, error)
 	// FindByUserID retrieves all platform accounts associated with a user from the storage.
 	FindByUserID(ctx context.Context, userID string) ([]*PlatformAccount, error)
+	// FindByPlatformUserID retrieves the account linked to an identity on a platform, or nil if none exists.
+	FindByPlatformUserID(ctx context.Context, platform PlatformType, platformUserID string) (*PlatformAccount, error)
 	// Delete removes a platform account from the storage.
 	Delete(ctx context.Context, id string) error
 }
@@ -31,6 +44,232 @@ type TokenRepository interface {
 	BlacklistToken(ctx context.Context, tokenID, userID, reason string, expiresAt time.Time) error
 	// IsBlacklisted checks if a token is currently blacklisted.
 	IsBlacklisted(ctx context.Context, tokenID string) (bool, error)
+	// PurgeExpired deletes up to limit blacklist entries that expired before the given time
+	// and returns the number of rows removed.
+	PurgeExpired(ctx context.Context, before time.Time, limit int) (int64, error)
+}
+
+// DPoPReplayRepository defines the interface for tracking used DPoP proof identifiers.
+type DPoPReplayRepository interface {
+	// MarkUsed records a proof jti until expiresAt and reports false if it was already recorded.
+	MarkUsed(ctx context.Context, jti string, expiresAt time.Time) (bool, error)
+	// PurgeExpired deletes up to limit records that expired before the given time
+	// and returns the number of rows removed.
+	PurgeExpired(ctx context.Context, before time.Time, limit int) (int64, error)
+}
+
+// OAuthClientRepository defines the interface for OAuth client data access.
+type OAuthClientRepository interface {
+	// Save saves a client to the underlying storage.
+	Save(ctx context.Context, client *OAuthClient) error
+	// FindByID retrieves a client by its ID from the storage, or nil if it does not exist.
+	FindByID(ctx context.Context, id string) (*OAuthClient, error)
+}
+
+// AuthorizationCodeRepository defines the interface for authorization code storage.
+type AuthorizationCodeRepository interface {
+	// Save stores a newly issued authorization code.
+	Save(ctx context.Context, code *AuthorizationCode) error
+	// Consume atomically removes and returns the code with the given hash, or nil if it does not exist.
+	// A code can therefore be redeemed at most once, even under concurrent requests.
+	Consume(ctx context.Context, codeHash string) (*AuthorizationCode, error)
+	// PurgeExpired deletes up to limit codes that expired before the given time
+	// and returns the number of rows removed.
+	PurgeExpired(ctx context.Context, before time.Time, limit int) (int64, error)
+}
+
+// DeviceAuthorizationRepository defines the interface for device authorization storage.
+type DeviceAuthorizationRepository interface {
+	// Save inserts a newly issued request.
+	Save(ctx context.Context, authorization *DeviceAuthorization) error
+	// RecordPoll stores the polling time and interval of a request, leaving its decision untouched.
+	RecordPoll(ctx context.Context, authorization *DeviceAuthorization) error
+	// Decide stores the decision of a request that is still pending and reports whether it was.
+	Decide(ctx context.Context, authorization *DeviceAuthorization) (bool, error)
+	// FindByDeviceCodeHash returns the request with the given device code hash, or nil if none exists.
+	FindByDeviceCodeHash(ctx context.Context, deviceCodeHash string) (*DeviceAuthorization, error)
+	// FindByUserCodeHash returns the request with the given user code hash, or nil if none exists.
+	FindByUserCodeHash(ctx context.Context, userCodeHash string) (*DeviceAuthorization, error)
+	// Consume atomically removes and returns the request, so a device code yields at most one token pair.
+	Consume(ctx context.Context, deviceCodeHash string) (*DeviceAuthorization, error)
+	// PurgeExpired deletes up to limit requests that expired before the given time
+	// and returns the number of rows removed.
+	PurgeExpired(ctx context.Context, before time.Time, limit int) (int64, error)
+}
+
+// ConsentRepository defines the interface for OAuth consent data access.
+type ConsentRepository interface {
+	// Save saves a consent to the underlying storage.
+	Save(ctx context.Context, consent *Consent) error
+	// Find retrieves the consent a user granted to a client, or nil if none exists.
+	Find(ctx context.Context, userID, clientID string) (*Consent, error)
+	// FindByUserID retrieves all consents granted by a user.
+	FindByUserID(ctx context.Context, userID string) ([]*Consent, error)
+	// Delete removes the consent a user granted to a client.
+	Delete(ctx context.Context, userID, clientID string) error
+}
+
+// SessionRepository defines the interface for session data access.
+type SessionRepository interface {
+	// Save saves a session to the underlying storage. A stored revocation is never undone.
+	Save(ctx context.Context, session *Session) error
+	// Touch stores the refresh time, expiry and IP address of a session that is not revoked
+	// and reports whether it was updated.
+	Touch(ctx context.Context, session *Session) (bool, error)
+	// FindByID retrieves a session by its ID from the storage, or nil if it does not exist.
+	FindByID(ctx context.Context, id string) (*Session, error)
+	// FindActiveByUserID retrieves the sessions of a user that are neither revoked nor expired.
+	FindActiveByUserID(ctx context.Context, userID string) ([]*Session, error)
+	// RevokeAllByUserID revokes every active session of a user except exceptID (if not empty)
+	// and returns the number of sessions revoked.
+	RevokeAllByUserID(ctx context.Context, userID, exceptID string, revokedAt time.Time) (int64, error)
+	// PurgeExpired deletes up to limit sessions that expired before the given time
+	// and returns the number of rows removed.
+	PurgeExpired(ctx context.Context, before time.Time, limit int) (int64, error)
+}
+
+// RoleRepository defines the interface for role assignment data access.
+type RoleRepository interface {
+	// FindNamesByUserID returns the names of the roles assigned to a user.
+	FindNamesByUserID(ctx context.Context, userID string) ([]string, error)
+}
+
+// APIKeyRepository defines the interface for personal API key data access.
+type APIKeyRepository interface {
+	// Save inserts a new key or updates the revocation of an existing one.
+	Save(ctx context.Context, key *APIKey) error
+	// FindByID retrieves a key by its ID, or nil if it does not exist.
+	FindByID(ctx context.Context, id string) (*APIKey, error)
+	// FindByHash retrieves the key with the given hash, or nil if none exists.
+	FindByHash(ctx context.Context, keyHash string) (*APIKey, error)
+	// FindByUserID retrieves the unrevoked keys of a user.
+	FindByUserID(ctx context.Context, userID string) ([]*APIKey, error)
+	// RecordUsage stores when and from which IP address a key was last used.
+	RecordUsage(ctx context.Context, id string, usedAt time.Time, ip string) error
+}
+
+// TOTPCredentialRepository defines the interface for TOTP credential data access.
+type TOTPCredentialRepository interface {
+	// Save inserts or replaces the TOTP credential of a user.
+	Save(ctx context.Context, credential *TOTPCredential) error
+	// FindByUserID retrieves the TOTP credential of a user, or nil if none exists.
+	FindByUserID(ctx context.Context, userID string) (*TOTPCredential, error)
+	// MarkStepUsed records step as the last used time step if it is newer than the stored one,
+	// and reports whether it was. Concurrent redemptions of one code cannot both succeed.
+	MarkStepUsed(ctx context.Context, userID string, step int64) (bool, error)
+	// Delete removes the TOTP credential of a user.
+	Delete(ctx context.Context, userID string) error
+}
+
+// RecoveryCodeRepository defines the interface for MFA recovery code data access.
+type RecoveryCodeRepository interface {
+	// ReplaceForUser atomically deletes all codes of a user and stores the new set.
+	ReplaceForUser(ctx context.Context, userID string, codes []*RecoveryCode) error
+	// FindUnusedByUserID retrieves the codes of a user that were not consumed yet.
+	FindUnusedByUserID(ctx context.Context, userID string) ([]*RecoveryCode, error)
+	// MarkUsed consumes a code if it is still unused and reports whether it was.
+	// Concurrent redemptions of one code cannot both succeed.
+	MarkUsed(ctx context.Context, id string, usedAt time.Time) (bool, error)
+}
+
+// MFAChallengeRepository defines the interface for pending MFA challenge data access.
+type MFAChallengeRepository interface {
+	// Save stores a newly issued challenge.
+	Save(ctx context.Context, challenge *MFAChallenge) error
+	// FindByTokenHash retrieves a challenge without counting an attempt, or nil if it does not exist.
+	FindByTokenHash(ctx context.Context, tokenHash string) (*MFAChallenge, error)
+	// IncrementAttempts atomically counts an attempt and returns the updated challenge,
+	// or nil if it does not exist.
+	IncrementAttempts(ctx context.Context, tokenHash string) (*MFAChallenge, error)
+	// Consume deletes the challenge and returns it, or nil if it was already consumed.
+	Consume(ctx context.Context, tokenHash string) (*MFAChallenge, error)
+	// PurgeExpired deletes up to limit challenges that expired before the given time
+	// and returns the number of rows removed.
+	PurgeExpired(ctx context.Context, before time.Time, limit int) (int64, error)
+}
+
+// WebAuthnCredentialRepository defines the interface for passkey data access.
+type WebAuthnCredentialRepository interface {
+	// Save stores a newly registered credential.
+	Save(ctx context.Context, credential *WebAuthnCredential) error
+	// FindByID retrieves a credential by its credential ID, or nil if it does not exist.
+	FindByID(ctx context.Context, id []byte) (*WebAuthnCredential, error)
+	// FindByUserID retrieves the credentials registered by a user.
+	FindByUserID(ctx context.Context, userID string) ([]*WebAuthnCredential, error)
+	// MarkUsed stores signCount if it is greater than the stored counter, or if both are zero,
+	// and reports whether it was stored. Replayed or cloned assertions cannot both succeed.
+	MarkUsed(ctx context.Context, id []byte, signCount uint32, usedAt time.Time) (bool, error)
+	// Delete removes a credential of the user and reports whether it existed.
+	Delete(ctx context.Context, userID string, id []byte) (bool, error)
+}
+
+// WebAuthnCeremonyRepository defines the interface for pending WebAuthn ceremony data access.
+type WebAuthnCeremonyRepository interface {
+	// Save stores a newly started ceremony.
+	Save(ctx context.Context, ceremony *WebAuthnCeremony) error
+	// Consume deletes the ceremony and returns it, or nil if it was already consumed.
+	Consume(ctx context.Context, challengeHash string) (*WebAuthnCeremony, error)
+	// PurgeExpired deletes up to limit ceremonies that expired before the given time
+	// and returns the number of rows removed.
+	PurgeExpired(ctx context.Context, before time.Time, limit int) (int64, error)
+}
+
+// MagicLinkTokenRepository defines the interface for pending login link data access.
+type MagicLinkTokenRepository interface {
+	// Save stores a newly issued link token.
+	Save(ctx context.Context, token *MagicLinkToken) error
+	// Consume deletes the token and returns it, or nil if it was already consumed.
+	Consume(ctx context.Context, tokenHash string) (*MagicLinkToken, error)
+	// PurgeExpired deletes up to limit tokens that expired before the given time
+	// and returns the number of rows removed.
+	PurgeExpired(ctx context.Context, before time.Time, limit int) (int64, error)
+}
+
+// EmailVerificationTokenRepository defines the interface for pending email verification data access.
+type EmailVerificationTokenRepository interface {
+	// Save stores a newly issued verification token.
+	Save(ctx context.Context, token *EmailVerificationToken) error
+	// Consume deletes the token and returns it, or nil if it was already consumed.
+	Consume(ctx context.Context, tokenHash string) (*EmailVerificationToken, error)
+	// PurgeExpired deletes up to limit tokens that expired before the given time
+	// and returns the number of rows removed.
+	PurgeExpired(ctx context.Context, before time.Time, limit int) (int64, error)
+}
+
+// PasswordResetTokenRepository defines the interface for pending password reset data access.
+type PasswordResetTokenRepository interface {
+	// Save stores a newly issued reset token.
+	Save(ctx context.Context, token *PasswordResetToken) error
+	// Consume deletes the token and returns it, or nil if it was already consumed.
+	Consume(ctx context.Context, tokenHash string) (*PasswordResetToken, error)
+	// DeleteByUserID deletes every outstanding reset token of a user.
+	DeleteByUserID(ctx context.Context, userID string) error
+	// PurgeExpired deletes up to limit tokens that expired before the given time
+	// and returns the number of rows removed.
+	PurgeExpired(ctx context.Context, before time.Time, limit int) (int64, error)
+}
+
+// RateLimitRepository defines the interface for fixed-window request counters.
+type RateLimitRepository interface {
+	// Increment atomically counts a request for key in the current window of the given
+	// length and returns the number of requests in that window so far.
+	Increment(ctx context.Context, key string, window time.Duration) (int, error)
+	// PurgeExpired deletes up to limit counters whose window ended before the given time
+	// and returns the number of rows removed.
+	PurgeExpired(ctx context.Context, before time.Time, limit int) (int64, error)
+}
+
+// LoginAttemptRepository defines the interface for login history data access.
+type LoginAttemptRepository interface {
+	// Save stores a login attempt.
+	Save(ctx context.Context, attempt *LoginAttempt) error
+	// FindRecentSuccessful retrieves up to limit successful logins of a user, newest first.
+	FindRecentSuccessful(ctx context.Context, userID string, limit int) ([]*LoginAttempt, error)
+	// CountFailedSince returns the number of failed logins of a user since the given time.
+	CountFailedSince(ctx context.Context, userID string, since time.Time) (int, error)
+	// PurgeExpired deletes up to limit attempts that expired before the given time
+	// and returns the number of rows removed.
+	PurgeExpired(ctx context.Context, before time.Time, limit int) (int64, error)
 }
 
 // AuditLogRepository defines the interface for audit log data access.
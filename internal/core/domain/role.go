@@ -4,6 +4,14 @@ import (
 	"errors"
 )
 
+// Predefined role names.
+const (
+	RoleAdmin     = "ADMIN"
+	RoleModerator = "MODERATOR"
+	RoleStreamer  = "STREAMER"
+	RoleUser      = "USER"
+)
+
 // Role represents a user role for access control.
 type Role struct {
 	id          string
@@ -48,10 +56,10 @@ func (r *Role) Description() string {
 // isValidRoleName checks if the role name is one of the predefined valid roles.
 func isValidRoleName(name string) bool {
 	validRoles := map[string]bool{
-		"ADMIN":     true,
-		"MODERATOR": true,
-		"STREAMER":  true,
-		"USER":      true,
+		RoleAdmin:     true,
+		RoleModerator: true,
+		RoleStreamer:  true,
+		RoleUser:      true,
 	}
 	return validRoles[name]
 }
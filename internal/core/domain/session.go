@@ -0,0 +1,134 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// Session represents a signed-in device. Tokens issued at login carry its ID in the sid
+// claim, so revoking the session invalidates every token of that device.
+type Session struct {
+	id              string
+	userID          string
+	deviceName      string
+	userAgent       string
+	ipAddress       string
+	createdAt       time.Time
+	lastRefreshedAt time.Time
+	expiresAt       time.Time // 마지막 갱신 시점 기준으로 연장
+	revokedAt       *time.Time
+}
+
+// NewSession creates a new Session instance that stays valid for ttl unless refreshed.
+func NewSession(id, userID string, metadata ClientMetadata, ttl time.Duration) (*Session, error) {
+	if id == "" {
+		return nil, errors.New("session id must not be empty")
+	}
+	if userID == "" {
+		return nil, errors.New("user id must not be empty")
+	}
+	if ttl <= 0 {
+		return nil, errors.New("session ttl must be positive")
+	}
+
+	now := time.Now()
+	return &Session{
+		id:              id,
+		userID:          userID,
+		deviceName:      metadata.DeviceName,
+		userAgent:       metadata.UserAgent,
+		ipAddress:       metadata.IPAddress,
+		createdAt:       now,
+		lastRefreshedAt: now,
+		expiresAt:       now.Add(ttl),
+	}, nil
+}
+
+// NewSessionFromStorage restores a Session loaded from storage.
+func NewSessionFromStorage(id, userID string, metadata ClientMetadata, createdAt, lastRefreshedAt, expiresAt time.Time, revokedAt *time.Time) (*Session, error) {
+	if id == "" || userID == "" {
+		return nil, errors.New("session id and user id must not be empty")
+	}
+
+	return &Session{
+		id:              id,
+		userID:          userID,
+		deviceName:      metadata.DeviceName,
+		userAgent:       metadata.UserAgent,
+		ipAddress:       metadata.IPAddress,
+		createdAt:       createdAt,
+		lastRefreshedAt: lastRefreshedAt,
+		expiresAt:       expiresAt,
+		revokedAt:       revokedAt,
+	}, nil
+}
+
+// ID returns the session's unique identifier.
+func (s *Session) ID() string {
+	return s.id
+}
+
+// UserID returns the ID of the user who owns the session.
+func (s *Session) UserID() string {
+	return s.userID
+}
+
+// DeviceName returns the device name reported by the client.
+func (s *Session) DeviceName() string {
+	return s.deviceName
+}
+
+// UserAgent returns the user agent of the client at login.
+func (s *Session) UserAgent() string {
+	return s.userAgent
+}
+
+// IPAddress returns the IP address of the client at its last login or refresh.
+func (s *Session) IPAddress() string {
+	return s.ipAddress
+}
+
+// CreatedAt returns the time when the user logged in.
+func (s *Session) CreatedAt() time.Time {
+	return s.createdAt
+}
+
+// LastRefreshedAt returns the time when tokens were last refreshed.
+func (s *Session) LastRefreshedAt() time.Time {
+	return s.lastRefreshedAt
+}
+
+// ExpiresAt returns the time when the session expires unless refreshed.
+func (s *Session) ExpiresAt() time.Time {
+	return s.expiresAt
+}
+
+// RevokedAt returns the time when the session was revoked, or nil if it is not revoked.
+func (s *Session) RevokedAt() *time.Time {
+	return s.revokedAt
+}
+
+// IsActive reports whether the session is neither revoked nor expired.
+func (s *Session) IsActive() bool {
+	return s.revokedAt == nil && time.Now().Before(s.expiresAt)
+}
+
+// Touch records a token refresh from the given client and extends the session by ttl.
+func (s *Session) Touch(metadata ClientMetadata, ttl time.Duration) {
+	now := time.Now()
+	s.lastRefreshedAt = now
+	s.expiresAt = now.Add(ttl)
+	if metadata.IPAddress != "" {
+		s.ipAddress = metadata.IPAddress
+	}
+}
+
+// Revoke ends the session; tokens carrying its ID are rejected from then on.
+func (s *Session) Revoke() error {
+	if s.revokedAt != nil {
+		return errors.New("session is already revoked")
+	}
+	now := time.Now()
+	s.revokedAt = &now
+	return nil
+}
@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrSessionNotFound is returned when a session does not exist, has ended or belongs to another user.
+var ErrSessionNotFound = errors.New("session not found")
+
+// SessionService defines operations for users to review and end their signed-in devices.
+type SessionService interface {
+	// ListSessions returns the active sessions of a user.
+	ListSessions(ctx context.Context, userID string) ([]*Session, error)
+	// RevokeSession signs one of the user's devices out; its tokens are rejected afterwards.
+	RevokeSession(ctx context.Context, userID, sessionID string) error
+}
+
+// sessionService implements SessionService with domain logic.
+type sessionService struct {
+	sessionRepo SessionRepository
+	eventPub    EventPublisher
+}
+
+// NewSessionService creates a new instance of sessionService.
+func NewSessionService(sessionRepo SessionRepository, eventPub EventPublisher) SessionService {
+	return &sessionService{
+		sessionRepo: sessionRepo,
+		eventPub:    eventPub,
+	}
+}
+
+// ListSessions returns the active sessions of a user.
+func (s *sessionService) ListSessions(ctx context.Context, userID string) ([]*Session, error) {
+	if userID == "" {
+		return nil, errors.New("user id must not be empty")
+	}
+
+	sessions, err := s.sessionRepo.FindActiveByUserID(ctx, userID)
+	if err != nil {
+		return nil, errors.New("failed to find sessions: " + err.Error())
+	}
+	return sessions, nil
+}
+
+// RevokeSession revokes a session owned by the user.
+func (s *sessionService) RevokeSession(ctx context.Context, userID, sessionID string) error {
+	if userID == "" || sessionID == "" {
+		return errors.New("user id and session id must not be empty")
+	}
+
+	session, err := s.sessionRepo.FindByID(ctx, sessionID)
+	if err != nil {
+		return errors.New("failed to find session: " + err.Error())
+	}
+	// 다른 사용자의 세션은 존재 여부를 노출하지 않음
+	if session == nil || session.UserID() != userID || !session.IsActive() {
+		return ErrSessionNotFound
+	}
+
+	if err := session.Revoke(); err != nil {
+		return err
+	}
+	if err := s.sessionRepo.Save(ctx, session); err != nil {
+		return errors.New("failed to revoke session: " + err.Error())
+	}
+
+	_ = s.eventPub.Publish(&SessionRevoked{userID: userID, sessionID: sessionID, timestamp: time.Now()})
+	return nil
+}
@@ -0,0 +1,189 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+)
+
+// Authentication context class references (acr claim), derived from the amr values of a login.
+const (
+	ACRSingleFactor = "aal1"
+	ACRMultiFactor  = "aal2"
+)
+
+// ReAuthMethodPassword is the ReAuthenticate method for re-entering the password. Second
+// factors use their MFA method names, e.g. MFAMethodTOTP.
+const ReAuthMethodPassword = "password"
+
+// reAuthRateLimitPrefix prefixes the rate limit key of a user's re-authentication attempts.
+const reAuthRateLimitPrefix = "reauth:"
+
+// ErrReAuthenticationFailed is returned by ReAuthenticate for a wrong password or second factor.
+var ErrReAuthenticationFailed = errors.New("re-authentication failed")
+
+// ACRForAMR returns the acr value satisfied by the given authentication methods, or an
+// empty string when none are known.
+func ACRForAMR(amr []string) string {
+	if len(amr) == 0 {
+		return ""
+	}
+	if containsString(amr, AMRMFA) {
+		return ACRMultiFactor
+	}
+	return ACRSingleFactor
+}
+
+// acrSatisfies reports whether acr is at least as strong as required.
+func acrSatisfies(acr, required string) bool {
+	switch required {
+	case "":
+		return true
+	case ACRSingleFactor:
+		return acr == ACRSingleFactor || acr == ACRMultiFactor
+	case ACRMultiFactor:
+		return acr == ACRMultiFactor
+	}
+	return false
+}
+
+// StepUpRequirement describes how recent and how strong the authentication behind an
+// access token must be for a sensitive operation. Zero fields are not checked.
+type StepUpRequirement struct {
+	MaxAge time.Duration
+	ACR    string
+}
+
+// StepUpRequiredError is returned when a token does not meet a StepUpRequirement. The
+// client obtains a sufficient token with ReAuthenticate and retries (RFC 9470).
+type StepUpRequiredError struct {
+	Requirement StepUpRequirement
+}
+
+// Error implements the error interface.
+func (e *StepUpRequiredError) Error() string {
+	msg := "step-up authentication required"
+	if e.Requirement.MaxAge > 0 {
+		msg += ": authenticated more than " + strconv.Itoa(int(e.Requirement.MaxAge.Seconds())) + "s ago"
+	}
+	return msg
+}
+
+// Check returns a *StepUpRequiredError when the claims do not meet the requirement.
+func (r StepUpRequirement) Check(claims *TokenClaims) error {
+	if claims == nil {
+		return errors.New("claims must not be nil")
+	}
+	if r.MaxAge > 0 && (claims.AuthTime.IsZero() || time.Since(claims.AuthTime) > r.MaxAge) {
+		return &StepUpRequiredError{Requirement: r}
+	}
+	if !acrSatisfies(claims.ACR, r.ACR) {
+		return &StepUpRequiredError{Requirement: r}
+	}
+	return nil
+}
+
+// WithReAuthentication enables ReAuthenticate. Elevated tokens live for tokenTTL, and a user
+// may make at most maxAttempts re-authentication attempts per window.
+func WithReAuthentication(tokenTTL time.Duration, rateLimitRepo RateLimitRepository, maxAttempts int, window time.Duration) AuthServiceOption {
+	return func(s *authService) {
+		s.reAuthTokenTTL = tokenTTL
+		s.reAuthRateLimitRepo = rateLimitRepo
+		s.reAuthMaxAttempts = maxAttempts
+		s.reAuthWindow = window
+	}
+}
+
+// ReAuthenticate checks the password or a second factor of the token's user again and issues
+// a short-lived access token with a fresh auth_time. The session, client binding, scope and
+// DPoP key of the presented token are kept; no refresh token is issued.
+func (s *authService) ReAuthenticate(ctx context.Context, claims *TokenClaims, method, response string) (*Token, error) {
+	if s.reAuthTokenTTL <= 0 {
+		return nil, errors.New("re-authentication is not enabled")
+	}
+	if claims == nil || claims.Subject == "" || claims.IsClientToken() {
+		return nil, errors.New("re-authentication requires a user token")
+	}
+	if response == "" {
+		return nil, ErrReAuthenticationFailed
+	}
+
+	// 액세스 토큰만으로 두 번째 인증 요소를 추측하지 못하도록 시도 횟수 제한
+	attempts, err := s.reAuthRateLimitRepo.Increment(ctx, reAuthRateLimitPrefix+claims.Subject, s.reAuthWindow)
+	if err != nil {
+		return nil, errors.New("failed to check re-authentication rate limit: " + err.Error())
+	}
+	if attempts > s.reAuthMaxAttempts {
+		return nil, ErrRateLimited
+	}
+
+	user, err := s.userRepo.FindByID(ctx, claims.Subject)
+	if err != nil {
+		return nil, errors.New("failed to find user: " + err.Error())
+	}
+	if user == nil {
+		return nil, errors.New("user not found")
+	}
+
+	var amr []string
+	switch method {
+	case ReAuthMethodPassword:
+		if !user.PasswordHash().Verify(response) {
+			_ = s.eventPub.Publish(&LoginFailed{userID: user.ID(), timestamp: time.Now()})
+			return nil, ErrReAuthenticationFailed
+		}
+		amr = []string{AMRPassword}
+	default:
+		if s.mfa == nil {
+			return nil, ErrMFANotEnrolled
+		}
+		factorAMR, err := s.mfa.VerifyFactor(ctx, user.ID(), method, response)
+		if err != nil {
+			if errors.Is(err, ErrInvalidMFACode) {
+				_ = s.eventPub.Publish(&LoginFailed{userID: user.ID(), timestamp: time.Now()})
+				return nil, ErrReAuthenticationFailed
+			}
+			return nil, err
+		}
+		// 첫 번째 인증 요소는 기존 로그인에서 확인됨
+		amr = appendUnique(appendUnique(firstFactorAMR(claims.AMR), factorAMR), AMRMFA)
+	}
+
+	elevated := applyTokenOptions([]TokenOption{
+		WithClientID(claims.ClientID),
+		WithScope(claims.Scope...),
+		WithAudience(claims.Audience...),
+		WithConfirmationKey(claims.ConfirmationKeyThumbprint),
+		WithAuthentication(time.Now(), amr...),
+		WithSessionID(claims.SessionID),
+	})
+	elevated.TokenID = generateRandomString(16)
+	expiry := time.Now().Add(s.reAuthTokenTTL)
+	accessToken, err := s.tokenGen.GenerateAccessToken(user.ID(), expiry, elevated)
+	if err != nil {
+		return nil, errors.New("failed to generate access token: " + err.Error())
+	}
+	return NewAccessToken(accessToken, elevated.TokenID, expiry)
+}
+
+// firstFactorAMR returns the amr values of a login that do not stand for a second factor.
+func firstFactorAMR(amr []string) []string {
+	var result []string
+	for _, method := range amr {
+		switch method {
+		case AMROTP, AMRHardwareKey, AMRMFA:
+		default:
+			result = append(result, method)
+		}
+	}
+	return result
+}
+
+// appendUnique appends value to values unless it is already present.
+func appendUnique(values []string, value string) []string {
+	if containsString(values, value) {
+		return values
+	}
+	return append(values, value)
+}
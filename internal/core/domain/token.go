@@ -11,6 +11,7 @@ type Token struct {
 	refreshToken string
 	jti          string
 	expiry       time.Time
+	idToken      string // OpenID Connect ID 토큰 (openid 범위 요청 시에만)
 }
 
 // NewToken creates a new Token instance.
@@ -36,16 +37,46 @@ func NewToken(accessToken, refreshToken, jti string, expiry time.Time) (*Token,
 	}, nil
 }
 
+// NewAccessToken creates a Token that carries only an access token, for grants that
+// do not issue refresh tokens (e.g. token exchange).
+func NewAccessToken(accessToken, jti string, expiry time.Time) (*Token, error) {
+	if accessToken == "" {
+		return nil, errors.New("access token must not be empty")
+	}
+	if jti == "" {
+		return nil, errors.New("jti must not be empty")
+	}
+	if expiry.Before(time.Now()) {
+		return nil, errors.New("token expiry must be in the future")
+	}
+
+	return &Token{
+		accessToken: accessToken,
+		jti:         jti,
+		expiry:      expiry,
+	}, nil
+}
+
 // AccessToken returns the access token string.
 func (t *Token) AccessToken() string {
 	return t.accessToken
 }
 
-// RefreshToken returns the refresh token string.
+// RefreshToken returns the refresh token string, empty for access-only tokens.
 func (t *Token) RefreshToken() string {
 	return t.refreshToken
 }
 
+// IDToken returns the OpenID Connect ID token, or an empty string if none was issued.
+func (t *Token) IDToken() string {
+	return t.idToken
+}
+
+// SetIDToken attaches an OpenID Connect ID token to the token response.
+func (t *Token) SetIDToken(idToken string) {
+	t.idToken = idToken
+}
+
 // JTI returns the JWT token identifier.
 func (t *Token) JTI() string {
 	return t.jti
@@ -0,0 +1,138 @@
+package domain
+
+import "time"
+
+// Token types carried in the "typ" claim.
+const (
+	TokenTypeAccess  = "access"
+	TokenTypeRefresh = "refresh"
+)
+
+// TokenClaims holds the verified claims of an issued token.
+// Generators read the optional fields when signing and populate all fields when parsing.
+type TokenClaims struct {
+	Subject   string
+	TokenID   string // jti
+	Type      string // TokenTypeAccess, TokenTypeRefresh
+	IssuedAt  time.Time
+	ExpiresAt time.Time
+	// ConfirmationKeyThumbprint is the RFC 7638 JWK thumbprint (cnf.jkt) of the key the token is bound to.
+	// 비어 있으면 일반 Bearer 토큰
+	ConfirmationKeyThumbprint string
+	Audience                  []string
+	Scope                     []string
+	// ClientID is the OAuth client the token was issued to (client_id claim, RFC 9068).
+	ClientID string
+	// Actor identifies the party acting on behalf of the subject (RFC 8693 act claim), nil if none.
+	Actor *ActorClaim
+	// AuthTime is when the user originally authenticated; it is kept across refreshes.
+	AuthTime time.Time
+	// AMR lists the authentication methods used (RFC 8176), e.g. "pwd".
+	AMR []string
+	// ACR is the authentication context class satisfied by AMR, e.g. ACRMultiFactor.
+	ACR string
+	// SessionID identifies the login session the token belongs to (sid claim).
+	SessionID string
+}
+
+// ActorClaim represents one link of a delegation chain (RFC 8693 4.1).
+// Actor refers to the prior actor when the subject token was itself delegated.
+type ActorClaim struct {
+	Subject string
+	Actor   *ActorClaim
+}
+
+// HasScope reports whether the token grants the given scope.
+func (c *TokenClaims) HasScope(scope string) bool {
+	return containsString(c.Scope, scope)
+}
+
+// HasAudience reports whether the token is intended for the given audience.
+func (c *TokenClaims) HasAudience(audience string) bool {
+	return containsString(c.Audience, audience)
+}
+
+// IsClientToken reports whether the token was issued to a client acting on its own behalf
+// (client credentials grant) rather than to a user.
+func (c *TokenClaims) IsClientToken() bool {
+	return c.ClientID != "" && c.ClientID == c.Subject
+}
+
+// IsDelegated reports whether someone other than the subject acts with the token, such as
+// a support agent impersonating the user or a service that exchanged the user's token.
+func (c *TokenClaims) IsDelegated() bool {
+	return c.Actor != nil
+}
+
+// IsGuest reports whether the token was issued to an anonymous guest user.
+func (c *TokenClaims) IsGuest() bool {
+	return c.HasScope(ScopeGuest)
+}
+
+// IsSenderConstrained reports whether the token is bound to a DPoP key.
+func (c *TokenClaims) IsSenderConstrained() bool {
+	return c.ConfirmationKeyThumbprint != ""
+}
+
+// TokenOption customizes the claims of a token pair being issued.
+type TokenOption func(*TokenClaims)
+
+// WithConfirmationKey binds the issued tokens to the DPoP key with the given JWK thumbprint.
+func WithConfirmationKey(jkt string) TokenOption {
+	return func(c *TokenClaims) {
+		c.ConfirmationKeyThumbprint = jkt
+	}
+}
+
+// WithAudience restricts the issued tokens to the given audiences.
+func WithAudience(audience ...string) TokenOption {
+	return func(c *TokenClaims) {
+		c.Audience = audience
+	}
+}
+
+// WithScope limits the issued tokens to the given scopes.
+func WithScope(scope ...string) TokenOption {
+	return func(c *TokenClaims) {
+		c.Scope = scope
+	}
+}
+
+// WithClientID records the OAuth client the tokens are issued to.
+func WithClientID(clientID string) TokenOption {
+	return func(c *TokenClaims) {
+		c.ClientID = clientID
+	}
+}
+
+// WithActor records the party acting on behalf of the subject.
+func WithActor(actor *ActorClaim) TokenOption {
+	return func(c *TokenClaims) {
+		c.Actor = actor
+	}
+}
+
+// WithAuthentication records when and how the user authenticated (auth_time, amr and acr claims).
+func WithAuthentication(authTime time.Time, amr ...string) TokenOption {
+	return func(c *TokenClaims) {
+		c.AuthTime = authTime
+		c.AMR = amr
+		c.ACR = ACRForAMR(amr)
+	}
+}
+
+// WithSessionID ties the issued tokens to a login session.
+func WithSessionID(sessionID string) TokenOption {
+	return func(c *TokenClaims) {
+		c.SessionID = sessionID
+	}
+}
+
+// applyTokenOptions builds the optional claims described by opts.
+func applyTokenOptions(opts []TokenOption) TokenClaims {
+	var claims TokenClaims
+	for _, opt := range opts {
+		opt(&claims)
+	}
+	return claims
+}
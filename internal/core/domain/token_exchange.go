@@ -0,0 +1,170 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Token type identifiers defined by RFC 8693 section 3.
+const (
+	TokenTypeIdentifierAccessToken = "urn:ietf:params:oauth:token-type:access_token"
+	GrantTypeTokenExchange         = "urn:ietf:params:oauth:grant-type:token-exchange"
+)
+
+// TokenExchangePolicy describes which audiences and scopes a calling service may request
+// when exchanging a user's token.
+type TokenExchangePolicy struct {
+	actor     string
+	audiences []string
+	scopes    []string
+}
+
+// NewTokenExchangePolicy creates a new TokenExchangePolicy instance.
+func NewTokenExchangePolicy(actor string, audiences, scopes []string) (*TokenExchangePolicy, error) {
+	if actor == "" {
+		return nil, errors.New("actor must not be empty")
+	}
+	if len(audiences) == 0 {
+		return nil, errors.New("at least one audience must be allowed")
+	}
+	if len(scopes) == 0 {
+		return nil, errors.New("at least one scope must be allowed")
+	}
+
+	return &TokenExchangePolicy{
+		actor:     actor,
+		audiences: audiences,
+		scopes:    scopes,
+	}, nil
+}
+
+// Actor returns the calling service the policy applies to.
+func (p *TokenExchangePolicy) Actor() string {
+	return p.actor
+}
+
+// Audiences returns the audiences the actor may request.
+func (p *TokenExchangePolicy) Audiences() []string {
+	return p.audiences
+}
+
+// Scopes returns the scopes the actor may request.
+func (p *TokenExchangePolicy) Scopes() []string {
+	return p.scopes
+}
+
+// AllowsAudience reports whether the actor may request a token for the audience.
+func (p *TokenExchangePolicy) AllowsAudience(audience string) bool {
+	return containsString(p.audiences, audience)
+}
+
+// AllowsScopes reports whether every requested scope is permitted for the actor.
+func (p *TokenExchangePolicy) AllowsScopes(scopes []string) bool {
+	for _, scope := range scopes {
+		if !containsString(p.scopes, scope) {
+			return false
+		}
+	}
+	return true
+}
+
+// TokenExchangeService defines the OAuth 2.0 token exchange operation (RFC 8693).
+type TokenExchangeService interface {
+	// ExchangeToken issues a down-scoped, audience-restricted access token for the subject of
+	// subjectToken, with an act claim naming the authenticated calling service.
+	ExchangeToken(ctx context.Context, subjectToken, actor, audience string, scopes []string) (*Token, error)
+}
+
+// tokenExchangeService implements TokenExchangeService with domain logic.
+type tokenExchangeService struct {
+	authService AuthService
+	tokenGen    TokenGenerator
+	eventPub    EventPublisher
+	policies    map[string]*TokenExchangePolicy
+	ttl         time.Duration
+}
+
+// NewTokenExchangeService creates a new instance of tokenExchangeService.
+// ttl caps the lifetime of exchanged tokens; they never outlive the subject token.
+func NewTokenExchangeService(authService AuthService, tokenGen TokenGenerator, eventPub EventPublisher, policies []*TokenExchangePolicy, ttl time.Duration) TokenExchangeService {
+	byActor := make(map[string]*TokenExchangePolicy, len(policies))
+	for _, policy := range policies {
+		byActor[policy.Actor()] = policy
+	}
+	return &tokenExchangeService{
+		authService: authService,
+		tokenGen:    tokenGen,
+		eventPub:    eventPub,
+		policies:    byActor,
+		ttl:         ttl,
+	}
+}
+
+// ExchangeToken validates the subject token and issues a delegated token for the actor.
+// actor must already be authenticated by the transport layer (e.g. mTLS or a client credential).
+// Failures are returned as *OAuthError.
+func (s *tokenExchangeService) ExchangeToken(ctx context.Context, subjectToken, actor, audience string, scopes []string) (*Token, error) {
+	if subjectToken == "" || actor == "" || audience == "" {
+		return nil, NewOAuthError(OAuthErrInvalidRequest, "subject token, actor, and audience must not be empty")
+	}
+	if len(scopes) == 0 {
+		return nil, NewOAuthError(OAuthErrInvalidScope, "at least one scope must be requested")
+	}
+
+	policy, ok := s.policies[actor]
+	if !ok {
+		return nil, NewOAuthError(OAuthErrUnauthorizedClient, "client is not allowed to exchange tokens")
+	}
+	if !policy.AllowsAudience(audience) {
+		return nil, NewOAuthError(OAuthErrInvalidTarget, "audience is not allowed for client")
+	}
+	if !policy.AllowsScopes(scopes) {
+		return nil, NewOAuthError(OAuthErrInvalidScope, "requested scope is not allowed for client")
+	}
+
+	subject, err := s.authService.ValidateAccessToken(ctx, subjectToken)
+	if err != nil {
+		return nil, NewOAuthError(OAuthErrInvalidGrant, "invalid subject token")
+	}
+	if subject.IsClientToken() {
+		return nil, NewOAuthError(OAuthErrInvalidGrant, "subject token must belong to a user")
+	}
+	// 범위가 지정된 토큰은 그 범위 안에서만 축소 가능
+	if len(subject.Scope) > 0 {
+		for _, scope := range scopes {
+			if !subject.HasScope(scope) {
+				return nil, NewOAuthError(OAuthErrInvalidScope, "requested scope exceeds subject token scope")
+			}
+		}
+	}
+
+	// 위임 체인 유지: 기존 act 클레임은 새 행위자의 하위로 중첩
+	act := &ActorClaim{Subject: actor, Actor: subject.Actor}
+
+	expiry := time.Now().Add(s.ttl)
+	if subject.ExpiresAt.Before(expiry) {
+		expiry = subject.ExpiresAt
+	}
+
+	claims := applyTokenOptions([]TokenOption{WithAudience(audience), WithScope(scopes...), WithActor(act)})
+	claims.TokenID = generateRandomString(16)
+
+	accessToken, err := s.tokenGen.GenerateAccessToken(subject.Subject, expiry, claims)
+	if err != nil {
+		return nil, errors.New("failed to generate exchanged token: " + err.Error())
+	}
+
+	_ = s.eventPub.Publish(&TokenExchanged{userID: subject.Subject, actor: actor, audience: audience, timestamp: time.Now()})
+	return NewAccessToken(accessToken, claims.TokenID, expiry)
+}
+
+// containsString reports whether values contains target.
+func containsString(values []string, target string) bool {
+	for _, v := range values {
+		if v == target {
+			return true
+		}
+	}
+	return false
+}
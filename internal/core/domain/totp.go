@@ -0,0 +1,170 @@
+package domain
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// TOTP parameters (RFC 6238). They are the defaults of common authenticator apps, which
+// ignore other values in the provisioning URI.
+const (
+	totpPeriod     = 30 * time.Second
+	totpDigits     = 6
+	totpSecretSize = 20 // HMAC-SHA1 블록 크기에 맞춘 160비트 (RFC 4226 4)
+)
+
+// totpEncoding is the unpadded base32 alphabet used in provisioning URIs.
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// TOTPCredential is a user's TOTP authenticator. The shared secret is kept encrypted;
+// it is only decrypted in memory to show it at enrollment or to verify a code.
+type TOTPCredential struct {
+	userID          string
+	encryptedSecret string
+	confirmedAt     *time.Time // nil이면 등록 확인 전
+	lastUsedStep    int64      // 재사용 방지를 위한 마지막 사용 time step
+	createdAt       time.Time
+}
+
+// NewTOTPCredential creates an unconfirmed TOTP credential.
+func NewTOTPCredential(userID, encryptedSecret string) (*TOTPCredential, error) {
+	if userID == "" {
+		return nil, errors.New("user id must not be empty")
+	}
+	if encryptedSecret == "" {
+		return nil, errors.New("encrypted secret must not be empty")
+	}
+
+	return &TOTPCredential{
+		userID:          userID,
+		encryptedSecret: encryptedSecret,
+		createdAt:       time.Now(),
+	}, nil
+}
+
+// NewTOTPCredentialFromStorage restores a TOTPCredential loaded from storage.
+func NewTOTPCredentialFromStorage(userID, encryptedSecret string, confirmedAt *time.Time, lastUsedStep int64, createdAt time.Time) (*TOTPCredential, error) {
+	credential, err := NewTOTPCredential(userID, encryptedSecret)
+	if err != nil {
+		return nil, err
+	}
+	credential.confirmedAt = confirmedAt
+	credential.lastUsedStep = lastUsedStep
+	credential.createdAt = createdAt
+	return credential, nil
+}
+
+// UserID returns the ID of the user who owns the credential.
+func (c *TOTPCredential) UserID() string {
+	return c.userID
+}
+
+// EncryptedSecret returns the encrypted shared secret.
+func (c *TOTPCredential) EncryptedSecret() string {
+	return c.encryptedSecret
+}
+
+// ConfirmedAt returns when the user proved possession of the authenticator, or nil.
+func (c *TOTPCredential) ConfirmedAt() *time.Time {
+	return c.confirmedAt
+}
+
+// IsConfirmed reports whether the credential can be used as a second factor.
+func (c *TOTPCredential) IsConfirmed() bool {
+	return c.confirmedAt != nil
+}
+
+// LastUsedStep returns the time step of the last accepted code.
+func (c *TOTPCredential) LastUsedStep() int64 {
+	return c.lastUsedStep
+}
+
+// CreatedAt returns the time when enrollment started.
+func (c *TOTPCredential) CreatedAt() time.Time {
+	return c.createdAt
+}
+
+// Confirm marks the credential as confirmed after the first valid code at the given step.
+func (c *TOTPCredential) Confirm(step int64) error {
+	if c.confirmedAt != nil {
+		return errors.New("totp credential is already confirmed")
+	}
+	now := time.Now()
+	c.confirmedAt = &now
+	c.lastUsedStep = step
+	return nil
+}
+
+// NewTOTPSecret generates a random shared secret.
+func NewTOTPSecret() ([]byte, error) {
+	secret := make([]byte, totpSecretSize)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, errors.New("failed to generate totp secret: " + err.Error())
+	}
+	return secret, nil
+}
+
+// TOTPProvisioningURI builds the otpauth:// URI that authenticator apps import, usually as a QR code.
+func TOTPProvisioningURI(issuer, accountName string, secret []byte) string {
+	label := url.PathEscape(issuer + ":" + accountName)
+	params := url.Values{
+		"secret":    {totpEncoding.EncodeToString(secret)},
+		"issuer":    {issuer},
+		"algorithm": {"SHA1"},
+		"digits":    {fmt.Sprint(totpDigits)},
+		"period":    {fmt.Sprint(int(totpPeriod.Seconds()))},
+	}
+	return "otpauth://totp/" + label + "?" + params.Encode()
+}
+
+// EncodeTOTPSecret returns the base32 form of the secret for manual entry.
+func EncodeTOTPSecret(secret []byte) string {
+	return totpEncoding.EncodeToString(secret)
+}
+
+// TOTPCode computes the code for a time step (RFC 6238 4, RFC 4226 5.3).
+func TOTPCode(secret []byte, step int64) string {
+	var counter [8]byte
+	binary.BigEndian.PutUint64(counter[:], uint64(step))
+	mac := hmac.New(sha1.New, secret)
+	mac.Write(counter[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	return fmt.Sprintf("%0*d", totpDigits, value%1_000_000)
+}
+
+// TOTPStep returns the time step containing t.
+func TOTPStep(t time.Time) int64 {
+	return t.Unix() / int64(totpPeriod.Seconds())
+}
+
+// MatchTOTPCode checks code against the steps within drift of now and returns the matching step.
+// Every candidate is compared so the timing does not reveal which step matched.
+func MatchTOTPCode(secret []byte, code string, now time.Time, drift int) (int64, bool) {
+	if len(code) != totpDigits {
+		return 0, false
+	}
+
+	current := TOTPStep(now)
+	var (
+		matched int64
+		found   bool
+	)
+	for i := -drift; i <= drift; i++ {
+		step := current + int64(i)
+		if subtle.ConstantTimeCompare([]byte(TOTPCode(secret, step)), []byte(code)) == 1 && !found {
+			matched, found = step, true
+		}
+	}
+	return matched, found
+}
@@ -12,6 +12,8 @@ const (
 	UserStatusActive    UserStatus = "ACTIVE"
 	UserStatusSuspended UserStatus = "SUSPENDED"
 	UserStatusDeleted   UserStatus = "DELETED"
+	// UserStatusGuest marks an anonymous viewer without credentials, see NewGuestUser.
+	UserStatusGuest UserStatus = "GUEST"
 )
 
 // User represents a user entity in the ImmersiVerse system.
@@ -19,6 +21,7 @@ type User struct {
 	id               string
 	username         string
 	email            Email    // 값 객체 사용
+	emailVerified    bool     // 이메일 소유 확인 여부
 	passwordHash     Password // 값 객체 사용
 	roleIDs          []string
 	status           UserStatus
@@ -54,6 +57,27 @@ func NewUser(id, username string, email Email, passwordHash Password, roleIDs []
 	}, nil
 }
 
+// NewGuestUser creates an anonymous guest user with a generated username and without an
+// email address or password. Guests become regular users with Upgrade.
+func NewGuestUser(id, username string) (*User, error) {
+	if id == "" {
+		return nil, errors.New("user id must not be empty")
+	}
+	if username == "" {
+		return nil, errors.New("username must not be empty")
+	}
+
+	now := time.Now()
+	return &User{
+		id:               id,
+		username:         username,
+		status:           UserStatusGuest,
+		subscriptionTier: "FREE",
+		createdAt:        now,
+		updatedAt:        now,
+	}, nil
+}
+
 // ID returns the user's unique identifier.
 func (u *User) ID() string {
 	return u.id
@@ -69,6 +93,11 @@ func (u *User) Email() Email {
 	return u.email
 }
 
+// EmailVerified reports whether the user has proven ownership of the email address.
+func (u *User) EmailVerified() bool {
+	return u.emailVerified
+}
+
 // PasswordHash returns the hashed password.
 func (u *User) PasswordHash() Password {
 	return u.passwordHash
@@ -104,10 +133,40 @@ func (u *User) LastLoginAt() *time.Time {
 	return u.lastLoginAt
 }
 
+// IsGuest reports whether the user is an anonymous guest.
+func (u *User) IsGuest() bool {
+	return u.status == UserStatusGuest
+}
+
+// Upgrade turns a guest into a regular active user with the given credentials. The user
+// keeps its ID, so everything recorded for the guest stays with the account.
+func (u *User) Upgrade(username string, email Email, passwordHash Password) error {
+	if !u.IsGuest() {
+		return errors.New("user is not a guest")
+	}
+	if username == "" {
+		return errors.New("username must not be empty")
+	}
+	if !email.IsValid() {
+		return errors.New("invalid email address")
+	}
+	if len(passwordHash.Hash()) == 0 {
+		return errors.New("password must not be empty")
+	}
+
+	u.username = username
+	u.email = email
+	u.emailVerified = false
+	u.passwordHash = passwordHash
+	u.status = UserStatusActive
+	u.updatedAt = time.Now()
+	return nil
+}
+
 // SetStatus updates the user's status and marks the update time.
 func (u *User) SetStatus(status UserStatus) error {
 	switch status {
-	case UserStatusActive, UserStatusSuspended, UserStatusDeleted:
+	case UserStatusActive, UserStatusSuspended, UserStatusDeleted, UserStatusGuest:
 		u.status = status
 		u.updatedAt = time.Now()
 		return nil
@@ -116,6 +175,18 @@ func (u *User) SetStatus(status UserStatus) error {
 	}
 }
 
+// SetPasswordHash replaces the user's password hash.
+func (u *User) SetPasswordHash(passwordHash Password) {
+	u.passwordHash = passwordHash
+	u.updatedAt = time.Now()
+}
+
+// SetEmailVerified records whether the email address has been verified.
+func (u *User) SetEmailVerified(verified bool) {
+	u.emailVerified = verified
+	u.updatedAt = time.Now()
+}
+
 // SetLastLoginAt updates the last login time.
 func (u *User) SetLastLoginAt(t time.Time) {
 	u.lastLoginAt = &t
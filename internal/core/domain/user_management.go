@@ -3,6 +3,7 @@ package domain
 import (
 	"context"
 	"errors"
+	"strings"
 	"time"
 )
 
@@ -10,27 +11,69 @@ import (
 type UserManagementService interface {
 	CreateUser(ctx context.Context, username, email, password, subscriptionTier string) (*User, error)
 	UpdateUserRole(ctx context.Context, userID, roleID string) error
+	// ChangePassword replaces the user's password after checking the current one. Every other
+	// session of the user is signed out; keepSessionID, if not empty, stays signed in.
+	ChangePassword(ctx context.Context, userID, currentPassword, newPassword, keepSessionID string) error
+	// DeleteAccount marks the user as deleted and signs out every session. Deleted users
+	// can no longer log in.
+	DeleteAccount(ctx context.Context, userID string) error
 }
 
+const (
+	// AuditActionPasswordChanged is recorded when a user changes their own password.
+	AuditActionPasswordChanged = "PASSWORD_CHANGED"
+	// AuditActionAccountDeleted is recorded when a user deletes their own account.
+	AuditActionAccountDeleted = "ACCOUNT_DELETED"
+)
+
+// ErrInvalidCurrentPassword is returned when the current password given to ChangePassword is wrong.
+var ErrInvalidCurrentPassword = errors.New("current password is incorrect")
+
 // userManagementService implements UserManagementService with domain logic.
 type userManagementService struct {
-	userRepo UserRepository
-	eventPub EventPublisher
+	userRepo    UserRepository
+	sessionRepo SessionRepository
+	auditRepo   AuditLogRepository
+	eventPub    EventPublisher
+
+	// 가입 시 이메일 확인 메일 발송은 WithSignupVerification 옵션을 지정한 경우에만 활성화
+	emailVerification EmailVerificationService
+}
+
+// UserManagementServiceOption configures optional features of the user management service.
+type UserManagementServiceOption func(*userManagementService)
+
+// WithSignupVerification sends a verification email to every newly created user.
+func WithSignupVerification(svc EmailVerificationService) UserManagementServiceOption {
+	return func(s *userManagementService) {
+		s.emailVerification = svc
+	}
 }
 
 // NewUserManagementService creates a new instance of userManagementService.
-func NewUserManagementService(userRepo UserRepository, eventPub EventPublisher) UserManagementService {
-	return &userManagementService{
-		userRepo: userRepo,
-		eventPub: eventPub,
+func NewUserManagementService(userRepo UserRepository, sessionRepo SessionRepository, auditRepo AuditLogRepository, eventPub EventPublisher, opts ...UserManagementServiceOption) UserManagementService {
+	s := &userManagementService{
+		userRepo:    userRepo,
+		sessionRepo: sessionRepo,
+		auditRepo:   auditRepo,
+		eventPub:    eventPub,
+	}
+	for _, opt := range opts {
+		opt(s)
 	}
+	return s
 }
 
-// CreateUser creates a new user with the given attributes.
+// CreateUser creates a new user with the given attributes. The email address starts out
+// unverified.
 func (s *userManagementService) CreateUser(ctx context.Context, username, emailStr, password, subscriptionTier string) (*User, error) {
 	if username == "" || emailStr == "" || password == "" {
 		return nil, errors.New("username, email, and password must not be empty")
 	}
+	// 로그인 식별자에서 "@"는 이메일을 뜻하므로 사용자 이름에 허용하지 않음
+	if strings.Contains(username, "@") {
+		return nil, errors.New("username must not contain @")
+	}
 
 	email, err := NewEmail(emailStr)
 	if err != nil {
@@ -54,6 +97,10 @@ func (s *userManagementService) CreateUser(ctx context.Context, username, emailS
 	}
 
 	_ = s.eventPub.Publish(&UserCreated{userID: user.ID(), timestamp: user.CreatedAt()})
+	if s.emailVerification != nil {
+		// 발송에 실패해도 가입은 유지하고, 사용자가 재발송을 요청할 수 있음
+		_ = s.emailVerification.RequestVerification(ctx, user.ID())
+	}
 	return user, nil
 }
 
@@ -64,7 +111,7 @@ func (s *userManagementService) UpdateUserRole(ctx context.Context, userID, role
 	}
 
 	// 사용자 조회
-	user, err := s.userRepo.FindByUsername(ctx, "") // 임시로 빈 username (FindByID 필요 시 추가)
+	user, err := s.userRepo.FindByID(ctx, userID)
 	if err != nil {
 		return errors.New("failed to find user: " + err.Error())
 	}
@@ -81,3 +128,83 @@ func (s *userManagementService) UpdateUserRole(ctx context.Context, userID, role
 	_ = s.eventPub.Publish(&UserUpdated{userID: userID, timestamp: time.Now()})
 	return nil
 }
+
+// ChangePassword verifies the current password, applies the password policy to the new one
+// and revokes the user's other sessions so a stolen session cannot outlive the change.
+func (s *userManagementService) ChangePassword(ctx context.Context, userID, currentPassword, newPassword, keepSessionID string) error {
+	if userID == "" {
+		return errors.New("user id must not be empty")
+	}
+
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return errors.New("failed to find user: " + err.Error())
+	}
+	if user == nil {
+		return errors.New("user not found")
+	}
+	if !user.PasswordHash().Verify(currentPassword) {
+		_ = s.eventPub.Publish(&LoginFailed{userID: userID, timestamp: time.Now()})
+		return ErrInvalidCurrentPassword
+	}
+	if currentPassword == newPassword {
+		return ErrPasswordUnchanged
+	}
+
+	pwd, err := user.PasswordHash().Change(newPassword)
+	if err != nil {
+		return err
+	}
+	user.SetPasswordHash(pwd)
+	if err := s.userRepo.SaveUser(ctx, user); err != nil {
+		return errors.New("failed to update password: " + err.Error())
+	}
+
+	now := time.Now()
+	revoked, err := s.sessionRepo.RevokeAllByUserID(ctx, userID, keepSessionID, now)
+	if err != nil {
+		return errors.New("failed to revoke sessions: " + err.Error())
+	}
+
+	recordAudit(ctx, s.auditRepo, AuditActionPasswordChanged, auditEntityUser, &userID, &userID, map[string]interface{}{
+		"revoked_sessions": revoked,
+		"kept_session":     keepSessionID != "",
+	})
+	_ = s.eventPub.Publish(&PasswordChanged{userID: userID, reason: PasswordChangeReasonChange, timestamp: now})
+	return nil
+}
+
+// DeleteAccount soft-deletes the user so audit history keeps referring to it, and revokes
+// every session so no refresh token outlives the account.
+func (s *userManagementService) DeleteAccount(ctx context.Context, userID string) error {
+	if userID == "" {
+		return errors.New("user id must not be empty")
+	}
+
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return errors.New("failed to find user: " + err.Error())
+	}
+	if user == nil || user.Status() == UserStatusDeleted {
+		return errors.New("user not found")
+	}
+
+	if err := user.SetStatus(UserStatusDeleted); err != nil {
+		return err
+	}
+	if err := s.userRepo.SaveUser(ctx, user); err != nil {
+		return errors.New("failed to delete user: " + err.Error())
+	}
+
+	now := time.Now()
+	revoked, err := s.sessionRepo.RevokeAllByUserID(ctx, userID, "", now)
+	if err != nil {
+		return errors.New("failed to revoke sessions: " + err.Error())
+	}
+
+	recordAudit(ctx, s.auditRepo, AuditActionAccountDeleted, auditEntityUser, &userID, &userID, map[string]interface{}{
+		"revoked_sessions": revoked,
+	})
+	_ = s.eventPub.Publish(&UserStatusChanged{userID: userID, newStatus: UserStatusDeleted, timestamp: now})
+	return nil
+}
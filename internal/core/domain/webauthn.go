@@ -0,0 +1,212 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// Purposes of a WebAuthn ceremony. A challenge issued for one purpose cannot complete another.
+const (
+	WebAuthnCeremonyRegistration = "registration"
+	WebAuthnCeremonyLogin        = "login"  // 패스워드 없는 로그인
+	WebAuthnCeremonyFactor       = "factor" // MFA 두 번째 인증 요소
+)
+
+// WebAuthnCredential is a public key credential (passkey) registered by a user.
+type WebAuthnCredential struct {
+	id         []byte // 인증기가 생성한 credential ID
+	userID     string
+	name       string // 사용자가 붙인 이름 (예: "MacBook Touch ID")
+	publicKey  []byte // COSE_Key 인코딩 공개 키
+	signCount  uint32
+	transports []string
+	createdAt  time.Time
+	lastUsedAt *time.Time
+}
+
+// NewWebAuthnCredential creates a new WebAuthnCredential instance.
+func NewWebAuthnCredential(id []byte, userID, name string, publicKey []byte, signCount uint32, transports []string) (*WebAuthnCredential, error) {
+	if len(id) == 0 {
+		return nil, errors.New("credential id must not be empty")
+	}
+	if userID == "" {
+		return nil, errors.New("user id must not be empty")
+	}
+	if len(publicKey) == 0 {
+		return nil, errors.New("public key must not be empty")
+	}
+	if len(name) > 100 {
+		return nil, errors.New("credential name must be at most 100 characters")
+	}
+
+	return &WebAuthnCredential{
+		id:         id,
+		userID:     userID,
+		name:       name,
+		publicKey:  publicKey,
+		signCount:  signCount,
+		transports: transports,
+		createdAt:  time.Now(),
+	}, nil
+}
+
+// NewWebAuthnCredentialFromStorage restores a WebAuthnCredential loaded from storage.
+func NewWebAuthnCredentialFromStorage(id []byte, userID, name string, publicKey []byte, signCount uint32, transports []string, createdAt time.Time, lastUsedAt *time.Time) (*WebAuthnCredential, error) {
+	credential, err := NewWebAuthnCredential(id, userID, name, publicKey, signCount, transports)
+	if err != nil {
+		return nil, err
+	}
+	credential.createdAt = createdAt
+	credential.lastUsedAt = lastUsedAt
+	return credential, nil
+}
+
+// ID returns the credential ID chosen by the authenticator.
+func (c *WebAuthnCredential) ID() []byte {
+	return c.id
+}
+
+// UserID returns the owner of the credential.
+func (c *WebAuthnCredential) UserID() string {
+	return c.userID
+}
+
+// Name returns the label the user gave the credential.
+func (c *WebAuthnCredential) Name() string {
+	return c.name
+}
+
+// PublicKey returns the COSE-encoded public key of the credential.
+func (c *WebAuthnCredential) PublicKey() []byte {
+	return c.publicKey
+}
+
+// SignCount returns the last signature counter reported by the authenticator.
+func (c *WebAuthnCredential) SignCount() uint32 {
+	return c.signCount
+}
+
+// Transports returns the transports the authenticator reported (e.g. "usb", "internal").
+func (c *WebAuthnCredential) Transports() []string {
+	return c.transports
+}
+
+// CreatedAt returns when the credential was registered.
+func (c *WebAuthnCredential) CreatedAt() time.Time {
+	return c.createdAt
+}
+
+// LastUsedAt returns when the credential last completed an authentication, if ever.
+func (c *WebAuthnCredential) LastUsedAt() *time.Time {
+	return c.lastUsedAt
+}
+
+// WebAuthnCeremony is a pending registration or authentication ceremony. The raw challenge
+// goes to the browser; only its hash is stored.
+type WebAuthnCeremony struct {
+	challengeHash string
+	userID        string // 패스워드 없는 로그인은 사용자를 모르므로 비어 있음
+	purpose       string
+	expiresAt     time.Time
+	createdAt     time.Time
+}
+
+// NewWebAuthnCeremony creates a new WebAuthnCeremony instance.
+func NewWebAuthnCeremony(challengeHash, userID, purpose string, expiresAt time.Time) (*WebAuthnCeremony, error) {
+	if challengeHash == "" {
+		return nil, errors.New("challenge hash must not be empty")
+	}
+	switch purpose {
+	case WebAuthnCeremonyRegistration, WebAuthnCeremonyFactor:
+		if userID == "" {
+			return nil, errors.New("user id must not be empty")
+		}
+	case WebAuthnCeremonyLogin:
+	default:
+		return nil, errors.New("unknown ceremony purpose: " + purpose)
+	}
+
+	return &WebAuthnCeremony{
+		challengeHash: challengeHash,
+		userID:        userID,
+		purpose:       purpose,
+		expiresAt:     expiresAt,
+		createdAt:     time.Now(),
+	}, nil
+}
+
+// NewWebAuthnCeremonyFromStorage restores a WebAuthnCeremony loaded from storage.
+func NewWebAuthnCeremonyFromStorage(challengeHash, userID, purpose string, expiresAt, createdAt time.Time) (*WebAuthnCeremony, error) {
+	ceremony, err := NewWebAuthnCeremony(challengeHash, userID, purpose, expiresAt)
+	if err != nil {
+		return nil, err
+	}
+	ceremony.createdAt = createdAt
+	return ceremony, nil
+}
+
+// ChallengeHash returns the SHA-256 hash of the challenge.
+func (c *WebAuthnCeremony) ChallengeHash() string {
+	return c.challengeHash
+}
+
+// UserID returns the user the ceremony was started for, or "" for a passwordless login.
+func (c *WebAuthnCeremony) UserID() string {
+	return c.userID
+}
+
+// Purpose returns what the ceremony was started for.
+func (c *WebAuthnCeremony) Purpose() string {
+	return c.purpose
+}
+
+// ExpiresAt returns when the ceremony expires.
+func (c *WebAuthnCeremony) ExpiresAt() time.Time {
+	return c.expiresAt
+}
+
+// CreatedAt returns when the ceremony was started.
+func (c *WebAuthnCeremony) CreatedAt() time.Time {
+	return c.createdAt
+}
+
+// IsExpired reports whether the ceremony can no longer be completed.
+func (c *WebAuthnCeremony) IsExpired() bool {
+	return time.Now().After(c.expiresAt)
+}
+
+// WebAuthnRegistration is a registration response whose client data and authenticator
+// data were verified against the relying party. The challenge is not checked yet.
+type WebAuthnRegistration struct {
+	Challenge    string // clientDataJSON의 challenge (base64url)
+	CredentialID []byte
+	PublicKey    []byte // COSE_Key
+	SignCount    uint32
+	Transports   []string
+	UserVerified bool
+}
+
+// WebAuthnAssertion is an authentication response whose client data and authenticator
+// data were verified against the relying party. The challenge and signature are not checked yet.
+type WebAuthnAssertion struct {
+	Challenge    string
+	CredentialID []byte
+	UserHandle   string // 검색 가능한 자격 증명이 반환한 사용자 ID
+	SignCount    uint32
+	UserVerified bool
+	SignedData   []byte // authenticatorData || SHA-256(clientDataJSON)
+	Signature    []byte
+}
+
+// WebAuthnVerifier checks WebAuthn responses (W3C Web Authentication Level 2, 7).
+type WebAuthnVerifier interface {
+	// SupportedAlgorithms returns the COSE algorithm identifiers accepted for credentials.
+	SupportedAlgorithms() []int
+	// ParseRegistration verifies a registration response's origin, relying party ID,
+	// flags and public key, and returns the credential it creates.
+	ParseRegistration(response []byte) (*WebAuthnRegistration, error)
+	// ParseAssertion verifies an authentication response's origin, relying party ID and flags.
+	ParseAssertion(response []byte) (*WebAuthnAssertion, error)
+	// VerifySignature checks the assertion signature with a credential's COSE public key.
+	VerifySignature(publicKey []byte, assertion *WebAuthnAssertion) error
+}
@@ -0,0 +1,33 @@
+package jobs
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	// purgedRows counts rows removed by purge jobs, labelled by job name.
+	purgedRows = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "iv_auth",
+		Subsystem: "purge",
+		Name:      "rows_removed_total",
+		Help:      "Number of expired rows removed by purge jobs.",
+	}, []string{"job"})
+
+	// purgeRuns counts purge runs by outcome (success, error, cancelled).
+	purgeRuns = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "iv_auth",
+		Subsystem: "purge",
+		Name:      "runs_total",
+		Help:      "Number of purge job runs by result.",
+	}, []string{"job", "result"})
+
+	// purgeDuration observes how long a single purge run takes.
+	purgeDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "iv_auth",
+		Subsystem: "purge",
+		Name:      "run_duration_seconds",
+		Help:      "Duration of purge job runs.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"job"})
+)
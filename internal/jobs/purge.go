@@ -0,0 +1,113 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"time"
+
+	"github.com/sukryu/IV-auth-services/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// ExpiredRecordPurger is implemented by repositories that can delete expired rows in bounded batches.
+type ExpiredRecordPurger interface {
+	// PurgeExpired deletes up to limit rows that expired before the given time and returns the number removed.
+	PurgeExpired(ctx context.Context, before time.Time, limit int) (int64, error)
+}
+
+// PurgeJob periodically removes expired rows from a single table.
+type PurgeJob struct {
+	name       string
+	target     ExpiredRecordPurger
+	interval   time.Duration
+	batchSize  int
+	maxBatches int
+	logger     *logger.Logger
+}
+
+// NewPurgeJob creates a new PurgeJob instance.
+// maxBatches bounds the work done per run so a large backlog is drained over several runs.
+func NewPurgeJob(name string, target ExpiredRecordPurger, interval time.Duration, batchSize, maxBatches int, log *logger.Logger) (*PurgeJob, error) {
+	if name == "" {
+		return nil, errors.New("job name must not be empty")
+	}
+	if target == nil {
+		return nil, errors.New("purge target must not be nil")
+	}
+	if interval <= 0 {
+		return nil, errors.New("purge interval must be positive")
+	}
+	if batchSize <= 0 {
+		return nil, errors.New("purge batch size must be positive")
+	}
+	if maxBatches <= 0 {
+		return nil, errors.New("purge max batches must be positive")
+	}
+
+	return &PurgeJob{
+		name:       name,
+		target:     target,
+		interval:   interval,
+		batchSize:  batchSize,
+		maxBatches: maxBatches,
+		logger:     log.With(zap.String("component", "purge_job"), zap.String("job", name)),
+	}, nil
+}
+
+// Run executes the job on every interval until the context is cancelled.
+func (j *PurgeJob) Run(ctx context.Context) {
+	// 레플리카들이 동시에 시작해도 실행 시점이 겹치지 않도록 첫 실행을 지연
+	jitter := time.Duration(rand.Int63n(int64(j.interval)/10 + 1))
+	timer := time.NewTimer(jitter)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			j.logger.Info("Purge job stopped")
+			return
+		case <-timer.C:
+			if _, err := j.RunOnce(ctx); err != nil && !errors.Is(err, context.Canceled) {
+				j.logger.Warn("Purge run failed", zap.Error(err))
+			}
+			timer.Reset(j.interval)
+		}
+	}
+}
+
+// RunOnce deletes expired rows batch by batch until a short batch is returned or maxBatches is reached.
+// It returns the total number of rows removed.
+func (j *PurgeJob) RunOnce(ctx context.Context) (int64, error) {
+	start := time.Now()
+	defer func() {
+		purgeDuration.WithLabelValues(j.name).Observe(time.Since(start).Seconds())
+	}()
+
+	var total int64
+	for i := 0; i < j.maxBatches; i++ {
+		if err := ctx.Err(); err != nil {
+			purgeRuns.WithLabelValues(j.name, "cancelled").Inc()
+			return total, err
+		}
+
+		removed, err := j.target.PurgeExpired(ctx, start, j.batchSize)
+		if err != nil {
+			purgeRuns.WithLabelValues(j.name, "error").Inc()
+			return total, errors.New("failed to purge expired rows: " + err.Error())
+		}
+		total += removed
+		purgedRows.WithLabelValues(j.name).Add(float64(removed))
+
+		// 배치가 가득 차지 않았다면 남은 만료 행이 없음
+		if removed < int64(j.batchSize) {
+			break
+		}
+	}
+
+	purgeRuns.WithLabelValues(j.name, "success").Inc()
+	if total > 0 {
+		j.logger.Info("Expired rows purged", zap.Int64("removed", total), zap.Duration("elapsed", time.Since(start)))
+	}
+	return total, nil
+}
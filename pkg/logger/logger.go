@@ -81,3 +81,8 @@ func (l *Logger) Fatal(msg string, fields ...zap.Field) {
 func (l *Logger) With(fields ...zap.Field) *Logger {
 	return &Logger{zap: l.zap.With(fields...)}
 }
+
+// Zap returns the underlying zap.Logger for components that depend on it directly.
+func (l *Logger) Zap() *zap.Logger {
+	return l.zap
+}
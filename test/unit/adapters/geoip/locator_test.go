@@ -0,0 +1,80 @@
+package geoip_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sukryu/IV-auth-services/internal/adapters/geoip"
+	"github.com/sukryu/IV-auth-services/internal/config"
+	"github.com/sukryu/IV-auth-services/pkg/logger"
+)
+
+func TestHTTPLocator(t *testing.T) {
+	log, err := logger.NewLogger("development")
+	require.NoError(t, err)
+
+	var requests int
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{ip}/json/", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch r.PathValue("ip") {
+		case "8.8.8.8":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"asn": "AS15169", "country_code": "us", "latitude": 37.42, "longitude": -122.08,
+			})
+		case "192.0.2.1":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"error": true, "reason": "Reserved IP Address"})
+		default:
+			w.WriteHeader(http.StatusTooManyRequests)
+		}
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	cfg := &config.Config{}
+	cfg.LoginRisk.GeoIP.URL = server.URL + "/{ip}/json/"
+	cfg.LoginRisk.GeoIP.Timeout = time.Second
+	locator, err := geoip.NewHTTPLocator(cfg, log)
+	require.NoError(t, err)
+
+	location, err := locator.Locate(context.Background(), "8.8.8.8")
+	require.NoError(t, err)
+	require.NotNil(t, location)
+	assert.Equal(t, "AS15169", location.ASN)
+	assert.Equal(t, "US", location.CountryCode)
+	assert.InDelta(t, 37.42, location.Latitude, 0.001)
+	assert.InDelta(t, -122.08, location.Longitude, 0.001)
+
+	// 조회할 수 없는 주소는 위치 없음
+	location, err = locator.Locate(context.Background(), "192.0.2.1")
+	assert.NoError(t, err)
+	assert.Nil(t, location)
+
+	_, err = locator.Locate(context.Background(), "1.1.1.1")
+	assert.Error(t, err)
+
+	// 사설 주소와 잘못된 주소는 조회하지 않음
+	before := requests
+	for _, ip := range []string{"10.0.0.1", "127.0.0.1", "::1", "not-an-ip", ""} {
+		location, err := locator.Locate(context.Background(), ip)
+		assert.NoError(t, err)
+		assert.Nil(t, location)
+	}
+	assert.Equal(t, before, requests)
+}
+
+func TestNewHTTPLocatorRequiresPlaceholder(t *testing.T) {
+	log, err := logger.NewLogger("development")
+	require.NoError(t, err)
+
+	cfg := &config.Config{}
+	cfg.LoginRisk.GeoIP.URL = "https://ipapi.co/json/"
+	_, err = geoip.NewHTTPLocator(cfg, log)
+	assert.Error(t, err)
+}
@@ -0,0 +1,139 @@
+package httpapi_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	httpapi "github.com/sukryu/IV-auth-services/internal/adapters/http"
+	"github.com/sukryu/IV-auth-services/internal/config"
+	"github.com/sukryu/IV-auth-services/internal/core/domain"
+	"github.com/sukryu/IV-auth-services/pkg/logger"
+)
+
+// stubAuthService implements the token validation of domain.AuthService with fixed claims per token.
+type stubAuthService struct {
+	domain.AuthService
+	tokens map[string]*domain.TokenClaims
+}
+
+func (s *stubAuthService) ValidateAccessToken(ctx context.Context, tokenStr string) (*domain.TokenClaims, error) {
+	claims, ok := s.tokens[tokenStr]
+	if !ok {
+		return nil, errors.New("invalid token")
+	}
+	return claims, nil
+}
+
+// stubAuthorizationService implements the consent management of domain.AuthorizationService.
+type stubAuthorizationService struct {
+	domain.AuthorizationService
+	revoked []string
+}
+
+func (s *stubAuthorizationService) ListConsents(ctx context.Context, userID string) ([]*domain.Consent, error) {
+	return nil, nil
+}
+
+func (s *stubAuthorizationService) RevokeConsent(ctx context.Context, userID, clientID string) error {
+	s.revoked = append(s.revoked, clientID)
+	return nil
+}
+
+// stubSessionService implements domain.SessionService and records revoked sessions.
+type stubSessionService struct {
+	revoked []string
+}
+
+func (s *stubSessionService) ListSessions(ctx context.Context, userID string) ([]*domain.Session, error) {
+	return nil, nil
+}
+
+func (s *stubSessionService) RevokeSession(ctx context.Context, userID, sessionID string) error {
+	s.revoked = append(s.revoked, sessionID)
+	return nil
+}
+
+// handlerEnv is a handler serving the account routes with stub services.
+type handlerEnv struct {
+	routes   http.Handler
+	authz    *stubAuthorizationService
+	sessions *stubSessionService
+}
+
+// Bearer tokens accepted by newHandlerEnv.
+const (
+	userToken          = "user-token"
+	exchangedToken     = "exchanged-token"
+	impersonationToken = "impersonation-token"
+)
+
+func newHandlerEnv(t *testing.T) *handlerEnv {
+	t.Helper()
+	log, err := logger.NewLogger("development")
+	require.NoError(t, err)
+	cfg := &config.Config{}
+	cfg.HTTP.PublicURL = "https://auth.example.com"
+
+	auth := &stubAuthService{tokens: map[string]*domain.TokenClaims{
+		userToken: {Subject: "user-123", Type: domain.TokenTypeAccess, SessionID: "session-1"},
+		// 다른 서비스가 사용자 토큰을 교환해 받은 토큰
+		exchangedToken: {Subject: "user-123", Type: domain.TokenTypeAccess, Audience: []string{"chat-service"},
+			Actor: &domain.ActorClaim{Subject: "streaming-client"}},
+		// 관리자가 사용자를 대리 실행하는 토큰
+		impersonationToken: {Subject: "user-123", Type: domain.TokenTypeAccess, Actor: &domain.ActorClaim{Subject: "admin-1"}},
+	}}
+	e := &handlerEnv{authz: &stubAuthorizationService{}, sessions: &stubSessionService{}}
+	h := httpapi.NewHandler(cfg, log, auth, e.authz, httpapi.WithSessionManagement(e.sessions))
+	e.routes = h.Routes()
+	return e
+}
+
+// do sends a request with the bearer token and returns the response status.
+func (e *handlerEnv) do(method, path, token string) int {
+	req := httptest.NewRequest(method, path, nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+	e.routes.ServeHTTP(rec, req)
+	return rec.Code
+}
+
+func TestAuthenticateUserRejectsOtherAudiences(t *testing.T) {
+	e := newHandlerEnv(t)
+
+	routes := []struct{ method, path string }{
+		{http.MethodGet, "/v1/sessions"},
+		{http.MethodDelete, "/v1/sessions/session-2"},
+		{http.MethodGet, "/v1/consents"},
+		{http.MethodDelete, "/v1/consents/client-1"},
+	}
+	for _, route := range routes {
+		t.Run(route.method+" "+route.path, func(t *testing.T) {
+			assert.Equal(t, http.StatusUnauthorized, e.do(route.method, route.path, exchangedToken))
+		})
+	}
+	assert.Empty(t, e.sessions.revoked)
+	assert.Empty(t, e.authz.revoked)
+
+	// 사용자 본인의 토큰은 허용
+	assert.Equal(t, http.StatusOK, e.do(http.MethodGet, "/v1/sessions", userToken))
+	assert.Equal(t, http.StatusNoContent, e.do(http.MethodDelete, "/v1/sessions/session-2", userToken))
+	assert.Equal(t, []string{"session-2"}, e.sessions.revoked)
+}
+
+func TestImpersonationCannotSignUserOut(t *testing.T) {
+	e := newHandlerEnv(t)
+
+	assert.Equal(t, http.StatusForbidden, e.do(http.MethodDelete, "/v1/sessions/session-2", impersonationToken))
+	assert.Equal(t, http.StatusForbidden, e.do(http.MethodDelete, "/v1/consents/client-1", impersonationToken))
+	assert.Empty(t, e.sessions.revoked)
+	assert.Empty(t, e.authz.revoked)
+
+	// 조회는 대리 실행 중에도 허용
+	assert.Equal(t, http.StatusOK, e.do(http.MethodGet, "/v1/sessions", impersonationToken))
+	assert.Equal(t, http.StatusOK, e.do(http.MethodGet, "/v1/consents", impersonationToken))
+}
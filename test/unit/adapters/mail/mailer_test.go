@@ -0,0 +1,53 @@
+package mail_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sukryu/IV-auth-services/internal/adapters/mail"
+	"github.com/sukryu/IV-auth-services/internal/config"
+	"github.com/sukryu/IV-auth-services/internal/core/domain"
+	"github.com/sukryu/IV-auth-services/pkg/logger"
+)
+
+func TestMemoryMailer_Send(t *testing.T) {
+	log, err := logger.NewLogger("development")
+	require.NoError(t, err)
+	mailer := mail.NewMemoryMailer(log)
+
+	to, err := domain.NewEmail("jane@example.com")
+	require.NoError(t, err)
+	require.NoError(t, mailer.Send(context.Background(), domain.EmailMessage{To: to, Subject: "Hello", Body: "Body"}))
+
+	messages := mailer.Messages()
+	require.Len(t, messages, 1)
+	assert.Equal(t, to, messages[0].To)
+	assert.Equal(t, "Hello", messages[0].Subject)
+
+	// 반환된 목록을 수정해도 기록에는 영향 없음
+	messages[0].Subject = "Changed"
+	assert.Equal(t, "Hello", mailer.Messages()[0].Subject)
+
+	assert.Error(t, mailer.Send(context.Background(), domain.EmailMessage{To: domain.Email("invalid")}))
+}
+
+func TestNewMailer(t *testing.T) {
+	log, err := logger.NewLogger("development")
+	require.NoError(t, err)
+
+	cfg := &config.Config{}
+	cfg.Mail.Driver = mail.DriverMemory
+	mailer, err := mail.NewMailer(cfg, log)
+	require.NoError(t, err)
+	assert.IsType(t, &mail.MemoryMailer{}, mailer)
+
+	cfg.Mail.Driver = mail.DriverSMTP
+	_, err = mail.NewMailer(cfg, log)
+	assert.Error(t, err, "smtp driver requires a host")
+
+	cfg.Mail.Driver = "carrier-pigeon"
+	_, err = mail.NewMailer(cfg, log)
+	assert.Error(t, err)
+}
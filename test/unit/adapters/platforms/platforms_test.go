@@ -0,0 +1,145 @@
+package platforms_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sukryu/IV-auth-services/internal/adapters/platforms"
+	"github.com/sukryu/IV-auth-services/internal/config"
+	"github.com/sukryu/IV-auth-services/internal/core/domain"
+	"github.com/sukryu/IV-auth-services/pkg/logger"
+)
+
+// newPlatformServer serves a token endpoint and the given profile endpoints for one platform.
+func newPlatformServer(t *testing.T, profiles map[string]interface{}) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /token", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		if r.PostForm.Get("code") != "good-code" || r.PostForm.Get("client_secret") != "secret" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token":  "platform-access",
+			"refresh_token": "platform-refresh",
+			"expires_in":    3600,
+			"token_type":    "bearer",
+		})
+	})
+	for path, body := range profiles {
+		body := body
+		mux.HandleFunc("GET "+path, func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "Bearer platform-access" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(body)
+		})
+	}
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestTwitchClient(t *testing.T) {
+	log, err := logger.NewLogger("development")
+	require.NoError(t, err)
+
+	server := newPlatformServer(t, map[string]interface{}{
+		"/helix/users": map[string]interface{}{
+			"data": []map[string]string{{"id": "1234", "login": "streamer", "email": "streamer@example.com"}},
+		},
+	})
+
+	cfg := &config.Config{}
+	cfg.Platforms.Twitch.ClientID = "twitch-client"
+	cfg.Platforms.Twitch.ClientSecret = "secret"
+	cfg.Platforms.Twitch.RedirectURL = "https://app.example.com/callback"
+	cfg.Platforms.Twitch.AuthURL = server.URL + "/authorize"
+	cfg.Platforms.Twitch.TokenURL = server.URL + "/token"
+	cfg.Platforms.Twitch.APIURL = server.URL + "/helix"
+
+	client, err := platforms.NewTwitchClient(cfg, &http.Client{Timeout: time.Second}, log)
+	require.NoError(t, err)
+	assert.Equal(t, domain.PlatformTwitch, client.Platform())
+
+	authURL, err := url.Parse(client.AuthorizationURL("xyz"))
+	require.NoError(t, err)
+	assert.Equal(t, "twitch-client", authURL.Query().Get("client_id"))
+	assert.Equal(t, "xyz", authURL.Query().Get("state"))
+	assert.Equal(t, "user:read:email", authURL.Query().Get("scope"))
+
+	identity, err := client.ExchangeCode(context.Background(), "good-code")
+	require.NoError(t, err)
+	assert.Equal(t, "1234", identity.PlatformUserID)
+	assert.Equal(t, "streamer", identity.Username)
+	assert.Equal(t, "streamer@example.com", identity.Email)
+	assert.True(t, identity.EmailVerified)
+	assert.Equal(t, "platform-refresh", identity.RefreshToken)
+	require.NotNil(t, identity.ExpiresAt)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), *identity.ExpiresAt, 5*time.Second)
+
+	_, err = client.ExchangeCode(context.Background(), "bad-code")
+	assert.Error(t, err)
+}
+
+func TestYouTubeClient(t *testing.T) {
+	log, err := logger.NewLogger("development")
+	require.NoError(t, err)
+
+	server := newPlatformServer(t, map[string]interface{}{
+		"/userinfo": map[string]interface{}{"sub": "g-1", "email": "creator@example.com", "email_verified": true},
+		"/youtube/v3/channels": map[string]interface{}{
+			"items": []map[string]interface{}{{
+				"id":      "UC123",
+				"snippet": map[string]string{"title": "My Channel", "customUrl": "@creator"},
+			}},
+		},
+	})
+
+	cfg := &config.Config{}
+	cfg.Platforms.YouTube.ClientID = "google-client"
+	cfg.Platforms.YouTube.ClientSecret = "secret"
+	cfg.Platforms.YouTube.RedirectURL = "https://app.example.com/callback"
+	cfg.Platforms.YouTube.AuthURL = server.URL + "/authorize"
+	cfg.Platforms.YouTube.TokenURL = server.URL + "/token"
+	cfg.Platforms.YouTube.UserInfoURL = server.URL + "/userinfo"
+	cfg.Platforms.YouTube.APIURL = server.URL + "/youtube/v3"
+
+	client, err := platforms.NewYouTubeClient(cfg, &http.Client{Timeout: time.Second}, log)
+	require.NoError(t, err)
+	assert.Equal(t, domain.PlatformYouTube, client.Platform())
+
+	authURL, err := url.Parse(client.AuthorizationURL("xyz"))
+	require.NoError(t, err)
+	assert.Equal(t, "offline", authURL.Query().Get("access_type"))
+
+	identity, err := client.ExchangeCode(context.Background(), "good-code")
+	require.NoError(t, err)
+	assert.Equal(t, "UC123", identity.PlatformUserID)
+	assert.Equal(t, "creator", identity.Username)
+	assert.Equal(t, "creator@example.com", identity.Email)
+	assert.True(t, identity.EmailVerified)
+}
+
+func TestNewOAuthClients(t *testing.T) {
+	log, err := logger.NewLogger("development")
+	require.NoError(t, err)
+
+	cfg := &config.Config{}
+	clients, err := platforms.NewOAuthClients(cfg, log)
+	require.NoError(t, err)
+	assert.Empty(t, clients)
+
+	// 클라이언트 ID만 있고 시크릿이 없으면 설정 오류
+	cfg.Platforms.Twitch.ClientID = "twitch-client"
+	_, err = platforms.NewOAuthClients(cfg, log)
+	assert.Error(t, err)
+}
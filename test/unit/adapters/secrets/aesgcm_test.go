@@ -0,0 +1,61 @@
+package secrets_test
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sukryu/IV-auth-services/internal/adapters/secrets"
+)
+
+func newTestKey(t *testing.T) string {
+	t.Helper()
+	key := make([]byte, 32)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+	return base64.StdEncoding.EncodeToString(key)
+}
+
+func TestAESGCMCipherRoundTrip(t *testing.T) {
+	cipher, err := secrets.NewAESGCMCipher(newTestKey(t))
+	require.NoError(t, err)
+
+	first, err := cipher.Encrypt([]byte("totp-seed"))
+	require.NoError(t, err)
+	second, err := cipher.Encrypt([]byte("totp-seed"))
+	require.NoError(t, err)
+	// 매번 새 nonce를 사용하므로 같은 평문도 다른 암호문이 됨
+	assert.NotEqual(t, first, second)
+
+	plaintext, err := cipher.Decrypt(first)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("totp-seed"), plaintext)
+}
+
+func TestAESGCMCipherRejectsTamperingAndWrongKey(t *testing.T) {
+	cipher, err := secrets.NewAESGCMCipher(newTestKey(t))
+	require.NoError(t, err)
+	other, err := secrets.NewAESGCMCipher(newTestKey(t))
+	require.NoError(t, err)
+
+	ciphertext, err := cipher.Encrypt([]byte("totp-seed"))
+	require.NoError(t, err)
+	raw, _ := base64.StdEncoding.DecodeString(ciphertext)
+	raw[len(raw)-1] ^= 0x01
+
+	_, err = cipher.Decrypt(base64.StdEncoding.EncodeToString(raw))
+	assert.Error(t, err)
+	_, err = other.Decrypt(ciphertext)
+	assert.Error(t, err)
+	_, err = cipher.Decrypt("AAAA")
+	assert.Error(t, err)
+}
+
+func TestNewAESGCMCipherRejectsInvalidKey(t *testing.T) {
+	_, err := secrets.NewAESGCMCipher(base64.StdEncoding.EncodeToString([]byte("short")))
+	assert.Error(t, err)
+	_, err = secrets.NewAESGCMCipher("not base64!")
+	assert.Error(t, err)
+}
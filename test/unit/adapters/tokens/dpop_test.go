@@ -0,0 +1,156 @@
+package tokens_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sukryu/IV-auth-services/internal/adapters/tokens"
+	"github.com/sukryu/IV-auth-services/internal/config"
+	"github.com/sukryu/IV-auth-services/pkg/logger"
+)
+
+const dpopURI = "https://auth.immersiverse.io/v1/token"
+
+// ecJWK returns the public JWK of key and its expected RFC 7638 thumbprint.
+func ecJWK(key *ecdsa.PrivateKey) (map[string]interface{}, string) {
+	x := base64.RawURLEncoding.EncodeToString(key.X.FillBytes(make([]byte, 32)))
+	y := base64.RawURLEncoding.EncodeToString(key.Y.FillBytes(make([]byte, 32)))
+	sum := sha256.Sum256([]byte(`{"crv":"P-256","kty":"EC","x":"` + x + `","y":"` + y + `"}`))
+	return map[string]interface{}{"kty": "EC", "crv": "P-256", "x": x, "y": y}, base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func signProof(t *testing.T, key *ecdsa.PrivateKey, jwk map[string]interface{}, claims jwt.MapClaims) string {
+	t.Helper()
+	token := jwt.NewWithClaims(jwt.SigningMethodES256, claims)
+	token.Header["typ"] = "dpop+jwt"
+	token.Header["jwk"] = jwk
+	proof, err := token.SignedString(key)
+	require.NoError(t, err)
+	return proof
+}
+
+func TestDPoPProofVerifier(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.DPoP.ProofMaxAge = time.Minute
+	cfg.JWT.ClockSkew = 5 * time.Second
+	log, err := logger.NewLogger("development")
+	require.NoError(t, err)
+	verifier, err := tokens.NewDPoPProofVerifier(cfg, log)
+	require.NoError(t, err)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	jwk, wantJKT := ecJWK(key)
+	accessToken := "access-token-value"
+	ath := sha256.Sum256([]byte(accessToken))
+	now := time.Now()
+
+	tests := []struct {
+		name        string
+		claims      jwt.MapClaims
+		method      string
+		uri         string
+		accessToken string
+		wantErr     bool
+	}{
+		{
+			name:   "Valid issuance proof",
+			claims: jwt.MapClaims{"jti": "proof-1", "htm": "POST", "htu": dpopURI, "iat": now.Unix()},
+			method: "POST", uri: dpopURI,
+		},
+		{
+			name:   "Query string is ignored",
+			claims: jwt.MapClaims{"jti": "proof-2", "htm": "POST", "htu": dpopURI, "iat": now.Unix()},
+			method: "POST", uri: dpopURI + "?grant_type=refresh_token",
+		},
+		{
+			name:        "Valid resource proof with ath",
+			claims:      jwt.MapClaims{"jti": "proof-3", "htm": "GET", "htu": dpopURI, "iat": now.Unix(), "ath": base64.RawURLEncoding.EncodeToString(ath[:])},
+			method:      "GET",
+			uri:         dpopURI,
+			accessToken: accessToken,
+		},
+		{
+			name:        "Missing ath",
+			claims:      jwt.MapClaims{"jti": "proof-4", "htm": "GET", "htu": dpopURI, "iat": now.Unix()},
+			method:      "GET",
+			uri:         dpopURI,
+			accessToken: accessToken,
+			wantErr:     true,
+		},
+		{
+			name:   "Method mismatch",
+			claims: jwt.MapClaims{"jti": "proof-5", "htm": "GET", "htu": dpopURI, "iat": now.Unix()},
+			method: "POST", uri: dpopURI, wantErr: true,
+		},
+		{
+			name:   "URI mismatch",
+			claims: jwt.MapClaims{"jti": "proof-6", "htm": "POST", "htu": "https://evil.example/v1/token", "iat": now.Unix()},
+			method: "POST", uri: dpopURI, wantErr: true,
+		},
+		{
+			name:   "Stale proof",
+			claims: jwt.MapClaims{"jti": "proof-7", "htm": "POST", "htu": dpopURI, "iat": now.Add(-10 * time.Minute).Unix()},
+			method: "POST", uri: dpopURI, wantErr: true,
+		},
+		{
+			name:   "Missing jti",
+			claims: jwt.MapClaims{"htm": "POST", "htu": dpopURI, "iat": now.Unix()},
+			method: "POST", uri: dpopURI, wantErr: true,
+		},
+		{
+			name:   "Longest storable jti",
+			claims: jwt.MapClaims{"jti": strings.Repeat("a", 255), "htm": "POST", "htu": dpopURI, "iat": now.Unix()},
+			method: "POST", uri: dpopURI,
+		},
+		{
+			name:   "Overlong jti",
+			claims: jwt.MapClaims{"jti": strings.Repeat("a", 256), "htm": "POST", "htu": dpopURI, "iat": now.Unix()},
+			method: "POST", uri: dpopURI, wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			proof, err := verifier.Verify(signProof(t, key, jwk, tt.claims), tt.method, tt.uri, tt.accessToken)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, proof)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, wantJKT, proof.Thumbprint())
+				assert.Equal(t, tt.claims["jti"], proof.JTI())
+				assert.True(t, proof.ExpiresAt().After(now))
+			}
+		})
+	}
+}
+
+func TestDPoPProofVerifierRejectsForeignSignature(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.DPoP.ProofMaxAge = time.Minute
+	log, err := logger.NewLogger("development")
+	require.NoError(t, err)
+	verifier, err := tokens.NewDPoPProofVerifier(cfg, log)
+	require.NoError(t, err)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	other, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	otherJWK, _ := ecJWK(other)
+
+	// 헤더의 jwk와 다른 키로 서명된 증명
+	proof := signProof(t, key, otherJWK, jwt.MapClaims{"jti": "proof-1", "htm": "POST", "htu": dpopURI, "iat": time.Now().Unix()})
+	_, err = verifier.Verify(proof, "POST", dpopURI, "")
+	assert.Error(t, err)
+}
@@ -0,0 +1,69 @@
+package tokens_test
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sukryu/IV-auth-services/internal/core/domain"
+)
+
+func TestJWTTokenGeneratorIDToken(t *testing.T) {
+	gen, key := newTestGenerator(t, 10*time.Second)
+
+	authTime := time.Now().Add(-time.Minute).Truncate(time.Second)
+	idToken, err := gen.GenerateIDToken(domain.IDTokenClaims{
+		Subject:     "user-123",
+		Audience:    "client-123",
+		Nonce:       "nonce-123",
+		AuthTime:    authTime,
+		AMR:         []string{domain.AMRPassword},
+		ACR:         domain.ACRSingleFactor,
+		ExpiresAt:   time.Now().Add(time.Minute),
+		AccessToken: "access-token",
+	})
+	require.NoError(t, err)
+
+	claims := jwt.MapClaims{}
+	parsed, err := jwt.ParseWithClaims(idToken, claims, func(*jwt.Token) (interface{}, error) {
+		return &key.PublicKey, nil
+	}, jwt.WithIssuer("https://auth.example.com"), jwt.WithAudience("client-123"))
+	require.NoError(t, err)
+	assert.Equal(t, "user-123", claims["sub"])
+	assert.Equal(t, "nonce-123", claims["nonce"])
+	assert.Equal(t, float64(authTime.Unix()), claims["auth_time"])
+	assert.Equal(t, []interface{}{domain.AMRPassword}, claims["amr"])
+	assert.Equal(t, domain.ACRSingleFactor, claims["acr"])
+
+	sum := sha256.Sum256([]byte("access-token"))
+	assert.Equal(t, base64.RawURLEncoding.EncodeToString(sum[:16]), claims["at_hash"])
+
+	// kid 헤더는 JWKS에 게시된 키를 가리켜야 함
+	var set struct {
+		Keys []map[string]string `json:"keys"`
+	}
+	keys, err := gen.JWKS()
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(keys, &set))
+	require.Len(t, set.Keys, 1)
+	assert.Equal(t, set.Keys[0]["kid"], parsed.Header["kid"])
+	assert.Equal(t, "RSA", set.Keys[0]["kty"])
+	assert.Equal(t, "RS256", set.Keys[0]["alg"])
+
+	n, err := base64.RawURLEncoding.DecodeString(set.Keys[0]["n"])
+	require.NoError(t, err)
+	assert.Equal(t, 0, new(big.Int).SetBytes(n).Cmp(key.PublicKey.N))
+}
+
+func TestJWTTokenGeneratorIDTokenRequiresAudience(t *testing.T) {
+	gen, _ := newTestGenerator(t, 10*time.Second)
+
+	_, err := gen.GenerateIDToken(domain.IDTokenClaims{Subject: "user-123", ExpiresAt: time.Now().Add(time.Minute)})
+	assert.Error(t, err)
+}
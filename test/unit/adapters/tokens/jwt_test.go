@@ -0,0 +1,169 @@
+package tokens_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sukryu/IV-auth-services/internal/adapters/tokens"
+	"github.com/sukryu/IV-auth-services/internal/config"
+	"github.com/sukryu/IV-auth-services/internal/core/domain"
+	"github.com/sukryu/IV-auth-services/pkg/logger"
+)
+
+// newTestGenerator writes a fresh RSA private key to a temp dir and builds a generator signing with it.
+func newTestGenerator(t *testing.T, clockSkew time.Duration) (*tokens.JWTTokenGenerator, *rsa.PrivateKey) {
+	t.Helper()
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	privatePath := filepath.Join(t.TempDir(), "private.pem")
+	require.NoError(t, os.WriteFile(privatePath, pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(privateKey)}), 0o600))
+	signer, err := tokens.NewLocalSigner(privatePath)
+	require.NoError(t, err)
+
+	cfg := &config.Config{}
+	cfg.JWT.ClockSkew = clockSkew
+	cfg.OIDC.Issuer = "https://auth.example.com"
+
+	log, err := logger.NewLogger("development")
+	require.NoError(t, err)
+	gen, err := tokens.NewJWTTokenGenerator(cfg, log, signer)
+	require.NoError(t, err)
+	return gen, privateKey
+}
+
+func signClaims(t *testing.T, key *rsa.PrivateKey, claims jwt.MapClaims) string {
+	t.Helper()
+	tokenStr, err := jwt.NewWithClaims(jwt.SigningMethodRS256, claims).SignedString(key)
+	require.NoError(t, err)
+	return tokenStr
+}
+
+func TestJWTTokenGeneratorRoundTrip(t *testing.T) {
+	gen, _ := newTestGenerator(t, 10*time.Second)
+
+	tokenStr, err := gen.GenerateAccessToken("user-123", time.Now().Add(time.Minute), domain.TokenClaims{TokenID: "jti-123", ConfirmationKeyThumbprint: "jkt-123"})
+	require.NoError(t, err)
+
+	userID, err := gen.ValidateToken(tokenStr)
+	assert.NoError(t, err)
+	assert.Equal(t, "user-123", userID)
+
+	claims, err := gen.ParseToken(tokenStr)
+	require.NoError(t, err)
+	assert.Equal(t, domain.TokenTypeAccess, claims.Type)
+	assert.Equal(t, "jti-123", claims.TokenID)
+	assert.Equal(t, "jkt-123", claims.ConfirmationKeyThumbprint)
+	assert.True(t, claims.IsSenderConstrained())
+
+	raw := jwt.MapClaims{}
+	_, _, err = jwt.NewParser().ParseUnverified(tokenStr, raw)
+	require.NoError(t, err)
+	assert.Contains(t, raw, "nbf")
+}
+
+func TestJWTTokenGeneratorDelegationClaims(t *testing.T) {
+	gen, _ := newTestGenerator(t, 10*time.Second)
+
+	actor := &domain.ActorClaim{Subject: "streaming-service", Actor: &domain.ActorClaim{Subject: "gateway"}}
+	tokenStr, err := gen.GenerateAccessToken("user-123", time.Now().Add(time.Minute), domain.TokenClaims{
+		Audience: []string{"chat-service"},
+		Scope:    []string{"chat:read", "chat:write"},
+		Actor:    actor,
+	})
+	require.NoError(t, err)
+
+	claims, err := gen.ParseToken(tokenStr)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"chat-service"}, claims.Audience)
+	assert.Equal(t, []string{"chat:read", "chat:write"}, claims.Scope)
+	assert.Equal(t, actor, claims.Actor)
+	assert.True(t, claims.HasScope("chat:write"))
+	assert.True(t, claims.HasAudience("chat-service"))
+}
+
+func TestJWTTokenGeneratorAuthenticationClaims(t *testing.T) {
+	gen, _ := newTestGenerator(t, 10*time.Second)
+
+	authTime := time.Now().Add(-time.Hour).Truncate(time.Second)
+	tokenStr, err := gen.GenerateRefreshToken("user-123", time.Now().Add(time.Minute), domain.TokenClaims{
+		AuthTime:  authTime,
+		AMR:       []string{domain.AMRPassword},
+		ACR:       domain.ACRSingleFactor,
+		SessionID: "session-123",
+	})
+	require.NoError(t, err)
+
+	claims, err := gen.ParseToken(tokenStr)
+	require.NoError(t, err)
+	assert.Equal(t, domain.TokenTypeRefresh, claims.Type)
+	assert.True(t, authTime.Equal(claims.AuthTime))
+	assert.Equal(t, []string{domain.AMRPassword}, claims.AMR)
+	assert.Equal(t, domain.ACRSingleFactor, claims.ACR)
+	assert.Equal(t, "session-123", claims.SessionID)
+}
+
+func TestJWTTokenGeneratorClockSkew(t *testing.T) {
+	gen, key := newTestGenerator(t, 10*time.Second)
+	now := time.Now()
+
+	tests := []struct {
+		name    string
+		claims  jwt.MapClaims
+		wantErr bool
+	}{
+		{
+			name:    "Issued slightly in the future",
+			claims:  jwt.MapClaims{"sub": "user-123", "iat": now.Add(5 * time.Second).Unix(), "nbf": now.Add(5 * time.Second).Unix(), "exp": now.Add(time.Minute).Unix()},
+			wantErr: false,
+		},
+		{
+			name:    "Expired within skew",
+			claims:  jwt.MapClaims{"sub": "user-123", "iat": now.Add(-time.Minute).Unix(), "exp": now.Add(-5 * time.Second).Unix()},
+			wantErr: false,
+		},
+		{
+			name:    "Issued implausibly in the future",
+			claims:  jwt.MapClaims{"sub": "user-123", "iat": now.Add(time.Hour).Unix(), "exp": now.Add(2 * time.Hour).Unix()},
+			wantErr: true,
+		},
+		{
+			name:    "Not yet valid",
+			claims:  jwt.MapClaims{"sub": "user-123", "iat": now.Unix(), "nbf": now.Add(time.Minute).Unix(), "exp": now.Add(time.Hour).Unix()},
+			wantErr: true,
+		},
+		{
+			name:    "Expired beyond skew",
+			claims:  jwt.MapClaims{"sub": "user-123", "iat": now.Add(-time.Hour).Unix(), "exp": now.Add(-time.Minute).Unix()},
+			wantErr: true,
+		},
+		{
+			name:    "Missing expiry",
+			claims:  jwt.MapClaims{"sub": "user-123", "iat": now.Unix()},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userID, err := gen.ValidateToken(signClaims(t, key, tt.claims))
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Empty(t, userID)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "user-123", userID)
+			}
+		})
+	}
+}
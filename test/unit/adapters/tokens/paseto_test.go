@@ -0,0 +1,164 @@
+package tokens_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sukryu/IV-auth-services/internal/adapters/tokens"
+	"github.com/sukryu/IV-auth-services/internal/config"
+	"github.com/sukryu/IV-auth-services/internal/core/domain"
+	"github.com/sukryu/IV-auth-services/pkg/logger"
+)
+
+// newTestPASETOGenerator writes a fresh Ed25519 key pair to a temp dir and builds a generator from it.
+func newTestPASETOGenerator(t *testing.T) *tokens.PASETOTokenGenerator {
+	t.Helper()
+
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	privateDER, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	require.NoError(t, err)
+	publicDER, err := x509.MarshalPKIXPublicKey(publicKey)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	cfg := &config.Config{}
+	cfg.PASETO.PrivateKeyPath = filepath.Join(dir, "paseto_private.pem")
+	cfg.PASETO.PublicKeyPath = filepath.Join(dir, "paseto_public.pem")
+	cfg.JWT.ClockSkew = 10 * time.Second
+	require.NoError(t, os.WriteFile(cfg.PASETO.PrivateKeyPath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateDER}), 0o600))
+	require.NoError(t, os.WriteFile(cfg.PASETO.PublicKeyPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER}), 0o600))
+
+	log, err := logger.NewLogger("development")
+	require.NoError(t, err)
+	gen, err := tokens.NewPASETOTokenGenerator(cfg, log)
+	require.NoError(t, err)
+	return gen
+}
+
+func TestPASETOTokenGeneratorRoundTrip(t *testing.T) {
+	gen := newTestPASETOGenerator(t)
+
+	authTime := time.Now().Add(-time.Hour).Truncate(time.Second)
+	tokenStr, err := gen.GenerateAccessToken("user-123", time.Now().Add(time.Minute), domain.TokenClaims{
+		TokenID:                   "jti-123",
+		ConfirmationKeyThumbprint: "jkt-123",
+		Audience:                  []string{"chat-service"},
+		Scope:                     []string{"chat:read", "chat:write"},
+		ClientID:                  "client-123",
+		Actor:                     &domain.ActorClaim{Subject: "streaming-service"},
+		AuthTime:                  authTime,
+		AMR:                       []string{domain.AMRPassword},
+		ACR:                       domain.ACRSingleFactor,
+		SessionID:                 "session-123",
+	})
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(tokenStr, "v4.public."))
+
+	claims, err := gen.ParseToken(tokenStr)
+	require.NoError(t, err)
+	assert.Equal(t, "user-123", claims.Subject)
+	assert.Equal(t, domain.TokenTypeAccess, claims.Type)
+	assert.Equal(t, "jti-123", claims.TokenID)
+	assert.Equal(t, "jkt-123", claims.ConfirmationKeyThumbprint)
+	assert.Equal(t, []string{"chat-service"}, claims.Audience)
+	assert.Equal(t, []string{"chat:read", "chat:write"}, claims.Scope)
+	assert.Equal(t, "client-123", claims.ClientID)
+	assert.Equal(t, "streaming-service", claims.Actor.Subject)
+	assert.True(t, authTime.Equal(claims.AuthTime))
+	assert.Equal(t, []string{domain.AMRPassword}, claims.AMR)
+	assert.Equal(t, domain.ACRSingleFactor, claims.ACR)
+	assert.Equal(t, "session-123", claims.SessionID)
+
+	userID, err := gen.ValidateToken(tokenStr)
+	assert.NoError(t, err)
+	assert.Equal(t, "user-123", userID)
+}
+
+func TestPASETOTokenGeneratorRejectsInvalidTokens(t *testing.T) {
+	gen := newTestPASETOGenerator(t)
+	other := newTestPASETOGenerator(t)
+
+	valid, err := gen.GenerateAccessToken("user-123", time.Now().Add(time.Minute), domain.TokenClaims{})
+	require.NoError(t, err)
+	foreign, err := other.GenerateAccessToken("user-123", time.Now().Add(time.Minute), domain.TokenClaims{})
+	require.NoError(t, err)
+	// 허용 오차(10초)를 넘겨 만료된 토큰
+	expired, err := gen.GenerateAccessToken("user-123", time.Now().Add(-time.Minute), domain.TokenClaims{})
+	require.NoError(t, err)
+
+	body, footer, _ := strings.Cut(strings.TrimPrefix(valid, "v4.public."), ".")
+	tampered := "v4.public." + body[:10] + flipChar(body[10]) + body[11:] + "." + footer
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"Signed with another key", foreign},
+		{"Expired", expired},
+		{"Tampered payload", tampered},
+		{"Missing footer", "v4.public." + body},
+		{"Wrong version", "v3.public." + body + "." + footer},
+		{"Empty", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := gen.ParseToken(tt.token)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestPASETOTokenGeneratorRequiresLocalSigner(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Signer.Type = tokens.SignerTypeTransit
+	log, err := logger.NewLogger("development")
+	require.NoError(t, err)
+
+	// 원격 서명기를 설정했는데 PASETO 개인 키를 디스크에서 읽지 않도록 거부
+	_, err = tokens.NewPASETOTokenGenerator(cfg, log)
+	assert.ErrorContains(t, err, "signer type")
+}
+
+func TestDualFormatTokenGenerator(t *testing.T) {
+	pasetoGen := newTestPASETOGenerator(t)
+	jwtGen, _ := newTestGenerator(t, 10*time.Second)
+	log, err := logger.NewLogger("development")
+	require.NoError(t, err)
+	gen := tokens.NewDualFormatTokenGenerator(pasetoGen, jwtGen, log)
+
+	// 새 토큰은 PASETO로 발급
+	issued, err := gen.GenerateAccessToken("user-123", time.Now().Add(time.Minute), domain.TokenClaims{})
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(issued, "v4.public."))
+	claims, err := gen.ParseToken(issued)
+	require.NoError(t, err)
+	assert.Equal(t, "user-123", claims.Subject)
+
+	// 전환 이전에 발급된 JWT도 검증
+	legacy, err := jwtGen.GenerateRefreshToken("user-456", time.Now().Add(time.Minute), domain.TokenClaims{SessionID: "session-123"})
+	require.NoError(t, err)
+	claims, err = gen.ParseToken(legacy)
+	require.NoError(t, err)
+	assert.Equal(t, "user-456", claims.Subject)
+	assert.Equal(t, domain.TokenTypeRefresh, claims.Type)
+	assert.Equal(t, "session-123", claims.SessionID)
+}
+
+// flipChar returns a different base64url character so the decoded bytes change.
+func flipChar(c byte) string {
+	if c == 'A' {
+		return "B"
+	}
+	return "A"
+}
@@ -0,0 +1,160 @@
+package tokens_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sukryu/IV-auth-services/internal/adapters/tokens"
+	"github.com/sukryu/IV-auth-services/internal/config"
+	"github.com/sukryu/IV-auth-services/internal/core/domain"
+	"github.com/sukryu/IV-auth-services/pkg/logger"
+)
+
+// fakeTransit is a local stand-in for the Vault transit engine's key and sign endpoints.
+type fakeTransit struct {
+	key       *rsa.PrivateKey
+	signCalls atomic.Int32
+}
+
+func (f *fakeTransit) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("X-Vault-Token") != "test-token" {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/v1/transit/keys/jwt":
+		der, _ := x509.MarshalPKIXPublicKey(&f.key.PublicKey)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": map[string]interface{}{
+				"latest_version": 2,
+				"keys": map[string]interface{}{
+					"2": map[string]string{"public_key": string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))},
+				},
+			},
+		})
+	case r.Method == http.MethodPost && r.URL.Path == "/v1/transit/sign/jwt/sha2-256":
+		f.signCalls.Add(1)
+		var req struct {
+			BatchInput []struct {
+				Input string `json:"input"`
+			} `json:"batch_input"`
+			KeyVersion int  `json:"key_version"`
+			Prehashed  bool `json:"prehashed"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || !req.Prehashed || req.KeyVersion != 2 {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		results := make([]map[string]string, 0, len(req.BatchInput))
+		for _, in := range req.BatchInput {
+			digest, _ := base64.StdEncoding.DecodeString(in.Input)
+			sig, err := rsa.SignPKCS1v15(rand.Reader, f.key, crypto.SHA256, digest)
+			if err != nil {
+				results = append(results, map[string]string{"error": err.Error()})
+				continue
+			}
+			results = append(results, map[string]string{"signature": "vault:v2:" + base64.StdEncoding.EncodeToString(sig)})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{"batch_results": results}})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// newTestTransitSigner starts a fake transit server and builds a signer pointing at it.
+func newTestTransitSigner(t *testing.T, batchWindow time.Duration) (*tokens.TransitSigner, *fakeTransit) {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	fake := &fakeTransit{key: key}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	cfg := &config.Config{}
+	cfg.Signer.Transit.Address = server.URL
+	cfg.Signer.Transit.Token = "test-token"
+	cfg.Signer.Transit.Mount = "transit"
+	cfg.Signer.Transit.KeyName = "jwt"
+	cfg.Signer.Transit.BatchWindow = batchWindow
+	cfg.Signer.Transit.MaxBatchSize = 64
+	cfg.Signer.Transit.Timeout = 2 * time.Second
+
+	log, err := logger.NewLogger("development")
+	require.NoError(t, err)
+	signer, err := tokens.NewTransitSigner(cfg, log)
+	require.NoError(t, err)
+	return signer, fake
+}
+
+func TestTransitSignerSignsWithRemoteKey(t *testing.T) {
+	signer, fake := newTestTransitSigner(t, 0)
+	assert.Equal(t, &fake.key.PublicKey, signer.Public())
+
+	sig, err := signer.Sign(context.Background(), []byte("header.payload"))
+	require.NoError(t, err)
+	digest := sha256.Sum256([]byte("header.payload"))
+	assert.NoError(t, rsa.VerifyPKCS1v15(signer.Public(), crypto.SHA256, digest[:], sig))
+}
+
+func TestTransitSignerBatchesConcurrentRequests(t *testing.T) {
+	signer, fake := newTestTransitSigner(t, 50*time.Millisecond)
+
+	const n = 20
+	var wg sync.WaitGroup
+	errs := make([]error, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			message := []byte(fmt.Sprintf("message-%d", i))
+			sig, err := signer.Sign(context.Background(), message)
+			if err == nil {
+				digest := sha256.Sum256(message)
+				err = rsa.VerifyPKCS1v15(signer.Public(), crypto.SHA256, digest[:], sig)
+			}
+			errs[i] = err
+		}(i)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		assert.NoError(t, err)
+	}
+	// 모든 요청이 배치 대기 시간 안에 도착하므로 원격 호출은 요청 수보다 훨씬 적어야 함
+	assert.Less(t, int(fake.signCalls.Load()), n)
+}
+
+func TestJWTTokenGeneratorWithTransitSigner(t *testing.T) {
+	signer, _ := newTestTransitSigner(t, 0)
+
+	cfg := &config.Config{}
+	cfg.JWT.ClockSkew = 10 * time.Second
+	log, err := logger.NewLogger("development")
+	require.NoError(t, err)
+	gen, err := tokens.NewJWTTokenGenerator(cfg, log, signer)
+	require.NoError(t, err)
+
+	tokenStr, err := gen.GenerateAccessToken("user-123", time.Now().Add(time.Minute), domain.TokenClaims{})
+	require.NoError(t, err)
+	claims, err := gen.ParseToken(tokenStr)
+	require.NoError(t, err)
+	assert.Equal(t, "user-123", claims.Subject)
+}
@@ -0,0 +1,211 @@
+package webauthn_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sukryu/IV-auth-services/internal/adapters/webauthn"
+	"github.com/sukryu/IV-auth-services/internal/config"
+	"github.com/sukryu/IV-auth-services/internal/core/domain"
+	"github.com/sukryu/IV-auth-services/pkg/logger"
+)
+
+const (
+	testRPID   = "immersiverse.io"
+	testOrigin = "https://immersiverse.io"
+)
+
+var b64 = base64.RawURLEncoding
+
+// cborHead encodes a CBOR initial byte and argument.
+func cborHead(major byte, n int) []byte {
+	switch {
+	case n < 24:
+		return []byte{major<<5 | byte(n)}
+	case n < 256:
+		return []byte{major<<5 | 24, byte(n)}
+	default:
+		return []byte{major<<5 | 25, byte(n >> 8), byte(n)}
+	}
+}
+
+func cborInt(n int) []byte {
+	if n < 0 {
+		return cborHead(1, -1-n)
+	}
+	return cborHead(0, n)
+}
+
+func cborBytes(b []byte) []byte { return append(cborHead(2, len(b)), b...) }
+func cborText(s string) []byte  { return append(cborHead(3, len(s)), s...) }
+
+// cborMap encodes alternating, already encoded keys and values.
+func cborMap(items ...[]byte) []byte {
+	out := cborHead(5, len(items)/2)
+	for _, item := range items {
+		out = append(out, item...)
+	}
+	return out
+}
+
+// coseES256 encodes the public key of key as a COSE_Key.
+func coseES256(key *ecdsa.PrivateKey) []byte {
+	return cborMap(
+		cborInt(1), cborInt(2),
+		cborInt(3), cborInt(-7),
+		cborInt(-1), cborInt(1),
+		cborInt(-2), cborBytes(key.X.FillBytes(make([]byte, 32))),
+		cborInt(-3), cborBytes(key.Y.FillBytes(make([]byte, 32))),
+	)
+}
+
+// authData builds authenticator data, with attested credential data when credentialID is set.
+func authData(rpID string, flags byte, signCount uint32, credentialID, publicKey []byte) []byte {
+	hash := sha256.Sum256([]byte(rpID))
+	out := append(hash[:], flags)
+	out = binary.BigEndian.AppendUint32(out, signCount)
+	if credentialID != nil {
+		out = append(out, make([]byte, 16)...) // aaguid
+		out = binary.BigEndian.AppendUint16(out, uint16(len(credentialID)))
+		out = append(out, credentialID...)
+		out = append(out, publicKey...)
+	}
+	return out
+}
+
+func clientDataJSON(typ, challenge, origin string) []byte {
+	data, _ := json.Marshal(map[string]interface{}{"type": typ, "challenge": challenge, "origin": origin})
+	return data
+}
+
+func registrationJSON(t *testing.T, credentialID, clientData, authenticatorData []byte) []byte {
+	t.Helper()
+	attestation := cborMap(
+		cborText("fmt"), cborText("none"),
+		cborText("attStmt"), cborMap(),
+		cborText("authData"), cborBytes(authenticatorData),
+	)
+	body, err := json.Marshal(map[string]interface{}{
+		"id":    b64.EncodeToString(credentialID),
+		"rawId": b64.EncodeToString(credentialID),
+		"type":  "public-key",
+		"response": map[string]interface{}{
+			"clientDataJSON":    b64.EncodeToString(clientData),
+			"attestationObject": b64.EncodeToString(attestation),
+			"transports":        []string{"internal"},
+		},
+	})
+	require.NoError(t, err)
+	return body
+}
+
+func assertionJSON(t *testing.T, key *ecdsa.PrivateKey, credentialID []byte, userHandle string, clientData, authenticatorData []byte) []byte {
+	t.Helper()
+	hash := sha256.Sum256(clientData)
+	digest := sha256.Sum256(append(append([]byte(nil), authenticatorData...), hash[:]...))
+	signature, err := ecdsa.SignASN1(rand.Reader, key, digest[:])
+	require.NoError(t, err)
+	body, err := json.Marshal(map[string]interface{}{
+		"id":    b64.EncodeToString(credentialID),
+		"rawId": b64.EncodeToString(credentialID),
+		"type":  "public-key",
+		"response": map[string]interface{}{
+			"clientDataJSON":    b64.EncodeToString(clientData),
+			"authenticatorData": b64.EncodeToString(authenticatorData),
+			"signature":         b64.EncodeToString(signature),
+			"userHandle":        b64.EncodeToString([]byte(userHandle)),
+		},
+	})
+	require.NoError(t, err)
+	return body
+}
+
+func newTestVerifier(t *testing.T) domain.WebAuthnVerifier {
+	t.Helper()
+	cfg := &config.Config{}
+	cfg.WebAuthn.RPID = testRPID
+	cfg.WebAuthn.Origins = []string{testOrigin}
+	log, err := logger.NewLogger("development")
+	require.NoError(t, err)
+	verifier, err := webauthn.NewVerifier(cfg, log)
+	require.NoError(t, err)
+	return verifier
+}
+
+func TestVerifierRegistrationAndAssertion(t *testing.T) {
+	verifier := newTestVerifier(t)
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	credentialID := []byte("credential-0001")
+
+	registration, err := verifier.ParseRegistration(registrationJSON(t, credentialID,
+		clientDataJSON("webauthn.create", "reg-challenge", testOrigin),
+		authData(testRPID, 0x45, 0, credentialID, coseES256(key))))
+	require.NoError(t, err)
+	assert.Equal(t, "reg-challenge", registration.Challenge)
+	assert.Equal(t, credentialID, registration.CredentialID)
+	assert.Equal(t, coseES256(key), registration.PublicKey)
+	assert.Equal(t, []string{"internal"}, registration.Transports)
+	assert.True(t, registration.UserVerified)
+
+	assertion, err := verifier.ParseAssertion(assertionJSON(t, key, credentialID, "user-123",
+		clientDataJSON("webauthn.get", "login-challenge", testOrigin),
+		authData(testRPID, 0x05, 7, nil, nil)))
+	require.NoError(t, err)
+	assert.Equal(t, "login-challenge", assertion.Challenge)
+	assert.Equal(t, "user-123", assertion.UserHandle)
+	assert.Equal(t, uint32(7), assertion.SignCount)
+	assert.True(t, assertion.UserVerified)
+	assert.NoError(t, verifier.VerifySignature(registration.PublicKey, assertion))
+
+	// 다른 키로 등록된 자격 증명으로는 서명 검증 실패
+	other, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	assert.Error(t, verifier.VerifySignature(coseES256(other), assertion))
+
+	// 서명 대상 데이터 변조
+	assertion.SignedData[len(assertion.SignedData)-1] ^= 0x01
+	assert.Error(t, verifier.VerifySignature(registration.PublicKey, assertion))
+}
+
+func TestVerifierRejectsInvalidResponses(t *testing.T) {
+	verifier := newTestVerifier(t)
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	credentialID := []byte("credential-0001")
+	publicKey := coseES256(key)
+
+	registrations := []struct {
+		name       string
+		clientData []byte
+		authData   []byte
+	}{
+		{"Wrong ceremony type", clientDataJSON("webauthn.get", "c", testOrigin), authData(testRPID, 0x45, 0, credentialID, publicKey)},
+		{"Foreign origin", clientDataJSON("webauthn.create", "c", "https://evil.example"), authData(testRPID, 0x45, 0, credentialID, publicKey)},
+		{"Foreign relying party", clientDataJSON("webauthn.create", "c", testOrigin), authData("evil.example", 0x45, 0, credentialID, publicKey)},
+		{"User not present", clientDataJSON("webauthn.create", "c", testOrigin), authData(testRPID, 0x44, 0, credentialID, publicKey)},
+		{"No attested credential", clientDataJSON("webauthn.create", "c", testOrigin), authData(testRPID, 0x05, 0, nil, nil)},
+		{"Credential id mismatch", clientDataJSON("webauthn.create", "c", testOrigin), authData(testRPID, 0x45, 0, []byte("other-id"), publicKey)},
+		{"Truncated public key", clientDataJSON("webauthn.create", "c", testOrigin), authData(testRPID, 0x45, 0, credentialID, publicKey[:20])},
+	}
+	for _, tt := range registrations {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := verifier.ParseRegistration(registrationJSON(t, credentialID, tt.clientData, tt.authData))
+			assert.Error(t, err)
+		})
+	}
+
+	_, err = verifier.ParseRegistration([]byte(`{"type":"public-key"}`))
+	assert.Error(t, err)
+	_, err = verifier.ParseAssertion(assertionJSON(t, key, credentialID, "",
+		clientDataJSON("webauthn.create", "c", testOrigin), authData(testRPID, 0x05, 1, nil, nil)))
+	assert.Error(t, err)
+}
@@ -0,0 +1,111 @@
+package domain_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/sukryu/IV-auth-services/internal/core/domain"
+)
+
+func TestNewAPIKey(t *testing.T) {
+	future := time.Now().Add(24 * time.Hour)
+	past := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name      string
+		id        string
+		userID    string
+		keyName   string
+		scopes    []string
+		expiresAt *time.Time
+		wantErr   bool
+	}{
+		{"Valid key", "key-123", "user-123", "Chat bot", []string{"chat:read"}, nil, false},
+		{"Valid key with expiry", "key-123", "user-123", "OBS plugin", []string{"chat:read"}, &future, false},
+		{"Empty id", "", "user-123", "Chat bot", []string{"chat:read"}, nil, true},
+		{"Empty user", "key-123", "", "Chat bot", []string{"chat:read"}, nil, true},
+		{"Blank name", "key-123", "user-123", "   ", []string{"chat:read"}, nil, true},
+		{"Name too long", "key-123", "user-123", strings.Repeat("a", 101), []string{"chat:read"}, nil, true},
+		{"No scopes", "key-123", "user-123", "Chat bot", nil, nil, true},
+		{"Expiry in the past", "key-123", "user-123", "Chat bot", []string{"chat:read"}, &past, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, raw, err := domain.NewAPIKey(tt.id, tt.userID, tt.keyName, tt.scopes, tt.expiresAt)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, key)
+				assert.Empty(t, raw)
+			} else {
+				assert.NoError(t, err)
+				assert.True(t, domain.IsAPIKey(raw))
+				assert.True(t, strings.HasPrefix(raw, key.Prefix()))
+				assert.Len(t, key.Prefix(), 12)
+				assert.Equal(t, domain.HashAPIKey(raw), key.KeyHash())
+				assert.NotContains(t, key.KeyHash(), raw)
+				assert.True(t, key.IsActive())
+				assert.Nil(t, key.LastUsedAt())
+			}
+		})
+	}
+}
+
+func TestAPIKeyUniqueness(t *testing.T) {
+	_, raw1, err := domain.NewAPIKey("key-1", "user-123", "Chat bot", []string{"chat:read"}, nil)
+	assert.NoError(t, err)
+	_, raw2, err := domain.NewAPIKey("key-2", "user-123", "Chat bot", []string{"chat:read"}, nil)
+	assert.NoError(t, err)
+	assert.NotEqual(t, raw1, raw2)
+}
+
+func TestAPIKeyIsActive(t *testing.T) {
+	expired := time.Now().Add(-time.Minute)
+	key, err := domain.NewAPIKeyFromStorage("key-123", "user-123", "Chat bot", "ivk_Ab3dEf9h", "hash",
+		[]string{"chat:read"}, &expired, nil, "", time.Now().Add(-time.Hour), nil)
+	assert.NoError(t, err)
+	assert.False(t, key.IsActive())
+
+	key, _, err = domain.NewAPIKey("key-123", "user-123", "Chat bot", []string{"chat:read"}, nil)
+	assert.NoError(t, err)
+	assert.NoError(t, key.Revoke())
+	assert.False(t, key.IsActive())
+	assert.NotNil(t, key.RevokedAt())
+	assert.Error(t, key.Revoke())
+}
+
+func TestAPIKeyRecordUsage(t *testing.T) {
+	key, _, err := domain.NewAPIKey("key-123", "user-123", "Chat bot", []string{"chat:read"}, nil)
+	assert.NoError(t, err)
+
+	now := time.Now()
+	assert.True(t, key.RecordUsage("203.0.113.7", now))
+	assert.Equal(t, "203.0.113.7", key.LastUsedIP())
+	assert.Equal(t, now, *key.LastUsedAt())
+
+	// 같은 IP에서 짧은 간격으로 반복 사용하면 기록을 갱신하지 않음
+	assert.False(t, key.RecordUsage("203.0.113.7", now.Add(10*time.Second)))
+	assert.Equal(t, now, *key.LastUsedAt())
+
+	assert.True(t, key.RecordUsage("198.51.100.2", now.Add(20*time.Second)))
+	assert.Equal(t, "198.51.100.2", key.LastUsedIP())
+
+	assert.True(t, key.RecordUsage("198.51.100.2", now.Add(2*time.Minute)))
+}
+
+func TestAPIKeyClaims(t *testing.T) {
+	expiresAt := time.Now().Add(time.Hour)
+	key, _, err := domain.NewAPIKey("key-123", "user-123", "Chat bot", []string{"chat:read", "chat:write"}, &expiresAt)
+	assert.NoError(t, err)
+
+	claims := key.Claims()
+	assert.Equal(t, "user-123", claims.Subject)
+	assert.Equal(t, "key-123", claims.TokenID)
+	assert.Equal(t, domain.TokenTypeAPIKey, claims.Type)
+	assert.True(t, claims.HasScope("chat:write"))
+	assert.False(t, claims.HasScope("openid"))
+	assert.Equal(t, expiresAt, claims.ExpiresAt)
+	assert.False(t, claims.IsClientToken())
+}
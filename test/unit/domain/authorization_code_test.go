@@ -0,0 +1,85 @@
+package domain_test
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/sukryu/IV-auth-services/internal/core/domain"
+)
+
+func TestNewAuthorizationCode(t *testing.T) {
+	expiry := time.Now().Add(time.Minute)
+	tests := []struct {
+		name        string
+		codeHash    string
+		clientID    string
+		userID      string
+		redirectURI string
+		challenge   string
+		wantErr     bool
+	}{
+		{"Valid code", "hash", "client-123", "user-123", "https://app.example.com/callback", "challenge", false},
+		{"Empty hash", "", "client-123", "user-123", "https://app.example.com/callback", "challenge", true},
+		{"Empty client", "hash", "", "user-123", "https://app.example.com/callback", "challenge", true},
+		{"Empty user", "hash", "client-123", "", "https://app.example.com/callback", "challenge", true},
+		{"Empty redirect uri", "hash", "client-123", "user-123", "", "challenge", true},
+		{"Empty challenge", "hash", "client-123", "user-123", "https://app.example.com/callback", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, err := domain.NewAuthorizationCode(tt.codeHash, tt.clientID, tt.userID, tt.redirectURI, []string{"profile"}, tt.challenge, expiry)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, code)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.clientID, code.ClientID())
+				assert.Equal(t, tt.userID, code.UserID())
+				assert.Equal(t, tt.redirectURI, code.RedirectURI())
+				assert.Equal(t, []string{"profile"}, code.Scopes())
+				assert.False(t, code.IsExpired())
+			}
+		})
+	}
+}
+
+func TestAuthorizationCodeVerifyCodeVerifier(t *testing.T) {
+	// RFC 7636 Appendix B 예시 값
+	verifier := "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+	sum := sha256.Sum256([]byte(verifier))
+	challenge := base64.RawURLEncoding.EncodeToString(sum[:])
+	assert.Equal(t, "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM", challenge)
+
+	code, err := domain.NewAuthorizationCode("hash", "client-123", "user-123", "https://app.example.com/callback", nil, challenge, time.Now().Add(time.Minute))
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		verifier string
+		want     bool
+	}{
+		{"Matching verifier", verifier, true},
+		{"Different verifier", strings.Repeat("a", 43), false},
+		{"Too short", "short", false},
+		{"Too long", strings.Repeat("a", 129), false},
+		{"Invalid characters", verifier[:42] + "!", false},
+		{"Challenge as verifier", challenge, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, code.VerifyCodeVerifier(tt.verifier))
+		})
+	}
+}
+
+func TestAuthorizationCodeIsExpired(t *testing.T) {
+	code, err := domain.NewAuthorizationCode("hash", "client-123", "user-123", "https://app.example.com/callback", nil, "challenge", time.Now().Add(-time.Second))
+	assert.NoError(t, err)
+	assert.True(t, code.IsExpired())
+}
@@ -0,0 +1,48 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/sukryu/IV-auth-services/internal/core/domain"
+)
+
+func TestNewConsent(t *testing.T) {
+	tests := []struct {
+		name     string
+		userID   string
+		clientID string
+		wantErr  bool
+	}{
+		{"Valid consent", "user-123", "client-123", false},
+		{"Empty user", "", "client-123", true},
+		{"Empty client", "user-123", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			consent, err := domain.NewConsent(tt.userID, tt.clientID, []string{"profile"})
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, consent)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.userID, consent.UserID())
+				assert.Equal(t, tt.clientID, consent.ClientID())
+				assert.Equal(t, []string{"profile"}, consent.Scopes())
+			}
+		})
+	}
+}
+
+func TestConsentCoversAndAddScopes(t *testing.T) {
+	consent, err := domain.NewConsent("user-123", "client-123", []string{"profile"})
+	assert.NoError(t, err)
+
+	assert.True(t, consent.Covers([]string{"profile"}))
+	assert.False(t, consent.Covers([]string{"profile", "chat:read"}))
+
+	consent.AddScopes([]string{"chat:read", "profile"})
+	assert.Equal(t, []string{"profile", "chat:read"}, consent.Scopes())
+	assert.True(t, consent.Covers([]string{"profile", "chat:read"}))
+}
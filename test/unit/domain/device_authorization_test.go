@@ -0,0 +1,198 @@
+package domain_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sukryu/IV-auth-services/internal/core/domain"
+)
+
+func TestNewDeviceAuthorization(t *testing.T) {
+	expiresAt := time.Now().Add(10 * time.Minute)
+
+	d, err := domain.NewDeviceAuthorization("device-hash", "user-hash", "client-123", []string{"profile"}, 5*time.Second, expiresAt)
+	require.NoError(t, err)
+	assert.Equal(t, domain.DeviceAuthorizationPending, d.Status())
+	assert.Equal(t, 5*time.Second, d.Interval())
+	assert.Empty(t, d.UserID())
+	assert.False(t, d.IsExpired())
+
+	_, err = domain.NewDeviceAuthorization("", "user-hash", "client-123", nil, 5*time.Second, expiresAt)
+	assert.Error(t, err)
+	_, err = domain.NewDeviceAuthorization("device-hash", "user-hash", "", nil, 5*time.Second, expiresAt)
+	assert.Error(t, err)
+	_, err = domain.NewDeviceAuthorization("device-hash", "user-hash", "client-123", nil, 0, expiresAt)
+	assert.Error(t, err)
+}
+
+func TestDeviceAuthorizationDecision(t *testing.T) {
+	d, err := domain.NewDeviceAuthorization("device-hash", "user-hash", "client-123", nil, 5*time.Second, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+
+	authTime := time.Now()
+	require.NoError(t, d.Approve("user-123", authTime, []string{domain.AMRPassword}))
+	assert.Equal(t, domain.DeviceAuthorizationApproved, d.Status())
+	assert.Equal(t, "user-123", d.UserID())
+	assert.Equal(t, []string{domain.AMRPassword}, d.AMR())
+
+	// 결정은 한 번만 가능
+	assert.Error(t, d.Deny())
+	assert.Error(t, d.Approve("user-456", authTime, nil))
+
+	denied, err := domain.NewDeviceAuthorization("device-hash", "user-hash", "client-123", nil, 5*time.Second, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	require.NoError(t, denied.Deny())
+	assert.Equal(t, domain.DeviceAuthorizationDenied, denied.Status())
+}
+
+func TestDeviceAuthorizationPoll(t *testing.T) {
+	d, err := domain.NewDeviceAuthorization("device-hash", "user-hash", "client-123", nil, 5*time.Second, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+
+	now := time.Now()
+	assert.False(t, d.Poll(now))
+	assert.False(t, d.Poll(now.Add(5*time.Second)))
+
+	// 간격보다 빨리 폴링하면 slow_down, 간격은 5초 증가
+	assert.True(t, d.Poll(now.Add(6*time.Second)))
+	assert.Equal(t, 10*time.Second, d.Interval())
+	assert.False(t, d.Poll(now.Add(16*time.Second)))
+}
+
+func TestHashUserCode(t *testing.T) {
+	assert.Equal(t, domain.HashUserCode("WDJB-MJHT"), domain.HashUserCode("wdjb mjht"))
+	assert.Equal(t, domain.HashUserCode("WDJB-MJHT"), domain.HashUserCode("WDJBMJHT"))
+	assert.NotEqual(t, domain.HashUserCode("WDJB-MJHT"), domain.HashUserCode("WDJB-MJHV"))
+}
+
+func TestDeviceAuthorizationServicePollToken(t *testing.T) {
+	ctx := context.Background()
+	type env struct {
+		svc      domain.DeviceAuthorizationService
+		tokenGen *fakeTokenGenerator
+		authz    *recordingAuthorizationService
+		devices  *memoryDeviceAuthorizationRepo
+	}
+	newEnv := func(t *testing.T) *env {
+		tv, err := domain.NewPublicOAuthClient("tv-app", "TV App", []string{"profile", "stream:read"}, time.Hour)
+		require.NoError(t, err)
+		other, err := domain.NewPublicOAuthClient("other-app", "Other App", []string{"profile"}, time.Hour)
+		require.NoError(t, err)
+		e := &env{
+			tokenGen: newFakeTokenGenerator(),
+			authz:    &recordingAuthorizationService{},
+			devices:  newMemoryDeviceAuthorizationRepo(),
+		}
+		user := newTestUser("user-123", "jane", "jane@example.com", "Password123!")
+		authSvc := domain.NewAuthService(newMemoryUserRepo(user), newMemoryTokenRepo(), e.tokenGen, &recordingEventPublisher{})
+		e.svc = domain.NewDeviceAuthorizationService(authSvc, e.authz, newMemoryOAuthClientRepo(tv, other), e.devices,
+			newMemoryRateLimitRepo(), &memoryAuditRepo{}, "https://app.example.com/device", 10*time.Minute, 5*time.Second,
+			3, time.Hour)
+		return e
+	}
+	approver := &domain.TokenClaims{Subject: "user-123", AuthTime: time.Now().Add(-time.Minute), AMR: []string{domain.AMRPassword}}
+
+	t.Run("Approved device code yields one token pair", func(t *testing.T) {
+		e := newEnv(t)
+		grant, err := e.svc.StartDeviceAuthorization(ctx, "tv-app", "", []string{"stream:read"})
+		require.NoError(t, err)
+
+		_, err = e.svc.PollToken(ctx, "tv-app", "", grant.DeviceCode)
+		assertOAuthError(t, err, domain.OAuthErrAuthorizationPending)
+		// 간격 안에 다시 폴링하면 slow_down
+		_, err = e.svc.PollToken(ctx, "tv-app", "", grant.DeviceCode)
+		assertOAuthError(t, err, domain.OAuthErrSlowDown)
+
+		verification, err := e.svc.LookupUserCode(ctx, approver, grant.UserCode)
+		require.NoError(t, err)
+		assert.Equal(t, "TV App", verification.ClientName)
+		require.NoError(t, e.svc.DecideUserCode(ctx, approver, grant.UserCode, true))
+		assert.Equal(t, []string{"stream:read"}, e.authz.consents["tv-app"])
+
+		token, err := e.svc.PollToken(ctx, "tv-app", "", grant.DeviceCode)
+		require.NoError(t, err)
+		claims, err := e.tokenGen.ParseToken(token.AccessToken())
+		require.NoError(t, err)
+		assert.Equal(t, "user-123", claims.Subject)
+		assert.Equal(t, "tv-app", claims.ClientID)
+		assert.Equal(t, []string{"stream:read"}, claims.Scope)
+		assert.Equal(t, []string{domain.AMRPassword}, claims.AMR)
+
+		_, err = e.svc.PollToken(ctx, "tv-app", "", grant.DeviceCode)
+		assertOAuthError(t, err, domain.OAuthErrInvalidGrant)
+		assert.Empty(t, e.devices.authorizations)
+	})
+
+	t.Run("Device code is bound to its client", func(t *testing.T) {
+		e := newEnv(t)
+		grant, err := e.svc.StartDeviceAuthorization(ctx, "tv-app", "", nil)
+		require.NoError(t, err)
+		require.NoError(t, e.svc.DecideUserCode(ctx, approver, grant.UserCode, true))
+
+		_, err = e.svc.PollToken(ctx, "other-app", "", grant.DeviceCode)
+		assertOAuthError(t, err, domain.OAuthErrInvalidGrant)
+		_, err = e.svc.PollToken(ctx, "tv-app", "", "unknown")
+		assertOAuthError(t, err, domain.OAuthErrInvalidGrant)
+	})
+
+	t.Run("Denied request ends the grant", func(t *testing.T) {
+		e := newEnv(t)
+		grant, err := e.svc.StartDeviceAuthorization(ctx, "tv-app", "", nil)
+		require.NoError(t, err)
+		require.NoError(t, e.svc.DecideUserCode(ctx, approver, grant.UserCode, false))
+		assert.Empty(t, e.authz.consents)
+
+		// 결정된 코드는 다시 승인할 수 없음
+		assert.ErrorIs(t, e.svc.DecideUserCode(ctx, approver, grant.UserCode, true), domain.ErrUserCodeInvalid)
+		_, err = e.svc.PollToken(ctx, "tv-app", "", grant.DeviceCode)
+		assertOAuthError(t, err, domain.OAuthErrAccessDenied)
+		_, err = e.svc.PollToken(ctx, "tv-app", "", grant.DeviceCode)
+		assertOAuthError(t, err, domain.OAuthErrInvalidGrant)
+	})
+	t.Run("Poll racing an approval does not revert it", func(t *testing.T) {
+		e := newEnv(t)
+		grant, err := e.svc.StartDeviceAuthorization(ctx, "tv-app", "", nil)
+		require.NoError(t, err)
+		// 폴링이 대기 상태를 읽은 직후 사용자가 승인
+		e.devices.afterFind = func() {
+			e.devices.afterFind = nil
+			require.NoError(t, e.svc.DecideUserCode(ctx, approver, grant.UserCode, true))
+		}
+
+		_, err = e.svc.PollToken(ctx, "tv-app", "", grant.DeviceCode)
+		assertOAuthError(t, err, domain.OAuthErrAuthorizationPending)
+		_, err = e.svc.PollToken(ctx, "tv-app", "", grant.DeviceCode)
+		require.NoError(t, err)
+	})
+
+	t.Run("Decision is made once", func(t *testing.T) {
+		e := newEnv(t)
+		grant, err := e.svc.StartDeviceAuthorization(ctx, "tv-app", "", nil)
+		require.NoError(t, err)
+		stale, err := e.devices.FindByUserCodeHash(ctx, domain.HashUserCode(grant.UserCode))
+		require.NoError(t, err)
+		require.NoError(t, e.svc.DecideUserCode(ctx, approver, grant.UserCode, false))
+
+		// 거부 전에 읽은 요청으로 승인을 저장해도 반영되지 않음
+		require.NoError(t, stale.Approve("user-123", time.Now(), nil))
+		decided, err := e.devices.Decide(ctx, stale)
+		require.NoError(t, err)
+		assert.False(t, decided)
+		_, err = e.svc.PollToken(ctx, "tv-app", "", grant.DeviceCode)
+		assertOAuthError(t, err, domain.OAuthErrAccessDenied)
+	})
+
+	t.Run("User code attempts are limited per user", func(t *testing.T) {
+		e := newEnv(t)
+		for i := 0; i < 3; i++ {
+			_, err := e.svc.LookupUserCode(ctx, approver, "BCDF-GHJK")
+			assert.ErrorIs(t, err, domain.ErrUserCodeInvalid)
+		}
+		_, err := e.svc.LookupUserCode(ctx, approver, "BCDF-GHJK")
+		assert.ErrorIs(t, err, domain.ErrRateLimited)
+		assert.ErrorIs(t, e.svc.DecideUserCode(ctx, approver, "BCDF-GHJK", true), domain.ErrRateLimited)
+	})
+}
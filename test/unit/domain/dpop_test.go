@@ -0,0 +1,53 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/sukryu/IV-auth-services/internal/core/domain"
+)
+
+func TestNewDPoPProof(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name       string
+		thumbprint string
+		jti        string
+		issuedAt   time.Time
+		expiresAt  time.Time
+		wantErr    bool
+	}{
+		{"Valid proof", "jkt-123", "proof-123", now, now.Add(time.Minute), false},
+		{"Empty thumbprint", "", "proof-123", now, now.Add(time.Minute), true},
+		{"Empty jti", "jkt-123", "", now, now.Add(time.Minute), true},
+		{"Zero issued at", "jkt-123", "proof-123", time.Time{}, now.Add(time.Minute), true},
+		{"Expiry before issued at", "jkt-123", "proof-123", now, now.Add(-time.Minute), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			proof, err := domain.NewDPoPProof(tt.thumbprint, tt.jti, tt.issuedAt, tt.expiresAt)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, proof)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.thumbprint, proof.Thumbprint())
+				assert.Equal(t, tt.jti, proof.JTI())
+				assert.Equal(t, tt.issuedAt, proof.IssuedAt())
+				assert.Equal(t, tt.expiresAt, proof.ExpiresAt())
+			}
+		})
+	}
+}
+
+func TestTokenClaimsConfirmationKey(t *testing.T) {
+	claims := domain.TokenClaims{}
+	assert.False(t, claims.IsSenderConstrained())
+
+	domain.WithConfirmationKey("jkt-123")(&claims)
+	assert.True(t, claims.IsSenderConstrained())
+	assert.Equal(t, "jkt-123", claims.ConfirmationKeyThumbprint)
+}
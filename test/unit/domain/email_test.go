@@ -37,3 +37,12 @@ func TestNewEmail(t *testing.T) {
 		})
 	}
 }
+
+func TestEmailNormalize(t *testing.T) {
+	// 정규화 이전에 저장된 대소문자 혼합 주소도 같은 값으로 비교되어야 함
+	assert.Equal(t, domain.Email("jane@example.com"), domain.Email("Jane@Example.COM").Normalize())
+
+	email, err := domain.NewEmail("Jane@Example.COM")
+	assert.NoError(t, err)
+	assert.Equal(t, email, email.Normalize())
+}
@@ -0,0 +1,51 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sukryu/IV-auth-services/internal/core/domain"
+)
+
+func TestNewEmailVerificationToken(t *testing.T) {
+	email, err := domain.NewEmail("jane@example.com")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		tokenHash string
+		userID    string
+		email     domain.Email
+		wantErr   bool
+	}{
+		{"Valid token", "hash", "user-123", email, false},
+		{"Empty hash", "", "user-123", email, true},
+		{"Empty user id", "hash", "", email, true},
+		{"Invalid email", "hash", "user-123", domain.Email(""), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := domain.NewEmailVerificationToken(tt.tokenHash, tt.userID, tt.email, time.Now().Add(24*time.Hour))
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.tokenHash, token.TokenHash())
+			assert.Equal(t, tt.email, token.Email())
+			assert.False(t, token.IsExpired())
+		})
+	}
+}
+
+func TestEmailVerificationToken_IsExpired(t *testing.T) {
+	email, err := domain.NewEmail("jane@example.com")
+	require.NoError(t, err)
+
+	token, err := domain.NewEmailVerificationTokenFromStorage("hash", "user-123", email, time.Now().Add(-time.Second), time.Now().Add(-24*time.Hour))
+	require.NoError(t, err)
+	assert.True(t, token.IsExpired())
+}
@@ -0,0 +1,804 @@
+package domain_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/sukryu/IV-auth-services/internal/core/domain"
+)
+
+// In-memory implementations of the domain ports used by the service-level tests.
+
+// memoryUserRepo implements domain.UserRepository.
+type memoryUserRepo struct {
+	mu    sync.Mutex
+	users map[string]*domain.User
+}
+
+func newMemoryUserRepo(users ...*domain.User) *memoryUserRepo {
+	r := &memoryUserRepo{users: make(map[string]*domain.User)}
+	for _, u := range users {
+		r.users[u.ID()] = u
+	}
+	return r
+}
+
+func (r *memoryUserRepo) SaveUser(ctx context.Context, user *domain.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.users[user.ID()] = user
+	return nil
+}
+
+func (r *memoryUserRepo) FindByUsername(ctx context.Context, username string) (*domain.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, u := range r.users {
+		if u.Username() == username {
+			return u, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memoryUserRepo) FindByID(ctx context.Context, id string) (*domain.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.users[id], nil
+}
+
+func (r *memoryUserRepo) FindByEmail(ctx context.Context, email domain.Email) (*domain.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, u := range r.users {
+		if u.Email().String() != "" && u.Email().Normalize() == email.Normalize() {
+			return u, nil
+		}
+	}
+	return nil, nil
+}
+
+// memoryTokenRepo implements domain.TokenRepository.
+type memoryTokenRepo struct {
+	mu          sync.Mutex
+	blacklisted map[string]string // 토큰 -> 사유
+}
+
+func newMemoryTokenRepo() *memoryTokenRepo {
+	return &memoryTokenRepo{blacklisted: make(map[string]string)}
+}
+
+func (r *memoryTokenRepo) BlacklistToken(ctx context.Context, tokenID, userID, reason string, expiresAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.blacklisted[tokenID] = reason
+	return nil
+}
+
+func (r *memoryTokenRepo) IsBlacklisted(ctx context.Context, tokenID string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, ok := r.blacklisted[tokenID]
+	return ok, nil
+}
+
+func (r *memoryTokenRepo) PurgeExpired(ctx context.Context, before time.Time, limit int) (int64, error) {
+	return 0, nil
+}
+
+// memoryPlatformRepo implements domain.PlatformAccountRepository.
+type memoryPlatformRepo struct {
+	mu       sync.Mutex
+	accounts map[string]*domain.PlatformAccount
+}
+
+func newMemoryPlatformRepo(accounts ...*domain.PlatformAccount) *memoryPlatformRepo {
+	r := &memoryPlatformRepo{accounts: make(map[string]*domain.PlatformAccount)}
+	for _, a := range accounts {
+		r.accounts[a.ID()] = a
+	}
+	return r
+}
+
+func (r *memoryPlatformRepo) Save(ctx context.Context, account *domain.PlatformAccount) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.accounts[account.ID()] = account
+	return nil
+}
+
+func (r *memoryPlatformRepo) FindByID(ctx context.Context, id string) (*domain.PlatformAccount, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.accounts[id], nil
+}
+
+func (r *memoryPlatformRepo) FindByUserID(ctx context.Context, userID string) ([]*domain.PlatformAccount, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var accounts []*domain.PlatformAccount
+	for _, a := range r.accounts {
+		if a.UserID() == userID {
+			accounts = append(accounts, a)
+		}
+	}
+	return accounts, nil
+}
+
+func (r *memoryPlatformRepo) FindByPlatformUserID(ctx context.Context, platform domain.PlatformType, platformUserID string) (*domain.PlatformAccount, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, a := range r.accounts {
+		if a.Platform() == platform && a.PlatformUserID() == platformUserID {
+			return a, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memoryPlatformRepo) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.accounts, id)
+	return nil
+}
+
+// fakePlatformClient implements domain.PlatformOAuthClient and returns a fixed identity for every code.
+type fakePlatformClient struct {
+	platform domain.PlatformType
+	identity domain.PlatformIdentity
+}
+
+func (c *fakePlatformClient) Platform() domain.PlatformType {
+	return c.platform
+}
+
+func (c *fakePlatformClient) AuthorizationURL(state string) string {
+	return "https://platform.example.com/authorize?state=" + state
+}
+
+func (c *fakePlatformClient) ExchangeCode(ctx context.Context, code string) (*domain.PlatformIdentity, error) {
+	identity := c.identity
+	return &identity, nil
+}
+
+// memorySessionRepo implements domain.SessionRepository. Like a database it hands out
+// copies, so a session loaded before a revocation does not see it.
+type memorySessionRepo struct {
+	mu        sync.Mutex
+	sessions  map[string]*domain.Session
+	afterFind func(id string) // 조회와 저장 사이의 동시 요청을 흉내 내는 훅
+}
+
+func newMemorySessionRepo() *memorySessionRepo {
+	return &memorySessionRepo{sessions: make(map[string]*domain.Session)}
+}
+
+// copySession returns a detached copy of the session.
+func copySession(s *domain.Session) *domain.Session {
+	metadata := domain.ClientMetadata{IPAddress: s.IPAddress(), UserAgent: s.UserAgent(), DeviceName: s.DeviceName()}
+	c, err := domain.NewSessionFromStorage(s.ID(), s.UserID(), metadata, s.CreatedAt(), s.LastRefreshedAt(), s.ExpiresAt(), s.RevokedAt())
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+func (r *memorySessionRepo) Save(ctx context.Context, session *domain.Session) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c := copySession(session)
+	if stored := r.sessions[session.ID()]; stored != nil && stored.RevokedAt() != nil && c.RevokedAt() == nil {
+		_ = c.Revoke() // 저장된 폐기는 되돌리지 않음
+	}
+	r.sessions[session.ID()] = c
+	return nil
+}
+
+func (r *memorySessionRepo) Touch(ctx context.Context, session *domain.Session) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored := r.sessions[session.ID()]
+	if stored == nil || stored.RevokedAt() != nil {
+		return false, nil
+	}
+	r.sessions[session.ID()] = copySession(session)
+	return true, nil
+}
+
+func (r *memorySessionRepo) FindByID(ctx context.Context, id string) (*domain.Session, error) {
+	r.mu.Lock()
+	stored := r.sessions[id]
+	r.mu.Unlock()
+	if stored == nil {
+		return nil, nil
+	}
+	session := copySession(stored)
+	if r.afterFind != nil {
+		r.afterFind(id)
+	}
+	return session, nil
+}
+
+func (r *memorySessionRepo) FindActiveByUserID(ctx context.Context, userID string) ([]*domain.Session, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var sessions []*domain.Session
+	for _, s := range r.sessions {
+		if s.UserID() == userID && s.IsActive() {
+			sessions = append(sessions, copySession(s))
+		}
+	}
+	return sessions, nil
+}
+
+func (r *memorySessionRepo) RevokeAllByUserID(ctx context.Context, userID, exceptID string, revokedAt time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var revoked int64
+	for _, s := range r.sessions {
+		if s.UserID() != userID || s.ID() == exceptID || !s.IsActive() {
+			continue
+		}
+		if err := s.Revoke(); err != nil {
+			return revoked, err
+		}
+		revoked++
+	}
+	return revoked, nil
+}
+
+func (r *memorySessionRepo) PurgeExpired(ctx context.Context, before time.Time, limit int) (int64, error) {
+	return 0, nil
+}
+
+// addSession stores an active session of the user and returns its ID.
+func (r *memorySessionRepo) addSession(id, userID string) string {
+	session, err := domain.NewSession(id, userID, domain.ClientMetadata{}, time.Hour)
+	if err != nil {
+		panic(err)
+	}
+	_ = r.Save(context.Background(), session)
+	return id
+}
+
+// memoryRateLimitRepo implements domain.RateLimitRepository with counters that never expire.
+type memoryRateLimitRepo struct {
+	mu     sync.Mutex
+	counts map[string]int
+}
+
+func newMemoryRateLimitRepo() *memoryRateLimitRepo {
+	return &memoryRateLimitRepo{counts: make(map[string]int)}
+}
+
+func (r *memoryRateLimitRepo) Increment(ctx context.Context, key string, window time.Duration) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.counts[key]++
+	return r.counts[key], nil
+}
+
+func (r *memoryRateLimitRepo) PurgeExpired(ctx context.Context, before time.Time, limit int) (int64, error) {
+	return 0, nil
+}
+
+// memoryMFAChallengeRepo implements domain.MFAChallengeRepository.
+type memoryMFAChallengeRepo struct {
+	mu         sync.Mutex
+	challenges map[string]*domain.MFAChallenge
+}
+
+func newMemoryMFAChallengeRepo() *memoryMFAChallengeRepo {
+	return &memoryMFAChallengeRepo{challenges: make(map[string]*domain.MFAChallenge)}
+}
+
+func (r *memoryMFAChallengeRepo) Save(ctx context.Context, challenge *domain.MFAChallenge) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.challenges[challenge.TokenHash()] = challenge
+	return nil
+}
+
+func (r *memoryMFAChallengeRepo) FindByTokenHash(ctx context.Context, tokenHash string) (*domain.MFAChallenge, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.challenges[tokenHash], nil
+}
+
+func (r *memoryMFAChallengeRepo) IncrementAttempts(ctx context.Context, tokenHash string) (*domain.MFAChallenge, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c, ok := r.challenges[tokenHash]
+	if !ok {
+		return nil, nil
+	}
+	updated, err := domain.NewMFAChallengeFromStorage(c.TokenHash(), c.UserID(), c.AuthTime(), c.AMR(),
+		c.Attempts()+1, c.EmailCodeHash(), c.ExpiresAt(), c.CreatedAt())
+	if err != nil {
+		return nil, err
+	}
+	r.challenges[tokenHash] = updated
+	return updated, nil
+}
+
+func (r *memoryMFAChallengeRepo) Consume(ctx context.Context, tokenHash string) (*domain.MFAChallenge, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c, ok := r.challenges[tokenHash]
+	if !ok {
+		return nil, nil
+	}
+	delete(r.challenges, tokenHash)
+	return c, nil
+}
+
+func (r *memoryMFAChallengeRepo) PurgeExpired(ctx context.Context, before time.Time, limit int) (int64, error) {
+	return 0, nil
+}
+
+// stubMFAService implements domain.MFAService for users with the given enabled methods.
+// Only EnabledMethods and VerifyFactor are implemented; VerifyFactor accepts validCode.
+type stubMFAService struct {
+	domain.MFAService
+	methods   []string
+	validCode string
+}
+
+func (s *stubMFAService) EnabledMethods(ctx context.Context, userID string) ([]string, error) {
+	return s.methods, nil
+}
+
+func (s *stubMFAService) VerifyFactor(ctx context.Context, userID, method, response string) (string, error) {
+	if len(s.methods) == 0 {
+		return "", domain.ErrMFANotEnrolled
+	}
+	if response != s.validCode {
+		return "", domain.ErrInvalidMFACode
+	}
+	return domain.AMROTP, nil
+}
+
+// memoryLoginAttemptRepo implements domain.LoginAttemptRepository.
+type memoryLoginAttemptRepo struct {
+	mu       sync.Mutex
+	attempts []*domain.LoginAttempt
+}
+
+func (r *memoryLoginAttemptRepo) Save(ctx context.Context, attempt *domain.LoginAttempt) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.attempts = append(r.attempts, attempt)
+	return nil
+}
+
+func (r *memoryLoginAttemptRepo) FindRecentSuccessful(ctx context.Context, userID string, limit int) ([]*domain.LoginAttempt, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var recent []*domain.LoginAttempt
+	for i := len(r.attempts) - 1; i >= 0 && len(recent) < limit; i-- {
+		if a := r.attempts[i]; a.UserID() == userID && a.Succeeded() {
+			recent = append(recent, a)
+		}
+	}
+	return recent, nil
+}
+
+func (r *memoryLoginAttemptRepo) CountFailedSince(ctx context.Context, userID string, since time.Time) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	count := 0
+	for _, a := range r.attempts {
+		if a.UserID() == userID && !a.Succeeded() && a.CreatedAt().After(since) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *memoryLoginAttemptRepo) PurgeExpired(ctx context.Context, before time.Time, limit int) (int64, error) {
+	return 0, nil
+}
+
+// countingLocator implements domain.IPLocator with fixed locations and counts the lookups.
+type countingLocator struct {
+	mu        sync.Mutex
+	locations map[string]*domain.IPLocation
+	lookups   int
+}
+
+func (l *countingLocator) Locate(ctx context.Context, ip string) (*domain.IPLocation, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.lookups++
+	return l.locations[ip], nil
+}
+
+// recordingMailer implements domain.Mailer and keeps every sent message.
+type recordingMailer struct {
+	mu       sync.Mutex
+	messages []domain.EmailMessage
+}
+
+func (m *recordingMailer) Send(ctx context.Context, msg domain.EmailMessage) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.messages = append(m.messages, msg)
+	return nil
+}
+
+// sent returns the messages sent so far.
+func (m *recordingMailer) sent() []domain.EmailMessage {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]domain.EmailMessage(nil), m.messages...)
+}
+
+// waitForSent waits for messages sent in the background and returns them.
+func (m *recordingMailer) waitForSent(t *testing.T, n int) []domain.EmailMessage {
+	t.Helper()
+	require.Eventually(t, func() bool { return len(m.sent()) >= n }, time.Second, 5*time.Millisecond)
+	return m.sent()
+}
+
+// recordingEmailVerificationService implements domain.EmailVerificationService and records
+// the users a verification was requested for.
+type recordingEmailVerificationService struct {
+	domain.EmailVerificationService
+	requested []string
+}
+
+func (s *recordingEmailVerificationService) RequestVerification(ctx context.Context, userID string) error {
+	s.requested = append(s.requested, userID)
+	return nil
+}
+
+// fakeTokenGenerator implements domain.TokenGenerator with opaque tokens mapped to their claims.
+type fakeTokenGenerator struct {
+	mu     sync.Mutex
+	next   int
+	claims map[string]domain.TokenClaims
+}
+
+func newFakeTokenGenerator() *fakeTokenGenerator {
+	return &fakeTokenGenerator{claims: make(map[string]domain.TokenClaims)}
+}
+
+func (g *fakeTokenGenerator) issue(userID, tokenType string, expiry time.Time, claims domain.TokenClaims) string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.next++
+	token := tokenType + "-" + strconv.Itoa(g.next)
+	claims.Subject = userID
+	claims.Type = tokenType
+	claims.IssuedAt = time.Now()
+	claims.ExpiresAt = expiry
+	g.claims[token] = claims
+	return token
+}
+
+func (g *fakeTokenGenerator) GenerateAccessToken(userID string, expiry time.Time, claims domain.TokenClaims) (string, error) {
+	return g.issue(userID, domain.TokenTypeAccess, expiry, claims), nil
+}
+
+func (g *fakeTokenGenerator) GenerateRefreshToken(userID string, expiry time.Time, claims domain.TokenClaims) (string, error) {
+	return g.issue(userID, domain.TokenTypeRefresh, expiry, claims), nil
+}
+
+func (g *fakeTokenGenerator) ValidateToken(tokenStr string) (string, error) {
+	claims, err := g.ParseToken(tokenStr)
+	if err != nil {
+		return "", err
+	}
+	return claims.Subject, nil
+}
+
+func (g *fakeTokenGenerator) ParseToken(tokenStr string) (*domain.TokenClaims, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	claims, ok := g.claims[tokenStr]
+	if !ok {
+		return nil, errors.New("unknown token")
+	}
+	if time.Now().After(claims.ExpiresAt) {
+		return nil, errors.New("token expired")
+	}
+	return &claims, nil
+}
+
+// recordingEventPublisher implements domain.EventPublisher and keeps every published event.
+type recordingEventPublisher struct {
+	mu     sync.Mutex
+	events []domain.Event
+}
+
+func (p *recordingEventPublisher) Publish(event domain.Event) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.events = append(p.events, event)
+	return nil
+}
+
+func (p *recordingEventPublisher) Close() error {
+	return nil
+}
+
+// names returns the names of the published events in order.
+func (p *recordingEventPublisher) names() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	names := make([]string, 0, len(p.events))
+	for _, e := range p.events {
+		names = append(names, e.EventName())
+	}
+	return names
+}
+
+// memoryAuditRepo implements domain.AuditLogRepository.
+type memoryAuditRepo struct {
+	mu   sync.Mutex
+	logs []*domain.AuditLog
+}
+
+func (r *memoryAuditRepo) LogAction(ctx context.Context, log *domain.AuditLog) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.logs = append(r.logs, log)
+	return nil
+}
+
+func (r *memoryAuditRepo) GetLogs(ctx context.Context, userID string, limit, offset int) ([]*domain.AuditLog, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]*domain.AuditLog(nil), r.logs...), nil
+}
+
+// actions returns the recorded audit actions in order.
+func (r *memoryAuditRepo) actions() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	actions := make([]string, 0, len(r.logs))
+	for _, l := range r.logs {
+		actions = append(actions, l.Action())
+	}
+	return actions
+}
+
+// newTestUser creates an active user with the given password.
+func newTestUser(id, username, email, password string) *domain.User {
+	e, err := domain.NewEmail(email)
+	if err != nil {
+		panic(err)
+	}
+	pwd, err := domain.NewPassword(password)
+	if err != nil {
+		panic(err)
+	}
+	user, err := domain.NewUser(id, username, e, pwd, nil, "FREE")
+	if err != nil {
+		panic(err)
+	}
+	return user
+}
+
+// memoryMagicLinkRepo implements domain.MagicLinkTokenRepository.
+type memoryMagicLinkRepo struct {
+	mu     sync.Mutex
+	tokens map[string]*domain.MagicLinkToken
+}
+
+func newMemoryMagicLinkRepo() *memoryMagicLinkRepo {
+	return &memoryMagicLinkRepo{tokens: make(map[string]*domain.MagicLinkToken)}
+}
+
+func (r *memoryMagicLinkRepo) Save(ctx context.Context, token *domain.MagicLinkToken) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.tokens[token.TokenHash()] = token
+	return nil
+}
+
+func (r *memoryMagicLinkRepo) Consume(ctx context.Context, tokenHash string) (*domain.MagicLinkToken, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	token := r.tokens[tokenHash]
+	delete(r.tokens, tokenHash)
+	return token, nil
+}
+
+func (r *memoryMagicLinkRepo) PurgeExpired(ctx context.Context, before time.Time, limit int) (int64, error) {
+	return 0, nil
+}
+
+// memoryPasswordResetRepo implements domain.PasswordResetTokenRepository.
+type memoryPasswordResetRepo struct {
+	mu     sync.Mutex
+	tokens map[string]*domain.PasswordResetToken
+}
+
+func newMemoryPasswordResetRepo() *memoryPasswordResetRepo {
+	return &memoryPasswordResetRepo{tokens: make(map[string]*domain.PasswordResetToken)}
+}
+
+func (r *memoryPasswordResetRepo) Save(ctx context.Context, token *domain.PasswordResetToken) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.tokens[token.TokenHash()] = token
+	return nil
+}
+
+func (r *memoryPasswordResetRepo) Consume(ctx context.Context, tokenHash string) (*domain.PasswordResetToken, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	token := r.tokens[tokenHash]
+	delete(r.tokens, tokenHash)
+	return token, nil
+}
+
+func (r *memoryPasswordResetRepo) DeleteByUserID(ctx context.Context, userID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for hash, token := range r.tokens {
+		if token.UserID() == userID {
+			delete(r.tokens, hash)
+		}
+	}
+	return nil
+}
+
+func (r *memoryPasswordResetRepo) PurgeExpired(ctx context.Context, before time.Time, limit int) (int64, error) {
+	return 0, nil
+}
+
+// memoryOAuthClientRepo implements domain.OAuthClientRepository.
+type memoryOAuthClientRepo struct {
+	mu      sync.Mutex
+	clients map[string]*domain.OAuthClient
+}
+
+func newMemoryOAuthClientRepo(clients ...*domain.OAuthClient) *memoryOAuthClientRepo {
+	r := &memoryOAuthClientRepo{clients: make(map[string]*domain.OAuthClient)}
+	for _, c := range clients {
+		r.clients[c.ID()] = c
+	}
+	return r
+}
+
+func (r *memoryOAuthClientRepo) Save(ctx context.Context, client *domain.OAuthClient) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.clients[client.ID()] = client
+	return nil
+}
+
+func (r *memoryOAuthClientRepo) FindByID(ctx context.Context, id string) (*domain.OAuthClient, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.clients[id], nil
+}
+
+// memoryDeviceAuthorizationRepo implements domain.DeviceAuthorizationRepository. Like a
+// database it hands out copies, so a request loaded before a decision does not see it.
+type memoryDeviceAuthorizationRepo struct {
+	mu             sync.Mutex
+	authorizations map[string]*domain.DeviceAuthorization
+	afterFind      func() // 기기 코드 조회 직후의 동시 요청을 흉내 내는 훅
+}
+
+func newMemoryDeviceAuthorizationRepo() *memoryDeviceAuthorizationRepo {
+	return &memoryDeviceAuthorizationRepo{authorizations: make(map[string]*domain.DeviceAuthorization)}
+}
+
+// copyDeviceAuthorization returns a detached copy of the request.
+func copyDeviceAuthorization(d *domain.DeviceAuthorization) *domain.DeviceAuthorization {
+	c, err := domain.NewDeviceAuthorizationFromStorage(d.DeviceCodeHash(), d.UserCodeHash(), d.ClientID(), d.Scopes(),
+		d.Status(), d.Interval(), d.LastPolledAt(), d.UserID(), d.AuthTime(), d.AMR(), d.ExpiresAt(), d.CreatedAt())
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+func (r *memoryDeviceAuthorizationRepo) Save(ctx context.Context, authorization *domain.DeviceAuthorization) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.authorizations[authorization.DeviceCodeHash()]; ok {
+		return errors.New("device authorization already exists")
+	}
+	r.authorizations[authorization.DeviceCodeHash()] = copyDeviceAuthorization(authorization)
+	return nil
+}
+
+func (r *memoryDeviceAuthorizationRepo) RecordPoll(ctx context.Context, authorization *domain.DeviceAuthorization) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored := r.authorizations[authorization.DeviceCodeHash()]
+	if stored == nil {
+		return nil
+	}
+	updated, err := domain.NewDeviceAuthorizationFromStorage(stored.DeviceCodeHash(), stored.UserCodeHash(), stored.ClientID(),
+		stored.Scopes(), stored.Status(), authorization.Interval(), authorization.LastPolledAt(), stored.UserID(),
+		stored.AuthTime(), stored.AMR(), stored.ExpiresAt(), stored.CreatedAt())
+	if err != nil {
+		return err
+	}
+	r.authorizations[authorization.DeviceCodeHash()] = updated
+	return nil
+}
+
+func (r *memoryDeviceAuthorizationRepo) Decide(ctx context.Context, authorization *domain.DeviceAuthorization) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored := r.authorizations[authorization.DeviceCodeHash()]
+	if stored == nil || stored.Status() != domain.DeviceAuthorizationPending {
+		return false, nil
+	}
+	r.authorizations[authorization.DeviceCodeHash()] = copyDeviceAuthorization(authorization)
+	return true, nil
+}
+
+func (r *memoryDeviceAuthorizationRepo) FindByDeviceCodeHash(ctx context.Context, deviceCodeHash string) (*domain.DeviceAuthorization, error) {
+	r.mu.Lock()
+	stored := r.authorizations[deviceCodeHash]
+	r.mu.Unlock()
+	if stored == nil {
+		return nil, nil
+	}
+	authorization := copyDeviceAuthorization(stored)
+	if r.afterFind != nil {
+		r.afterFind()
+	}
+	return authorization, nil
+}
+
+func (r *memoryDeviceAuthorizationRepo) FindByUserCodeHash(ctx context.Context, userCodeHash string) (*domain.DeviceAuthorization, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, authorization := range r.authorizations {
+		if authorization.UserCodeHash() == userCodeHash {
+			return copyDeviceAuthorization(authorization), nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memoryDeviceAuthorizationRepo) Consume(ctx context.Context, deviceCodeHash string) (*domain.DeviceAuthorization, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	authorization := r.authorizations[deviceCodeHash]
+	delete(r.authorizations, deviceCodeHash)
+	return authorization, nil
+}
+
+func (r *memoryDeviceAuthorizationRepo) PurgeExpired(ctx context.Context, before time.Time, limit int) (int64, error) {
+	return 0, nil
+}
+
+// recordingAuthorizationService implements the consent part of domain.AuthorizationService.
+type recordingAuthorizationService struct {
+	domain.AuthorizationService
+	mu       sync.Mutex
+	consents map[string][]string // client ID별 승인한 scope
+}
+
+func (s *recordingAuthorizationService) GrantConsent(ctx context.Context, userID, clientID string, scopes []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.consents == nil {
+		s.consents = make(map[string][]string)
+	}
+	s.consents[clientID] = scopes
+	return nil
+}
+
+// memoryRoleRepo implements domain.RoleRepository with role names keyed by user ID.
+type memoryRoleRepo map[string][]string
+
+func (r memoryRoleRepo) FindNamesByUserID(ctx context.Context, userID string) ([]string, error) {
+	return r[userID], nil
+}
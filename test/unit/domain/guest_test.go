@@ -0,0 +1,141 @@
+package domain_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sukryu/IV-auth-services/internal/core/domain"
+)
+
+func TestAuthServiceUpgradeGuest(t *testing.T) {
+	ctx := domain.ContextWithClientMetadata(context.Background(), domain.ClientMetadata{IPAddress: "203.0.113.7"})
+	type env struct {
+		svc       domain.AuthService
+		users     *memoryUserRepo
+		sessions  *memorySessionRepo
+		platforms *memoryPlatformRepo
+		tokenGen  *fakeTokenGenerator
+		verifier  *recordingEmailVerificationService
+	}
+	newEnv := func() *env {
+		e := &env{
+			users:     newMemoryUserRepo(newTestUser("user-123", "jane", "jane@example.com", "Password123!")),
+			sessions:  newMemorySessionRepo(),
+			platforms: newMemoryPlatformRepo(),
+			tokenGen:  newFakeTokenGenerator(),
+			verifier:  &recordingEmailVerificationService{},
+		}
+		platformLogin := domain.NewPlatformLoginService(e.users, e.platforms, &recordingEventPublisher{},
+			[]domain.PlatformOAuthClient{twitchClient("streamer@example.com", true)})
+		e.svc = domain.NewAuthService(e.users, newMemoryTokenRepo(), e.tokenGen, &recordingEventPublisher{},
+			domain.WithSessions(e.sessions),
+			domain.WithGuests(newMemoryRateLimitRepo(), []string{"chat:read"}, 2, time.Hour),
+			domain.WithGuestVerification(e.verifier),
+			domain.WithPlatformLogin(platformLogin))
+		return e
+	}
+	// signInGuest creates a guest and returns the claims of its access token.
+	signInGuest := func(t *testing.T, e *env) *domain.TokenClaims {
+		t.Helper()
+		token, err := e.svc.AuthenticateGuest(ctx)
+		require.NoError(t, err)
+		claims, err := e.tokenGen.ParseToken(token.AccessToken())
+		require.NoError(t, err)
+		require.True(t, claims.IsGuest())
+		return claims
+	}
+
+	t.Run("Password upgrade keeps the user and drops guest sessions", func(t *testing.T) {
+		e := newEnv()
+		guest := signInGuest(t, e)
+
+		token, err := e.svc.UpgradeGuest(ctx, guest, domain.GuestUpgrade{
+			Username: "newbie", Email: "newbie@example.com", Password: "Password123!",
+		})
+		require.NoError(t, err)
+		claims, err := e.tokenGen.ParseToken(token.AccessToken())
+		require.NoError(t, err)
+		assert.Equal(t, guest.Subject, claims.Subject)
+		assert.False(t, claims.IsGuest())
+		assert.Equal(t, []string{domain.AMRPassword}, claims.AMR)
+
+		user, err := e.users.FindByID(ctx, guest.Subject)
+		require.NoError(t, err)
+		assert.False(t, user.IsGuest())
+		assert.Equal(t, "newbie", user.Username())
+		// 새 이메일 주소는 확인되지 않은 상태이므로 확인 메일을 보냄
+		assert.False(t, user.EmailVerified())
+		assert.Equal(t, []string{guest.Subject}, e.verifier.requested)
+
+		active, err := e.sessions.FindActiveByUserID(ctx, guest.Subject)
+		require.NoError(t, err)
+		require.Len(t, active, 1)
+		assert.NotEqual(t, guest.SessionID, active[0].ID())
+
+		// 업그레이드된 계정은 다시 업그레이드할 수 없음
+		_, err = e.svc.UpgradeGuest(ctx, guest, domain.GuestUpgrade{
+			Username: "other", Email: "other@example.com", Password: "Password123!",
+		})
+		assert.ErrorIs(t, err, domain.ErrNotGuest)
+	})
+
+	t.Run("Taken or ambiguous credentials are rejected", func(t *testing.T) {
+		e := newEnv()
+		guest := signInGuest(t, e)
+
+		_, err := e.svc.UpgradeGuest(ctx, guest, domain.GuestUpgrade{Username: "jane", Email: "new@example.com", Password: "Password123!"})
+		assert.ErrorIs(t, err, domain.ErrUsernameTaken)
+		_, err = e.svc.UpgradeGuest(ctx, guest, domain.GuestUpgrade{Username: "newbie", Email: "Jane@Example.com", Password: "Password123!"})
+		assert.ErrorIs(t, err, domain.ErrEmailInUse)
+		_, err = e.svc.UpgradeGuest(ctx, guest, domain.GuestUpgrade{Username: "new@bie", Email: "new@example.com", Password: "Password123!"})
+		assertOAuthError(t, err, domain.OAuthErrInvalidRequest)
+
+		user, err := e.users.FindByID(ctx, guest.Subject)
+		require.NoError(t, err)
+		assert.True(t, user.IsGuest())
+		assert.Empty(t, e.verifier.requested)
+	})
+
+	t.Run("Only the guest itself can upgrade", func(t *testing.T) {
+		e := newEnv()
+		guest := signInGuest(t, e)
+		upgrade := domain.GuestUpgrade{Username: "newbie", Email: "newbie@example.com", Password: "Password123!"}
+
+		_, err := e.svc.UpgradeGuest(ctx, &domain.TokenClaims{Subject: "user-123"}, upgrade)
+		assert.ErrorIs(t, err, domain.ErrNotGuest)
+		// 대리 실행 중인 관리자는 게스트 계정을 업그레이드할 수 없음
+		impersonated := *guest
+		impersonated.Actor = &domain.ActorClaim{Subject: "admin-1"}
+		_, err = e.svc.UpgradeGuest(ctx, &impersonated, upgrade)
+		assert.ErrorIs(t, err, domain.ErrNotGuest)
+	})
+
+	t.Run("Platform upgrade links the account", func(t *testing.T) {
+		e := newEnv()
+		guest := signInGuest(t, e)
+
+		token, err := e.svc.UpgradeGuest(ctx, guest, domain.GuestUpgrade{Platform: domain.PlatformTwitch, Code: "code"})
+		require.NoError(t, err)
+		claims, err := e.tokenGen.ParseToken(token.AccessToken())
+		require.NoError(t, err)
+		assert.Equal(t, []string{domain.AMRFederated}, claims.AMR)
+		account, err := e.platforms.FindByPlatformUserID(ctx, domain.PlatformTwitch, "twitch-123")
+		require.NoError(t, err)
+		require.NotNil(t, account)
+		assert.Equal(t, guest.Subject, account.UserID())
+		// 플랫폼 계정으로 전환하면 비밀번호 업그레이드용 확인 메일은 보내지 않음
+		assert.Empty(t, e.verifier.requested)
+	})
+
+	t.Run("Guest creation is limited per address", func(t *testing.T) {
+		e := newEnv()
+		signInGuest(t, e)
+		signInGuest(t, e)
+
+		_, err := e.svc.AuthenticateGuest(ctx)
+		assert.ErrorIs(t, err, domain.ErrRateLimited)
+	})
+}
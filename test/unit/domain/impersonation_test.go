@@ -0,0 +1,102 @@
+package domain_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sukryu/IV-auth-services/internal/core/domain"
+)
+
+func TestImpersonationService(t *testing.T) {
+	ctx := context.Background()
+	type env struct {
+		svc      domain.ImpersonationService
+		tokenGen *fakeTokenGenerator
+		tokens   *memoryTokenRepo
+		audit    *memoryAuditRepo
+	}
+	newEnv := func() *env {
+		users := newMemoryUserRepo(
+			newTestUser("admin-1", "admin", "admin@example.com", "Password123!"),
+			newTestUser("admin-2", "admin2", "admin2@example.com", "Password123!"),
+			newTestUser("user-123", "jane", "jane@example.com", "Password123!"),
+		)
+		roles := memoryRoleRepo{
+			"admin-1":  {domain.RoleAdmin},
+			"admin-2":  {domain.RoleAdmin},
+			"user-123": {domain.RoleUser},
+		}
+		e := &env{tokenGen: newFakeTokenGenerator(), tokens: newMemoryTokenRepo(), audit: &memoryAuditRepo{}}
+		e.svc = domain.NewImpersonationService(users, roles, e.tokens, e.tokenGen, e.audit, 15*time.Minute)
+		return e
+	}
+	admin := &domain.TokenClaims{Subject: "admin-1"}
+
+	t.Run("Admin gets a short-lived token acting as the user", func(t *testing.T) {
+		e := newEnv()
+
+		token, err := e.svc.Impersonate(ctx, admin, "user-123", "ticket 4821")
+		require.NoError(t, err)
+		assert.Empty(t, token.RefreshToken())
+		assert.WithinDuration(t, time.Now().Add(15*time.Minute), token.Expiry(), 5*time.Second)
+
+		claims, err := e.tokenGen.ParseToken(token.AccessToken())
+		require.NoError(t, err)
+		assert.Equal(t, "user-123", claims.Subject)
+		require.NotNil(t, claims.Actor)
+		assert.Equal(t, "admin-1", claims.Actor.Subject)
+		assert.True(t, claims.IsDelegated())
+		assert.Equal(t, []string{domain.AuditActionImpersonationStarted}, e.audit.actions())
+	})
+
+	t.Run("Delegated tokens cannot impersonate again", func(t *testing.T) {
+		e := newEnv()
+		delegated := &domain.TokenClaims{Subject: "admin-1", Actor: &domain.ActorClaim{Subject: "admin-2"}}
+
+		_, err := e.svc.Impersonate(ctx, delegated, "user-123", "ticket 4821")
+		assert.ErrorIs(t, err, domain.ErrImpersonationForbidden)
+		assert.Equal(t, []string{domain.AuditActionImpersonationDenied}, e.audit.actions())
+	})
+
+	t.Run("Only admins impersonate, and never other admins", func(t *testing.T) {
+		e := newEnv()
+
+		_, err := e.svc.Impersonate(ctx, &domain.TokenClaims{Subject: "user-123"}, "admin-1", "curious")
+		assert.ErrorIs(t, err, domain.ErrImpersonationForbidden)
+		_, err = e.svc.Impersonate(ctx, admin, "admin-2", "ticket 4821")
+		assert.ErrorIs(t, err, domain.ErrImpersonationForbidden)
+		_, err = e.svc.Impersonate(ctx, admin, "admin-1", "ticket 4821")
+		assert.ErrorIs(t, err, domain.ErrImpersonationForbidden)
+		assert.Equal(t, []string{domain.AuditActionImpersonationDenied, domain.AuditActionImpersonationDenied}, e.audit.actions())
+	})
+
+	t.Run("Reason and an existing target are required", func(t *testing.T) {
+		e := newEnv()
+
+		_, err := e.svc.Impersonate(ctx, admin, "user-123", "  ")
+		assert.ErrorIs(t, err, domain.ErrImpersonationReasonRequired)
+		_, err = e.svc.Impersonate(ctx, admin, "user-404", "ticket 4821")
+		assert.ErrorIs(t, err, domain.ErrImpersonationTargetNotFound)
+	})
+
+	t.Run("Ending revokes the token", func(t *testing.T) {
+		e := newEnv()
+		token, err := e.svc.Impersonate(ctx, admin, "user-123", "ticket 4821")
+		require.NoError(t, err)
+		claims, err := e.tokenGen.ParseToken(token.AccessToken())
+		require.NoError(t, err)
+
+		require.NoError(t, e.svc.EndImpersonation(ctx, claims, token.AccessToken()))
+		blacklisted, err := e.tokens.IsBlacklisted(ctx, token.AccessToken())
+		require.NoError(t, err)
+		assert.True(t, blacklisted)
+		assert.Contains(t, e.audit.actions(), domain.AuditActionImpersonationEnded)
+
+		// 사용자 자신의 토큰으로는 종료할 수 없음
+		err = e.svc.EndImpersonation(ctx, &domain.TokenClaims{Subject: "user-123"}, "user-token")
+		assert.ErrorIs(t, err, domain.ErrNotImpersonating)
+	})
+}
@@ -0,0 +1,306 @@
+package domain_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sukryu/IV-auth-services/internal/core/domain"
+)
+
+var (
+	seoul = &domain.IPLocation{ASN: "AS4766", CountryCode: "KR", Latitude: 37.57, Longitude: 126.98}
+	busan = &domain.IPLocation{ASN: "AS4766", CountryCode: "KR", Latitude: 35.18, Longitude: 129.08}
+	paris = &domain.IPLocation{ASN: "AS3215", CountryCode: "FR", Latitude: 48.86, Longitude: 2.35}
+)
+
+// testRiskPolicy mirrors the default configuration.
+var testRiskPolicy = domain.RiskPolicy{
+	NewDeviceScore:        30,
+	NewIPScore:            10,
+	NewASNScore:           20,
+	ImpossibleTravelScore: 60,
+	FailureScore:          10,
+	MaxFailureScore:       40,
+	MFAThreshold:          40,
+	BlockThreshold:        90,
+	MaxTravelSpeed:        1000,
+	FailureWindow:         time.Hour,
+	HistorySize:           20,
+}
+
+// loginAt builds a login attempt from the given device, IP address and location at a point in time.
+func loginAt(t *testing.T, device, ip string, location *domain.IPLocation, at time.Time) *domain.LoginAttempt {
+	fingerprint := domain.DeviceFingerprint(domain.ClientMetadata{DeviceFingerprint: device})
+	attempt, err := domain.NewLoginAttemptFromStorage("attempt-"+device+ip, "user-123", ip, fingerprint, location, true, at, at.Add(90*24*time.Hour))
+	require.NoError(t, err)
+	return attempt
+}
+
+func TestNewLoginAttempt(t *testing.T) {
+	metadata := domain.ClientMetadata{IPAddress: "203.0.113.7", UserAgent: "Mozilla/5.0", DeviceFingerprint: "device-1"}
+	attempt, err := domain.NewLoginAttempt("attempt-123", "user-123", metadata, seoul, true, time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, "203.0.113.7", attempt.IPAddress())
+	assert.Equal(t, domain.DeviceFingerprint(metadata), attempt.DeviceFingerprint())
+	assert.Equal(t, seoul, attempt.Location())
+	assert.True(t, attempt.Succeeded())
+	assert.WithinDuration(t, time.Now().Add(time.Hour), attempt.ExpiresAt(), time.Second)
+
+	_, err = domain.NewLoginAttempt("", "user-123", metadata, nil, false, time.Hour)
+	assert.Error(t, err)
+	_, err = domain.NewLoginAttempt("attempt-123", "", metadata, nil, false, time.Hour)
+	assert.Error(t, err)
+	_, err = domain.NewLoginAttempt("attempt-123", "user-123", metadata, nil, false, 0)
+	assert.Error(t, err)
+}
+
+func TestDeviceFingerprint(t *testing.T) {
+	withDevice := domain.DeviceFingerprint(domain.ClientMetadata{UserAgent: "Mozilla/5.0", DeviceFingerprint: "device-1"})
+	assert.Len(t, withDevice, 64)
+	assert.NotContains(t, withDevice, "device-1")
+	assert.Equal(t, withDevice, domain.DeviceFingerprint(domain.ClientMetadata{UserAgent: "curl/8.0", DeviceFingerprint: "device-1"}))
+
+	// 기기 식별자가 없으면 User-Agent로 구분
+	withUserAgent := domain.DeviceFingerprint(domain.ClientMetadata{UserAgent: "Mozilla/5.0"})
+	assert.NotEmpty(t, withUserAgent)
+	assert.NotEqual(t, withDevice, withUserAgent)
+	assert.Empty(t, domain.DeviceFingerprint(domain.ClientMetadata{}))
+}
+
+func TestRiskPolicyEvaluate(t *testing.T) {
+	now := time.Now()
+	history := []*domain.LoginAttempt{
+		loginAt(t, "laptop", "203.0.113.7", seoul, now.Add(-2*time.Hour)),
+		loginAt(t, "phone", "203.0.113.8", seoul, now.Add(-24*time.Hour)),
+	}
+
+	tests := []struct {
+		name         string
+		attempt      *domain.LoginAttempt
+		history      []*domain.LoginAttempt
+		failures     int
+		mfaAvailable bool
+		wantScore    int
+		wantSignals  []string
+		wantDecision domain.RiskDecision
+	}{
+		{
+			name:         "Known device and network",
+			attempt:      loginAt(t, "laptop", "203.0.113.7", seoul, now),
+			history:      history,
+			wantDecision: domain.RiskDecisionAllow,
+		},
+		{
+			name:         "First login",
+			attempt:      loginAt(t, "laptop", "198.51.100.1", paris, now),
+			wantDecision: domain.RiskDecisionAllow,
+		},
+		{
+			name:         "New device on a known network",
+			attempt:      loginAt(t, "tablet", "203.0.113.7", seoul, now),
+			history:      history,
+			wantScore:    30,
+			wantSignals:  []string{domain.RiskSignalNewDevice},
+			wantDecision: domain.RiskDecisionAllow,
+		},
+		{
+			name:         "New device on a new network",
+			attempt:      loginAt(t, "tablet", "198.51.100.1", &domain.IPLocation{ASN: "AS9318", CountryCode: "KR", Latitude: 37.57, Longitude: 126.98}, now),
+			history:      history,
+			mfaAvailable: true,
+			wantScore:    60,
+			wantSignals:  []string{domain.RiskSignalNewDevice, domain.RiskSignalNewIP, domain.RiskSignalNewASN},
+			wantDecision: domain.RiskDecisionRequireMFA,
+		},
+		{
+			name:         "Reachable move",
+			attempt:      loginAt(t, "laptop", "198.51.100.1", busan, now),
+			history:      history,
+			wantScore:    10,
+			wantSignals:  []string{domain.RiskSignalNewIP},
+			wantDecision: domain.RiskDecisionAllow,
+		},
+		{
+			name:         "Impossible travel",
+			attempt:      loginAt(t, "tablet", "198.51.100.1", paris, now),
+			history:      history,
+			mfaAvailable: true,
+			wantScore:    120,
+			wantSignals:  []string{domain.RiskSignalNewDevice, domain.RiskSignalNewIP, domain.RiskSignalNewASN, domain.RiskSignalImpossibleTravel},
+			wantDecision: domain.RiskDecisionBlock,
+		},
+		{
+			name:         "Recent failures are capped",
+			attempt:      loginAt(t, "laptop", "203.0.113.7", seoul, now),
+			history:      history,
+			failures:     10,
+			mfaAvailable: true,
+			wantScore:    40,
+			wantSignals:  []string{domain.RiskSignalRecentFailures},
+			wantDecision: domain.RiskDecisionRequireMFA,
+		},
+		{
+			name:         "MFA required but not available",
+			attempt:      loginAt(t, "laptop", "203.0.113.7", seoul, now),
+			history:      history,
+			failures:     4,
+			wantScore:    40,
+			wantSignals:  []string{domain.RiskSignalRecentFailures},
+			wantDecision: domain.RiskDecisionBlock,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assessment := testRiskPolicy.Evaluate(tt.attempt, tt.history, tt.failures, tt.mfaAvailable)
+			assert.Equal(t, tt.wantScore, assessment.Score)
+			assert.Equal(t, tt.wantSignals, assessment.Signals)
+			assert.Equal(t, tt.wantDecision, assessment.Decision)
+			assert.Equal(t, tt.wantScore >= testRiskPolicy.MFAThreshold, assessment.Suspicious)
+		})
+	}
+}
+
+func TestNewSuspiciousLogin(t *testing.T) {
+	event, err := domain.NewSuspiciousLogin("user-123", domain.RiskDecisionBlock, 120,
+		[]string{domain.RiskSignalImpossibleTravel}, "198.51.100.1", time.Now())
+	assert.NoError(t, err)
+	assert.Equal(t, "SuspiciousLogin", event.EventName())
+	assert.Equal(t, domain.RiskDecisionBlock, event.Decision())
+	assert.Equal(t, 120, event.Score())
+
+	_, err = domain.NewSuspiciousLogin("", domain.RiskDecisionBlock, 120, nil, "", time.Now())
+	assert.Error(t, err)
+	_, err = domain.NewSuspiciousLogin("user-123", "", 120, nil, "", time.Now())
+	assert.Error(t, err)
+	_, err = domain.NewSuspiciousLogin("user-123", domain.RiskDecisionBlock, 120, nil, "", time.Time{})
+	assert.Error(t, err)
+}
+
+// riskEnv is an auth service with login risk scoring backed by in-memory repositories.
+type riskEnv struct {
+	auth     domain.AuthService
+	tokenGen *fakeTokenGenerator
+	attempts *memoryLoginAttemptRepo
+	locator  *countingLocator
+	mailer   *recordingMailer
+	events   *recordingEventPublisher
+	audit    *memoryAuditRepo
+}
+
+func newRiskEnv(user *domain.User, methods []string) *riskEnv {
+	env := &riskEnv{
+		tokenGen: newFakeTokenGenerator(),
+		attempts: &memoryLoginAttemptRepo{},
+		locator: &countingLocator{locations: map[string]*domain.IPLocation{
+			"203.0.113.7":  seoul,
+			"198.51.100.1": {ASN: "AS9318", CountryCode: "KR", Latitude: 37.57, Longitude: 126.98},
+		}},
+		mailer: &recordingMailer{},
+		events: &recordingEventPublisher{},
+		audit:  &memoryAuditRepo{},
+	}
+	riskSvc := domain.NewLoginRiskService(env.attempts, env.locator, env.audit, env.events, testRiskPolicy, time.Hour)
+	env.auth = domain.NewAuthService(newMemoryUserRepo(user), newMemoryTokenRepo(), env.tokenGen, env.events,
+		domain.WithMFA(&stubMFAService{methods: methods, validCode: "123456"}, newMemoryMFAChallengeRepo(), 5*time.Minute, 5),
+		domain.WithLoginRisk(riskSvc, env.mailer))
+	return env
+}
+
+// clientContext returns a context carrying the client metadata of a login.
+func clientContext(device, ip string) context.Context {
+	return domain.ContextWithClientMetadata(context.Background(), domain.ClientMetadata{IPAddress: ip, DeviceFingerprint: device})
+}
+
+var emailCodePattern = regexp.MustCompile(`\b\d{6}\b`)
+
+func TestAuthServiceLoginRisk(t *testing.T) {
+	newUser := func(emailVerified bool) *domain.User {
+		user := newTestUser("user-123", "viewer", "viewer@example.com", "Password123!")
+		user.SetEmailVerified(emailVerified)
+		return user
+	}
+
+	t.Run("Known device is allowed and located once", func(t *testing.T) {
+		env := newRiskEnv(newUser(true), nil)
+		_, err := env.auth.Authenticate(clientContext("laptop", "203.0.113.7"), "viewer", "Password123!")
+		require.NoError(t, err)
+		_, err = env.auth.Authenticate(clientContext("laptop", "203.0.113.7"), "viewer", "Password123!")
+		require.NoError(t, err)
+
+		// 평가와 기록이 같은 위치 조회 결과를 사용
+		assert.Equal(t, 2, env.locator.lookups)
+		require.Len(t, env.attempts.attempts, 2)
+		assert.Equal(t, seoul, env.attempts.attempts[1].Location())
+		assert.Empty(t, env.mailer.sent())
+	})
+
+	t.Run("High-risk login without a second factor is challenged by email", func(t *testing.T) {
+		env := newRiskEnv(newUser(true), nil)
+		_, err := env.auth.Authenticate(clientContext("laptop", "203.0.113.7"), "viewer", "Password123!")
+		require.NoError(t, err)
+
+		ctx := clientContext("tablet", "198.51.100.1")
+		_, err = env.auth.Authenticate(ctx, "viewer", "Password123!")
+		var mfaErr *domain.MFARequiredError
+		require.ErrorAs(t, err, &mfaErr)
+		assert.Equal(t, []string{domain.MFAMethodEmail}, mfaErr.Methods)
+		assert.Contains(t, env.events.names(), "SuspiciousLogin")
+
+		sent := env.mailer.sent()
+		require.Len(t, sent, 1)
+		assert.Equal(t, "viewer@example.com", sent[0].To.String())
+		code := emailCodePattern.FindString(sent[0].Body)
+		require.NotEmpty(t, code)
+
+		_, err = env.auth.CompleteMFA(ctx, mfaErr.ChallengeToken, domain.MFAMethodEmail, "abcdef")
+		assert.ErrorIs(t, err, domain.ErrInvalidMFACode)
+		_, err = env.auth.CompleteMFA(ctx, mfaErr.ChallengeToken, domain.MFAMethodTOTP, code)
+		assert.Error(t, err)
+
+		token, err := env.auth.CompleteMFA(ctx, mfaErr.ChallengeToken, domain.MFAMethodEmail, code)
+		require.NoError(t, err)
+		claims, err := env.tokenGen.ParseToken(token.AccessToken())
+		require.NoError(t, err)
+		// 이메일 코드는 두 번째 인증 수단이 아니므로 단일 요소 인증으로 남음
+		assert.Equal(t, []string{domain.AMRPassword, domain.AMREmail}, claims.AMR)
+		assert.Equal(t, domain.ACRSingleFactor, claims.ACR)
+		stepUp := domain.StepUpRequirement{ACR: domain.ACRMultiFactor}
+		assert.Error(t, stepUp.Check(claims))
+
+		// 챌린지는 한 번만 사용 가능
+		_, err = env.auth.CompleteMFA(ctx, mfaErr.ChallengeToken, domain.MFAMethodEmail, code)
+		assert.ErrorIs(t, err, domain.ErrMFAChallengeInvalid)
+	})
+
+	t.Run("High-risk login without any factor is blocked", func(t *testing.T) {
+		env := newRiskEnv(newUser(false), nil)
+		_, err := env.auth.Authenticate(clientContext("laptop", "203.0.113.7"), "viewer", "Password123!")
+		require.NoError(t, err)
+
+		_, err = env.auth.Authenticate(clientContext("tablet", "198.51.100.1"), "viewer", "Password123!")
+		assert.ErrorIs(t, err, domain.ErrLoginBlocked)
+		assert.Empty(t, env.mailer.sent())
+	})
+
+	t.Run("High-risk login with a second factor uses it", func(t *testing.T) {
+		env := newRiskEnv(newUser(true), []string{domain.MFAMethodTOTP})
+		_, err := env.auth.Authenticate(clientContext("tablet", "198.51.100.1"), "viewer", "Password123!")
+		var mfaErr *domain.MFARequiredError
+		require.ErrorAs(t, err, &mfaErr)
+		assert.Equal(t, []string{domain.MFAMethodTOTP}, mfaErr.Methods)
+		assert.Empty(t, env.mailer.sent())
+	})
+
+	t.Run("Wrong passwords are recorded as failures", func(t *testing.T) {
+		env := newRiskEnv(newUser(true), nil)
+		_, err := env.auth.Authenticate(clientContext("laptop", "203.0.113.7"), "viewer", "wrong-password")
+		assert.ErrorIs(t, err, domain.ErrInvalidCredentials)
+		require.Len(t, env.attempts.attempts, 1)
+		assert.False(t, env.attempts.attempts[0].Succeeded())
+	})
+}
@@ -0,0 +1,124 @@
+package domain_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sukryu/IV-auth-services/internal/core/domain"
+)
+
+func TestNewMagicLinkToken(t *testing.T) {
+	email, err := domain.NewEmail("Jane@Example.com")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		tokenHash string
+		userID    string
+		email     domain.Email
+		wantErr   bool
+	}{
+		{"Valid token", "hash", "user-123", email, false},
+		{"Empty hash", "", "user-123", email, true},
+		{"Empty user id", "hash", "", email, true},
+		{"Invalid email", "hash", "user-123", domain.Email("not-an-email"), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := domain.NewMagicLinkToken(tt.tokenHash, tt.userID, tt.email, time.Now().Add(15*time.Minute))
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.userID, token.UserID())
+			assert.Equal(t, "jane@example.com", token.Email().String())
+			assert.False(t, token.IsExpired())
+		})
+	}
+}
+
+func TestMagicLinkToken_IsExpired(t *testing.T) {
+	email, err := domain.NewEmail("jane@example.com")
+	require.NoError(t, err)
+
+	createdAt := time.Now().Add(-time.Hour)
+	token, err := domain.NewMagicLinkTokenFromStorage("hash", "user-123", email, time.Now().Add(-time.Minute), createdAt)
+	require.NoError(t, err)
+	assert.True(t, token.IsExpired())
+	assert.Equal(t, createdAt, token.CreatedAt())
+}
+
+// linkTokenPattern extracts the raw token from an emailed link.
+var linkTokenPattern = regexp.MustCompile(`token=([A-Za-z0-9]+)`)
+
+// linkToken returns the token of the link in an emailed message.
+func linkToken(t *testing.T, msg domain.EmailMessage) string {
+	t.Helper()
+	match := linkTokenPattern.FindStringSubmatch(msg.Body)
+	require.Len(t, match, 2, "message carries no link token")
+	return match[1]
+}
+
+func TestMagicLinkService(t *testing.T) {
+	newService := func(users *memoryUserRepo, tokens *memoryMagicLinkRepo, mailer domain.Mailer) domain.MagicLinkService {
+		return domain.NewMagicLinkService(users, tokens, newMemoryRateLimitRepo(), mailer,
+			"https://app.example.com/login", 15*time.Minute, 1, time.Hour)
+	}
+
+	t.Run("Link logs in once", func(t *testing.T) {
+		user := newTestUser("user-123", "jane", "jane@example.com", "Password123!")
+		mailer := &recordingMailer{}
+		svc := newService(newMemoryUserRepo(user), newMemoryMagicLinkRepo(), mailer)
+
+		require.NoError(t, svc.RequestLink(context.Background(), "Jane@Example.com"))
+		sent := mailer.waitForSent(t, 1)
+		assert.Equal(t, "jane@example.com", sent[0].To.String())
+		token := linkToken(t, sent[0])
+
+		userID, err := svc.Redeem(context.Background(), token)
+		require.NoError(t, err)
+		assert.Equal(t, "user-123", userID)
+
+		_, err = svc.Redeem(context.Background(), token)
+		assert.ErrorIs(t, err, domain.ErrMagicLinkInvalid)
+	})
+
+	t.Run("Link is sent after the request is cancelled", func(t *testing.T) {
+		user := newTestUser("user-123", "jane", "jane@example.com", "Password123!")
+		mailer := &recordingMailer{}
+		svc := newService(newMemoryUserRepo(user), newMemoryMagicLinkRepo(), mailer)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		require.NoError(t, svc.RequestLink(ctx, "jane@example.com"))
+		cancel()
+		assert.Len(t, mailer.waitForSent(t, 1), 1)
+	})
+
+	t.Run("Unknown address gets the same response", func(t *testing.T) {
+		tokens := newMemoryMagicLinkRepo()
+		mailer := &recordingMailer{}
+		svc := newService(newMemoryUserRepo(), tokens, mailer)
+
+		assert.NoError(t, svc.RequestLink(context.Background(), "nobody@example.com"))
+		assert.NoError(t, svc.RequestLink(context.Background(), "not-an-email"))
+		// 등록되지 않은 주소도 같은 한도를 적용
+		assert.ErrorIs(t, svc.RequestLink(context.Background(), "nobody@example.com"), domain.ErrRateLimited)
+		assert.Empty(t, tokens.tokens)
+		assert.Empty(t, mailer.sent())
+	})
+
+	t.Run("Unknown token is rejected", func(t *testing.T) {
+		svc := newService(newMemoryUserRepo(), newMemoryMagicLinkRepo(), &recordingMailer{})
+
+		_, err := svc.Redeem(context.Background(), "unknown")
+		assert.ErrorIs(t, err, domain.ErrMagicLinkInvalid)
+		_, err = svc.Redeem(context.Background(), "")
+		assert.ErrorIs(t, err, domain.ErrMagicLinkInvalid)
+	})
+}
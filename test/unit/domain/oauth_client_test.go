@@ -0,0 +1,113 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/sukryu/IV-auth-services/internal/core/domain"
+)
+
+func TestNewOAuthClient(t *testing.T) {
+	secret, _ := domain.NewPassword("client-secret-123")
+
+	tests := []struct {
+		name       string
+		id         string
+		clientName string
+		secret     domain.Password
+		scopes     []string
+		ttl        time.Duration
+		wantErr    bool
+	}{
+		{"Valid client", "client-123", "chat-bot", secret, []string{"chat:write"}, time.Hour, false},
+		{"Empty ID", "", "chat-bot", secret, []string{"chat:write"}, time.Hour, true},
+		{"Empty name", "client-123", "", secret, []string{"chat:write"}, time.Hour, true},
+		{"Empty secret", "client-123", "chat-bot", domain.Password{}, []string{"chat:write"}, time.Hour, true},
+		{"Zero ttl", "client-123", "chat-bot", secret, []string{"chat:write"}, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := domain.NewOAuthClient(tt.id, tt.clientName, tt.secret, tt.scopes, tt.ttl)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, client)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.id, client.ID())
+				assert.Equal(t, tt.clientName, client.Name())
+				assert.Equal(t, tt.scopes, client.AllowedScopes())
+				assert.Equal(t, tt.ttl, client.TokenTTL())
+			}
+		})
+	}
+}
+
+func TestOAuthClientCredentials(t *testing.T) {
+	secret, _ := domain.NewPassword("client-secret-123")
+	client, err := domain.NewOAuthClient("client-123", "chat-bot", secret, []string{"chat:read", "chat:write"}, time.Hour)
+	assert.NoError(t, err)
+
+	assert.True(t, client.VerifySecret("client-secret-123"))
+	assert.False(t, client.VerifySecret("wrong-secret"))
+	assert.True(t, client.AllowsScopes([]string{"chat:read"}))
+	assert.True(t, client.AllowsScopes(nil))
+	assert.False(t, client.AllowsScopes([]string{"user:delete"}))
+}
+
+func TestPublicOAuthClient(t *testing.T) {
+	client, err := domain.NewPublicOAuthClient("client-123", "stream-overlay", []string{"profile"}, time.Hour)
+	assert.NoError(t, err)
+
+	assert.True(t, client.IsPublic())
+	assert.Equal(t, domain.OAuthClientPublic, client.Type())
+	// 공개 클라이언트는 시크릿이 없으므로 어떤 값도 검증되지 않음
+	assert.False(t, client.VerifySecret(""))
+	assert.False(t, client.VerifySecret("anything"))
+}
+
+func TestOAuthClientRedirectURIs(t *testing.T) {
+	secret, _ := domain.NewPassword("client-secret-123")
+	client, err := domain.NewOAuthClient("client-123", "partner-app", secret, []string{"profile"}, time.Hour)
+	assert.NoError(t, err)
+	assert.False(t, client.IsPublic())
+
+	tests := []struct {
+		name    string
+		uri     string
+		wantErr bool
+	}{
+		{"HTTPS", "https://partner.example.com/callback", false},
+		{"Loopback HTTP", "http://127.0.0.1:8400/callback", false},
+		{"Localhost HTTP", "http://localhost:3000/callback", false},
+		{"Plain HTTP", "http://partner.example.com/callback", true},
+		{"Relative", "/callback", true},
+		{"Fragment", "https://partner.example.com/callback#frag", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := client.SetRedirectURIs([]string{tt.uri})
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.True(t, client.AllowsRedirectURI(tt.uri))
+			}
+		})
+	}
+
+	assert.NoError(t, client.SetRedirectURIs([]string{"https://partner.example.com/callback"}))
+	assert.False(t, client.AllowsRedirectURI("https://partner.example.com/callback/evil"))
+	assert.False(t, client.AllowsRedirectURI(""))
+}
+
+func TestTokenClaimsIsClientToken(t *testing.T) {
+	clientToken := domain.TokenClaims{Subject: "client-123", ClientID: "client-123"}
+	assert.True(t, clientToken.IsClientToken())
+
+	// 사용자를 대신해 클라이언트가 받은 토큰은 클라이언트 토큰이 아님
+	userToken := domain.TokenClaims{Subject: "user-123", ClientID: "client-123"}
+	assert.False(t, userToken.IsClientToken())
+}
@@ -0,0 +1,152 @@
+package domain_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sukryu/IV-auth-services/internal/core/domain"
+)
+
+func TestNewPasswordResetToken(t *testing.T) {
+	email, err := domain.NewEmail("jane@example.com")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		tokenHash string
+		userID    string
+		email     domain.Email
+		wantErr   bool
+	}{
+		{"Valid token", "hash", "user-123", email, false},
+		{"Empty hash", "", "user-123", email, true},
+		{"Empty user id", "hash", "", email, true},
+		{"Invalid email", "hash", "user-123", domain.Email(""), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := domain.NewPasswordResetToken(tt.tokenHash, tt.userID, tt.email, time.Now().Add(30*time.Minute))
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.userID, token.UserID())
+			assert.False(t, token.IsExpired())
+		})
+	}
+}
+
+func TestPasswordResetToken_IsExpired(t *testing.T) {
+	email, err := domain.NewEmail("jane@example.com")
+	require.NoError(t, err)
+
+	token, err := domain.NewPasswordResetTokenFromStorage("hash", "user-123", email, time.Now().Add(-time.Second), time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	assert.True(t, token.IsExpired())
+}
+
+func TestNewPasswordChanged(t *testing.T) {
+	event, err := domain.NewPasswordChanged("user-123", domain.PasswordChangeReasonReset, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, "PasswordChanged", event.EventName())
+	assert.Equal(t, domain.PasswordChangeReasonReset, event.Reason())
+
+	_, err = domain.NewPasswordChanged("", domain.PasswordChangeReasonReset, time.Now())
+	assert.Error(t, err)
+	_, err = domain.NewPasswordChanged("user-123", "", time.Now())
+	assert.Error(t, err)
+}
+
+func TestPasswordResetService(t *testing.T) {
+	type env struct {
+		svc      domain.PasswordResetService
+		user     *domain.User
+		tokens   *memoryPasswordResetRepo
+		sessions *memorySessionRepo
+		mailer   *recordingMailer
+		events   *recordingEventPublisher
+		audit    *memoryAuditRepo
+	}
+	newEnv := func() *env {
+		e := &env{
+			user:     newTestUser("user-123", "jane", "jane@example.com", "Password123!"),
+			tokens:   newMemoryPasswordResetRepo(),
+			sessions: newMemorySessionRepo(),
+			mailer:   &recordingMailer{},
+			events:   &recordingEventPublisher{},
+			audit:    &memoryAuditRepo{},
+		}
+		e.svc = domain.NewPasswordResetService(newMemoryUserRepo(e.user), e.tokens, e.sessions, newMemoryRateLimitRepo(),
+			e.mailer, e.audit, e.events, "https://app.example.com/reset", 30*time.Minute, 2, time.Hour)
+		return e
+	}
+	ctx := context.Background()
+
+	t.Run("Reset replaces the password and revokes sessions", func(t *testing.T) {
+		e := newEnv()
+		e.sessions.addSession("session-1", "user-123")
+		e.sessions.addSession("session-2", "user-123")
+
+		require.NoError(t, e.svc.RequestPasswordReset(ctx, "jane@example.com"))
+		token := linkToken(t, e.mailer.waitForSent(t, 1)[0])
+
+		require.NoError(t, e.svc.CompletePasswordReset(ctx, token, "NewPassword456!"))
+		assert.True(t, e.user.PasswordHash().Verify("NewPassword456!"))
+		assert.False(t, e.user.PasswordHash().Verify("Password123!"))
+		assert.True(t, e.user.EmailVerified())
+
+		active, err := e.sessions.FindActiveByUserID(ctx, "user-123")
+		require.NoError(t, err)
+		assert.Empty(t, active)
+		assert.Contains(t, e.events.names(), "PasswordChanged")
+		assert.Contains(t, e.audit.actions(), domain.AuditActionPasswordReset)
+	})
+
+	t.Run("Link can be used once", func(t *testing.T) {
+		e := newEnv()
+		require.NoError(t, e.svc.RequestPasswordReset(ctx, "jane@example.com"))
+		token := linkToken(t, e.mailer.waitForSent(t, 1)[0])
+
+		require.NoError(t, e.svc.CompletePasswordReset(ctx, token, "NewPassword456!"))
+		err := e.svc.CompletePasswordReset(ctx, token, "Another789!")
+		assert.ErrorIs(t, err, domain.ErrPasswordResetInvalid)
+		assert.True(t, e.user.PasswordHash().Verify("NewPassword456!"))
+	})
+
+	t.Run("Reset revokes other outstanding links", func(t *testing.T) {
+		e := newEnv()
+		require.NoError(t, e.svc.RequestPasswordReset(ctx, "jane@example.com"))
+		require.NoError(t, e.svc.RequestPasswordReset(ctx, "jane@example.com"))
+		sent := e.mailer.waitForSent(t, 2)
+
+		require.NoError(t, e.svc.CompletePasswordReset(ctx, linkToken(t, sent[0]), "NewPassword456!"))
+		err := e.svc.CompletePasswordReset(ctx, linkToken(t, sent[1]), "Another789!")
+		assert.ErrorIs(t, err, domain.ErrPasswordResetInvalid)
+	})
+
+	t.Run("Weak password does not use up the link", func(t *testing.T) {
+		e := newEnv()
+		require.NoError(t, e.svc.RequestPasswordReset(ctx, "jane@example.com"))
+		token := linkToken(t, e.mailer.waitForSent(t, 1)[0])
+
+		assert.Error(t, e.svc.CompletePasswordReset(ctx, token, "short"))
+		require.NoError(t, e.svc.CompletePasswordReset(ctx, token, "NewPassword456!"))
+	})
+
+	t.Run("Unknown address gets the same response", func(t *testing.T) {
+		e := newEnv()
+
+		assert.NoError(t, e.svc.RequestPasswordReset(ctx, "nobody@example.com"))
+		assert.NoError(t, e.svc.RequestPasswordReset(ctx, "not-an-email"))
+		assert.NoError(t, e.svc.RequestPasswordReset(ctx, "nobody@example.com"))
+		// 등록되지 않은 주소도 같은 한도를 적용
+		assert.ErrorIs(t, e.svc.RequestPasswordReset(ctx, "nobody@example.com"), domain.ErrRateLimited)
+		assert.Empty(t, e.tokens.tokens)
+		assert.Empty(t, e.mailer.sent())
+	})
+}
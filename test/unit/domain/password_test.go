@@ -11,18 +11,18 @@ func TestNewPassword(t *testing.T) {
 	tests := []struct {
 		name    string
 		input   string
-		wantErr bool
+		wantErr error
 	}{
-		{"Valid password", "StrongP@ssw0rd!", false},
-		{"Empty password", "", true},
-		{"Short password", "short", true},
+		{"Valid password", "StrongP@ssw0rd!", nil},
+		{"Empty password", "", domain.ErrPasswordEmpty},
+		{"Short password", "short", domain.ErrPasswordTooShort},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			pwd, err := domain.NewPassword(tt.input)
-			if tt.wantErr {
-				assert.Error(t, err)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
 				assert.Empty(t, pwd)
 			} else {
 				assert.NoError(t, err)
@@ -109,3 +109,22 @@ func TestNewPlatformAccount(t *testing.T) {
 		})
 	}
 }
+
+func TestPlatformAccountUpdateTokens(t *testing.T) {
+	pa, err := domain.NewPlatformAccount("pa-123", "user-123", domain.PlatformTwitch, "twitch123", "TwitchUser", "old_access", "old_refresh", nil)
+	assert.NoError(t, err)
+
+	expiresAt := time.Now().Add(time.Hour)
+	pa.UpdateTokens("RenamedUser", "new_access", "new_refresh", &expiresAt)
+	assert.Equal(t, "RenamedUser", pa.PlatformUsername())
+	assert.Equal(t, "new_access", pa.AccessToken())
+	assert.Equal(t, "new_refresh", pa.RefreshToken())
+	assert.Equal(t, &expiresAt, pa.TokenExpiresAt())
+
+	// 비어 있는 사용자명과 리프레시 토큰은 기존 값 유지
+	pa.UpdateTokens("", "newer_access", "", nil)
+	assert.Equal(t, "RenamedUser", pa.PlatformUsername())
+	assert.Equal(t, "newer_access", pa.AccessToken())
+	assert.Equal(t, "new_refresh", pa.RefreshToken())
+	assert.Nil(t, pa.TokenExpiresAt())
+}
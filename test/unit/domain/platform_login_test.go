@@ -0,0 +1,114 @@
+package domain_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sukryu/IV-auth-services/internal/core/domain"
+)
+
+// twitchClient returns a platform client that signs in as the given Twitch identity.
+func twitchClient(email string, emailVerified bool) *fakePlatformClient {
+	return &fakePlatformClient{
+		platform: domain.PlatformTwitch,
+		identity: domain.PlatformIdentity{
+			PlatformUserID: "twitch-123",
+			Username:       "streamer",
+			Email:          email,
+			EmailVerified:  emailVerified,
+			AccessToken:    "platform-access",
+			RefreshToken:   "platform-refresh",
+		},
+	}
+}
+
+func TestPlatformLoginServiceLogin(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Creates and links a new user", func(t *testing.T) {
+		users := newMemoryUserRepo()
+		platforms := newMemoryPlatformRepo()
+		svc := domain.NewPlatformLoginService(users, platforms, &recordingEventPublisher{},
+			[]domain.PlatformOAuthClient{twitchClient("streamer@example.com", true)}, domain.WithVerifiedPlatformEmailRequired())
+
+		user, err := svc.Login(ctx, domain.PlatformTwitch, "code")
+		require.NoError(t, err)
+		assert.Equal(t, "streamer", user.Username())
+		assert.True(t, user.EmailVerified())
+
+		account, err := platforms.FindByPlatformUserID(ctx, domain.PlatformTwitch, "twitch-123")
+		require.NoError(t, err)
+		require.NotNil(t, account)
+		assert.Equal(t, user.ID(), account.UserID())
+
+		// 이미 연결된 계정은 같은 사용자로 로그인
+		again, err := svc.Login(ctx, domain.PlatformTwitch, "code")
+		require.NoError(t, err)
+		assert.Equal(t, user.ID(), again.ID())
+	})
+
+	t.Run("Unverified platform email is rejected when required", func(t *testing.T) {
+		users := newMemoryUserRepo()
+		platforms := newMemoryPlatformRepo()
+		svc := domain.NewPlatformLoginService(users, platforms, &recordingEventPublisher{},
+			[]domain.PlatformOAuthClient{twitchClient("streamer@example.com", false)}, domain.WithVerifiedPlatformEmailRequired())
+
+		_, err := svc.Login(ctx, domain.PlatformTwitch, "code")
+		assert.ErrorIs(t, err, domain.ErrEmailNotVerified)
+		assert.Empty(t, users.users)
+		assert.Empty(t, platforms.accounts)
+	})
+
+	t.Run("Unverified platform email is allowed by default", func(t *testing.T) {
+		svc := domain.NewPlatformLoginService(newMemoryUserRepo(), newMemoryPlatformRepo(), &recordingEventPublisher{},
+			[]domain.PlatformOAuthClient{twitchClient("streamer@example.com", false)})
+
+		user, err := svc.Login(ctx, domain.PlatformTwitch, "code")
+		require.NoError(t, err)
+		assert.False(t, user.EmailVerified())
+	})
+
+	t.Run("Existing email address is not taken over", func(t *testing.T) {
+		existing := newTestUser("user-123", "viewer", "streamer@example.com", "Password123!")
+		svc := domain.NewPlatformLoginService(newMemoryUserRepo(existing), newMemoryPlatformRepo(), &recordingEventPublisher{},
+			[]domain.PlatformOAuthClient{twitchClient("Streamer@Example.com", true)})
+
+		_, err := svc.Login(ctx, domain.PlatformTwitch, "code")
+		assert.ErrorIs(t, err, domain.ErrPlatformEmailInUse)
+	})
+}
+
+func TestPlatformLoginServiceUpgradeGuest(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Unverified platform email is rejected when required", func(t *testing.T) {
+		guest, err := domain.NewGuestUser("guest-123", "guest_abc")
+		require.NoError(t, err)
+		platforms := newMemoryPlatformRepo()
+		svc := domain.NewPlatformLoginService(newMemoryUserRepo(guest), platforms, &recordingEventPublisher{},
+			[]domain.PlatformOAuthClient{twitchClient("streamer@example.com", false)}, domain.WithVerifiedPlatformEmailRequired())
+
+		err = svc.UpgradeGuest(ctx, guest, domain.PlatformTwitch, "code")
+		assert.ErrorIs(t, err, domain.ErrEmailNotVerified)
+		assert.True(t, guest.IsGuest())
+		assert.Empty(t, platforms.accounts)
+	})
+
+	t.Run("Verified platform email upgrades the guest", func(t *testing.T) {
+		guest, err := domain.NewGuestUser("guest-123", "guest_abc")
+		require.NoError(t, err)
+		platforms := newMemoryPlatformRepo()
+		svc := domain.NewPlatformLoginService(newMemoryUserRepo(guest), platforms, &recordingEventPublisher{},
+			[]domain.PlatformOAuthClient{twitchClient("streamer@example.com", true)}, domain.WithVerifiedPlatformEmailRequired())
+
+		require.NoError(t, svc.UpgradeGuest(ctx, guest, domain.PlatformTwitch, "code"))
+		assert.False(t, guest.IsGuest())
+		assert.True(t, guest.EmailVerified())
+		account, err := platforms.FindByPlatformUserID(ctx, domain.PlatformTwitch, "twitch-123")
+		require.NoError(t, err)
+		require.NotNil(t, account)
+		assert.Equal(t, guest.ID(), account.UserID())
+	})
+}
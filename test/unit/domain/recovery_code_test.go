@@ -0,0 +1,64 @@
+package domain_test
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sukryu/IV-auth-services/internal/core/domain"
+)
+
+func TestGenerateRecoveryCodes(t *testing.T) {
+	codes, raws, err := domain.GenerateRecoveryCodes("user-123")
+	require.NoError(t, err)
+	require.Len(t, codes, 10)
+	require.Len(t, raws, 10)
+
+	format := regexp.MustCompile(`^[2-9A-HJKMNP-Z]{5}-[2-9A-HJKMNP-Z]{5}$`)
+	seen := make(map[string]bool)
+	for i, raw := range raws {
+		assert.Regexp(t, format, raw)
+		assert.False(t, seen[raw], "duplicate code %s", raw)
+		seen[raw] = true
+
+		assert.Equal(t, "user-123", codes[i].UserID())
+		assert.NotEmpty(t, codes[i].ID())
+		assert.False(t, codes[i].IsUsed())
+		assert.NotContains(t, string(codes[i].Hash().Hash()), raw)
+	}
+
+	_, _, err = domain.GenerateRecoveryCodes("")
+	assert.Error(t, err)
+}
+
+func TestRecoveryCodeMatches(t *testing.T) {
+	code, err := domain.NewRecoveryCode("user-123", "K7QXM-2HDPA")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name  string
+		input string
+		want  bool
+	}{
+		{"Exact", "K7QXM-2HDPA", true},
+		{"Lower case without dash", "k7qxm2hdpa", true},
+		{"With spaces", "K7QXM 2HDPA", true},
+		{"Wrong code", "K7QXM-2HDPB", false},
+		{"Empty", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, code.Matches(tt.input))
+		})
+	}
+
+	// 저장소에서 복원한 코드도 같은 해시로 검증
+	usedAt := time.Now()
+	restored, err := domain.NewRecoveryCodeFromStorage(code.ID(), "user-123", code.Hash(), &usedAt, code.CreatedAt())
+	require.NoError(t, err)
+	assert.True(t, restored.IsUsed())
+	assert.True(t, restored.Matches(strings.ToLower("K7QXM-2HDPA")))
+}
@@ -0,0 +1,130 @@
+package domain_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sukryu/IV-auth-services/internal/core/domain"
+)
+
+func TestNewSession(t *testing.T) {
+	metadata := domain.ClientMetadata{IPAddress: "203.0.113.7", UserAgent: "Mozilla/5.0", DeviceName: "Work laptop"}
+
+	tests := []struct {
+		name    string
+		id      string
+		userID  string
+		ttl     time.Duration
+		wantErr bool
+	}{
+		{"Valid session", "session-123", "user-123", time.Hour, false},
+		{"Empty id", "", "user-123", time.Hour, true},
+		{"Empty user", "session-123", "", time.Hour, true},
+		{"Non-positive ttl", "session-123", "user-123", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			session, err := domain.NewSession(tt.id, tt.userID, metadata, tt.ttl)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, session)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "Work laptop", session.DeviceName())
+				assert.Equal(t, "Mozilla/5.0", session.UserAgent())
+				assert.Equal(t, "203.0.113.7", session.IPAddress())
+				assert.True(t, session.IsActive())
+			}
+		})
+	}
+}
+
+func TestSessionTouch(t *testing.T) {
+	session, err := domain.NewSession("session-123", "user-123", domain.ClientMetadata{IPAddress: "203.0.113.7"}, time.Minute)
+	assert.NoError(t, err)
+	previousExpiry := session.ExpiresAt()
+
+	session.Touch(domain.ClientMetadata{IPAddress: "198.51.100.2"}, time.Hour)
+	assert.True(t, session.ExpiresAt().After(previousExpiry))
+	assert.Equal(t, "198.51.100.2", session.IPAddress())
+
+	// 메타데이터가 없는 갱신은 기존 IP 유지
+	session.Touch(domain.ClientMetadata{}, time.Hour)
+	assert.Equal(t, "198.51.100.2", session.IPAddress())
+}
+
+func TestSessionRevoke(t *testing.T) {
+	session, err := domain.NewSession("session-123", "user-123", domain.ClientMetadata{}, time.Hour)
+	assert.NoError(t, err)
+
+	assert.NoError(t, session.Revoke())
+	assert.NotNil(t, session.RevokedAt())
+	assert.False(t, session.IsActive())
+	assert.Error(t, session.Revoke())
+}
+
+func TestSessionExpired(t *testing.T) {
+	now := time.Now()
+	session, err := domain.NewSessionFromStorage("session-123", "user-123", domain.ClientMetadata{},
+		now.Add(-2*time.Hour), now.Add(-2*time.Hour), now.Add(-time.Hour), nil)
+	assert.NoError(t, err)
+	assert.False(t, session.IsActive())
+}
+
+func TestAuthServiceRefreshTokenAfterRevocation(t *testing.T) {
+	ctx := context.Background()
+	newEnv := func() (domain.AuthService, *memorySessionRepo, *domain.Token) {
+		sessions := newMemorySessionRepo()
+		user := newTestUser("user-123", "jane", "jane@example.com", "Password123!")
+		svc := domain.NewAuthService(newMemoryUserRepo(user), newMemoryTokenRepo(), newFakeTokenGenerator(),
+			&recordingEventPublisher{}, domain.WithSessions(sessions))
+		token, err := svc.Authenticate(ctx, "jane", "Password123!")
+		require.NoError(t, err)
+		return svc, sessions, token
+	}
+
+	t.Run("Revoked session cannot be refreshed", func(t *testing.T) {
+		svc, sessions, token := newEnv()
+		_, err := sessions.RevokeAllByUserID(ctx, "user-123", "", time.Now())
+		require.NoError(t, err)
+
+		_, err = svc.RefreshToken(ctx, token.RefreshToken())
+		assert.Error(t, err)
+	})
+
+	t.Run("Refresh racing a revocation does not reactivate the session", func(t *testing.T) {
+		svc, sessions, token := newEnv()
+		// 갱신 요청이 세션을 읽은 직후 비밀번호 재설정이 모든 세션을 폐기
+		sessions.afterFind = func(string) {
+			sessions.afterFind = nil
+			_, _ = sessions.RevokeAllByUserID(ctx, "user-123", "", time.Now())
+		}
+
+		_, err := svc.RefreshToken(ctx, token.RefreshToken())
+		assert.Error(t, err)
+		active, err := sessions.FindActiveByUserID(ctx, "user-123")
+		require.NoError(t, err)
+		assert.Empty(t, active)
+		_, err = svc.RefreshToken(ctx, token.RefreshToken())
+		assert.Error(t, err)
+	})
+
+	t.Run("Saving a stale copy keeps the revocation", func(t *testing.T) {
+		_, sessions, _ := newEnv()
+		active, err := sessions.FindActiveByUserID(ctx, "user-123")
+		require.NoError(t, err)
+		require.Len(t, active, 1)
+		stale := active[0]
+		_, err = sessions.RevokeAllByUserID(ctx, "user-123", "", time.Now())
+		require.NoError(t, err)
+
+		require.NoError(t, sessions.Save(ctx, stale))
+		stored, err := sessions.FindByID(ctx, stale.ID())
+		require.NoError(t, err)
+		assert.False(t, stored.IsActive())
+	})
+}
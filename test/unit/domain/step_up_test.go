@@ -0,0 +1,123 @@
+package domain_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sukryu/IV-auth-services/internal/core/domain"
+)
+
+func TestACRForAMR(t *testing.T) {
+	assert.Equal(t, "", domain.ACRForAMR(nil))
+	assert.Equal(t, domain.ACRSingleFactor, domain.ACRForAMR([]string{domain.AMRPassword}))
+	assert.Equal(t, domain.ACRMultiFactor, domain.ACRForAMR([]string{domain.AMRPassword, domain.AMROTP, domain.AMRMFA}))
+}
+
+func TestWithAuthenticationSetsACR(t *testing.T) {
+	var claims domain.TokenClaims
+	domain.WithAuthentication(time.Now(), domain.AMRHardwareKey, domain.AMRMFA)(&claims)
+	assert.Equal(t, domain.ACRMultiFactor, claims.ACR)
+}
+
+func TestStepUpRequirementCheck(t *testing.T) {
+	recent := &domain.TokenClaims{AuthTime: time.Now().Add(-time.Minute), ACR: domain.ACRSingleFactor}
+	stale := &domain.TokenClaims{AuthTime: time.Now().Add(-time.Hour), ACR: domain.ACRMultiFactor}
+	unknown := &domain.TokenClaims{}
+
+	tests := []struct {
+		name        string
+		requirement domain.StepUpRequirement
+		claims      *domain.TokenClaims
+		wantStepUp  bool
+	}{
+		{"No requirement", domain.StepUpRequirement{}, unknown, false},
+		{"Recent enough", domain.StepUpRequirement{MaxAge: 5 * time.Minute}, recent, false},
+		{"Too old", domain.StepUpRequirement{MaxAge: 5 * time.Minute}, stale, true},
+		{"Missing auth time", domain.StepUpRequirement{MaxAge: 5 * time.Minute}, unknown, true},
+		{"Multi-factor satisfies", domain.StepUpRequirement{ACR: domain.ACRMultiFactor}, stale, false},
+		{"Single factor does not satisfy", domain.StepUpRequirement{ACR: domain.ACRMultiFactor}, recent, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.requirement.Check(tt.claims)
+			if !tt.wantStepUp {
+				assert.NoError(t, err)
+				return
+			}
+			var stepUpErr *domain.StepUpRequiredError
+			assert.ErrorAs(t, err, &stepUpErr)
+			assert.Equal(t, tt.requirement, stepUpErr.Requirement)
+		})
+	}
+}
+
+func TestAuthServiceReAuthenticate(t *testing.T) {
+	ctx := context.Background()
+	user := newTestUser("user-123", "viewer", "viewer@example.com", "Password123!")
+	staleClaims := &domain.TokenClaims{
+		Subject:   user.ID(),
+		SessionID: "session-123",
+		Scope:     []string{"chat:write"},
+		AuthTime:  time.Now().Add(-time.Hour),
+		AMR:       []string{domain.AMRPassword},
+	}
+	requirement := domain.StepUpRequirement{MaxAge: 5 * time.Minute}
+
+	newService := func() (domain.AuthService, *fakeTokenGenerator) {
+		tokenGen := newFakeTokenGenerator()
+		svc := domain.NewAuthService(newMemoryUserRepo(user), newMemoryTokenRepo(), tokenGen, &recordingEventPublisher{},
+			domain.WithReAuthentication(5*time.Minute, newMemoryRateLimitRepo(), 3, time.Hour))
+		return svc, tokenGen
+	}
+
+	t.Run("Password issues an elevated token for the same session", func(t *testing.T) {
+		svc, tokenGen := newService()
+		require.Error(t, requirement.Check(staleClaims))
+
+		token, err := svc.ReAuthenticate(ctx, staleClaims, domain.ReAuthMethodPassword, "Password123!")
+		require.NoError(t, err)
+		assert.Empty(t, token.RefreshToken())
+
+		claims, err := tokenGen.ParseToken(token.AccessToken())
+		require.NoError(t, err)
+		assert.NoError(t, requirement.Check(claims))
+		assert.Equal(t, "session-123", claims.SessionID)
+		assert.Equal(t, []string{"chat:write"}, claims.Scope)
+		assert.Equal(t, []string{domain.AMRPassword}, claims.AMR)
+		assert.WithinDuration(t, time.Now().Add(5*time.Minute), claims.ExpiresAt, time.Second)
+	})
+
+	t.Run("Wrong password is rejected", func(t *testing.T) {
+		svc, _ := newService()
+		_, err := svc.ReAuthenticate(ctx, staleClaims, domain.ReAuthMethodPassword, "wrong-password")
+		assert.ErrorIs(t, err, domain.ErrReAuthenticationFailed)
+	})
+
+	t.Run("Attempts are rate limited", func(t *testing.T) {
+		svc, _ := newService()
+		for i := 0; i < 3; i++ {
+			_, err := svc.ReAuthenticate(ctx, staleClaims, domain.ReAuthMethodPassword, "wrong-password")
+			require.ErrorIs(t, err, domain.ErrReAuthenticationFailed)
+		}
+		// 한도를 넘으면 올바른 비밀번호도 거부
+		_, err := svc.ReAuthenticate(ctx, staleClaims, domain.ReAuthMethodPassword, "Password123!")
+		assert.ErrorIs(t, err, domain.ErrRateLimited)
+	})
+
+	t.Run("Second factor without MFA is rejected", func(t *testing.T) {
+		svc, _ := newService()
+		_, err := svc.ReAuthenticate(ctx, staleClaims, domain.MFAMethodTOTP, "123456")
+		assert.ErrorIs(t, err, domain.ErrMFANotEnrolled)
+	})
+
+	t.Run("Client tokens cannot re-authenticate", func(t *testing.T) {
+		svc, _ := newService()
+		clientClaims := &domain.TokenClaims{Subject: "client-123", ClientID: "client-123"}
+		_, err := svc.ReAuthenticate(ctx, clientClaims, domain.ReAuthMethodPassword, "Password123!")
+		assert.Error(t, err)
+	})
+}
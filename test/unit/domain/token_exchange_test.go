@@ -0,0 +1,142 @@
+package domain_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sukryu/IV-auth-services/internal/core/domain"
+)
+
+func TestNewTokenExchangePolicy(t *testing.T) {
+	tests := []struct {
+		name      string
+		actor     string
+		audiences []string
+		scopes    []string
+		wantErr   bool
+	}{
+		{"Valid policy", "streaming-service", []string{"chat-service"}, []string{"chat:write"}, false},
+		{"Empty actor", "", []string{"chat-service"}, []string{"chat:write"}, true},
+		{"No audiences", "streaming-service", nil, []string{"chat:write"}, true},
+		{"No scopes", "streaming-service", []string{"chat-service"}, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy, err := domain.NewTokenExchangePolicy(tt.actor, tt.audiences, tt.scopes)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, policy)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.actor, policy.Actor())
+				assert.Equal(t, tt.audiences, policy.Audiences())
+				assert.Equal(t, tt.scopes, policy.Scopes())
+			}
+		})
+	}
+}
+
+func TestTokenExchangePolicyAllows(t *testing.T) {
+	policy, err := domain.NewTokenExchangePolicy("streaming-service", []string{"chat-service"}, []string{"chat:read", "chat:write"})
+	assert.NoError(t, err)
+
+	assert.True(t, policy.AllowsAudience("chat-service"))
+	assert.False(t, policy.AllowsAudience("billing-service"))
+	assert.True(t, policy.AllowsScopes([]string{"chat:write"}))
+	assert.True(t, policy.AllowsScopes([]string{"chat:read", "chat:write"}))
+	assert.False(t, policy.AllowsScopes([]string{"chat:write", "user:delete"}))
+}
+
+func TestTokenClaimsIsDelegated(t *testing.T) {
+	userToken := domain.TokenClaims{Subject: "user-123"}
+	assert.False(t, userToken.IsDelegated())
+
+	// 관리자가 사용자를 대리 실행하는 토큰
+	impersonation := domain.TokenClaims{Subject: "user-123", Actor: &domain.ActorClaim{Subject: "admin-1"}}
+	assert.True(t, impersonation.IsDelegated())
+}
+
+// assertOAuthError checks that err is an *OAuthError with the given code.
+func assertOAuthError(t *testing.T, err error, code string) {
+	t.Helper()
+	var oauthErr *domain.OAuthError
+	if assert.True(t, errors.As(err, &oauthErr), "expected OAuthError, got %v", err) {
+		assert.Equal(t, code, oauthErr.Code())
+	}
+}
+
+func TestTokenExchangeServiceExchangeToken(t *testing.T) {
+	ctx := context.Background()
+	tokenGen := newFakeTokenGenerator()
+	tokenRepo := newMemoryTokenRepo()
+	events := &recordingEventPublisher{}
+	authSvc := domain.NewAuthService(newMemoryUserRepo(), tokenRepo, tokenGen, events)
+
+	policy, err := domain.NewTokenExchangePolicy("streaming-client", []string{"chat-service"}, []string{"chat:read", "chat:write"})
+	require.NoError(t, err)
+	svc := domain.NewTokenExchangeService(authSvc, tokenGen, events, []*domain.TokenExchangePolicy{policy}, 5*time.Minute)
+
+	userToken, err := authSvc.GenerateTokenPair("user-123")
+	require.NoError(t, err)
+
+	t.Run("Issues a delegated, down-scoped token", func(t *testing.T) {
+		token, err := svc.ExchangeToken(ctx, userToken.AccessToken(), "streaming-client", "chat-service", []string{"chat:read"})
+		require.NoError(t, err)
+		assert.Empty(t, token.RefreshToken())
+		assert.WithinDuration(t, time.Now().Add(5*time.Minute), token.Expiry(), 5*time.Second)
+
+		claims, err := tokenGen.ParseToken(token.AccessToken())
+		require.NoError(t, err)
+		assert.Equal(t, "user-123", claims.Subject)
+		assert.Equal(t, []string{"chat-service"}, claims.Audience)
+		assert.Equal(t, []string{"chat:read"}, claims.Scope)
+		require.NotNil(t, claims.Actor)
+		assert.Equal(t, "streaming-client", claims.Actor.Subject)
+		assert.Contains(t, events.names(), "TokenExchanged")
+	})
+
+	t.Run("Never outlives the subject token", func(t *testing.T) {
+		shortLived, err := tokenGen.GenerateAccessToken("user-123", time.Now().Add(time.Minute), domain.TokenClaims{})
+		require.NoError(t, err)
+		token, err := svc.ExchangeToken(ctx, shortLived, "streaming-client", "chat-service", []string{"chat:read"})
+		require.NoError(t, err)
+		assert.WithinDuration(t, time.Now().Add(time.Minute), token.Expiry(), 5*time.Second)
+	})
+
+	t.Run("Rejects unknown actors, audiences and scopes", func(t *testing.T) {
+		_, err := svc.ExchangeToken(ctx, userToken.AccessToken(), "other-client", "chat-service", []string{"chat:read"})
+		assertOAuthError(t, err, domain.OAuthErrUnauthorizedClient)
+		_, err = svc.ExchangeToken(ctx, userToken.AccessToken(), "streaming-client", "billing-service", []string{"chat:read"})
+		assertOAuthError(t, err, domain.OAuthErrInvalidTarget)
+		_, err = svc.ExchangeToken(ctx, userToken.AccessToken(), "streaming-client", "chat-service", []string{"admin"})
+		assertOAuthError(t, err, domain.OAuthErrInvalidScope)
+	})
+
+	t.Run("Cannot widen the scope of the subject token", func(t *testing.T) {
+		scoped, err := authSvc.GenerateTokenPair("user-123", domain.WithScope("chat:read"))
+		require.NoError(t, err)
+		_, err = svc.ExchangeToken(ctx, scoped.AccessToken(), "streaming-client", "chat-service", []string{"chat:write"})
+		assertOAuthError(t, err, domain.OAuthErrInvalidScope)
+	})
+
+	t.Run("Rejects invalid, revoked and client subject tokens", func(t *testing.T) {
+		_, err := svc.ExchangeToken(ctx, "not-a-token", "streaming-client", "chat-service", []string{"chat:read"})
+		assertOAuthError(t, err, domain.OAuthErrInvalidGrant)
+
+		revoked, err := authSvc.GenerateTokenPair("user-123")
+		require.NoError(t, err)
+		require.NoError(t, tokenRepo.BlacklistToken(ctx, revoked.AccessToken(), "user-123", "logout", revoked.Expiry()))
+		_, err = svc.ExchangeToken(ctx, revoked.AccessToken(), "streaming-client", "chat-service", []string{"chat:read"})
+		assertOAuthError(t, err, domain.OAuthErrInvalidGrant)
+
+		clientToken, err := tokenGen.GenerateAccessToken("client-1", time.Now().Add(time.Hour), domain.TokenClaims{ClientID: "client-1"})
+		require.NoError(t, err)
+		_, err = svc.ExchangeToken(ctx, clientToken, "streaming-client", "chat-service", []string{"chat:read"})
+		assertOAuthError(t, err, domain.OAuthErrInvalidGrant)
+	})
+}
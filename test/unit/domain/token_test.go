@@ -98,3 +98,17 @@ func TestTokenIsExpired(t *testing.T) {
 	time.Sleep(2 * time.Second)
 	assert.True(t, expiredToken.IsExpired())
 }
+
+func TestNewAccessToken(t *testing.T) {
+	token, err := domain.NewAccessToken("access_token_123", "jti-123", time.Now().Add(time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, "access_token_123", token.AccessToken())
+	assert.Empty(t, token.RefreshToken())
+
+	_, err = domain.NewAccessToken("", "jti-123", time.Now().Add(time.Hour))
+	assert.Error(t, err)
+	_, err = domain.NewAccessToken("access_token_123", "", time.Now().Add(time.Hour))
+	assert.Error(t, err)
+	_, err = domain.NewAccessToken("access_token_123", "jti-123", time.Now().Add(-time.Hour))
+	assert.Error(t, err)
+}
@@ -0,0 +1,107 @@
+package domain_test
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sukryu/IV-auth-services/internal/core/domain"
+)
+
+// rfc6238Secret is the SHA-1 seed from RFC 6238 Appendix B.
+var rfc6238Secret = []byte("12345678901234567890")
+
+func TestTOTPCodeRFC6238Vectors(t *testing.T) {
+	// RFC 6238 Appendix B의 8자리 값 중 하위 6자리
+	tests := []struct {
+		unix int64
+		code string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+		{20000000000, "353130"},
+	}
+
+	for _, tt := range tests {
+		step := domain.TOTPStep(time.Unix(tt.unix, 0))
+		assert.Equal(t, tt.code, domain.TOTPCode(rfc6238Secret, step), "time %d", tt.unix)
+	}
+}
+
+func TestMatchTOTPCode(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	current := domain.TOTPStep(now)
+
+	tests := []struct {
+		name     string
+		code     string
+		wantStep int64
+		wantOK   bool
+	}{
+		{"Current step", domain.TOTPCode(rfc6238Secret, current), current, true},
+		{"Previous step within drift", domain.TOTPCode(rfc6238Secret, current-1), current - 1, true},
+		{"Next step within drift", domain.TOTPCode(rfc6238Secret, current+1), current + 1, true},
+		{"Outside drift", domain.TOTPCode(rfc6238Secret, current-2), 0, false},
+		{"Wrong length", "12345", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step, ok := domain.MatchTOTPCode(rfc6238Secret, tt.code, now, 1)
+			assert.Equal(t, tt.wantOK, ok)
+			if tt.wantOK {
+				assert.Equal(t, tt.wantStep, step)
+			}
+		})
+	}
+}
+
+func TestTOTPProvisioningURI(t *testing.T) {
+	uri := domain.TOTPProvisioningURI("IV", "streamer01", rfc6238Secret)
+	require.True(t, strings.HasPrefix(uri, "otpauth://totp/IV:streamer01?"))
+
+	parsed, err := url.Parse(uri)
+	require.NoError(t, err)
+	q := parsed.Query()
+	assert.Equal(t, domain.EncodeTOTPSecret(rfc6238Secret), q.Get("secret"))
+	assert.Equal(t, "IV", q.Get("issuer"))
+	assert.Equal(t, "6", q.Get("digits"))
+	assert.Equal(t, "30", q.Get("period"))
+}
+
+func TestTOTPCredentialConfirm(t *testing.T) {
+	_, err := domain.NewTOTPCredential("", "ciphertext")
+	assert.Error(t, err)
+
+	credential, err := domain.NewTOTPCredential("user-123", "ciphertext")
+	require.NoError(t, err)
+	assert.False(t, credential.IsConfirmed())
+
+	assert.NoError(t, credential.Confirm(42))
+	assert.True(t, credential.IsConfirmed())
+	assert.Equal(t, int64(42), credential.LastUsedStep())
+	assert.Error(t, credential.Confirm(43))
+}
+
+func TestNewMFAChallenge(t *testing.T) {
+	authTime := time.Now()
+	challenge, err := domain.NewMFAChallenge("hash", "user-123", authTime, []string{domain.AMRPassword}, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, []string{domain.AMRPassword}, challenge.AMR())
+	assert.Equal(t, 0, challenge.Attempts())
+	assert.False(t, challenge.IsExpired())
+
+	expired, err := domain.NewMFAChallengeFromStorage("hash", "user-123", authTime, nil, 3, "", time.Now().Add(-time.Second), authTime)
+	require.NoError(t, err)
+	assert.True(t, expired.IsExpired())
+	assert.Equal(t, 3, expired.Attempts())
+
+	_, err = domain.NewMFAChallenge("", "user-123", authTime, nil, time.Now())
+	assert.Error(t, err)
+}
@@ -0,0 +1,113 @@
+package domain_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sukryu/IV-auth-services/internal/core/domain"
+)
+
+func TestUserManagementServiceCreateUser(t *testing.T) {
+	ctx := context.Background()
+	users := newMemoryUserRepo()
+	svc := domain.NewUserManagementService(users, newMemorySessionRepo(), &memoryAuditRepo{}, &recordingEventPublisher{})
+
+	user, err := svc.CreateUser(ctx, "jane", "Jane@Example.com", "Password123!", "FREE")
+	require.NoError(t, err)
+	assert.Equal(t, "jane@example.com", user.Email().String())
+	assert.False(t, user.EmailVerified())
+
+	// "@"가 들어간 이름은 로그인 시 이메일로 해석되므로 거부
+	_, err = svc.CreateUser(ctx, "jane@example.org", "other@example.com", "Password123!", "FREE")
+	assert.Error(t, err)
+	found, err := users.FindByUsername(ctx, "jane@example.org")
+	require.NoError(t, err)
+	assert.Nil(t, found)
+}
+
+func TestUserManagementServiceChangePassword(t *testing.T) {
+	ctx := context.Background()
+	type env struct {
+		svc      domain.UserManagementService
+		user     *domain.User
+		sessions *memorySessionRepo
+		audit    *memoryAuditRepo
+		events   *recordingEventPublisher
+	}
+	newEnv := func() *env {
+		e := &env{
+			user:     newTestUser("user-123", "jane", "jane@example.com", "Password123!"),
+			sessions: newMemorySessionRepo(),
+			audit:    &memoryAuditRepo{},
+			events:   &recordingEventPublisher{},
+		}
+		e.sessions.addSession("current", "user-123")
+		e.sessions.addSession("other", "user-123")
+		e.svc = domain.NewUserManagementService(newMemoryUserRepo(e.user), e.sessions, e.audit, e.events)
+		return e
+	}
+
+	t.Run("Change keeps the current session and revokes the rest", func(t *testing.T) {
+		e := newEnv()
+
+		require.NoError(t, e.svc.ChangePassword(ctx, "user-123", "Password123!", "NewPassword456!", "current"))
+		assert.True(t, e.user.PasswordHash().Verify("NewPassword456!"))
+
+		active, err := e.sessions.FindActiveByUserID(ctx, "user-123")
+		require.NoError(t, err)
+		require.Len(t, active, 1)
+		assert.Equal(t, "current", active[0].ID())
+		assert.Equal(t, []string{domain.AuditActionPasswordChanged}, e.audit.actions())
+		assert.Equal(t, []string{"PasswordChanged"}, e.events.names())
+	})
+
+	t.Run("Wrong current password changes nothing", func(t *testing.T) {
+		e := newEnv()
+
+		err := e.svc.ChangePassword(ctx, "user-123", "WrongPassword1!", "NewPassword456!", "current")
+		assert.ErrorIs(t, err, domain.ErrInvalidCurrentPassword)
+		assert.True(t, e.user.PasswordHash().Verify("Password123!"))
+		active, err := e.sessions.FindActiveByUserID(ctx, "user-123")
+		require.NoError(t, err)
+		assert.Len(t, active, 2)
+		assert.Equal(t, []string{"LoginFailed"}, e.events.names())
+	})
+
+	t.Run("New password must differ and pass the policy", func(t *testing.T) {
+		e := newEnv()
+
+		err := e.svc.ChangePassword(ctx, "user-123", "Password123!", "Password123!", "current")
+		assert.ErrorIs(t, err, domain.ErrPasswordUnchanged)
+		assert.Error(t, e.svc.ChangePassword(ctx, "user-123", "Password123!", "short", "current"))
+		assert.True(t, e.user.PasswordHash().Verify("Password123!"))
+		assert.Empty(t, e.audit.actions())
+	})
+}
+
+func TestUserManagementServiceDeleteAccount(t *testing.T) {
+	ctx := context.Background()
+	user := newTestUser("user-123", "viewer", "viewer@example.com", "Password123!")
+	users := newMemoryUserRepo(user)
+	sessions := newMemorySessionRepo()
+	sessions.addSession("session-1", user.ID())
+	sessions.addSession("session-2", user.ID())
+	audit := &memoryAuditRepo{}
+	events := &recordingEventPublisher{}
+	svc := domain.NewUserManagementService(users, sessions, audit, events)
+
+	require.NoError(t, svc.DeleteAccount(ctx, user.ID()))
+	assert.Equal(t, domain.UserStatusDeleted, user.Status())
+	active, err := sessions.FindActiveByUserID(ctx, user.ID())
+	require.NoError(t, err)
+	assert.Empty(t, active)
+	assert.Equal(t, []string{domain.AuditActionAccountDeleted}, audit.actions())
+	assert.Equal(t, []string{"UserStatusChanged"}, events.names())
+
+	// 삭제된 계정은 다시 삭제하거나 로그인할 수 없음
+	assert.Error(t, svc.DeleteAccount(ctx, user.ID()))
+	authSvc := domain.NewAuthService(users, newMemoryTokenRepo(), newFakeTokenGenerator(), events)
+	_, err = authSvc.Authenticate(ctx, "viewer", "Password123!")
+	assert.ErrorIs(t, err, domain.ErrInvalidCredentials)
+}
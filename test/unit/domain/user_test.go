@@ -113,3 +113,73 @@ func TestUserSetStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestUserSetEmailVerified(t *testing.T) {
+	email, _ := domain.NewEmail("test@example.com")
+	password, _ := domain.NewPassword("StrongP@ssw0rd!")
+	user, _ := domain.NewUser("user-123", "testuser", email, password, nil, "FREE")
+
+	assert.False(t, user.EmailVerified(), "new users start unverified")
+	user.SetEmailVerified(true)
+	assert.True(t, user.EmailVerified())
+	assert.WithinDuration(t, time.Now(), user.UpdatedAt(), time.Second)
+}
+
+func TestUserSetPasswordHash(t *testing.T) {
+	email, _ := domain.NewEmail("test@example.com")
+	password, _ := domain.NewPassword("StrongP@ssw0rd!")
+	user, _ := domain.NewUser("user-123", "testuser", email, password, nil, "FREE")
+
+	changed, err := user.PasswordHash().Change("An0ther$trongOne")
+	assert.NoError(t, err)
+	user.SetPasswordHash(changed)
+	assert.True(t, user.PasswordHash().Verify("An0ther$trongOne"))
+	assert.False(t, user.PasswordHash().Verify("StrongP@ssw0rd!"))
+
+	_, err = user.PasswordHash().Change("short")
+	assert.ErrorIs(t, err, domain.ErrPasswordTooShort)
+}
+
+func TestNewGuestUser(t *testing.T) {
+	guest, err := domain.NewGuestUser("user-123", "guest_abc123")
+	assert.NoError(t, err)
+	assert.True(t, guest.IsGuest())
+	assert.Equal(t, domain.UserStatusGuest, guest.Status())
+	assert.Empty(t, guest.Email().String())
+	assert.False(t, guest.PasswordHash().Verify(""), "guests cannot log in with a password")
+
+	_, err = domain.NewGuestUser("", "guest_abc123")
+	assert.Error(t, err)
+	_, err = domain.NewGuestUser("user-123", "")
+	assert.Error(t, err)
+}
+
+func TestUserUpgrade(t *testing.T) {
+	email, _ := domain.NewEmail("viewer@example.com")
+	password, _ := domain.NewPassword("StrongP@ssw0rd!")
+
+	guest, _ := domain.NewGuestUser("user-123", "guest_abc123")
+	assert.Error(t, guest.Upgrade("", email, password))
+	assert.Error(t, guest.Upgrade("viewer", domain.Email("invalid"), password))
+	assert.Error(t, guest.Upgrade("viewer", email, domain.Password{}))
+	assert.True(t, guest.IsGuest(), "failed upgrades leave the guest unchanged")
+
+	assert.NoError(t, guest.Upgrade("viewer", email, password))
+	assert.Equal(t, "user-123", guest.ID(), "the user id is kept")
+	assert.Equal(t, "viewer", guest.Username())
+	assert.Equal(t, email, guest.Email())
+	assert.Equal(t, domain.UserStatusActive, guest.Status())
+	assert.False(t, guest.EmailVerified())
+	assert.True(t, guest.PasswordHash().Verify("StrongP@ssw0rd!"))
+
+	// 이미 전환된 사용자는 다시 전환할 수 없음
+	assert.Error(t, guest.Upgrade("viewer2", email, password))
+}
+
+func TestTokenClaimsIsGuest(t *testing.T) {
+	guestToken := domain.TokenClaims{Subject: "user-123", Scope: []string{domain.ScopeGuest, "chat:read"}}
+	assert.True(t, guestToken.IsGuest())
+
+	userToken := domain.TokenClaims{Subject: "user-123"}
+	assert.False(t, userToken.IsGuest())
+}
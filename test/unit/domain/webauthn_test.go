@@ -0,0 +1,65 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sukryu/IV-auth-services/internal/core/domain"
+)
+
+func TestNewWebAuthnCredential(t *testing.T) {
+	tests := []struct {
+		name      string
+		id        []byte
+		userID    string
+		credName  string
+		publicKey []byte
+		wantErr   bool
+	}{
+		{"Valid credential", []byte("cred"), "user-123", "Laptop", []byte("key"), false},
+		{"Empty id", nil, "user-123", "Laptop", []byte("key"), true},
+		{"Empty user id", []byte("cred"), "", "Laptop", []byte("key"), true},
+		{"Empty public key", []byte("cred"), "user-123", "Laptop", nil, true},
+		{"Name too long", []byte("cred"), "user-123", string(make([]byte, 101)), []byte("key"), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			credential, err := domain.NewWebAuthnCredential(tt.id, tt.userID, tt.credName, tt.publicKey, 3, []string{"usb"})
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.id, credential.ID())
+			assert.Equal(t, uint32(3), credential.SignCount())
+			assert.Equal(t, []string{"usb"}, credential.Transports())
+			assert.Nil(t, credential.LastUsedAt())
+		})
+	}
+}
+
+func TestNewWebAuthnCeremony(t *testing.T) {
+	expiresAt := time.Now().Add(time.Minute)
+
+	// 패스워드 없는 로그인은 사용자 없이 시작
+	login, err := domain.NewWebAuthnCeremony("hash", "", domain.WebAuthnCeremonyLogin, expiresAt)
+	require.NoError(t, err)
+	assert.Equal(t, "", login.UserID())
+	assert.False(t, login.IsExpired())
+
+	_, err = domain.NewWebAuthnCeremony("hash", "", domain.WebAuthnCeremonyRegistration, expiresAt)
+	assert.Error(t, err)
+	_, err = domain.NewWebAuthnCeremony("hash", "", domain.WebAuthnCeremonyFactor, expiresAt)
+	assert.Error(t, err)
+	_, err = domain.NewWebAuthnCeremony("hash", "user-123", "unknown", expiresAt)
+	assert.Error(t, err)
+	_, err = domain.NewWebAuthnCeremony("", "user-123", domain.WebAuthnCeremonyFactor, expiresAt)
+	assert.Error(t, err)
+
+	expired, err := domain.NewWebAuthnCeremonyFromStorage("hash", "user-123", domain.WebAuthnCeremonyFactor, time.Now().Add(-time.Second), time.Now())
+	require.NoError(t, err)
+	assert.True(t, expired.IsExpired())
+}
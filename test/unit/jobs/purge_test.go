@@ -0,0 +1,90 @@
+package jobs_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/sukryu/IV-auth-services/internal/jobs"
+	"github.com/sukryu/IV-auth-services/pkg/logger"
+)
+
+// fakePurger simulates a table holding a fixed number of expired rows.
+type fakePurger struct {
+	remaining int64
+	calls     int
+	err       error
+}
+
+func (f *fakePurger) PurgeExpired(ctx context.Context, before time.Time, limit int) (int64, error) {
+	f.calls++
+	if f.err != nil {
+		return 0, f.err
+	}
+	removed := int64(limit)
+	if f.remaining < removed {
+		removed = f.remaining
+	}
+	f.remaining -= removed
+	return removed, nil
+}
+
+func TestPurgeJobRunOnce(t *testing.T) {
+	log, err := logger.NewLogger("development")
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		rows          int64
+		maxBatches    int
+		wantRemoved   int64
+		wantCalls     int
+		wantRemaining int64
+	}{
+		{"No expired rows", 0, 10, 0, 1, 0},
+		{"Single short batch", 42, 10, 42, 1, 0},
+		{"Exact batch boundary", 200, 10, 200, 3, 0},
+		{"Bounded by max batches", 1000, 3, 300, 3, 700},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			purger := &fakePurger{remaining: tt.rows}
+			job, err := jobs.NewPurgeJob("test", purger, time.Minute, 100, tt.maxBatches, log)
+			assert.NoError(t, err)
+
+			removed, err := job.RunOnce(context.Background())
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantRemoved, removed)
+			assert.Equal(t, tt.wantCalls, purger.calls)
+			assert.Equal(t, tt.wantRemaining, purger.remaining)
+		})
+	}
+}
+
+func TestPurgeJobRunOnceError(t *testing.T) {
+	log, err := logger.NewLogger("development")
+	assert.NoError(t, err)
+
+	job, err := jobs.NewPurgeJob("test", &fakePurger{err: errors.New("db down")}, time.Minute, 100, 10, log)
+	assert.NoError(t, err)
+
+	_, err = job.RunOnce(context.Background())
+	assert.Error(t, err)
+}
+
+func TestNewPurgeJobValidation(t *testing.T) {
+	log, err := logger.NewLogger("development")
+	assert.NoError(t, err)
+
+	_, err = jobs.NewPurgeJob("", &fakePurger{}, time.Minute, 100, 10, log)
+	assert.Error(t, err)
+	_, err = jobs.NewPurgeJob("test", nil, time.Minute, 100, 10, log)
+	assert.Error(t, err)
+	_, err = jobs.NewPurgeJob("test", &fakePurger{}, 0, 100, 10, log)
+	assert.Error(t, err)
+	_, err = jobs.NewPurgeJob("test", &fakePurger{}, time.Minute, 0, 10, log)
+	assert.Error(t, err)
+}